@@ -39,6 +39,66 @@ type SecuritySpec struct {
 	ProjectMonitorSubjects []rbacv1.Subject `json:"projectMonitorSubjects,omitempty"`
 }
 
+// EgressProtocol is the protocol used to access an external host
+type EgressProtocol string
+
+const (
+	// EgressProtocolTLS is TLS traffic, which is passed through the egress gateway without being terminated
+	EgressProtocolTLS EgressProtocol = "TLS"
+
+	// EgressProtocolHTTP is plain HTTP traffic
+	EgressProtocolHTTP EgressProtocol = "HTTP"
+)
+
+// EgressHost specifies an external host that the applications in the project namespaces are allowed to access
+// through the Istio egress gateway
+type EgressHost struct {
+	// Host is the fully qualified name of the external host, such as api.example.com
+	Host string `json:"host"`
+	// Port of the external host.  Default is 443 for TLS and 80 for HTTP
+	// +optional
+	Port uint32 `json:"port,omitempty"`
+	// Protocol used to access the external host.  Default is TLS
+	// +optional
+	// +kubebuilder:validation:Enum=TLS;HTTP
+	Protocol EgressProtocol `json:"protocol,omitempty"`
+}
+
+// MTLSMode is the Istio mutual TLS mode of the workloads in the project namespaces
+type MTLSMode string
+
+const (
+	// MTLSModeStrict only accepts mutual TLS traffic
+	MTLSModeStrict MTLSMode = "STRICT"
+
+	// MTLSModePermissive accepts both mutual TLS and plain text traffic
+	MTLSModePermissive MTLSMode = "PERMISSIVE"
+
+	// MTLSModeDisable only accepts plain text traffic
+	MTLSModeDisable MTLSMode = "DISABLE"
+)
+
+// MTLSPortException specifies the mTLS mode of a port of the workloads selected by labels
+type MTLSPortException struct {
+	// Selector is the labels of the workloads, which Istio requires for port level mTLS modes
+	Selector map[string]string `json:"selector"`
+	// Port is the workload port, which is the target port of the Service
+	Port uint32 `json:"port"`
+	// Mode of the port
+	// +kubebuilder:validation:Enum=STRICT;PERMISSIVE;DISABLE
+	Mode MTLSMode `json:"mode"`
+}
+
+// MTLSSpec specifies the Istio mutual TLS configuration of a project
+type MTLSSpec struct {
+	// Mode of the workloads in the project namespaces
+	// +kubebuilder:validation:Enum=STRICT;PERMISSIVE;DISABLE
+	Mode MTLSMode `json:"mode"`
+	// Ports of selected workloads that use a different mode
+	// +optional
+	PortExceptions []MTLSPortException `json:"portExceptions,omitempty"`
+}
+
 // ProjectTemplate contains the resources for a project
 type ProjectTemplate struct {
 	Namespaces []NamespaceTemplate `json:"namespaces"`
@@ -50,6 +110,16 @@ type ProjectTemplate struct {
 	// Network policies applied to namespaces in the project
 	// +optional
 	NetworkPolicies []NetworkPolicyTemplate `json:"networkPolicies,omitempty"`
+
+	// External hosts that the applications in the project namespaces are allowed to access.  The traffic to these
+	// hosts is routed through the Istio egress gateway.
+	// +optional
+	AllowedEgress []EgressHost `json:"allowedEgress,omitempty"`
+
+	// Istio mutual TLS configuration of the project namespaces, which is rendered as PeerAuthentication resources.
+	// The mesh wide mTLS mode applies when not specified.
+	// +optional
+	MTLS *MTLSSpec `json:"mtls,omitempty"`
 }
 
 // VerrazzanoProjectSpec defines the desired state of VerrazzanoProject
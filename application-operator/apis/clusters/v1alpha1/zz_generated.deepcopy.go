@@ -135,6 +135,21 @@ func (in *ConfigMapTemplate) DeepCopy() *ConfigMapTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressHost) DeepCopyInto(out *EgressHost) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressHost.
+func (in *EgressHost) DeepCopy() *EgressHost {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmbeddedObjectMeta) DeepCopyInto(out *EmbeddedObjectMeta) {
 	*out = *in
@@ -164,6 +179,50 @@ func (in *EmbeddedObjectMeta) DeepCopy() *EmbeddedObjectMeta {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MTLSPortException) DeepCopyInto(out *MTLSPortException) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MTLSPortException.
+func (in *MTLSPortException) DeepCopy() *MTLSPortException {
+	if in == nil {
+		return nil
+	}
+	out := new(MTLSPortException)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MTLSSpec) DeepCopyInto(out *MTLSSpec) {
+	*out = *in
+	if in.PortExceptions != nil {
+		in, out := &in.PortExceptions, &out.PortExceptions
+		*out = make([]MTLSPortException, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MTLSSpec.
+func (in *MTLSSpec) DeepCopy() *MTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiClusterApplicationConfiguration) DeepCopyInto(out *MultiClusterApplicationConfiguration) {
 	*out = *in
@@ -570,6 +629,16 @@ func (in *ProjectTemplate) DeepCopyInto(out *ProjectTemplate) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AllowedEgress != nil {
+		in, out := &in.AllowedEgress, &out.AllowedEgress
+		*out = make([]EgressHost, len(*in))
+		copy(*out, *in)
+	}
+	if in.MTLS != nil {
+		in, out := &in.MTLS, &out.MTLS
+		*out = new(MTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectTemplate.
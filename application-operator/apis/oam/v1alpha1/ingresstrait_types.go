@@ -21,6 +21,11 @@ type IngressTraitSpec struct {
 	// +optional
 	TLS IngressSecurity `json:"tls,omitempty"`
 
+	// Gateway is the name of an additional Istio ingress gateway declared in the Verrazzano CR, such as an internal
+	// gateway, that the application is exposed through.  Default is the istio-ingressgateway.
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+
 	// The WorkloadReference to the workload to which this trait applies.
 	// This value is populated by the OAM runtime when a ApplicationConfiguration
 	// resource is processed.  When the ApplicationConfiguration is processed a trait and
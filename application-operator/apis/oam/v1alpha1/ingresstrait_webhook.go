@@ -88,6 +88,12 @@ func (r *IngressTrait) validateIngressTrait(existingTraits []IngressTrait) error
 	// - "All" ingressTrait's don't conflict with "prefix" ingressTraits which take precedence because they are more specific
 	// - "All" ingressTrait's don't conflict with "exact" ingressTraits which take precedence because they are more specific
 
+	if len(r.Spec.Gateway) > 0 {
+		if errs := k8sValidations.IsDNS1123Label(r.Spec.Gateway); len(errs) > 0 {
+			return fmt.Errorf("IngressTrait gateway '%v' is not a valid name: %v", r.Spec.Gateway, s.Join(errs, ", "))
+		}
+	}
+
 	hostPathMap, e := r.createIngressTraitMap()
 	if e != nil {
 		return e
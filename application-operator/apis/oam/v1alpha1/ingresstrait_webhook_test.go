@@ -184,6 +184,21 @@ func TestValidateCreateInvalidHost(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+// TestValidateCreateGateway tests validation of an IngressTrait create with an ingress gateway specified
+// WHEN validate is called on a new IngressTrait with a valid and an invalid ingress gateway name
+// THEN validate succeeds for the valid name, and fails and returns an error for the invalid name
+func TestValidateCreateGateway(t *testing.T) {
+	originalListIngressTraits := getAllIngressTraits
+	getAllIngressTraits = testListIngressTraits
+	defer func() { getAllIngressTraits = originalListIngressTraits }()
+
+	ingressTrait := IngressTrait{Spec: IngressTraitSpec{Gateway: "internal-gateway"}}
+	assert.Nil(t, ingressTrait.ValidateCreate())
+
+	ingressTrait = IngressTrait{Spec: IngressTraitSpec{Gateway: "Internal_Gateway"}}
+	assert.NotNil(t, ingressTrait.ValidateCreate())
+}
+
 // TestValidateCreateHostAndPathNoExisting tests validation of an IngressTrait create with a specified host and path.
 // GIVEN no existing IngressTrait's
 // WHEN validate is called on a new IngressTrait with a specified host and path
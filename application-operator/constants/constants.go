@@ -103,6 +103,9 @@ const StatusReasonSuccess = "success"
 // OCILoggingIDAnnotation Annotation name for a customized OCI log ID for all containers in a namespace
 const OCILoggingIDAnnotation = "verrazzano.io/oci-log-id"
 
+// LogRoutingClassAnnotation Annotation name for the log routing class of all containers in a namespace or project
+const LogRoutingClassAnnotation = "verrazzano.io/log-routing-class"
+
 // WorkloadTypeCoherence indicates the workload is Coherence
 const WorkloadTypeCoherence = "coherence"
 
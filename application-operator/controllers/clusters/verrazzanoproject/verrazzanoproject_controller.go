@@ -114,6 +114,12 @@ func (r *Reconciler) doReconcile(ctx context.Context, vp clustersv1alpha1.Verraz
 			if err := r.deleteRoleBindings(ctx, &vp, log); err != nil {
 				return reconcile.Result{}, err
 			}
+			if err := r.deleteEgress(ctx, &vp, nil, log); err != nil {
+				return reconcile.Result{}, err
+			}
+			if err := r.deletePeerAuthentications(ctx, &vp, nil, log); err != nil {
+				return reconcile.Result{}, err
+			}
 			// Remove the finalizer and update the Verrazzano resource if the deletion has finished.
 			vp.ObjectMeta.Finalizers = vzstring.RemoveStringFromSlice(vp.ObjectMeta.Finalizers, finalizerName)
 			err := r.Update(ctx, &vp)
@@ -174,6 +180,18 @@ func (r *Reconciler) syncAll(ctx context.Context, vp clustersv1alpha1.Verrazzano
 	if err != nil {
 		return err
 	}
+
+	// Sync the egress resources of the allowed egress hosts
+	err = r.syncEgress(ctx, &vp, log)
+	if err != nil {
+		return err
+	}
+
+	// Sync the PeerAuthentications of the mTLS configuration
+	err = r.syncPeerAuthentications(ctx, &vp, log)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -195,7 +213,7 @@ func (r *Reconciler) createOrUpdateNamespaces(ctx context.Context, vp clustersv1
 			}
 
 			opResult, err := controllerutil.CreateOrUpdate(ctx, r.Client, &namespace, func() error {
-				r.mutateNamespace(nsTemplate, istioInjection, &namespace)
+				r.mutateNamespace(vp, nsTemplate, istioInjection, &namespace)
 				return nil
 			})
 			if err != nil {
@@ -214,10 +232,22 @@ func (r *Reconciler) createOrUpdateNamespaces(ctx context.Context, vp clustersv1
 	return nil
 }
 
-func (r *Reconciler) mutateNamespace(nsTemplate clustersv1alpha1.NamespaceTemplate, istioInjection string, namespace *corev1.Namespace) {
-	namespace.Annotations = nsTemplate.Metadata.Annotations
+func (r *Reconciler) mutateNamespace(vp clustersv1alpha1.VerrazzanoProject, nsTemplate clustersv1alpha1.NamespaceTemplate, istioInjection string, namespace *corev1.Namespace) {
 	namespace.Spec = nsTemplate.Spec
 
+	// Replace the annotations with a copy of the template annotations, so that a log routing class inherited from the
+	// project is removed when the project annotation is removed, then apply the log routing class of the project
+	// unless the namespace specifies its own class
+	namespace.Annotations = make(map[string]string, len(nsTemplate.Metadata.Annotations)+1)
+	for annotation, value := range nsTemplate.Metadata.Annotations {
+		namespace.Annotations[annotation] = value
+	}
+	if class, ok := vp.Annotations[constants.LogRoutingClassAnnotation]; ok {
+		if _, ok := nsTemplate.Metadata.Annotations[constants.LogRoutingClassAnnotation]; !ok {
+			namespace.Annotations[constants.LogRoutingClassAnnotation] = class
+		}
+	}
+
 	// Add Verrazzano generated labels if not already present
 	if namespace.Labels == nil {
 		namespace.Labels = map[string]string{}
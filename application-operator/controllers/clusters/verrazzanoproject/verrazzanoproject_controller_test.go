@@ -21,6 +21,8 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/apis/clusters/v1alpha1"
 	vmcclient "github.com/verrazzano/verrazzano/platform-operator/clients/clusters/clientset/versioned/scheme"
 	"go.uber.org/zap"
+	istioclient "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	clisecurity "istio.io/client-go/pkg/apis/security/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -235,6 +237,8 @@ func TestReconcileVerrazzanoProject(t *testing.T) {
 
 			mocker := gomock.NewController(t)
 			mockClient := mocks.NewMockClient(mocker)
+			mockEgressListExpectations(mockClient)
+			mockMTLSListExpectations(mockClient)
 			mockStatusWriter := mocks.NewMockStatusWriter(mocker)
 
 			expectedAdminSubjects := defaultAdminSubjects
@@ -380,6 +384,8 @@ func TestNetworkPolicies(t *testing.T) {
 
 	mocker := gomock.NewController(t)
 	mockClient := mocks.NewMockClient(mocker)
+	mockEgressListExpectations(mockClient)
+	mockMTLSListExpectations(mockClient)
 	mockStatusWriter := mocks.NewMockStatusWriter(mocker)
 
 	// Expect call to get the project
@@ -522,6 +528,8 @@ func TestDeleteVerrazzanoProjectFinalizer(t *testing.T) {
 
 	mocker := gomock.NewController(t)
 	mockClient := mocks.NewMockClient(mocker)
+	mockEgressListExpectations(mockClient)
+	mockMTLSListExpectations(mockClient)
 
 	// Expect call to get the project
 	mockClient.EXPECT().
@@ -628,6 +636,28 @@ func newVerrazzanoProjectReconciler(c client.Client) Reconciler {
 	}
 }
 
+// mockEgressListExpectations mocks the expectations for listing the egress resources of a project, which
+// returns no resources
+func mockEgressListExpectations(mockClient *mocks.MockClient) {
+	mockClient.EXPECT().
+		List(gomock.Any(), gomock.AssignableToTypeOf(&istioclient.ServiceEntryList{}), gomock.Any(), gomock.Any()).
+		Return(nil).AnyTimes()
+	mockClient.EXPECT().
+		List(gomock.Any(), gomock.AssignableToTypeOf(&istioclient.GatewayList{}), gomock.Any(), gomock.Any()).
+		Return(nil).AnyTimes()
+	mockClient.EXPECT().
+		List(gomock.Any(), gomock.AssignableToTypeOf(&istioclient.VirtualServiceList{}), gomock.Any(), gomock.Any()).
+		Return(nil).AnyTimes()
+}
+
+// mockMTLSListExpectations mocks the expectations for listing the PeerAuthentication resources of a project, which
+// returns no resources
+func mockMTLSListExpectations(mockClient *mocks.MockClient) {
+	mockClient.EXPECT().
+		List(gomock.Any(), gomock.AssignableToTypeOf(&clisecurity.PeerAuthenticationList{}), gomock.Any(), gomock.Any()).
+		Return(nil).AnyTimes()
+}
+
 // mockClusterRoleBindingNoDelete mocks the expectations for deleting the managed cluster rolebinding
 func mockClusterRoleBindingNoDelete(assert *asserts.Assertions, mockClient *mocks.MockClient, name string) {
 	// Expect call to get list of VerrazzanoProjects
@@ -806,3 +836,64 @@ func TestReconcileKubeSystem(t *testing.T) {
 	assert.Nil(err)
 	assert.True(result.IsZero())
 }
+
+// TestMutateNamespaceLogRoutingClass tests applying the log routing class of a project to its namespaces
+// GIVEN a project with a log routing class annotation, and namespace templates with and without their own class
+// WHEN mutateNamespace is called
+// THEN the namespace without a class gets the project class, the namespace with a class keeps its class, and the
+// namespace templates are not modified
+func TestMutateNamespaceLogRoutingClass(t *testing.T) {
+	assert := asserts.New(t)
+	reconciler := Reconciler{}
+	vp := clustersv1alpha1.VerrazzanoProject{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{constants.LogRoutingClassAnnotation: "gold"},
+		},
+	}
+
+	nsTemplate := clustersv1alpha1.NamespaceTemplate{
+		Metadata: metav1.ObjectMeta{Name: "ns1", Annotations: map[string]string{"key": "value"}},
+	}
+	namespace := corev1.Namespace{}
+	reconciler.mutateNamespace(vp, nsTemplate, "enabled", &namespace)
+	assert.Equal(map[string]string{constants.LogRoutingClassAnnotation: "gold", "key": "value"}, namespace.Annotations)
+	assert.Equal(map[string]string{"key": "value"}, nsTemplate.Metadata.Annotations)
+
+	nsTemplate = clustersv1alpha1.NamespaceTemplate{
+		Metadata: metav1.ObjectMeta{Name: "ns2", Annotations: map[string]string{constants.LogRoutingClassAnnotation: "silver"}},
+	}
+	namespace = corev1.Namespace{}
+	reconciler.mutateNamespace(vp, nsTemplate, "enabled", &namespace)
+	assert.Equal("silver", namespace.Annotations[constants.LogRoutingClassAnnotation])
+}
+
+// TestMutateNamespaceLogRoutingClassRemoved tests removing the log routing class of a project
+// GIVEN a namespace that inherited the log routing class of its project
+// WHEN the log routing class annotation is removed from the project and mutateNamespace is called
+// THEN the inherited class is removed from the namespace, and the template annotations are kept
+func TestMutateNamespaceLogRoutingClassRemoved(t *testing.T) {
+	assert := asserts.New(t)
+	reconciler := Reconciler{}
+	vp := clustersv1alpha1.VerrazzanoProject{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{constants.LogRoutingClassAnnotation: "gold"},
+		},
+	}
+	nsTemplate := clustersv1alpha1.NamespaceTemplate{
+		Metadata: metav1.ObjectMeta{Name: "ns1", Annotations: map[string]string{"key": "value"}},
+	}
+	namespace := corev1.Namespace{}
+	reconciler.mutateNamespace(vp, nsTemplate, "enabled", &namespace)
+	assert.Equal("gold", namespace.Annotations[constants.LogRoutingClassAnnotation])
+
+	vp.Annotations = nil
+	reconciler.mutateNamespace(vp, nsTemplate, "enabled", &namespace)
+	assert.Equal(map[string]string{"key": "value"}, namespace.Annotations)
+
+	// A template without annotations
+	namespace.Annotations = map[string]string{constants.LogRoutingClassAnnotation: "gold"}
+	nsTemplate.Metadata.Annotations = nil
+	reconciler.mutateNamespace(vp, nsTemplate, "enabled", &namespace)
+	assert.Empty(namespace.Annotations)
+	assert.Nil(nsTemplate.Metadata.Annotations)
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzanoproject
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	vzlog2 "github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	istionet "istio.io/api/networking/v1alpha3"
+	istioclient "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// projectEgressLabel is the label of the egress resources, whose value is the name of the project
+	projectEgressLabel = "verrazzano.io/project-egress"
+
+	istioSystemNamespace    = "istio-system"
+	meshGateway             = "mesh"
+	egressGatewayHost       = "istio-egressgateway.istio-system.svc.cluster.local"
+	egressGatewaySelector   = "egressgateway"
+	egressGatewayTLSPort    = 443
+	egressGatewayHTTPPort   = 80
+	defaultEgressTLSPort    = 443
+	defaultEgressHTTPPort   = 80
+	egressResourceTemplate  = "egress-%s-%d"
+	egressGatewayNamePrefix = "egress-gw-"
+)
+
+// syncEgress syncs the Istio resources that route the traffic to the allowed egress hosts of the project through the
+// egress gateway.  A ServiceEntry, a Gateway and a VirtualService are created in each project namespace for each host.
+func (r *Reconciler) syncEgress(ctx context.Context, project *clustersv1alpha1.VerrazzanoProject, log vzlog2.VerrazzanoLogger) error {
+	desiredSet := make(map[string]bool)
+	for _, ns := range project.Spec.Template.Namespaces {
+		for _, egress := range project.Spec.Template.AllowedEgress {
+			desiredSet[ns.Metadata.Name+getEgressResourceName(egress)] = true
+			desiredSet[ns.Metadata.Name+getEgressGatewayName(egress)] = true
+			if err := r.createOrUpdateEgress(ctx, project.Name, ns.Metadata.Name, egress); err != nil {
+				log.Errorf("Failed to create or update the egress resources of host %s in namespace %s: %v", egress.Host, ns.Metadata.Name, err)
+				return err
+			}
+		}
+	}
+	// Delete the egress resources of hosts that are no longer allowed
+	return r.deleteEgress(ctx, project, desiredSet, log)
+}
+
+// createOrUpdateEgress creates or updates the ServiceEntry, Gateway and VirtualService of an allowed egress host
+func (r *Reconciler) createOrUpdateEgress(ctx context.Context, projectName string, namespace string, egress clustersv1alpha1.EgressHost) error {
+	name := getEgressResourceName(egress)
+	gwName := getEgressGatewayName(egress)
+	port := getEgressPort(egress)
+	protocol := getEgressProtocol(egress)
+	labels := map[string]string{projectEgressLabel: projectName}
+
+	serviceEntry := &istioclient.ServiceEntry{}
+	serviceEntry.Namespace = namespace
+	serviceEntry.Name = name
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, serviceEntry, func() error {
+		serviceEntry.Labels = labels
+		serviceEntry.Spec = istionet.ServiceEntry{
+			Hosts: []string{egress.Host},
+			Ports: []*istionet.Port{{
+				Number:   port,
+				Name:     formatEgressPortName(protocol, port),
+				Protocol: string(protocol),
+			}},
+			Location:   istionet.ServiceEntry_MESH_EXTERNAL,
+			Resolution: istionet.ServiceEntry_DNS,
+			// The egress gateway needs to resolve the host too
+			ExportTo: []string{".", istioSystemNamespace},
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	gateway := &istioclient.Gateway{}
+	gateway.Namespace = namespace
+	gateway.Name = gwName
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, gateway, func() error {
+		gateway.Labels = labels
+		gateway.Spec = istionet.Gateway{
+			Selector: map[string]string{"istio": egressGatewaySelector},
+			Servers:  []*istionet.Server{buildEgressGatewayServer(egress.Host, protocol)},
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	virtualService := &istioclient.VirtualService{}
+	virtualService.Namespace = namespace
+	virtualService.Name = name
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, virtualService, func() error {
+		virtualService.Labels = labels
+		virtualService.Spec = buildEgressVirtualService(egress.Host, port, protocol, gwName)
+		return nil
+	})
+	return err
+}
+
+// buildEgressGatewayServer returns the egress gateway server of a host.  TLS traffic is passed through
+// to the host by SNI, without being terminated by the egress gateway.
+func buildEgressGatewayServer(host string, protocol clustersv1alpha1.EgressProtocol) *istionet.Server {
+	if protocol == clustersv1alpha1.EgressProtocolHTTP {
+		return &istionet.Server{
+			Hosts: []string{host},
+			Port: &istionet.Port{
+				Number:   egressGatewayHTTPPort,
+				Name:     formatEgressPortName(protocol, egressGatewayHTTPPort),
+				Protocol: string(protocol),
+			},
+		}
+	}
+	return &istionet.Server{
+		Hosts: []string{host},
+		Port: &istionet.Port{
+			Number:   egressGatewayTLSPort,
+			Name:     formatEgressPortName(protocol, egressGatewayTLSPort),
+			Protocol: string(protocol),
+		},
+		Tls: &istionet.ServerTLSSettings{Mode: istionet.ServerTLSSettings_PASSTHROUGH},
+	}
+}
+
+// buildEgressVirtualService returns the VirtualService spec that routes the traffic of the sidecars to the egress
+// gateway, and the traffic of the egress gateway to the host
+func buildEgressVirtualService(host string, port uint32, protocol clustersv1alpha1.EgressProtocol, gwName string) istionet.VirtualService {
+	spec := istionet.VirtualService{
+		Hosts:    []string{host},
+		Gateways: []string{meshGateway, gwName},
+		ExportTo: []string{".", istioSystemNamespace},
+	}
+	if protocol == clustersv1alpha1.EgressProtocolHTTP {
+		spec.Http = []*istionet.HTTPRoute{
+			{
+				Match: []*istionet.HTTPMatchRequest{{Gateways: []string{meshGateway}, Port: port}},
+				Route: []*istionet.HTTPRouteDestination{{Destination: &istionet.Destination{
+					Host: egressGatewayHost,
+					Port: &istionet.PortSelector{Number: egressGatewayHTTPPort},
+				}}},
+			},
+			{
+				Match: []*istionet.HTTPMatchRequest{{Gateways: []string{gwName}, Port: egressGatewayHTTPPort}},
+				Route: []*istionet.HTTPRouteDestination{{Destination: &istionet.Destination{
+					Host: host,
+					Port: &istionet.PortSelector{Number: port},
+				}}},
+			},
+		}
+		return spec
+	}
+	spec.Tls = []*istionet.TLSRoute{
+		{
+			Match: []*istionet.TLSMatchAttributes{{Gateways: []string{meshGateway}, Port: port, SniHosts: []string{host}}},
+			Route: []*istionet.RouteDestination{{Destination: &istionet.Destination{
+				Host: egressGatewayHost,
+				Port: &istionet.PortSelector{Number: egressGatewayTLSPort},
+			}}},
+		},
+		{
+			Match: []*istionet.TLSMatchAttributes{{Gateways: []string{gwName}, Port: egressGatewayTLSPort, SniHosts: []string{host}}},
+			Route: []*istionet.RouteDestination{{Destination: &istionet.Destination{
+				Host: host,
+				Port: &istionet.PortSelector{Number: port},
+			}}},
+		},
+	}
+	return spec
+}
+
+// deleteEgress deletes the egress resources of the project that are not in the desired set.  All the egress
+// resources of the project are deleted if the desired set is nil.
+func (r *Reconciler) deleteEgress(ctx context.Context, project *clustersv1alpha1.VerrazzanoProject, desiredSet map[string]bool, log vzlog2.VerrazzanoLogger) error {
+	for _, ns := range project.Spec.Template.Namespaces {
+		listOptions := []client.ListOption{client.InNamespace(ns.Metadata.Name), client.MatchingLabels{projectEgressLabel: project.Name}}
+		var objects []client.Object
+
+		serviceEntries := istioclient.ServiceEntryList{}
+		if err := r.List(ctx, &serviceEntries, listOptions...); err != nil {
+			return err
+		}
+		for i := range serviceEntries.Items {
+			objects = append(objects, &serviceEntries.Items[i])
+		}
+		gateways := istioclient.GatewayList{}
+		if err := r.List(ctx, &gateways, listOptions...); err != nil {
+			return err
+		}
+		for i := range gateways.Items {
+			objects = append(objects, &gateways.Items[i])
+		}
+		virtualServices := istioclient.VirtualServiceList{}
+		if err := r.List(ctx, &virtualServices, listOptions...); err != nil {
+			return err
+		}
+		for i := range virtualServices.Items {
+			objects = append(objects, &virtualServices.Items[i])
+		}
+
+		for _, obj := range objects {
+			if desiredSet != nil && desiredSet[obj.GetNamespace()+obj.GetName()] {
+				continue
+			}
+			log.Debugf("Deleting egress resource %s from namespace %s", obj.GetName(), obj.GetNamespace())
+			if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+				log.Errorf("Failed to delete egress resource %s from namespace %s during cleanup of project: %v", obj.GetName(),
+					obj.GetNamespace(), err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getEgressResourceName returns the name of the ServiceEntry and VirtualService of an allowed egress host
+func getEgressResourceName(egress clustersv1alpha1.EgressHost) string {
+	return fmt.Sprintf(egressResourceTemplate, egress.Host, getEgressPort(egress))
+}
+
+// getEgressGatewayName returns the name of the Gateway of an allowed egress host
+func getEgressGatewayName(egress clustersv1alpha1.EgressHost) string {
+	return egressGatewayNamePrefix + getEgressResourceName(egress)
+}
+
+// getEgressProtocol returns the protocol of an allowed egress host, which defaults to TLS
+func getEgressProtocol(egress clustersv1alpha1.EgressHost) clustersv1alpha1.EgressProtocol {
+	if egress.Protocol == clustersv1alpha1.EgressProtocolHTTP {
+		return clustersv1alpha1.EgressProtocolHTTP
+	}
+	return clustersv1alpha1.EgressProtocolTLS
+}
+
+// getEgressPort returns the port of an allowed egress host, which defaults to the well-known port of the protocol
+func getEgressPort(egress clustersv1alpha1.EgressHost) uint32 {
+	if egress.Port != 0 {
+		return egress.Port
+	}
+	if getEgressProtocol(egress) == clustersv1alpha1.EgressProtocolHTTP {
+		return defaultEgressHTTPPort
+	}
+	return defaultEgressTLSPort
+}
+
+// formatEgressPortName returns the name of a port, such as tls-443
+func formatEgressPortName(protocol clustersv1alpha1.EgressProtocol, port uint32) string {
+	return fmt.Sprintf("%s-%d", strings.ToLower(string(protocol)), port)
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzanoproject
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	asserts "github.com/stretchr/testify/assert"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	istionet "istio.io/api/networking/v1alpha3"
+	istioclient "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+// thirdPartyNetworkPolicyTemplate is the template of the network policies of the istio-system namespace
+const thirdPartyNetworkPolicyTemplate = "../../../../platform-operator/helm_config/charts/verrazzano/templates/thirdparty-networkpolicy.yaml"
+
+// newEgressTestProject returns a project with two namespaces and the given allowed egress hosts
+func newEgressTestProject(egress ...clustersv1alpha1.EgressHost) *clustersv1alpha1.VerrazzanoProject {
+	return &clustersv1alpha1.VerrazzanoProject{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.VerrazzanoMultiClusterNamespace, Name: "egress-project"},
+		Spec: clustersv1alpha1.VerrazzanoProjectSpec{
+			Template: clustersv1alpha1.ProjectTemplate{
+				Namespaces:    []clustersv1alpha1.NamespaceTemplate{ns1, {Metadata: metav1.ObjectMeta{Name: "ns2"}}},
+				AllowedEgress: egress,
+			},
+		},
+	}
+}
+
+// TestSyncEgress tests syncing the egress resources of a project
+// GIVEN a project with a TLS and an HTTP allowed egress host
+// WHEN syncEgress is called
+// THEN a ServiceEntry, a Gateway and a VirtualService are created in each project namespace for each host, which
+// route the traffic through the egress gateway
+func TestSyncEgress(t *testing.T) {
+	assert := asserts.New(t)
+	scheme := newEgressTestScheme()
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := Reconciler{Client: c, Scheme: scheme}
+	project := newEgressTestProject(
+		clustersv1alpha1.EgressHost{Host: "api.example.com"},
+		clustersv1alpha1.EgressHost{Host: "www.example.com", Port: 8080, Protocol: clustersv1alpha1.EgressProtocolHTTP})
+
+	assert.NoError(reconciler.syncEgress(context.TODO(), project, vzlog.DefaultLogger()))
+
+	for _, ns := range []string{"ns1", "ns2"} {
+		se := istioclient.ServiceEntry{}
+		assert.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: "egress-api.example.com-443"}, &se))
+		assert.Equal("egress-project", se.Labels[projectEgressLabel])
+		assert.Equal([]string{"api.example.com"}, se.Spec.Hosts)
+		assert.Equal(uint32(443), se.Spec.Ports[0].Number)
+		assert.Equal("TLS", se.Spec.Ports[0].Protocol)
+		assert.Equal(istionet.ServiceEntry_MESH_EXTERNAL, se.Spec.Location)
+		assert.Equal(istionet.ServiceEntry_DNS, se.Spec.Resolution)
+		assert.Equal([]string{".", "istio-system"}, se.Spec.ExportTo)
+
+		gw := istioclient.Gateway{}
+		assert.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: "egress-gw-egress-api.example.com-443"}, &gw))
+		assert.Equal(map[string]string{"istio": "egressgateway"}, gw.Spec.Selector)
+		assert.Equal([]string{"api.example.com"}, gw.Spec.Servers[0].Hosts)
+		assert.Equal(istionet.ServerTLSSettings_PASSTHROUGH, gw.Spec.Servers[0].Tls.Mode)
+
+		vs := istioclient.VirtualService{}
+		assert.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: "egress-api.example.com-443"}, &vs))
+		assert.Equal([]string{"mesh", "egress-gw-egress-api.example.com-443"}, vs.Spec.Gateways)
+		assert.Len(vs.Spec.Tls, 2)
+		assert.Equal(egressGatewayHost, vs.Spec.Tls[0].Route[0].Destination.Host)
+		assert.Equal("api.example.com", vs.Spec.Tls[1].Route[0].Destination.Host)
+		assert.Equal(uint32(443), vs.Spec.Tls[1].Route[0].Destination.Port.Number)
+
+		vs = istioclient.VirtualService{}
+		assert.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: "egress-www.example.com-8080"}, &vs))
+		assert.Len(vs.Spec.Http, 2)
+		assert.Equal(uint32(8080), vs.Spec.Http[0].Match[0].Port)
+		assert.Equal(egressGatewayHost, vs.Spec.Http[0].Route[0].Destination.Host)
+		assert.Equal(uint32(80), vs.Spec.Http[0].Route[0].Destination.Port.Number)
+		assert.Equal("www.example.com", vs.Spec.Http[1].Route[0].Destination.Host)
+		assert.Equal(uint32(8080), vs.Spec.Http[1].Route[0].Destination.Port.Number)
+	}
+}
+
+// TestSyncEgressDelete tests deleting the egress resources of a project
+// GIVEN a project whose allowed egress hosts have been synced
+// WHEN a host is removed from the project and syncEgress is called, and then deleteEgress is called without a
+// desired set
+// THEN the resources of the removed host are deleted, and then all the egress resources of the project are deleted
+func TestSyncEgressDelete(t *testing.T) {
+	assert := asserts.New(t)
+	scheme := newEgressTestScheme()
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := Reconciler{Client: c, Scheme: scheme}
+	project := newEgressTestProject(
+		clustersv1alpha1.EgressHost{Host: "api.example.com"},
+		clustersv1alpha1.EgressHost{Host: "old.example.com"})
+	assert.NoError(reconciler.syncEgress(context.TODO(), project, vzlog.DefaultLogger()))
+
+	project.Spec.Template.AllowedEgress = project.Spec.Template.AllowedEgress[:1]
+	assert.NoError(reconciler.syncEgress(context.TODO(), project, vzlog.DefaultLogger()))
+	serviceEntries := istioclient.ServiceEntryList{}
+	assert.NoError(c.List(context.TODO(), &serviceEntries))
+	assert.Len(serviceEntries.Items, 2)
+	for _, se := range serviceEntries.Items {
+		assert.Equal("egress-api.example.com-443", se.Name)
+	}
+	gateways := istioclient.GatewayList{}
+	assert.NoError(c.List(context.TODO(), &gateways))
+	assert.Len(gateways.Items, 2)
+	virtualServices := istioclient.VirtualServiceList{}
+	assert.NoError(c.List(context.TODO(), &virtualServices))
+	assert.Len(virtualServices.Items, 2)
+
+	assert.NoError(reconciler.deleteEgress(context.TODO(), project, nil, vzlog.DefaultLogger()))
+	assert.NoError(c.List(context.TODO(), &serviceEntries))
+	assert.Empty(serviceEntries.Items)
+	assert.NoError(c.List(context.TODO(), &gateways))
+	assert.Empty(gateways.Items)
+	assert.NoError(c.List(context.TODO(), &virtualServices))
+	assert.Empty(virtualServices.Items)
+}
+
+// TestEgressGatewayNetworkPolicy tests that the istio-egressgateway network policy allows the egress traffic
+// GIVEN the gateway ports that the egress VirtualServices route the project traffic to
+// WHEN the istio-egressgateway network policy of the verrazzano chart is read
+// THEN the policy allows the container ports targeted by the gateway ports, and the HTTP port only from the project
+// namespaces
+func TestEgressGatewayNetworkPolicy(t *testing.T) {
+	assert := asserts.New(t)
+
+	// The container ports targeted by the Service ports of the egress gateway
+	targetPorts := map[uint32]int{
+		egressGatewayTLSPort:  8443,
+		egressGatewayHTTPPort: 8080,
+	}
+	vsTLS := buildEgressVirtualService("api.example.com", defaultEgressTLSPort, clustersv1alpha1.EgressProtocolTLS, "gw")
+	vsHTTP := buildEgressVirtualService("api.example.com", defaultEgressHTTPPort, clustersv1alpha1.EgressProtocolHTTP, "gw")
+	var gatewayPorts []uint32
+	for _, tls := range vsTLS.Tls {
+		for _, route := range tls.Route {
+			if route.Destination.Host == egressGatewayHost {
+				gatewayPorts = append(gatewayPorts, route.Destination.Port.Number)
+			}
+		}
+	}
+	for _, http := range vsHTTP.Http {
+		for _, route := range http.Route {
+			if route.Destination.Host == egressGatewayHost {
+				gatewayPorts = append(gatewayPorts, route.Destination.Port.Number)
+			}
+		}
+	}
+	assert.ElementsMatch([]uint32{egressGatewayTLSPort, egressGatewayHTTPPort}, gatewayPorts)
+
+	policy := readThirdPartyNetworkPolicy(t, "istio-egressgateway")
+	for _, gatewayPort := range gatewayPorts {
+		rule := findNetworkPolicyIngressRule(policy, targetPorts[gatewayPort])
+		if !assert.NotNil(rule, "The egress gateway port %d is not allowed", targetPorts[gatewayPort]) {
+			continue
+		}
+		if gatewayPort == egressGatewayHTTPPort {
+			assert.Len(rule.From, 1)
+			assert.Equal(map[string]string{vzconst.VerrazzanoManagedLabelKey: constants.LabelVerrazzanoManagedDefault}, rule.From[0].NamespaceSelector.MatchLabels)
+		}
+	}
+}
+
+// readThirdPartyNetworkPolicy returns the network policy with the given name in the third party network policies
+// template of the verrazzano chart
+func readThirdPartyNetworkPolicy(t *testing.T, name string) *netv1.NetworkPolicy {
+	data, err := os.ReadFile(thirdPartyNetworkPolicyTemplate)
+	asserts.NoError(t, err)
+	for _, doc := range strings.Split(string(data), "\n---\n") {
+		if !strings.Contains(doc, "name: "+name+"\n") || strings.Contains(doc, "{{") {
+			continue
+		}
+		policy := netv1.NetworkPolicy{}
+		asserts.NoError(t, yaml.Unmarshal([]byte(doc), &policy))
+		if policy.Name == name {
+			return &policy
+		}
+	}
+	t.Fatalf("Network policy %s not found in %s", name, thirdPartyNetworkPolicyTemplate)
+	return nil
+}
+
+// findNetworkPolicyIngressRule returns the ingress rule of the network policy that allows the given port
+func findNetworkPolicyIngressRule(policy *netv1.NetworkPolicy, port int) *netv1.NetworkPolicyIngressRule {
+	for i, rule := range policy.Spec.Ingress {
+		for _, rulePort := range rule.Ports {
+			if rulePort.Port != nil && rulePort.Port.IntValue() == port {
+				return &policy.Spec.Ingress[i]
+			}
+		}
+	}
+	return nil
+}
+
+// newEgressTestScheme returns a scheme with the project and Istio networking types
+func newEgressTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clustersv1alpha1.AddToScheme(scheme)
+	_ = istioclient.AddToScheme(scheme)
+	return scheme
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzanoproject
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	vzlog2 "github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	securityv1beta1 "istio.io/api/security/v1beta1"
+	istiotypes "istio.io/api/type/v1beta1"
+	clisecurity "istio.io/client-go/pkg/apis/security/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// projectMTLSLabel is the label of the PeerAuthentication resources, whose value is the name of the project
+	projectMTLSLabel = "verrazzano.io/project-mtls"
+
+	peerAuthenticationName         = "verrazzano-project-mtls"
+	peerAuthenticationNameTemplate = "verrazzano-project-mtls-%s"
+)
+
+// syncPeerAuthentications syncs the PeerAuthentication resources of the project mTLS configuration.  A namespace
+// wide PeerAuthentication is created in each project namespace, together with a PeerAuthentication for each workload
+// selector of the port exceptions.
+func (r *Reconciler) syncPeerAuthentications(ctx context.Context, project *clustersv1alpha1.VerrazzanoProject, log vzlog2.VerrazzanoLogger) error {
+	desiredSet := make(map[string]bool)
+	if project.Spec.Template.MTLS != nil {
+		desired := buildPeerAuthentications(project.Spec.Template.MTLS)
+		for _, ns := range project.Spec.Template.Namespaces {
+			for name, spec := range desired {
+				desiredSet[ns.Metadata.Name+name] = true
+				if err := r.createOrUpdatePeerAuthentication(ctx, project.Name, ns.Metadata.Name, name, spec); err != nil {
+					log.Errorf("Failed to create or update PeerAuthentication %s in namespace %s: %v", name, ns.Metadata.Name, err)
+					return err
+				}
+			}
+		}
+	}
+	// Delete the PeerAuthentication resources that are no longer needed
+	return r.deletePeerAuthentications(ctx, project, desiredSet, log)
+}
+
+// createOrUpdatePeerAuthentication creates or updates a PeerAuthentication of the project
+func (r *Reconciler) createOrUpdatePeerAuthentication(ctx context.Context, projectName string, namespace string, name string, spec *securityv1beta1.PeerAuthentication) error {
+	peerAuthentication := &clisecurity.PeerAuthentication{}
+	peerAuthentication.Namespace = namespace
+	peerAuthentication.Name = name
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, peerAuthentication, func() error {
+		peerAuthentication.Labels = map[string]string{projectMTLSLabel: projectName}
+		peerAuthentication.Spec = *spec
+		return nil
+	})
+	return err
+}
+
+// buildPeerAuthentications returns the PeerAuthentication specs of the project mTLS configuration by name.  The port
+// exceptions with the same selector are rendered in a single PeerAuthentication, because Istio only applies the
+// oldest workload PeerAuthentication when several ones select a workload.  The workload PeerAuthentication does not
+// set a mode, so it inherits the namespace mode for the ports that are not exceptions.  The workload
+// PeerAuthentication is named from a hash of its selector, so that its name does not change when the other port
+// exceptions are added or removed.
+func buildPeerAuthentications(mtls *clustersv1alpha1.MTLSSpec) map[string]*securityv1beta1.PeerAuthentication {
+	specs := map[string]*securityv1beta1.PeerAuthentication{
+		peerAuthenticationName: {
+			Mtls: &securityv1beta1.PeerAuthentication_MutualTLS{Mode: toPeerAuthenticationMode(mtls.Mode)},
+		},
+	}
+	selectorSpecs := make(map[string]*securityv1beta1.PeerAuthentication)
+	for _, exception := range mtls.PortExceptions {
+		selector := labels.SelectorFromSet(exception.Selector).String()
+		spec, ok := selectorSpecs[selector]
+		if !ok {
+			spec = &securityv1beta1.PeerAuthentication{
+				Selector:      &istiotypes.WorkloadSelector{MatchLabels: exception.Selector},
+				PortLevelMtls: map[uint32]*securityv1beta1.PeerAuthentication_MutualTLS{},
+			}
+			selectorSpecs[selector] = spec
+			specs[workloadPeerAuthenticationName(selector)] = spec
+		}
+		spec.PortLevelMtls[exception.Port] = &securityv1beta1.PeerAuthentication_MutualTLS{Mode: toPeerAuthenticationMode(exception.Mode)}
+	}
+	return specs
+}
+
+// workloadPeerAuthenticationName returns the name of the PeerAuthentication of a workload selector
+func workloadPeerAuthenticationName(selector string) string {
+	sum := sha256.Sum256([]byte(selector))
+	return fmt.Sprintf(peerAuthenticationNameTemplate, hex.EncodeToString(sum[:])[:10])
+}
+
+// toPeerAuthenticationMode returns the PeerAuthentication mode of an mTLS mode
+func toPeerAuthenticationMode(mode clustersv1alpha1.MTLSMode) securityv1beta1.PeerAuthentication_MutualTLS_Mode {
+	switch mode {
+	case clustersv1alpha1.MTLSModeStrict:
+		return securityv1beta1.PeerAuthentication_MutualTLS_STRICT
+	case clustersv1alpha1.MTLSModePermissive:
+		return securityv1beta1.PeerAuthentication_MutualTLS_PERMISSIVE
+	case clustersv1alpha1.MTLSModeDisable:
+		return securityv1beta1.PeerAuthentication_MutualTLS_DISABLE
+	}
+	return securityv1beta1.PeerAuthentication_MutualTLS_UNSET
+}
+
+// deletePeerAuthentications deletes the PeerAuthentication resources of the project that are not in the desired set
+func (r *Reconciler) deletePeerAuthentications(ctx context.Context, project *clustersv1alpha1.VerrazzanoProject, desiredSet map[string]bool, log vzlog2.VerrazzanoLogger) error {
+	for _, ns := range project.Spec.Template.Namespaces {
+		peerAuthentications := clisecurity.PeerAuthenticationList{}
+		if err := r.List(ctx, &peerAuthentications, client.InNamespace(ns.Metadata.Name), client.MatchingLabels{projectMTLSLabel: project.Name}); err != nil {
+			return err
+		}
+		for i, peerAuthentication := range peerAuthentications.Items {
+			if desiredSet[peerAuthentication.Namespace+peerAuthentication.Name] {
+				continue
+			}
+			log.Debugf("Deleting PeerAuthentication %s from namespace %s", peerAuthentication.Name, peerAuthentication.Namespace)
+			if err := r.Delete(ctx, &peerAuthentications.Items[i]); client.IgnoreNotFound(err) != nil {
+				log.Errorf("Failed to delete PeerAuthentication %s from namespace %s during cleanup of project: %v", peerAuthentication.Name,
+					peerAuthentication.Namespace, err)
+				return err
+			}
+		}
+	}
+	return nil
+}
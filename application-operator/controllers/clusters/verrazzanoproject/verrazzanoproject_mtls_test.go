@@ -0,0 +1,129 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzanoproject
+
+import (
+	"context"
+	"testing"
+
+	asserts "github.com/stretchr/testify/assert"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	securityv1beta1 "istio.io/api/security/v1beta1"
+	clisecurity "istio.io/client-go/pkg/apis/security/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newMTLSTestProject returns a project with two namespaces and the given mTLS configuration
+func newMTLSTestProject(mtls *clustersv1alpha1.MTLSSpec) *clustersv1alpha1.VerrazzanoProject {
+	return &clustersv1alpha1.VerrazzanoProject{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.VerrazzanoMultiClusterNamespace, Name: "mtls-project"},
+		Spec: clustersv1alpha1.VerrazzanoProjectSpec{
+			Template: clustersv1alpha1.ProjectTemplate{
+				Namespaces: []clustersv1alpha1.NamespaceTemplate{ns1, {Metadata: metav1.ObjectMeta{Name: "ns2"}}},
+				MTLS:       mtls,
+			},
+		},
+	}
+}
+
+// newMTLSTestScheme returns a scheme with the project and Istio security types
+func newMTLSTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clustersv1alpha1.AddToScheme(scheme)
+	_ = clisecurity.AddToScheme(scheme)
+	return scheme
+}
+
+// TestSyncPeerAuthentications tests syncing the PeerAuthentications of a project
+// GIVEN a project with the STRICT mTLS mode and port exceptions for two workload selectors
+// WHEN syncPeerAuthentications is called
+// THEN a namespace wide PeerAuthentication, and a PeerAuthentication for each selector with the port modes, are
+// created in each project namespace
+func TestSyncPeerAuthentications(t *testing.T) {
+	assert := asserts.New(t)
+	scheme := newMTLSTestScheme()
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := Reconciler{Client: c, Scheme: scheme}
+	project := newMTLSTestProject(&clustersv1alpha1.MTLSSpec{
+		Mode: clustersv1alpha1.MTLSModeStrict,
+		PortExceptions: []clustersv1alpha1.MTLSPortException{
+			{Selector: map[string]string{"app": "legacy"}, Port: 8080, Mode: clustersv1alpha1.MTLSModePermissive},
+			{Selector: map[string]string{"app": "metrics"}, Port: 9090, Mode: clustersv1alpha1.MTLSModeDisable},
+			{Selector: map[string]string{"app": "legacy"}, Port: 8081, Mode: clustersv1alpha1.MTLSModeDisable},
+		},
+	})
+
+	assert.NoError(reconciler.syncPeerAuthentications(context.TODO(), project, vzlog.DefaultLogger()))
+
+	for _, ns := range []string{"ns1", "ns2"} {
+		pa := clisecurity.PeerAuthentication{}
+		assert.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: "verrazzano-project-mtls"}, &pa))
+		assert.Equal("mtls-project", pa.Labels[projectMTLSLabel])
+		assert.Nil(pa.Spec.Selector)
+		assert.Equal(securityv1beta1.PeerAuthentication_MutualTLS_STRICT, pa.Spec.Mtls.Mode)
+
+		pa = clisecurity.PeerAuthentication{}
+		assert.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: workloadPeerAuthenticationName("app=legacy")}, &pa))
+		assert.Equal(map[string]string{"app": "legacy"}, pa.Spec.Selector.MatchLabels)
+		assert.Nil(pa.Spec.Mtls)
+		assert.Len(pa.Spec.PortLevelMtls, 2)
+		assert.Equal(securityv1beta1.PeerAuthentication_MutualTLS_PERMISSIVE, pa.Spec.PortLevelMtls[8080].Mode)
+		assert.Equal(securityv1beta1.PeerAuthentication_MutualTLS_DISABLE, pa.Spec.PortLevelMtls[8081].Mode)
+
+		pa = clisecurity.PeerAuthentication{}
+		assert.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: workloadPeerAuthenticationName("app=metrics")}, &pa))
+		assert.Equal(map[string]string{"app": "metrics"}, pa.Spec.Selector.MatchLabels)
+		assert.Equal(securityv1beta1.PeerAuthentication_MutualTLS_DISABLE, pa.Spec.PortLevelMtls[9090].Mode)
+	}
+}
+
+// TestSyncPeerAuthenticationsDelete tests deleting the PeerAuthentications of a project
+// GIVEN a project whose mTLS configuration with port exceptions for two workload selectors has been synced
+// WHEN the first port exception is removed, then the other port exceptions are removed, and then the mTLS
+// configuration is removed, and syncPeerAuthentications is called after each change
+// THEN the PeerAuthentication of the removed selector is deleted while the other one keeps its name, then the
+// workload PeerAuthentications are deleted, and then all the PeerAuthentications of the project are deleted
+func TestSyncPeerAuthenticationsDelete(t *testing.T) {
+	assert := asserts.New(t)
+	scheme := newMTLSTestScheme()
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := Reconciler{Client: c, Scheme: scheme}
+	project := newMTLSTestProject(&clustersv1alpha1.MTLSSpec{
+		Mode: clustersv1alpha1.MTLSModePermissive,
+		PortExceptions: []clustersv1alpha1.MTLSPortException{
+			{Selector: map[string]string{"app": "legacy"}, Port: 8080, Mode: clustersv1alpha1.MTLSModeDisable},
+			{Selector: map[string]string{"app": "metrics"}, Port: 9090, Mode: clustersv1alpha1.MTLSModeDisable},
+		},
+	})
+	assert.NoError(reconciler.syncPeerAuthentications(context.TODO(), project, vzlog.DefaultLogger()))
+	peerAuthentications := clisecurity.PeerAuthenticationList{}
+	assert.NoError(c.List(context.TODO(), &peerAuthentications))
+	assert.Len(peerAuthentications.Items, 6)
+
+	project.Spec.Template.MTLS.PortExceptions = project.Spec.Template.MTLS.PortExceptions[1:]
+	assert.NoError(reconciler.syncPeerAuthentications(context.TODO(), project, vzlog.DefaultLogger()))
+	assert.NoError(c.List(context.TODO(), &peerAuthentications))
+	assert.Len(peerAuthentications.Items, 4)
+	pa := clisecurity.PeerAuthentication{}
+	assert.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: "ns1", Name: workloadPeerAuthenticationName("app=metrics")}, &pa))
+	assert.Equal(securityv1beta1.PeerAuthentication_MutualTLS_DISABLE, pa.Spec.PortLevelMtls[9090].Mode)
+
+	project.Spec.Template.MTLS.PortExceptions = nil
+	assert.NoError(reconciler.syncPeerAuthentications(context.TODO(), project, vzlog.DefaultLogger()))
+	assert.NoError(c.List(context.TODO(), &peerAuthentications))
+	assert.Len(peerAuthentications.Items, 2)
+	for _, pa := range peerAuthentications.Items {
+		assert.Equal("verrazzano-project-mtls", pa.Name)
+	}
+
+	project.Spec.Template.MTLS = nil
+	assert.NoError(reconciler.syncPeerAuthentications(context.TODO(), project, vzlog.DefaultLogger()))
+	assert.NoError(c.List(context.TODO(), &peerAuthentications))
+	assert.Empty(peerAuthentications.Items)
+}
@@ -89,7 +89,7 @@ func Test_isConsoleIngressUpdated(t *testing.T) {
 // Test_isIstioIngressGatewayUpdated tests the isIstioIngressGatewayUpdated func for the following use case.
 // GIVEN a request to isIstioIngressGatewayUpdated
 // WHEN only the IstioIngressGateway has changed
-// THEN true is returned only when the IstioIngressGateway has changed, false otherwise
+// THEN true is returned only when the IstioIngressGateway or an additional ingress gateway has changed, false otherwise
 func Test_isIstioIngressGatewayUpdated(t *testing.T) {
 
 	asserts := assert.New(t)
@@ -132,6 +132,28 @@ func Test_isIstioIngressGatewayUpdated(t *testing.T) {
 		ObjectOld: oldOtherIngress,
 		ObjectNew: newOtherIngress,
 	}))
+
+	// An additional ingress gateway is labeled with its name, unlike the egress gateway
+	oldGatewaySvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal-gateway", Namespace: constants.IstioSystemNamespace, Labels: map[string]string{"istio": "internal-gateway"}},
+		Spec:       corev1.ServiceSpec{Type: "LoadBalancer"},
+	}
+	newGatewaySvc := oldGatewaySvc.DeepCopyObject().(*corev1.Service)
+	newGatewaySvc.Spec.Type = "NodePort"
+	asserts.True(r.isIstioIngressGatewayUpdated(event.UpdateEvent{
+		ObjectOld: oldGatewaySvc,
+		ObjectNew: newGatewaySvc,
+	}))
+
+	oldEgressSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio-egressgateway", Namespace: constants.IstioSystemNamespace, Labels: map[string]string{"istio": "egressgateway"}},
+	}
+	newEgressSvc := oldEgressSvc.DeepCopyObject().(*corev1.Service)
+	newEgressSvc.Spec.Type = "NodePort"
+	asserts.False(r.isIstioIngressGatewayUpdated(event.UpdateEvent{
+		ObjectOld: oldEgressSvc,
+		ObjectNew: newEgressSvc,
+	}))
 }
 
 // Test_createIngressTraitReconcileRequests tests the createIngressTraitReconcileRequests func for the following use case.
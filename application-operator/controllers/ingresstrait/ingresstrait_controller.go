@@ -76,6 +76,7 @@ const (
 	controllerName            = "ingresstrait"
 	httpsProtocol             = "HTTPS"
 	istioIngressGateway       = "istio-ingressgateway"
+	istioIngressGatewayLabel  = "ingressgateway"
 	finalizerName             = "ingresstrait.finalizers.verrazzano.io"
 )
 
@@ -244,12 +245,19 @@ func (r *Reconciler) createOrUpdateChildResources(ctx context.Context, trait *vz
 	secretName := r.createOrUseGatewaySecret(ctx, trait, allHostsForTrait, &status, log)
 	if secretName != "" {
 		gwName, err := buildGatewayName(trait)
+		if err == nil {
+			err = r.validateIngressGateway(ctx, trait)
+		}
 		if err != nil {
 			status.Errors = append(status.Errors, err)
 		} else {
 			// The Gateway is shared across all traits, update it with all known hosts for the trait
 			// - Must create GW before service so that external DNS sees the GW once the service is created
 			gateway := r.createOrUpdateGateway(ctx, trait, allHostsForTrait, gwName, secretName, &status, log)
+			// Remove the trait from the Gateway of the ingress gateway that the trait used before, if any
+			if err := removeServerFromOtherGateways(trait, gwName, r.Client, log); err != nil {
+				status.Errors = append(status.Errors, err)
+			}
 			for index, rule := range rules {
 				// Find the services associated with the trait in the application configuration.
 				var services []*corev1.Service
@@ -266,7 +274,7 @@ func (r *Reconciler) createOrUpdateChildResources(ctx context.Context, trait *vz
 				authzPolicyName := fmt.Sprintf("%s-rule-%d-authz", trait.Name, index)
 				r.createOrUpdateVirtualService(ctx, trait, rule, allHostsForTrait, vsName, services, gateway, &status, log)
 				r.createOrUpdateDestinationRule(ctx, trait, rule, drName, &status, log, services)
-				r.createOrUpdateAuthorizationPolicies(ctx, rule, authzPolicyName, allHostsForTrait, &status, log)
+				r.createOrUpdateAuthorizationPolicies(ctx, trait, rule, authzPolicyName, allHostsForTrait, &status, log)
 			}
 		}
 	}
@@ -284,17 +292,50 @@ func (r *Reconciler) coallateAllHostsForTrait(trait *vzapi.IngressTrait, status
 	return allHosts
 }
 
-// buildGatewayName will generate a gateway name from the namespace and application name of the provided trait. Returns
-// an error if the app name is not available.
+// buildGatewayName will generate a gateway name from the namespace and application name of the provided trait, and
+// the ingress gateway of the trait if it is not the default. Returns an error if the app name is not available.
 func buildGatewayName(trait *vzapi.IngressTrait) (string, error) {
 	appName, ok := trait.Labels[oam.LabelAppName]
 	if !ok {
 		return "", errors.New("OAM app name label missing from metadata, unable to generate gateway name")
 	}
+	if len(trait.Spec.Gateway) > 0 {
+		return fmt.Sprintf("%s-%s-%s-gw", trait.Namespace, appName, trait.Spec.Gateway), nil
+	}
 	gwName := fmt.Sprintf("%s-%s-gw", trait.Namespace, appName)
 	return gwName, nil
 }
 
+// getIngressGatewayName returns the name of the Service of the Istio ingress gateway that the trait is exposed through
+func getIngressGatewayName(trait *vzapi.IngressTrait) string {
+	if len(trait.Spec.Gateway) > 0 {
+		return trait.Spec.Gateway
+	}
+	return istioIngressGateway
+}
+
+// getIngressGatewaySelector returns the labels of the pods of the Istio ingress gateway that the trait is exposed
+// through.  The additional ingress gateways of the Verrazzano CR are labeled with their name.
+func getIngressGatewaySelector(trait *vzapi.IngressTrait) map[string]string {
+	if len(trait.Spec.Gateway) > 0 {
+		return map[string]string{"istio": trait.Spec.Gateway}
+	}
+	return map[string]string{"istio": istioIngressGatewayLabel}
+}
+
+// validateIngressGateway returns an error if the trait selects an Istio ingress gateway that is not installed
+func (r *Reconciler) validateIngressGateway(ctx context.Context, trait *vzapi.IngressTrait) error {
+	if len(trait.Spec.Gateway) == 0 {
+		return nil
+	}
+	svc := corev1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Name: trait.Spec.Gateway, Namespace: constants.IstioSystemNamespace}, &svc)
+	if k8serrors.IsNotFound(err) {
+		return fmt.Errorf("Istio ingress gateway %s of ingress trait %s does not exist, it must be declared in the Verrazzano CR", trait.Spec.Gateway, trait.Name)
+	}
+	return err
+}
+
 // buildCertificateName will construct a cert name from the trait.
 func buildCertificateName(trait *vzapi.IngressTrait) string {
 	return fmt.Sprintf("%s-%s-cert", trait.Namespace, trait.Name)
@@ -584,7 +625,7 @@ func (r *Reconciler) mutateGateway(gateway *istioclient.Gateway, trait *vzapi.In
 	gateway.Spec.Servers = r.updateGatewayServersList(gateway.Spec.Servers, server)
 
 	// Set the spec content.
-	gateway.Spec.Selector = map[string]string{"istio": "ingressgateway"}
+	gateway.Spec.Selector = getIngressGatewaySelector(trait)
 
 	// Set the owner reference.
 	appName, ok := trait.Labels[oam.LabelAppName]
@@ -776,7 +817,7 @@ func (r *Reconciler) mutateDestinationRule(destinationRule *istioclient.Destinat
 }
 
 //createOrUpdateAuthorizationPolicies creates or updates the authorization policies associated with the paths defined in the ingress rule.
-func (r *Reconciler) createOrUpdateAuthorizationPolicies(ctx context.Context, rule vzapi.IngressRule, namePrefix string, hosts []string, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger) {
+func (r *Reconciler) createOrUpdateAuthorizationPolicies(ctx context.Context, trait *vzapi.IngressTrait, rule vzapi.IngressRule, namePrefix string, hosts []string, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger) {
 	for _, path := range rule.Paths {
 		if path.Policy != nil {
 			pathSuffix := strings.Replace(path.Path, "/", "", -1)
@@ -792,7 +833,7 @@ func (r *Reconciler) createOrUpdateAuthorizationPolicies(ctx context.Context, ru
 				},
 			}
 			res, err := controllerutil.CreateOrUpdate(ctx, r.Client, authzPolicy, func() error {
-				return r.mutateAuthorizationPolicy(authzPolicy, path.Policy, path.Path, hosts)
+				return r.mutateAuthorizationPolicy(authzPolicy, path.Policy, path.Path, hosts, getIngressGatewaySelector(trait))
 			})
 
 			ref := vzapi.QualifiedResourceRelation{APIVersion: authzPolicyAPIVersion, Kind: authzPolicyKind, Name: namePrefix, Role: "authorizationpolicy"}
@@ -808,7 +849,7 @@ func (r *Reconciler) createOrUpdateAuthorizationPolicies(ctx context.Context, ru
 }
 
 // mutateDestinationRule changes the destination rule based upon a traits configuration
-func (r *Reconciler) mutateAuthorizationPolicy(authzPolicy *clisecurity.AuthorizationPolicy, vzPolicy *vzapi.AuthorizationPolicy, path string, hosts []string) error {
+func (r *Reconciler) mutateAuthorizationPolicy(authzPolicy *clisecurity.AuthorizationPolicy, vzPolicy *vzapi.AuthorizationPolicy, path string, hosts []string, selector map[string]string) error {
 	policyRules := make([]*v1beta1.Rule, len(vzPolicy.Rules))
 	var err error
 	for i, authzRule := range vzPolicy.Rules {
@@ -819,7 +860,7 @@ func (r *Reconciler) mutateAuthorizationPolicy(authzPolicy *clisecurity.Authoriz
 	}
 	authzPolicy.Spec = v1beta1.AuthorizationPolicy{
 		Selector: &v1beta12.WorkloadSelector{
-			MatchLabels: map[string]string{"istio": "ingressgateway"},
+			MatchLabels: selector,
 		},
 		Rules: policyRules,
 	}
@@ -923,10 +964,14 @@ func (r *Reconciler) isConsoleIngressUpdated(updateEvent event.UpdateEvent) bool
 	return false
 }
 
-// isIstioIngressGatewayUpdated Predicate func used by the watcher
+// isIstioIngressGatewayUpdated Predicate func used by the watcher, for the istio-ingressgateway and the additional
+// ingress gateways, whose Service is labeled with the gateway name
 func (r *Reconciler) isIstioIngressGatewayUpdated(updateEvent event.UpdateEvent) bool {
 	oldSvc := updateEvent.ObjectOld.(*corev1.Service)
-	if oldSvc.Namespace != vzconst.IstioSystemNamespace || oldSvc.Name != istioIngressGateway {
+	if oldSvc.Namespace != vzconst.IstioSystemNamespace {
+		return false
+	}
+	if oldSvc.Name != istioIngressGateway && oldSvc.Labels["istio"] != oldSvc.Name {
 		return false
 	}
 	newSvc := updateEvent.ObjectNew.(*corev1.Service)
@@ -1342,7 +1387,8 @@ func buildNamespacedDomainName(cli client.Reader, trait *vzapi.IngressTrait) (st
 // Get the IP from Istio resources
 func buildDomainNameForWildcard(cli client.Reader, trait *vzapi.IngressTrait, suffix string) (string, error) {
 	istio := corev1.Service{}
-	err := cli.Get(context.TODO(), types.NamespacedName{Name: istioIngressGateway, Namespace: constants.IstioSystemNamespace}, &istio)
+	gatewayName := getIngressGatewayName(trait)
+	err := cli.Get(context.TODO(), types.NamespacedName{Name: gatewayName, Namespace: constants.IstioSystemNamespace}, &istio)
 	if err != nil {
 		return "", err
 	}
@@ -1353,7 +1399,7 @@ func buildDomainNameForWildcard(cli client.Reader, trait *vzapi.IngressTrait, su
 		} else if len(istio.Status.LoadBalancer.Ingress) > 0 {
 			IP = istio.Status.LoadBalancer.Ingress[0].IP
 		} else {
-			return "", fmt.Errorf("%s is missing loadbalancer IP", istioIngressGateway)
+			return "", fmt.Errorf("%s is missing loadbalancer IP", gatewayName)
 		}
 	} else {
 		return "", fmt.Errorf("unsupported service type %s for istio_ingress", string(istio.Spec.Type))
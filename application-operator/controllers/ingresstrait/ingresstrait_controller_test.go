@@ -122,6 +122,7 @@ func TestSuccessfullyCreateNewIngress(t *testing.T) {
 	assert := asserts.New(t)
 	mocker := gomock.NewController(t)
 	mock := mocks.NewMockClient(mocker)
+	listGatewaysExpectations(mock)
 	mockStatus := mocks.NewMockStatusWriter(mocker)
 	getIngressTraitResourceExpectations(mock, assert)
 
@@ -208,6 +209,7 @@ func TestSuccessfullyCreateNewIngressWithCertSecret(t *testing.T) {
 	assert := asserts.New(t)
 	mocker := gomock.NewController(t)
 	mock := mocks.NewMockClient(mocker)
+	listGatewaysExpectations(mock)
 	mockStatus := mocks.NewMockStatusWriter(mocker)
 	// Expect a call to get the ingress trait resource.
 	mock.EXPECT().
@@ -291,6 +293,7 @@ func TestSuccessfullyCreateNewIngressWithAuthorizationPolicy(t *testing.T) {
 	assert := asserts.New(t)
 	mocker := gomock.NewController(t)
 	mock := mocks.NewMockClient(mocker)
+	listGatewaysExpectations(mock)
 	mockStatus := mocks.NewMockStatusWriter(mocker)
 	// Expect a call to get the ingress trait resource.
 	mock.EXPECT().
@@ -400,6 +403,7 @@ func TestSuccessfullyCreateNewIngressWithAuthorizationPolicyMultipleRules(t *tes
 	assert := asserts.New(t)
 	mocker := gomock.NewController(t)
 	mock := mocks.NewMockClient(mocker)
+	listGatewaysExpectations(mock)
 	mockStatus := mocks.NewMockStatusWriter(mocker)
 	// Expect a call to get the ingress trait resource.
 	mock.EXPECT().
@@ -518,6 +522,7 @@ func TestFailureCreateNewIngressWithAuthorizationPolicyNoFromClause(t *testing.T
 	assert := asserts.New(t)
 	mocker := gomock.NewController(t)
 	mock := mocks.NewMockClient(mocker)
+	listGatewaysExpectations(mock)
 	mockStatus := mocks.NewMockStatusWriter(mocker)
 	// Expect a call to get the ingress trait resource.
 	mock.EXPECT().
@@ -626,6 +631,7 @@ func TestSuccessfullyUpdateIngressWithCertSecret(t *testing.T) {
 	assert := asserts.New(t)
 	mocker := gomock.NewController(t)
 	mock := mocks.NewMockClient(mocker)
+	listGatewaysExpectations(mock)
 	mockStatus := mocks.NewMockStatusWriter(mocker)
 
 	// As of 1.3, this represents an older configuration; since the IngressTrait only defines 1 host that
@@ -879,6 +885,7 @@ func TestSuccessfullyCreateNewIngressForServiceComponent(t *testing.T) {
 	assert := asserts.New(t)
 	mocker := gomock.NewController(t)
 	mock := mocks.NewMockClient(mocker)
+	listGatewaysExpectations(mock)
 	mockStatus := mocks.NewMockStatusWriter(mocker)
 	// Expect a call to get the ingress trait resource.
 	mock.EXPECT().
@@ -989,6 +996,7 @@ func TestSuccessfullyCreateNewIngressForVerrazzanoWorkload(t *testing.T) {
 	assert := asserts.New(t)
 	mocker := gomock.NewController(t)
 	mock := mocks.NewMockClient(mocker)
+	listGatewaysExpectations(mock)
 	mockStatus := mocks.NewMockStatusWriter(mocker)
 	// Expect a call to get the ingress trait resource.
 	mock.EXPECT().
@@ -1145,6 +1153,7 @@ func TestFailureToGetWorkload(t *testing.T) {
 	assert := asserts.New(t)
 	mocker := gomock.NewController(t)
 	mock := mocks.NewMockClient(mocker)
+	listGatewaysExpectations(mock)
 	getIngressTraitResourceExpectations(mock, assert)
 	deleteCertExpectations(mock, "test-space-myapp-cert")
 	deleteCertSecretExpectations(mock, "test-space-myapp-cert-secret")
@@ -1195,6 +1204,7 @@ func TestFailureToGetWorkloadDefinition(t *testing.T) {
 	assert := asserts.New(t)
 	mocker := gomock.NewController(t)
 	mock := mocks.NewMockClient(mocker)
+	listGatewaysExpectations(mock)
 
 	getIngressTraitResourceExpectations(mock, assert)
 	deleteCertExpectations(mock, "test-space-myapp-cert")
@@ -1250,6 +1260,7 @@ func TestFailureToUpdateStatus(t *testing.T) {
 	assert := asserts.New(t)
 	mocker := gomock.NewController(t)
 	mock := mocks.NewMockClient(mocker)
+	listGatewaysExpectations(mock)
 	mockStatus := mocks.NewMockStatusWriter(mocker)
 
 	getIngressTraitResourceExpectations(mock, assert)
@@ -3141,6 +3152,7 @@ func TestSuccessfullyCreateNewIngressForVerrazzanoWorkloadWithHTTPCookieIstioEna
 	assert := asserts.New(t)
 	mocker := gomock.NewController(t)
 	mock := mocks.NewMockClient(mocker)
+	listGatewaysExpectations(mock)
 	mockStatus := mocks.NewMockStatusWriter(mocker)
 	labels := map[string]string{"verrazzano-managed": "true", "istio-injection": "enabled"}
 	namespace.Labels = labels
@@ -3325,6 +3337,7 @@ func TestSuccessfullyCreateNewIngressForVerrazzanoWorkloadWithHTTPCookieIstioDis
 	assert := asserts.New(t)
 	mocker := gomock.NewController(t)
 	mock := mocks.NewMockClient(mocker)
+	listGatewaysExpectations(mock)
 	mockStatus := mocks.NewMockStatusWriter(mocker)
 	labels := map[string]string{"verrazzano-managed": "true", "istio-injection": "disabled"}
 	namespace.Labels = labels
@@ -3717,6 +3730,61 @@ func TestMutateGatewayAddTrait(t *testing.T) {
 
 }
 
+// TestMutateGatewayMoveTraitToIngressGateway tests the createOrUpdateChildResources method
+// GIVEN a trait that is exposed through the default ingress gateway
+// WHEN the trait selects an additional ingress gateway
+// THEN the trait is added to a Gateway that selects the additional ingress gateway, and removed from the Gateway of
+// the default ingress gateway
+func TestMutateGatewayMoveTraitToIngressGateway(t *testing.T) {
+	assert := asserts.New(t)
+
+	const appName = "myapp"
+	const secretName = "secretName"
+	const ingressGateway = "internal-gateway"
+	trait1Server := createGatewayServer("trait1", []string{"trait1host"}, secretName)
+	trait2Server := createGatewayServer("trait2", []string{"trait2host"}, secretName)
+	gw := &istioclient.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: expectedAppGWName, Namespace: testNamespace},
+		Spec:       istionet.Gateway{Servers: []*istionet.Server{trait1Server, trait2Server}},
+	}
+	trait := &vzapi.IngressTrait{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "trait2",
+			Namespace: testNamespace,
+			Labels:    map[string]string{oam.LabelAppName: appName},
+		},
+		Spec: vzapi.IngressTraitSpec{
+			Rules:             []vzapi.IngressRule{{Hosts: []string{"trait2host"}}},
+			Gateway:           ingressGateway,
+			WorkloadReference: createWorkloadReference(appName),
+		},
+	}
+
+	// The trait is not reconciled while the ingress gateway does not exist
+	reconciler := setupTraitTestFakes(appName, gw)
+	status, _, err := reconciler.createOrUpdateChildResources(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.True(status.ContainsErrors())
+
+	assert.NoError(reconciler.Create(context.TODO(), &k8score.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: ingressGateway, Namespace: istioSystemNamespace, Labels: map[string]string{"istio": ingressGateway}},
+	}))
+	status, _, err = reconciler.createOrUpdateChildResources(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.False(status.ContainsErrors())
+
+	ingressGatewayGW := &istioclient.Gateway{}
+	assert.NoError(reconciler.Get(context.TODO(), types.NamespacedName{Name: "test-space-myapp-internal-gateway-gw", Namespace: testNamespace}, ingressGatewayGW))
+	assert.Equal(map[string]string{"istio": ingressGateway}, ingressGatewayGW.Spec.Selector)
+	assert.Len(ingressGatewayGW.Spec.Servers, 1)
+	assert.Equal("trait2", ingressGatewayGW.Spec.Servers[0].Name)
+
+	defaultGW := &istioclient.Gateway{}
+	assert.NoError(reconciler.Get(context.TODO(), types.NamespacedName{Name: expectedAppGWName, Namespace: testNamespace}, defaultGW))
+	assert.Len(defaultGW.Spec.Servers, 1)
+	assert.Equal("trait1", defaultGW.Spec.Servers[0].Name)
+}
+
 func createWorkloadReference(appName string) oamrt.TypedReference {
 	return oamrt.TypedReference{
 		APIVersion: "core.oam.dev/v1alpha2",
@@ -4240,3 +4308,12 @@ func TestReconcileFailed(t *testing.T) {
 	assert.Equal(reconcileFailedCounterBefore, reconcileFailedCounterAfter-1)
 
 }
+
+// listGatewaysExpectations expects calls to list the gateways of the application, to remove the trait from the
+// gateways of other ingress gateways, and returns no gateways
+func listGatewaysExpectations(mock *mocks.MockClient) {
+	mock.EXPECT().
+		List(gomock.Any(), gomock.AssignableToTypeOf(&istioclient.GatewayList{}), gomock.Any()).
+		Return(nil).
+		AnyTimes()
+}
@@ -14,6 +14,7 @@ import (
 
 	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
 
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
 	certapiv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/verrazzano/verrazzano/application-operator/constants"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
@@ -142,14 +143,50 @@ func cleanupGateway(trait *vzapi.IngressTrait, c client.Client, log vzlog.Verraz
 		return log.ErrorfThrottledNewErr(fmt.Sprintf("Failed to fetch gateway: %v", err))
 	}
 
+	return removeGatewayServer(gateway, trait.Name, c)
+}
+
+// removeServerFromOtherGateways removes the server of the trait from the Gateways of the application other than the
+// given one, which happens when the trait is moved to another Istio ingress gateway
+func removeServerFromOtherGateways(trait *vzapi.IngressTrait, gwName string, c client.Client, log vzlog.VerrazzanoLogger) error {
+	appName, ok := trait.Labels[oam.LabelAppName]
+	if !ok {
+		return nil
+	}
+	gateways := istioclient.GatewayList{}
+	if err := c.List(context.TODO(), &gateways, client.InNamespace(trait.Namespace)); err != nil {
+		return log.ErrorfThrottledNewErr(fmt.Sprintf("Failed to list gateways: %v", err))
+	}
+	prefix := fmt.Sprintf("%s-%s-", trait.Namespace, appName)
+	for i := range gateways.Items {
+		gateway := gateways.Items[i]
+		if gateway.Name == gwName || !strings.HasPrefix(gateway.Name, prefix) || !strings.HasSuffix(gateway.Name, "-gw") {
+			continue
+		}
+		for _, server := range gateway.Spec.Servers {
+			if server.Name != trait.Name {
+				continue
+			}
+			log.Infof("Removing ingress trait %s from gateway %s", trait.Name, gateway.Name)
+			if err := removeGatewayServer(&gateway, trait.Name, c); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// removeGatewayServer removes the server of a trait from a Gateway
+func removeGatewayServer(gateway *istioclient.Gateway, traitName string, c client.Client) error {
 	newServer := []*v1alpha3.Server{}
 	for _, server := range gateway.Spec.Servers {
-		if server.Name == trait.Name {
+		if server.Name == traitName {
 			continue
 		}
 		newServer = append(newServer, server)
 	}
-	_, err = controllerutil.CreateOrUpdate(context.TODO(), c, gateway, func() error {
+	_, err := controllerutil.CreateOrUpdate(context.TODO(), c, gateway, func() error {
 		gateway.Spec.Servers = newServer
 		return nil
 	})
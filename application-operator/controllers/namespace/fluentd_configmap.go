@@ -25,6 +25,10 @@ const (
 	nsConfigKeyTemplate         = "oci-logging-ns-%s.conf"
 	fluentdConfigKey            = "fluentd-standalone.conf"
 	startNamespaceConfigsMarker = "# Start namespace logging configs"
+
+	nsRoutingConfigKeyTemplate         = "log-routing-ns-%s.conf"
+	logClassConfigKeyTemplate          = "log-class-%s.conf"
+	startNamespaceRoutingConfigsMarker = "# Start namespace log routing configs"
 )
 
 const loggingTemplateBody = `|
@@ -44,11 +48,21 @@ const loggingTemplateBody = `|
 </match>
 `
 
+// routingTemplateBody relabels the records of a namespace to the label of its log routing class, whose pipeline is
+// rendered by the Fluentd Helm chart
+const routingTemplateBody = `<match kubernetes.**_{{ .namespace }}_**>
+  @type relabel
+  @label @log-class-{{ .class }}
+</match>
+`
+
 var loggingTemplate *template.Template
+var routingTemplate *template.Template
 
-// init creates a logging template.
+// init creates the logging and routing templates.
 func init() {
 	loggingTemplate, _ = template.New("loggingConfig").Parse(loggingTemplateBody)
+	routingTemplate, _ = template.New("routingConfig").Parse(routingTemplateBody)
 }
 
 // addNamespaceLogging updates the system Fluentd config map to include a match section that directs all logs for the given
@@ -111,14 +125,7 @@ func addNamespaceLoggingToConfigMap(configMap *corev1.ConfigMap, namespace strin
 	configMap.Data[nsConfigKey] = buff.String()
 
 	// if the logging config isn't already included in the main Fluentd config, include it
-	if fluentdConfig, ok := configMap.Data[fluentdConfigKey]; ok {
-		includeLine := fmt.Sprintf("@include %s", nsConfigKey)
-		if !strings.Contains(fluentdConfig, includeLine) {
-			replace := fmt.Sprintf("%s\n%s", startNamespaceConfigsMarker, includeLine)
-			fluentdConfig = strings.Replace(fluentdConfig, startNamespaceConfigsMarker, replace, 1)
-			configMap.Data[fluentdConfigKey] = fluentdConfig
-		}
-	}
+	addInclude(configMap, startNamespaceConfigsMarker, nsConfigKey)
 
 	return nil
 }
@@ -131,8 +138,106 @@ func removeNamespaceLoggingFromConfigMap(configMap *corev1.ConfigMap, namespace
 	delete(configMap.Data, nsConfigKey)
 
 	// if the logging config is included in the main Fluentd config, remove it
+	removeInclude(configMap, nsConfigKey)
+}
+
+// addNamespaceLogRouting updates the system Fluentd config map to include a match section that relabels all logs for
+// the given namespace to the pipeline of the given log routing class. Only the config of the namespace is changed in
+// the config map, but Fluentd rebuilds all of its pipelines when it reloads the config. It returns true if the config
+// map was updated.
+func addNamespaceLogRouting(ctx context.Context, cli client.Client, namespace string, class string) (bool, error) {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: fluentdConfigMapName, Namespace: constants.VerrazzanoSystemNamespace}}
+
+	opResult, err := controllerutil.CreateOrUpdate(ctx, cli, cm, func() error {
+		if cm.ObjectMeta.CreationTimestamp.IsZero() {
+			return fmt.Errorf("configmap '%s' in namespace '%s' must exist", cm.ObjectMeta.Name, cm.ObjectMeta.Namespace)
+		}
+		return addNamespaceLogRoutingToConfigMap(cm, namespace, class)
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return opResult != controllerutil.OperationResultNone, nil
+}
+
+// removeNamespaceLogRouting updates the system Fluentd config map, removing the namespace log routing configuration.
+// It returns true if the config map was updated.
+func removeNamespaceLogRouting(ctx context.Context, cli client.Client, namespace string) (bool, error) {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: fluentdConfigMapName, Namespace: constants.VerrazzanoSystemNamespace}}
+
+	opResult, err := controllerutil.CreateOrUpdate(ctx, cli, cm, func() error {
+		// if the config map exists, remove the namespace log routing config
+		if !cm.ObjectMeta.CreationTimestamp.IsZero() {
+			removeNamespaceLogRoutingFromConfigMap(cm, namespace)
+			return nil
+		}
+		// return an error here, otherwise the configmap will get created and we don't want that
+		return k8serrors.NewNotFound(schema.ParseGroupResource("ConfigMap"), fluentdConfigMapName)
+	})
+
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	return opResult != controllerutil.OperationResultNone, nil
+}
+
+// addNamespaceLogRoutingToConfigMap adds a config map key for the namespace log routing configuration and adds an
+// include directive in the main Fluentd config. The log routing class must have a pipeline in the config map, otherwise
+// Fluentd would fail to load the config. This function is idempotent.
+func addNamespaceLogRoutingToConfigMap(configMap *corev1.ConfigMap, namespace string, class string) error {
+	// make sure the routing template parsed
+	if routingTemplate == nil {
+		return fmt.Errorf("log routing config template is empty")
+	}
+
+	if _, ok := configMap.Data[fmt.Sprintf(logClassConfigKeyTemplate, class)]; !ok {
+		return fmt.Errorf("log routing class '%s' of namespace '%s' does not exist", class, namespace)
+	}
+
+	// use the template to create the routing config for the namespace and add it to the config map
+	pairs := map[string]string{"namespace": namespace, "class": class}
+	var buff bytes.Buffer
+	if err := routingTemplate.Execute(&buff, pairs); err != nil {
+		return err
+	}
+
+	nsConfigKey := fmt.Sprintf(nsRoutingConfigKeyTemplate, namespace)
+	configMap.Data[nsConfigKey] = buff.String()
+
+	// if the routing config isn't already included in the main Fluentd config, include it
+	addInclude(configMap, startNamespaceRoutingConfigsMarker, nsConfigKey)
+
+	return nil
+}
+
+// removeNamespaceLogRoutingFromConfigMap removes the config map key for the namespace log routing configuration and
+// removes the include directive in the main Fluentd config. This function is idempotent.
+func removeNamespaceLogRoutingFromConfigMap(configMap *corev1.ConfigMap, namespace string) {
+	nsConfigKey := fmt.Sprintf(nsRoutingConfigKeyTemplate, namespace)
+	delete(configMap.Data, nsConfigKey)
+	removeInclude(configMap, nsConfigKey)
+}
+
+// addInclude adds an include directive of the given config map key after the marker in the main Fluentd config, if
+// it is not already included
+func addInclude(configMap *corev1.ConfigMap, marker string, key string) {
+	if fluentdConfig, ok := configMap.Data[fluentdConfigKey]; ok {
+		includeLine := fmt.Sprintf("@include %s", key)
+		if !strings.Contains(fluentdConfig, includeLine) {
+			replace := fmt.Sprintf("%s\n%s", marker, includeLine)
+			fluentdConfig = strings.Replace(fluentdConfig, marker, replace, 1)
+			configMap.Data[fluentdConfigKey] = fluentdConfig
+		}
+	}
+}
+
+// removeInclude removes the include directive of the given config map key from the main Fluentd config
+func removeInclude(configMap *corev1.ConfigMap, key string) {
 	if fluentdConfig, ok := configMap.Data[fluentdConfigKey]; ok {
-		includeLine := fmt.Sprintf("@include %s", nsConfigKey)
+		includeLine := fmt.Sprintf("@include %s", key)
 		if strings.Contains(fluentdConfig, includeLine) {
 			toRemove := fmt.Sprintf("%s\n", includeLine)
 			fluentdConfig = strings.Replace(fluentdConfig, toRemove, "", 1)
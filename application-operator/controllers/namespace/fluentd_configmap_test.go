@@ -6,6 +6,7 @@ package namespace
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,6 +22,7 @@ import (
 const (
 	testNamespace = "unit-test-ns"
 	testLogID     = "ocid1.log.oc1.test"
+	testLogClass  = "gold"
 )
 
 const fluentdConfig = `|
@@ -37,6 +39,8 @@ const fluentdConfig = `|
 
 # Send to storage
 @include output.conf
+# Start namespace log routing configs
+# End namespace log routing configs
 # Start namespace logging configs
 # End namespace logging configs
 @include oci-logging-system.conf
@@ -187,6 +191,91 @@ func TestUpdateExistingLoggingConfig(t *testing.T) {
 	asserts.Contains(cm.Data[nsConfigKey], updatedLogID)
 }
 
+// TestAddAndRemoveNamespaceLogRouting tests adding, updating and removing namespace log routing config to the Fluentd
+// config map.
+func TestAddAndRemoveNamespaceLogRouting(t *testing.T) {
+	asserts := assert.New(t)
+
+	// GIVEN a system Fluentd config map with the pipelines of two log routing classes
+	// WHEN I add namespace log routing configuration
+	// THEN the config map gets updated in the cluster and relabels the namespace records to the class pipeline
+	cm := newConfigMap()
+	cm.Data = make(map[string]string)
+	cm.Data[fluentdConfigKey] = fluentdConfig
+	cm.Data[fmt.Sprintf(logClassConfigKeyTemplate, testLogClass)] = "<label @log-class-gold>\n</label>\n"
+	cm.Data[fmt.Sprintf(logClassConfigKeyTemplate, "silver")] = "<label @log-class-silver>\n</label>\n"
+
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(cm).Build()
+
+	updated, err := addNamespaceLogRouting(context.TODO(), client, testNamespace, testLogClass)
+	asserts.NoError(err)
+	asserts.True(updated)
+
+	cm = &corev1.ConfigMap{}
+	err = client.Get(context.TODO(), types.NamespacedName{Name: fluentdConfigMapName, Namespace: constants.VerrazzanoSystemNamespace}, cm)
+	asserts.NoError(err)
+
+	nsConfigKey := fmt.Sprintf(nsRoutingConfigKeyTemplate, testNamespace)
+	includeSection := fmt.Sprintf("%s\n@include log-routing-ns-unit-test-ns.conf\n", startNamespaceRoutingConfigsMarker)
+	asserts.Contains(cm.Data[fluentdConfigKey], includeSection)
+	asserts.Contains(cm.Data[nsConfigKey], "<match kubernetes.**_unit-test-ns_**>")
+	asserts.Contains(cm.Data[nsConfigKey], "@label @log-class-gold")
+
+	// GIVEN a system Fluentd config map with namespace log routing config
+	// WHEN I add the same configuration again, and then change the class of the namespace
+	// THEN the config map is not updated the first time, and only the namespace routing config is updated the second time
+	updated, err = addNamespaceLogRouting(context.TODO(), client, testNamespace, testLogClass)
+	asserts.NoError(err)
+	asserts.False(updated)
+
+	updated, err = addNamespaceLogRouting(context.TODO(), client, testNamespace, "silver")
+	asserts.NoError(err)
+	asserts.True(updated)
+
+	cm = &corev1.ConfigMap{}
+	err = client.Get(context.TODO(), types.NamespacedName{Name: fluentdConfigMapName, Namespace: constants.VerrazzanoSystemNamespace}, cm)
+	asserts.NoError(err)
+	asserts.Equal(1, strings.Count(cm.Data[fluentdConfigKey], "@include log-routing-ns-unit-test-ns.conf"))
+	asserts.Contains(cm.Data[nsConfigKey], "@label @log-class-silver")
+	asserts.Equal("<label @log-class-gold>\n</label>\n", cm.Data[fmt.Sprintf(logClassConfigKeyTemplate, testLogClass)])
+
+	// GIVEN a system Fluentd config map with namespace log routing config
+	// WHEN I remove the namespace log routing configuration
+	// THEN the config map gets updated in the cluster and the main config matches the state prior to adding the config
+	updated, err = removeNamespaceLogRouting(context.TODO(), client, testNamespace)
+	asserts.NoError(err)
+	asserts.True(updated)
+
+	cm = &corev1.ConfigMap{}
+	err = client.Get(context.TODO(), types.NamespacedName{Name: fluentdConfigMapName, Namespace: constants.VerrazzanoSystemNamespace}, cm)
+	asserts.NoError(err)
+	asserts.Equal(fluentdConfig, cm.Data[fluentdConfigKey])
+	asserts.NotContains(cm.Data, nsConfigKey)
+}
+
+// TestAddNamespaceLogRoutingUnknownClass tests the case where the log routing class of a namespace does not exist.
+func TestAddNamespaceLogRoutingUnknownClass(t *testing.T) {
+	asserts := assert.New(t)
+
+	// GIVEN a system Fluentd config map without log routing classes
+	// WHEN I add namespace log routing configuration
+	// THEN an error is returned and the config map is not updated
+	cm := newConfigMap()
+	cm.Data = make(map[string]string)
+	cm.Data[fluentdConfigKey] = fluentdConfig
+
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(cm).Build()
+
+	_, err := addNamespaceLogRouting(context.TODO(), client, testNamespace, testLogClass)
+	asserts.Error(err)
+	asserts.Contains(err.Error(), "does not exist")
+
+	cm = &corev1.ConfigMap{}
+	err = client.Get(context.TODO(), types.NamespacedName{Name: fluentdConfigMapName, Namespace: constants.VerrazzanoSystemNamespace}, cm)
+	asserts.NoError(err)
+	asserts.Equal(fluentdConfig, cm.Data[fluentdConfigKey])
+}
+
 // newConfigMap returns a ConfigMap populated with the system Fluentd config map name and namespace, and
 // a creation timestamp.
 func newConfigMap() *corev1.ConfigMap {
@@ -132,6 +132,10 @@ func (nc *NamespaceController) removeFinalizer(ctx context.Context, ns *corev1.N
 
 // reconcileNamespace - Reconcile any namespace changes
 func (nc *NamespaceController) reconcileNamespace(ctx context.Context, ns *corev1.Namespace, log vzlog.VerrazzanoLogger) error {
+	if err := nc.reconcileLogRouting(ctx, ns, log); err != nil {
+		log.Errorf("Failed to reconcile log routing: %v", err)
+		return err
+	}
 	if err := nc.reconcileOCILogging(ctx, ns, log); err != nil {
 		log.Errorf("Failed to reconcile OCI Logging: %v", err)
 		return err
@@ -142,15 +146,57 @@ func (nc *NamespaceController) reconcileNamespace(ctx context.Context, ns *corev
 
 // reconcileNamespaceDelete - Reconcile any post-delete changes required
 func (nc *NamespaceController) reconcileNamespaceDelete(ctx context.Context, ns *corev1.Namespace, log vzlog.VerrazzanoLogger) error {
-	// Update the OCI Logging configuration to remove the namespace configuration
-	// If the annotation is not present, remove any existing logging configuration
+	// Update the log routing and OCI Logging configuration to remove the namespace configuration
+	if err := nc.removeLogRouting(ctx, ns, log); err != nil {
+		return err
+	}
 	return nc.removeOCILogging(ctx, ns, log)
 }
 
+// reconcileLogRouting - Configure the log routing class of the namespace based on the annotation if present.  Fluentd
+// pods are not restarted, the config-reloader container of the Fluentd pods reloads the config when the config map
+// changes.  A reload rebuilds all the input and output plugins, so all the pipelines restart briefly.
+func (nc *NamespaceController) reconcileLogRouting(ctx context.Context, ns *corev1.Namespace, log vzlog.VerrazzanoLogger) error {
+	// If the annotation is present, add the finalizer if necessary and update the log routing configuration
+	if class, ok := ns.Annotations[constants.LogRoutingClassAnnotation]; ok {
+		var added bool
+		if ns.Finalizers, added = vzstring.SliceAddString(ns.Finalizers, namespaceControllerFinalizer); added {
+			if err := nc.Update(ctx, ns); err != nil {
+				return err
+			}
+		}
+		log.Debugw("Updating log routing configuration for namespace", namespaceField, ns.Name, "class", class)
+		updated, err := addNamespaceLogRoutingFunc(ctx, nc.Client, ns.Name, class)
+		if err != nil {
+			return err
+		}
+		if updated {
+			log.Debugw("Updated log routing configuration for namespace", namespaceField, ns.Name)
+		}
+		return nil
+	}
+	// If the annotation is not present, remove any existing log routing configuration
+	return nc.removeLogRouting(ctx, ns, log)
+}
+
+// removeLogRouting - Remove the log routing configuration of the namespace
+func (nc *NamespaceController) removeLogRouting(ctx context.Context, ns *corev1.Namespace, log vzlog.VerrazzanoLogger) error {
+	removed, err := removeNamespaceLogRoutingFunc(ctx, nc.Client, ns.Name)
+	if err != nil {
+		return err
+	}
+	if removed {
+		log.Debugw("Removed log routing configuration for namespace", namespaceField, ns.Name)
+	}
+	return nil
+}
+
 // reconcileOCILogging - Configure OCI logging based on the annotation if present
 func (nc *NamespaceController) reconcileOCILogging(ctx context.Context, ns *corev1.Namespace, log vzlog.VerrazzanoLogger) error {
-	// If the annotation is present, add the finalizer if necessary and update the logging configuration
-	if loggingOCID, ok := ns.Annotations[constants.OCILoggingIDAnnotation]; ok {
+	// If the annotation is present, add the finalizer if necessary and update the logging configuration.  The log
+	// routing class takes precedence over the OCI log id.
+	_, hasLogRoutingClass := ns.Annotations[constants.LogRoutingClassAnnotation]
+	if loggingOCID, ok := ns.Annotations[constants.OCILoggingIDAnnotation]; ok && !hasLogRoutingClass {
 		var added bool
 		if ns.Finalizers, added = vzstring.SliceAddString(ns.Finalizers, namespaceControllerFinalizer); added {
 			if err := nc.Update(ctx, ns); err != nil {
@@ -218,3 +264,15 @@ type removeNamespaceLoggingFuncSig func(_ context.Context, _ client.Client, _ st
 
 // removeNamespaceLoggingFunc - Variable to allow replacing remove namespace logging func for unit tests
 var removeNamespaceLoggingFunc removeNamespaceLoggingFuncSig = removeNamespaceLogging
+
+// addNamespaceLogRoutingFuncSig - Type for add namespace log routing function, for unit testing
+type addNamespaceLogRoutingFuncSig func(_ context.Context, _ client.Client, _ string, _ string) (bool, error)
+
+// addNamespaceLogRoutingFunc - Variable to allow replacing add namespace log routing func for unit tests
+var addNamespaceLogRoutingFunc addNamespaceLogRoutingFuncSig = addNamespaceLogRouting
+
+// removeNamespaceLogRoutingFuncSig - Type for remove namespace log routing function, for unit testing
+type removeNamespaceLogRoutingFuncSig func(_ context.Context, _ client.Client, _ string) (bool, error)
+
+// removeNamespaceLogRoutingFunc - Variable to allow replacing remove namespace log routing func for unit tests
+var removeNamespaceLogRoutingFunc removeNamespaceLogRoutingFuncSig = removeNamespaceLogRouting
@@ -95,6 +95,10 @@ func TestReconcileNamespaceUpdate(t *testing.T) {
 		return true, nil
 	}
 	defer func() { addNamespaceLoggingFunc = addNamespaceLogging }()
+	removeNamespaceLogRoutingFunc = func(_ context.Context, _ client.Client, _ string) (bool, error) {
+		return false, nil
+	}
+	defer func() { removeNamespaceLogRoutingFunc = removeNamespaceLogRouting }()
 
 	nc, err := newTestController(mock)
 	asserts.NoError(err)
@@ -198,6 +202,10 @@ func TestReconcileNamespaceDeleted(t *testing.T) {
 
 	nc, err := newTestController(mock)
 	asserts.NoError(err)
+	removeNamespaceLogRoutingFunc = func(_ context.Context, _ client.Client, _ string) (bool, error) {
+		return false, nil
+	}
+	defer func() { removeNamespaceLogRoutingFunc = removeNamespaceLogRouting }()
 
 	req := reconcile.Request{
 		NamespacedName: types.NamespacedName{Name: "myns"},
@@ -236,6 +244,10 @@ func TestReconcileNamespaceDeletedErrorOnUpdate(t *testing.T) {
 
 	nc, err := newTestController(mock)
 	asserts.NoError(err)
+	removeNamespaceLogRoutingFunc = func(_ context.Context, _ client.Client, _ string) (bool, error) {
+		return false, nil
+	}
+	defer func() { removeNamespaceLogRoutingFunc = removeNamespaceLogRouting }()
 
 	// Force a failure
 	returnedErr := fmt.Errorf("error updating OCI Logging")
@@ -404,6 +416,10 @@ func Test_reconcileNamespaceErrorOnUpdate(t *testing.T) {
 
 	nc, err := newTestController(mock)
 	asserts.NoError(err)
+	removeNamespaceLogRoutingFunc = func(_ context.Context, _ client.Client, _ string) (bool, error) {
+		return false, nil
+	}
+	defer func() { removeNamespaceLogRoutingFunc = removeNamespaceLogRouting }()
 
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -442,6 +458,10 @@ func Test_reconcileNamespace(t *testing.T) {
 
 	nc, err := newTestController(mock)
 	asserts.NoError(err)
+	removeNamespaceLogRoutingFunc = func(_ context.Context, _ client.Client, _ string) (bool, error) {
+		return false, nil
+	}
+	defer func() { removeNamespaceLogRoutingFunc = removeNamespaceLogRouting }()
 
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -464,6 +484,90 @@ func Test_reconcileNamespace(t *testing.T) {
 	asserts.NoError(err)
 }
 
+// Test_reconcileNamespaceLogRouting tests the reconcileNamespace method for the following use case
+// GIVEN a request to reconcileNamespace for a Namespace resource
+// WHEN the namespace has a log routing class and an OCI log id
+// THEN the log routing class is configured, the OCI Logging configuration is removed and Fluentd is not restarted
+func Test_reconcileNamespaceLogRouting(t *testing.T) {
+	asserts := assert.New(t)
+	mocker := gomock.NewController(t)
+	mock := mocks.NewMockClient(mocker)
+
+	nc, err := newTestController(mock)
+	asserts.NoError(err)
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "myns",
+			Annotations: map[string]string{
+				constants.LogRoutingClassAnnotation: "gold",
+				constants.OCILoggingIDAnnotation:    "myocid",
+			},
+			Finalizers: []string{"anotherFinalizer", namespaceControllerFinalizer},
+		},
+	}
+
+	// No calls to restart Fluentd are expected, Fluentd reloads the config
+	var routedClass string
+	addNamespaceLogRoutingFunc = func(_ context.Context, _ client.Client, _ string, class string) (bool, error) {
+		routedClass = class
+		return true, nil
+	}
+	defer func() { addNamespaceLogRoutingFunc = addNamespaceLogRouting }()
+	addNamespaceLoggingFunc = func(_ context.Context, _ client.Client, _ string, _ string) (bool, error) {
+		asserts.Fail("OCI Logging must not be configured when the namespace has a log routing class")
+		return false, nil
+	}
+	defer func() { addNamespaceLoggingFunc = addNamespaceLogging }()
+	removeCalled := false
+	removeNamespaceLoggingFunc = func(_ context.Context, _ client.Client, _ string) (bool, error) {
+		removeCalled = true
+		return false, nil
+	}
+	defer func() { removeNamespaceLoggingFunc = removeNamespaceLogging }()
+
+	err = nc.reconcileNamespace(context.TODO(), ns, logger)
+
+	mocker.Finish()
+	asserts.NoError(err)
+	asserts.Equal("gold", routedClass)
+	asserts.True(removeCalled)
+}
+
+// Test_reconcileNamespaceLogRoutingUnknownClass tests the reconcileNamespace method for the following use case
+// GIVEN a request to reconcileNamespace for a Namespace resource
+// WHEN the log routing class of the namespace does not exist
+// THEN an error is returned and Fluentd is not restarted
+func Test_reconcileNamespaceLogRoutingUnknownClass(t *testing.T) {
+	asserts := assert.New(t)
+	mocker := gomock.NewController(t)
+	mock := mocks.NewMockClient(mocker)
+
+	nc, err := newTestController(mock)
+	asserts.NoError(err)
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "myns",
+			Annotations: map[string]string{
+				constants.LogRoutingClassAnnotation: "unknown",
+			},
+			Finalizers: []string{namespaceControllerFinalizer},
+		},
+	}
+
+	expectedErr := fmt.Errorf("log routing class 'unknown' of namespace 'myns' does not exist")
+	addNamespaceLogRoutingFunc = func(_ context.Context, _ client.Client, _ string, _ string) (bool, error) {
+		return false, expectedErr
+	}
+	defer func() { addNamespaceLogRoutingFunc = addNamespaceLogRouting }()
+
+	err = nc.reconcileNamespace(context.TODO(), ns, logger)
+
+	mocker.Finish()
+	asserts.Equal(expectedErr, err)
+}
+
 // Test_reconcileNamespaceDelete tests the reconcileNamespaceDelete method for the following use case
 // GIVEN a request to reconcileNamespaceDelete for a Namespace resource
 // WHEN the namespace is configured for OCI Logging
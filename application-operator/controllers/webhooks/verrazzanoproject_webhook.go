@@ -13,6 +13,8 @@ import (
 
 	"github.com/verrazzano/verrazzano/application-operator/constants"
 	k8sadmission "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -76,6 +78,14 @@ func validateVerrazzanoProject(c client.Client, vp *v1alpha1.VerrazzanoProject)
 		return err
 	}
 
+	if err := validateAllowedEgress(vp); err != nil {
+		return err
+	}
+
+	if err := validateMTLS(vp); err != nil {
+		return err
+	}
+
 	if err := validateNamespaceCanBeUsed(c, vp); err != nil {
 		return err
 	}
@@ -104,6 +114,47 @@ func validateNetworkPolicies(vp *v1alpha1.VerrazzanoProject) error {
 	return nil
 }
 
+// validateAllowedEgress validates the allowed egress hosts specified in the project
+func validateAllowedEgress(vp *v1alpha1.VerrazzanoProject) error {
+	egressSet := make(map[string]bool)
+	for _, egress := range vp.Spec.Template.AllowedEgress {
+		if errs := validation.IsDNS1123Subdomain(egress.Host); len(errs) > 0 {
+			return fmt.Errorf("allowed egress host %q is not a fully qualified host name: %v", egress.Host, errs)
+		}
+		if egress.Port > 65535 {
+			return fmt.Errorf("port %d of allowed egress host %s is not a valid port", egress.Port, egress.Host)
+		}
+		key := fmt.Sprintf("%s:%d", egress.Host, egress.Port)
+		if egressSet[key] {
+			return fmt.Errorf("allowed egress host %s is specified more than once with port %d", egress.Host, egress.Port)
+		}
+		egressSet[key] = true
+	}
+	return nil
+}
+
+// validateMTLS validates the port exceptions of the mTLS configuration specified in the project
+func validateMTLS(vp *v1alpha1.VerrazzanoProject) error {
+	if vp.Spec.Template.MTLS == nil {
+		return nil
+	}
+	portSet := make(map[string]bool)
+	for _, exception := range vp.Spec.Template.MTLS.PortExceptions {
+		if len(exception.Selector) == 0 {
+			return fmt.Errorf("mTLS exception of port %d must have a workload selector", exception.Port)
+		}
+		if exception.Port == 0 || exception.Port > 65535 {
+			return fmt.Errorf("port %d of mTLS exception is not a valid port", exception.Port)
+		}
+		key := fmt.Sprintf("%s:%d", labels.SelectorFromSet(exception.Selector).String(), exception.Port)
+		if portSet[key] {
+			return fmt.Errorf("mTLS exception of port %d is specified more than once for selector %v", exception.Port, exception.Selector)
+		}
+		portSet[key] = true
+	}
+	return nil
+}
+
 func validateNamespaceCanBeUsed(c client.Client, vp *v1alpha1.VerrazzanoProject) error {
 	projectsList := &v1alpha1.VerrazzanoProjectList{}
 	listOptions := &client.ListOptions{Namespace: constants.VerrazzanoMultiClusterNamespace}
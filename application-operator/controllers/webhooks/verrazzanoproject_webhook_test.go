@@ -162,6 +162,96 @@ func TestNetworkPolicyMissingNamespace(t *testing.T) {
 	asrt.Containsf(res.Result.Reason, "namespace ns1 used in NetworkPolicy net1 does not exist in project", "Error validating VerrazzanProject with NetworkPolicyTemplate")
 }
 
+// TestAllowedEgress tests the validation of the VerrazzanoProject allowed egress hosts
+// GIVEN a call validate VerrazzanoProject on create or update
+// WHEN the VerrazzanoProject has valid, wildcard, invalid port or duplicate allowed egress hosts
+// THEN the validation should only succeed for the valid hosts
+func TestAllowedEgress(t *testing.T) {
+	tests := []struct {
+		name   string
+		egress []v1alpha12.EgressHost
+		reason string
+	}{
+		{name: "valid", egress: []v1alpha12.EgressHost{{Host: "api.example.com"}, {Host: "api.example.com", Port: 8443},
+			{Host: "www.example.com", Protocol: v1alpha12.EgressProtocolHTTP}}},
+		{name: "wildcard", egress: []v1alpha12.EgressHost{{Host: "*.example.com"}}, reason: "is not a fully qualified host name"},
+		{name: "port", egress: []v1alpha12.EgressHost{{Host: "api.example.com", Port: 70000}}, reason: "is not a valid port"},
+		{name: "duplicate", egress: []v1alpha12.EgressHost{{Host: "api.example.com"}, {Host: "api.example.com"}}, reason: "is specified more than once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asrt := assert.New(t)
+			v := newVerrazzanoProjectValidator()
+			testMC := testManagedCluster
+			asrt.NoError(v.client.Create(context.TODO(), &testMC))
+			testVP := v1alpha12.VerrazzanoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: constants.VerrazzanoMultiClusterNamespace},
+				Spec: v1alpha12.VerrazzanoProjectSpec{
+					Placement: v1alpha12.Placement{Clusters: []v1alpha12.Cluster{{Name: testManagedCluster.Name}}},
+					Template: v1alpha12.ProjectTemplate{
+						Namespaces:    []v1alpha12.NamespaceTemplate{{Metadata: metav1.ObjectMeta{Name: "ns1"}}},
+						AllowedEgress: tt.egress,
+					},
+				},
+			}
+
+			res := v.Handle(context.TODO(), newAdmissionRequest(admissionv1.Create, testVP))
+			if tt.reason == "" {
+				asrt.True(res.Allowed, "Error validating VerrazzanoProject with allowed egress hosts")
+			} else {
+				asrt.False(res.Allowed, "Expected project validation to fail due to invalid allowed egress hosts")
+				asrt.Contains(res.Result.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+// TestMTLSPortExceptions tests the validation of the VerrazzanoProject mTLS port exceptions
+// GIVEN a call validate VerrazzanoProject on create or update
+// WHEN the VerrazzanoProject has valid, unselected, invalid port or duplicate mTLS port exceptions
+// THEN the validation should only succeed for the valid exceptions
+func TestMTLSPortExceptions(t *testing.T) {
+	selector := map[string]string{"app": "legacy"}
+	tests := []struct {
+		name       string
+		exceptions []v1alpha12.MTLSPortException
+		reason     string
+	}{
+		{name: "valid", exceptions: []v1alpha12.MTLSPortException{{Selector: selector, Port: 8080, Mode: v1alpha12.MTLSModeDisable},
+			{Selector: selector, Port: 8081, Mode: v1alpha12.MTLSModeDisable}}},
+		{name: "selector", exceptions: []v1alpha12.MTLSPortException{{Port: 8080, Mode: v1alpha12.MTLSModeDisable}}, reason: "must have a workload selector"},
+		{name: "port", exceptions: []v1alpha12.MTLSPortException{{Selector: selector, Mode: v1alpha12.MTLSModeDisable}}, reason: "is not a valid port"},
+		{name: "duplicate", exceptions: []v1alpha12.MTLSPortException{{Selector: selector, Port: 8080, Mode: v1alpha12.MTLSModeDisable},
+			{Selector: selector, Port: 8080, Mode: v1alpha12.MTLSModePermissive}}, reason: "is specified more than once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asrt := assert.New(t)
+			v := newVerrazzanoProjectValidator()
+			testMC := testManagedCluster
+			asrt.NoError(v.client.Create(context.TODO(), &testMC))
+			testVP := v1alpha12.VerrazzanoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: constants.VerrazzanoMultiClusterNamespace},
+				Spec: v1alpha12.VerrazzanoProjectSpec{
+					Placement: v1alpha12.Placement{Clusters: []v1alpha12.Cluster{{Name: testManagedCluster.Name}}},
+					Template: v1alpha12.ProjectTemplate{
+						Namespaces: []v1alpha12.NamespaceTemplate{{Metadata: metav1.ObjectMeta{Name: "ns1"}}},
+						MTLS:       &v1alpha12.MTLSSpec{Mode: v1alpha12.MTLSModeStrict, PortExceptions: tt.exceptions},
+					},
+				},
+			}
+
+			res := v.Handle(context.TODO(), newAdmissionRequest(admissionv1.Create, testVP))
+			if tt.reason == "" {
+				asrt.True(res.Allowed, "Error validating VerrazzanoProject with mTLS port exceptions")
+			} else {
+				asrt.False(res.Allowed, "Expected project validation to fail due to invalid mTLS port exceptions")
+				asrt.Contains(res.Result.Reason, tt.reason)
+			}
+		})
+	}
+}
+
 // TestNamespaceUniquenessForProjects tests that the namespace of a VerrazzanoProject N does not conflict with a preexisting project
 // GIVEN a call validate VerrazzanoProject on create or update
 // WHEN the VerrazzanoProject has a a namespace that conflicts with any pre-existing projects
@@ -12,6 +12,23 @@ import (
 // RestartVersionAnnotation - the annotation used by user to tell Verrazzano applicaton to restart its components
 const RestartVersionAnnotation = "verrazzano.io/restart-version"
 
+// CredentialsVersionAnnotation - the annotation used by user to tell Verrazzano to rotate all the credentials that can be
+// rotated, and to sync the component credentials with the credentials store if one is configured
+const CredentialsVersionAnnotation = "verrazzano.io/credentials-version"
+
+// RotateCredentialsAnnotationPrefix - the prefix of the annotations used by user to tell Verrazzano to rotate a generated
+// credential, the name of the credential follows the prefix and the value identifies the rotation request
+const RotateCredentialsAnnotationPrefix = "verrazzano.io/rotate-credentials-"
+
+// KeycloakAdminCredential - the name of the credential of the Keycloak keycloakadmin user
+const KeycloakAdminCredential = "keycloak"
+
+// MySQLCredential - the name of the credential of the MySQL root and keycloak users
+const MySQLCredential = "mysql"
+
+// VerrazzanoUserCredential - the name of the credential of the verrazzano user
+const VerrazzanoUserCredential = "verrazzano"
+
 // VerrazzanoRestartAnnotation is the annotation used to restart platform workloads
 const VerrazzanoRestartAnnotation = "verrazzano.io/restartedAt"
 
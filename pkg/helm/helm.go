@@ -0,0 +1,487 @@
+// Copyright (c) 2020, 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"sigs.k8s.io/yaml"
+)
+
+// Debug is set from a platform-operator arg and enables the Helm debug log
+var Debug bool
+
+// Helm chart status values: unknown, deployed, uninstalled, superseded, failed, uninstalling, pending-install, pending-upgrade or pending-rollback
+const ChartNotFound = "NotFound"
+const ChartStatusDeployed = "deployed"
+const ChartStatusPendingInstall = "pending-install"
+const ChartStatusFailed = "failed"
+
+// defaultTimeout is the time to wait for Kubernetes operations, the same as the Helm CLI default
+const defaultTimeout = 5 * time.Minute
+
+// maxHistory is the number of revisions of a release that are kept, the same as the 'helm upgrade --history-max'
+// CLI default, older revisions are deleted by the upgrade
+const maxHistory = 10
+
+// ChartStatusFnType - Package-level var and functions to allow overriding GetChartStatus for unit test purposes
+type ChartStatusFnType func(releaseName string, namespace string) (string, error)
+
+// HelmOverrides contains all of the overrides that gets passed to the Helm upgrade
+type HelmOverrides struct {
+	SetOverrides       string // for --set
+	SetStringOverrides string // for --set-string
+	SetFileOverrides   string // for --set-file
+	FileOverride       string // for -f
+}
+
+var chartStatusFn ChartStatusFnType = getChartStatus
+
+// SetChartStatusFunction Override the chart status function for unit testing
+func SetChartStatusFunction(f ChartStatusFnType) {
+	chartStatusFn = f
+}
+
+// SetDefaultChartStatusFunction Reset the chart status function
+func SetDefaultChartStatusFunction() {
+	chartStatusFn = getChartStatus
+}
+
+// ReleaseAppVersionFnType - Package-level var and functions to allow overriding GetReleaseAppVersion for unit test purposes
+type ReleaseAppVersionFnType func(releaseName string, namespace string) (string, error)
+
+var releaseAppVersionFn ReleaseAppVersionFnType = getReleaseAppVersion
+
+// SetReleaseAppVersionFunction Override the GetReleaseAppVersion for unit testing
+func SetReleaseAppVersionFunction(f ReleaseAppVersionFnType) {
+	releaseAppVersionFn = f
+}
+
+// SetDefaultReleaseAppVersionFunction Reset the GetReleaseAppVersion function
+func SetDefaultReleaseAppVersionFunction() {
+	releaseAppVersionFn = getReleaseAppVersion
+}
+
+// Package-level var and functions to allow overriding getReleaseState for unit test purposes
+type releaseStateFnType func(releaseName string, namespace string) (string, error)
+
+var releaseStateFn releaseStateFnType = getReleaseState
+
+// SetChartStateFunction Override the chart state function for unit testing
+func SetChartStateFunction(f releaseStateFnType) {
+	releaseStateFn = f
+}
+
+// SetDefaultChartStateFunction Reset the chart state function
+func SetDefaultChartStateFunction() {
+	releaseStateFn = getChartStatus
+}
+
+// ActionConfigFnType - Package-level var and functions to allow overriding the Helm action configuration for unit test purposes
+type ActionConfigFnType func(log vzlog.VerrazzanoLogger, namespace string) (*action.Configuration, error)
+
+var actionConfigFn ActionConfigFnType = getActionConfig
+
+// SetActionConfigFunction Override the Helm action configuration function for unit testing
+func SetActionConfigFunction(f ActionConfigFnType) {
+	actionConfigFn = f
+}
+
+// SetDefaultActionConfigFunction Reset the Helm action configuration function
+func SetDefaultActionConfigFunction() {
+	actionConfigFn = getActionConfig
+}
+
+// getActionConfig creates the configuration used to run Helm actions against the cluster, with releases stored
+// in the given namespace
+func getActionConfig(log vzlog.VerrazzanoLogger, namespace string) (*action.Configuration, error) {
+	settings := cli.New()
+	if namespace != "" {
+		settings.SetNamespace(namespace)
+	}
+	actionConfig := new(action.Configuration)
+	if err := actionConfig.Init(settings.RESTClientGetter(), settings.Namespace(), os.Getenv("HELM_DRIVER"), debugLog(log)); err != nil {
+		return nil, err
+	}
+	return actionConfig, nil
+}
+
+// debugLog returns the function used by the Helm actions to log debug messages
+func debugLog(log vzlog.VerrazzanoLogger) action.DebugLog {
+	return func(format string, v ...interface{}) {
+		if Debug {
+			log.Infof(format, v...)
+		}
+	}
+}
+
+// GetValues will get the user supplied values of a Helm release as YAML, like 'helm get values'.
+func GetValues(log vzlog.VerrazzanoLogger, releaseName string, namespace string) ([]byte, error) {
+	// Helm get values will get the current set values for the installed chart.
+	// The output will be used as input to the Helm upgrade.
+	valuesMap, err := GetValuesMap(log, releaseName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(valuesMap)
+}
+
+// GetValuesMap will get the user supplied values of a Helm release as a map of Objects, like 'helm get values'.
+func GetValuesMap(log vzlog.VerrazzanoLogger, releaseName string, namespace string) (map[string]interface{}, error) {
+	actionConfig, err := actionConfigFn(log, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("Getting Helm values for release %s/%s", namespace, releaseName)
+	valuesMap, err := action.NewGetValues(actionConfig).Run(releaseName)
+	if err != nil {
+		log.Errorf("Failed to get Helm values for %s: %v", releaseName, err)
+		return nil, err
+	}
+	if valuesMap == nil {
+		valuesMap = map[string]interface{}{}
+	}
+	log.Debugf("Successfully fetched Helm get values %s", releaseName)
+	return valuesMap, nil
+}
+
+// Upgrade will upgrade a Helm release with the specified charts, or install it if it does not exist.  The override
+// files array are in order with the first files in the array have lower precedence than latter files.
+func Upgrade(log vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []HelmOverrides) (stdout []byte, stderr []byte, err error) {
+	// Do not reuse the values of the release.  Instead, the values retrieved with GetValues are passed
+	// as the first override file. This is a workaround to avoid a failed Helm upgrade that results from
+	// a nil reference.  The nil reference occurs when a default value is added to a new chart and
+	// new chart references the new value.
+	valueOpts := &values.Options{}
+	for _, override := range overrides {
+		if len(override.FileOverride) > 0 {
+			valueOpts.ValueFiles = append(valueOpts.ValueFiles, override.FileOverride)
+		}
+		if len(override.SetOverrides) > 0 {
+			valueOpts.Values = append(valueOpts.Values, override.SetOverrides)
+		}
+		if len(override.SetStringOverrides) > 0 {
+			valueOpts.StringValues = append(valueOpts.StringValues, override.SetStringOverrides)
+		}
+		if len(override.SetFileOverrides) > 0 {
+			valueOpts.FileValues = append(valueOpts.FileValues, override.SetFileOverrides)
+		}
+	}
+
+	operation := func(actionConfig *action.Configuration) (*release.Release, error) {
+		chart, err := loader.Load(chartDir)
+		if err != nil {
+			return nil, err
+		}
+		vals, err := valueOpts.MergeValues(getter.All(cli.New()))
+		if err != nil {
+			return nil, err
+		}
+
+		// Install the release if there is no release history, like 'helm upgrade --install'
+		history := action.NewHistory(actionConfig)
+		history.Max = 1
+		if _, err := history.Run(releaseName); errors.Is(err, driver.ErrReleaseNotFound) {
+			install := action.NewInstall(actionConfig)
+			install.ReleaseName = releaseName
+			install.Namespace = namespace
+			install.Wait = wait
+			install.DryRun = dryRun
+			install.Timeout = defaultTimeout
+			return install.Run(chart, vals)
+		}
+
+		upgrade := action.NewUpgrade(actionConfig)
+		upgrade.Namespace = namespace
+		upgrade.Wait = wait
+		upgrade.DryRun = dryRun
+		upgrade.Timeout = defaultTimeout
+		upgrade.MaxHistory = maxHistory
+		return upgrade.Run(releaseName, chart, vals)
+	}
+
+	return runAction(log, releaseName, namespace, fmt.Sprintf("upgrade %s", describeOverrides(overrides)), operation)
+}
+
+// Uninstall will uninstall the release in the specified namespace, like 'helm uninstall'
+func Uninstall(log vzlog.VerrazzanoLogger, releaseName string, namespace string, dryRun bool) (stdout []byte, stderr []byte, err error) {
+	operation := func(actionConfig *action.Configuration) (*release.Release, error) {
+		uninstall := action.NewUninstall(actionConfig)
+		uninstall.DryRun = dryRun
+		uninstall.Timeout = defaultTimeout
+		response, err := uninstall.Run(releaseName)
+		if err != nil {
+			return nil, err
+		}
+		return response.Release, nil
+	}
+
+	return runAction(log, releaseName, namespace, "uninstall", operation)
+}
+
+// runAction is a helper function to run a Helm action and return a result
+func runAction(log vzlog.VerrazzanoLogger, releaseName string, namespace string, operation string, runFunc func(*action.Configuration) (*release.Release, error)) (stdout []byte, stderr []byte, err error) {
+	// Try to upgrade several times.  Sometimes upgrade fails with "already exists" or "no deployed release".
+	// We have seen from tests that doing a retry will eventually succeed if these 2 errors occur.
+	const maxRetry = 5
+	for i := 1; i <= maxRetry; i++ {
+		if i == 1 {
+			log.Progressf("Running Helm %s for release %s", operation, releaseName)
+		} else {
+			log.Progressf("Re-running Helm operation for release %s", releaseName)
+		}
+
+		var actionConfig *action.Configuration
+		actionConfig, err = actionConfigFn(log, namespace)
+		if err != nil {
+			log.Errorf("Failed creating the Helm configuration for release %s: %v", releaseName, err)
+			return nil, []byte(err.Error()), err
+		}
+		var rel *release.Release
+		rel, err = runFunc(actionConfig)
+		if err == nil {
+			log.Debugf("Successfully ran Helm operation %s for release %s", operation, releaseName)
+			return []byte(releaseSummary(releaseName, rel)), nil, nil
+		}
+		if i == 1 || i == maxRetry {
+			log.Errorf("Failed running Helm operation for release %s: %v", releaseName, err)
+			return nil, []byte(err.Error()), err
+		}
+		log.Infof("Failed running Helm operation %s for release %s. Retrying %d of %d", operation, releaseName, i+1, maxRetry)
+	}
+
+	return nil, nil, nil
+}
+
+// releaseSummary describes the release resulting from a Helm operation
+func releaseSummary(releaseName string, rel *release.Release) string {
+	if rel == nil || rel.Info == nil {
+		return fmt.Sprintf("release \"%s\"", releaseName)
+	}
+	return fmt.Sprintf("release \"%s\" revision %d %s", releaseName, rel.Version, rel.Info.Status.String())
+}
+
+// describeOverrides describes the overrides of a Helm upgrade the same way as the Helm command line
+// arguments, with the sensitive data masked
+func describeOverrides(overrides []HelmOverrides) string {
+	var args []string
+	for _, override := range overrides {
+		if len(override.FileOverride) > 0 {
+			args = append(args, "-f", override.FileOverride)
+		}
+		if len(override.SetOverrides) > 0 {
+			args = append(args, "--set", maskSensitiveData(override.SetOverrides))
+		}
+		if len(override.SetStringOverrides) > 0 {
+			args = append(args, "--set-string", maskSensitiveData(override.SetStringOverrides))
+		}
+		if len(override.SetFileOverrides) > 0 {
+			args = append(args, "--set-file", override.SetFileOverrides)
+		}
+	}
+	return strings.Join(args, " ")
+}
+
+// maskSensitiveData replaces sensitive data in a string with mask characters.
+func maskSensitiveData(str string) string {
+	const maskString = "*****"
+	re := regexp.MustCompile(`[Pp]assword=(.+?)(?:,|\z)`)
+
+	matches := re.FindAllStringSubmatch(str, -1)
+	for _, match := range matches {
+		if len(match) == 2 {
+			str = strings.Replace(str, match[1], maskString, 1)
+		}
+	}
+
+	return str
+}
+
+// IsReleaseFailed Returns true if the chart release state is marked 'failed'
+func IsReleaseFailed(releaseName string, namespace string) (bool, error) {
+	log := zap.S()
+	releaseStatus, err := releaseStateFn(releaseName, namespace)
+	if err != nil {
+		log.Errorf("Getting status for chart %s/%s failed with error: %v\n", namespace, releaseName, err)
+		return false, err
+	}
+	return releaseStatus == ChartStatusFailed, nil
+}
+
+// IsReleaseDeployed returns true if the release is deployed
+func IsReleaseDeployed(releaseName string, namespace string) (found bool, err error) {
+	log := zap.S()
+	releaseStatus, err := chartStatusFn(releaseName, namespace)
+	if err != nil {
+		log.Errorf("Getting status for chart %s/%s failed with error: %v\n", namespace, releaseName, err)
+		return false, err
+	}
+	switch releaseStatus {
+	case ChartNotFound:
+		log.Debugf("Chart %s/%s not found", namespace, releaseName)
+	case ChartStatusDeployed:
+		return true, nil
+	}
+	return false, nil
+}
+
+// IsReleaseInstalled returns true if the release is installed
+func IsReleaseInstalled(releaseName string, namespace string) (found bool, err error) {
+	log := zap.S()
+
+	rel, err := getReleaseStatus(releaseName, namespace)
+	if err != nil {
+		if isReleaseNotFound(err) {
+			return false, nil
+		}
+		log.Errorf("Helm status for release %s failed with error: %v\n", releaseName, err)
+		return false, err
+	}
+	log.Debugf("Helm status for release %s: %s", releaseName, rel.Info.Status.String())
+	return true, nil
+}
+
+// getChartStatus returns the Helm deployment status of the specified chart as a string
+func getChartStatus(releaseName string, namespace string) (string, error) {
+	rel, err := getReleaseStatus(releaseName, namespace)
+	if err != nil {
+		if isReleaseNotFound(err) {
+			return ChartNotFound, nil
+		}
+		return "", fmt.Errorf("helm status for release %s failed with error: %v", releaseName, err)
+	}
+	if rel.Info == nil {
+		return "", fmt.Errorf("No chart status found for %s/%s", namespace, releaseName)
+	}
+	return strings.TrimSpace(rel.Info.Status.String()), nil
+}
+
+// getReleaseState returns the state of the latest revision of a specific release/namespace, or an empty
+// string if the release is not found
+func getReleaseState(releaseName string, namespace string) (string, error) {
+	rel, err := getRelease(releaseName, namespace)
+	if err != nil {
+		return "", err
+	}
+	if rel == nil || rel.Info == nil {
+		return "", nil
+	}
+	return strings.TrimSpace(rel.Info.Status.String()), nil
+}
+
+// GetReleaseAppVersion - public function to execute releaseAppVersionFn
+func GetReleaseAppVersion(releaseName string, namespace string) (string, error) {
+	return releaseAppVersionFn(releaseName, namespace)
+}
+
+//GetReleaseStringValues - Returns a subset of Helm release values as a map of strings
+func GetReleaseStringValues(log vzlog.VerrazzanoLogger, valueKeys []string, releaseName string, namespace string) (map[string]string, error) {
+	values, err := GetReleaseValues(log, valueKeys, releaseName, namespace)
+	if err != nil {
+		return map[string]string{}, err
+	}
+	returnVals := map[string]string{}
+	for key, val := range values {
+		returnVals[key] = fmt.Sprintf("%v", val)
+	}
+	return returnVals, err
+}
+
+//GetReleaseValues - Returns a subset of Helm release values as a map of objects
+func GetReleaseValues(log vzlog.VerrazzanoLogger, valueKeys []string, releaseName string, namespace string) (map[string]interface{}, error) {
+	isDeployed, err := IsReleaseDeployed(releaseName, namespace)
+	if err != nil {
+		return map[string]interface{}{}, err
+	}
+	var values = map[string]interface{}{}
+	if isDeployed {
+		valuesMap, err := GetValuesMap(log, releaseName, namespace)
+		if err != nil {
+			return map[string]interface{}{}, err
+		}
+		for _, valueKey := range valueKeys {
+			if mapVal, ok := valuesMap[valueKey]; ok {
+				log.Debugf("Found value for %s: %v", valueKey, mapVal)
+				values[valueKey] = mapVal
+			}
+		}
+	}
+	return values, nil
+}
+
+// GetReleaseManifest returns the rendered manifest of the latest revision of a specific release/namespace
+func GetReleaseManifest(releaseName string, namespace string) (string, error) {
+	rel, err := getRelease(releaseName, namespace)
+	if err != nil {
+		return "", err
+	}
+	if rel == nil {
+		return "", nil
+	}
+	return rel.Manifest, nil
+}
+
+// getReleaseAppVersion returns the chart app version of the latest revision of a specific release/namespace
+func getReleaseAppVersion(releaseName string, namespace string) (string, error) {
+	rel, err := getRelease(releaseName, namespace)
+	if err != nil {
+		return "", err
+	}
+	if rel == nil || rel.Chart == nil || rel.Chart.Metadata == nil {
+		return "", nil
+	}
+	return strings.TrimSpace(rel.Chart.Metadata.AppVersion), nil
+}
+
+// getReleaseStatus returns the latest revision of a release, like 'helm status'
+func getReleaseStatus(releaseName string, namespace string) (*release.Release, error) {
+	actionConfig, err := actionConfigFn(vzlog.DefaultLogger(), namespace)
+	if err != nil {
+		return nil, err
+	}
+	return action.NewStatus(actionConfig).Run(releaseName)
+}
+
+// getRelease returns the latest revision of a release in any state, like 'helm ls --all',
+// or nil if the release is not found
+func getRelease(releaseName string, namespace string) (*release.Release, error) {
+	actionConfig, err := actionConfigFn(vzlog.DefaultLogger(), namespace)
+	if err != nil {
+		return nil, err
+	}
+	list := action.NewList(actionConfig)
+	list.All = true
+	list.SetStateMask()
+	list.Filter = fmt.Sprintf("^%s$", regexp.QuoteMeta(releaseName))
+	releases, err := list.Run()
+	if err != nil {
+		return nil, fmt.Errorf("helm status for namespace %s failed with error: %v", namespace, err)
+	}
+	for _, rel := range releases {
+		if rel.Name == releaseName {
+			return rel, nil
+		}
+	}
+	return nil, nil
+}
+
+// isReleaseNotFound returns true if the error is returned by Helm for a release that does not exist
+func isReleaseNotFound(err error) bool {
+	return errors.Is(err, driver.ErrReleaseNotFound) || strings.Contains(err.Error(), "not found")
+}
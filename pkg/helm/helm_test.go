@@ -0,0 +1,527 @@
+// Copyright (c) 2020, 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+const ns = "my-namespace"
+const chartdir = "./testdata"
+const release1 = "my-release"
+const missingRelease = "no-release"
+
+// failingActionConfig returns an error for every Helm action, to simulate a Helm error
+func failingActionConfig(_ vzlog.VerrazzanoLogger, _ string) (*action.Configuration, error) {
+	return nil, fmt.Errorf("helm error")
+}
+
+// TestGetValues tests getting the values of a Helm release
+// GIVEN a deployed release with user supplied values
+// WHEN I call GetValues and GetValuesMap
+// THEN the user supplied values are returned as YAML and as a map
+func TestGetValues(t *testing.T) {
+	assert := assert.New(t)
+	SetActionConfigFunction(NewMemoryActionConfigFunction(
+		CreateRelease(release1, ns, release.StatusDeployed, "1.0", map[string]interface{}{"key1": "value1"}),
+	))
+	defer SetDefaultActionConfigFunction()
+
+	stdout, err := GetValues(vzlog.DefaultLogger(), release1, ns)
+	assert.NoError(err, "GetValues returned an error")
+	assert.Equal("key1: value1\n", string(stdout))
+
+	values, err := GetValuesMap(vzlog.DefaultLogger(), release1, ns)
+	assert.NoError(err, "GetValuesMap returned an error")
+	assert.Equal(map[string]interface{}{"key1": "value1"}, values)
+
+	_, err = GetValuesMap(vzlog.DefaultLogger(), missingRelease, ns)
+	assert.Error(err, "GetValuesMap should return an error for a missing release")
+}
+
+// TestUpgrade tests the Helm upgrade
+// GIVEN a chart and a set of overrides
+// WHEN I call Upgrade for a release that does not exist, and then again with different overrides
+// THEN the release is installed and then upgraded, with the values of the overrides
+func TestUpgrade(t *testing.T) {
+	assert := assert.New(t)
+	SetActionConfigFunction(NewMemoryActionConfigFunction())
+	defer SetDefaultActionConfigFunction()
+
+	overridesFile, err := os.CreateTemp("", "helm-overrides-*.yaml")
+	assert.NoError(err)
+	defer os.Remove(overridesFile.Name())
+	_, err = overridesFile.WriteString("key1: fileValue\nkey2: fileValue\n")
+	assert.NoError(err)
+	assert.NoError(overridesFile.Close())
+
+	overrides := []HelmOverrides{
+		{FileOverride: overridesFile.Name()},
+		{SetOverrides: "key2=setValue"},
+		{SetStringOverrides: "key3=true"},
+	}
+	stdout, stderr, err := Upgrade(vzlog.DefaultLogger(), release1, ns, chartdir, false, false, overrides)
+	assert.NoError(err, "Upgrade returned an error")
+	assert.Len(stderr, 0, "Upgrade stderr should be empty")
+	assert.NotZero(stdout, "Upgrade stdout should not be empty")
+
+	values, err := GetValuesMap(vzlog.DefaultLogger(), release1, ns)
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{"key1": "fileValue", "key2": "setValue", "key3": "true"}, values)
+	status, err := getChartStatus(release1, ns)
+	assert.NoError(err)
+	assert.Equal(ChartStatusDeployed, status)
+	appVersion, err := GetReleaseAppVersion(release1, ns)
+	assert.NoError(err)
+	assert.Equal("0.8.0-app", appVersion)
+
+	_, _, err = Upgrade(vzlog.DefaultLogger(), release1, ns, chartdir, false, false, []HelmOverrides{{SetOverrides: "key1=newValue"}})
+	assert.NoError(err, "Upgrade returned an error")
+	values, err = GetValuesMap(vzlog.DefaultLogger(), release1, ns)
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{"key1": "newValue"}, values)
+}
+
+// TestUpgradeMaxHistory tests that the Helm upgrade limits the release history
+// GIVEN a chart
+// WHEN I call Upgrade more times than the maximum number of revisions that are kept
+// THEN the oldest revisions are deleted
+func TestUpgradeMaxHistory(t *testing.T) {
+	assert := assert.New(t)
+	actionConfigFn := NewMemoryActionConfigFunction()
+	SetActionConfigFunction(actionConfigFn)
+	defer SetDefaultActionConfigFunction()
+
+	for i := 0; i < maxHistory+2; i++ {
+		_, _, err := Upgrade(vzlog.DefaultLogger(), release1, ns, chartdir, false, false, []HelmOverrides{{SetOverrides: fmt.Sprintf("key1=%d", i)}})
+		assert.NoError(err, "Upgrade returned an error")
+	}
+
+	actionConfig, err := actionConfigFn(vzlog.DefaultLogger(), ns)
+	assert.NoError(err)
+	history, err := action.NewHistory(actionConfig).Run(release1)
+	assert.NoError(err)
+	assert.Len(history, maxHistory)
+	assert.Equal(maxHistory+2, history[len(history)-1].Version)
+}
+
+// TestUpgradeDryRun tests the Helm upgrade dry run
+// GIVEN a chart
+// WHEN I call Upgrade with dry run for a release that does not exist
+// THEN the release is not installed
+func TestUpgradeDryRun(t *testing.T) {
+	assert := assert.New(t)
+	SetActionConfigFunction(NewMemoryActionConfigFunction())
+	defer SetDefaultActionConfigFunction()
+
+	_, _, err := Upgrade(vzlog.DefaultLogger(), release1, ns, chartdir, false, true, nil)
+	assert.NoError(err, "Upgrade returned an error")
+	installed, err := IsReleaseInstalled(release1, ns)
+	assert.NoError(err)
+	assert.False(installed)
+}
+
+// TestUpgradeFail tests the Helm upgrade failures
+// GIVEN a set of upgrade parameters and a Helm error, or a chart that does not exist
+// WHEN I call Upgrade
+// THEN the Helm upgrade returns an error
+func TestUpgradeFail(t *testing.T) {
+	assert := assert.New(t)
+	SetActionConfigFunction(failingActionConfig)
+	defer SetDefaultActionConfigFunction()
+
+	stdout, stderr, err := Upgrade(vzlog.DefaultLogger(), release1, ns, chartdir, false, false, nil)
+	assert.Error(err, "Upgrade should have returned an error")
+	assert.Len(stdout, 0, "Upgrade stdout should be empty")
+	assert.NotZero(stderr, "Upgrade stderr should not be empty")
+
+	SetActionConfigFunction(NewMemoryActionConfigFunction())
+	_, _, err = Upgrade(vzlog.DefaultLogger(), release1, ns, "no-such-chart", false, false, nil)
+	assert.Error(err, "Upgrade should have returned an error")
+}
+
+// TestUninstall tests the Helm Uninstall fn
+// GIVEN a deployed release
+// WHEN I call Uninstall
+// THEN the release is uninstalled, and uninstalling it again returns an error
+func TestUninstall(t *testing.T) {
+	assert := assert.New(t)
+	SetActionConfigFunction(NewMemoryActionConfigFunction(
+		CreateRelease(release1, ns, release.StatusDeployed, "1.0", nil),
+	))
+	defer SetDefaultActionConfigFunction()
+
+	stdout, stderr, err := Uninstall(vzlog.DefaultLogger(), release1, ns, false)
+	assert.NoError(err)
+	assert.Len(stderr, 0)
+	assert.NotZero(stdout)
+	installed, err := IsReleaseInstalled(release1, ns)
+	assert.NoError(err)
+	assert.False(installed)
+
+	_, stderr, err = Uninstall(vzlog.DefaultLogger(), release1, ns, false)
+	assert.Error(err)
+	assert.NotZero(stderr)
+}
+
+// TestIsReleaseInstalled tests checking if a Helm release is installed
+// GIVEN a deployed release
+// WHEN I call IsReleaseInstalled for the release, for a release that does not exist and with a Helm error
+// THEN true is returned for the release, false for the missing release and an error for the Helm error
+func TestIsReleaseInstalled(t *testing.T) {
+	assert := assert.New(t)
+	SetActionConfigFunction(NewMemoryActionConfigFunction(
+		CreateRelease(release1, ns, release.StatusDeployed, "1.0", nil),
+	))
+	defer SetDefaultActionConfigFunction()
+
+	found, err := IsReleaseInstalled(release1, ns)
+	assert.NoError(err, "IsReleaseInstalled returned an error")
+	assert.True(found, "Release not found")
+
+	found, err = IsReleaseInstalled(missingRelease, ns)
+	assert.NoError(err, "IsReleaseInstalled returned an error")
+	assert.False(found, "Release should not be found")
+
+	found, err = IsReleaseInstalled(release1, "other-namespace")
+	assert.NoError(err, "IsReleaseInstalled returned an error")
+	assert.False(found, "Release should not be found in another namespace")
+
+	SetActionConfigFunction(failingActionConfig)
+	found, err = IsReleaseInstalled(release1, ns)
+	assert.Error(err, "IsReleaseInstalled should have returned an error")
+	assert.False(found, "Release should not be found")
+}
+
+// TestIsReleaseDeployed tests checking if a Helm release is deployed
+// GIVEN a Helm release
+// WHEN I call IsReleaseDeployed
+// THEN the function returns success and found true
+func TestIsReleaseDeployed(t *testing.T) {
+	assert := assert.New(t)
+	SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
+		return ChartStatusDeployed, nil
+	})
+	defer SetDefaultChartStatusFunction()
+
+	found, err := IsReleaseDeployed(release1, ns)
+	assert.NoError(err, "IsReleaseDeployed returned an error")
+	assert.True(found, "Release not found")
+}
+
+// TestIsReleaseNotDeployed tests checking if a Helm release is not deployed
+// GIVEN a Helm release
+// WHEN I call IsReleaseDeployed
+// THEN the function returns success and the correct found status
+func TestIsReleaseNotDeployed(t *testing.T) {
+	assert := assert.New(t)
+	SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
+		return ChartStatusPendingInstall, nil
+	})
+	defer SetDefaultChartStatusFunction()
+
+	found, err := IsReleaseDeployed(release1, ns)
+	assert.NoError(err, "IsReleaseDeployed returned an error")
+	assert.False(found, "Release should not be found")
+}
+
+// TestIsReleaseFailedChartNotFound tests checking if a Helm release is in a failed state
+// GIVEN a Helm release that does not exist
+// WHEN I call IsReleaseFailed
+// THEN the function returns false and no error
+func TestIsReleaseFailedChartNotFound(t *testing.T) {
+	assert := assert.New(t)
+	SetChartStateFunction(func(releaseName string, namespace string) (string, error) {
+		return ChartNotFound, nil
+	})
+	defer SetDefaultChartStateFunction()
+
+	failed, err := IsReleaseFailed(release1, ns)
+	assert.NoError(err)
+	assert.False(failed)
+}
+
+// TestIsReleaseFailedChartDeployed tests checking if a Helm release is in a failed state
+// GIVEN a deployed Helm release
+// WHEN I call IsReleaseFailed
+// THEN the function returns false and no error
+func TestIsReleaseFailedChartDeployed(t *testing.T) {
+	assert := assert.New(t)
+	SetChartStateFunction(func(releaseName string, namespace string) (string, error) {
+		return ChartStatusDeployed, nil
+	})
+	defer SetDefaultChartStateFunction()
+
+	failed, err := IsReleaseFailed(release1, ns)
+	assert.NoError(err)
+	assert.False(failed)
+}
+
+// TestIsReleaseFailed tests checking if a Helm release is in a failed state
+// GIVEN a failed Helm release
+// WHEN I call IsReleaseFailed
+// THEN the function returns true and no error
+func TestIsReleaseFailed(t *testing.T) {
+	assert := assert.New(t)
+	SetChartStateFunction(func(releaseName string, namespace string) (string, error) {
+		return ChartStatusFailed, nil
+	})
+	defer SetDefaultChartStateFunction()
+
+	failed, err := IsReleaseFailed(release1, ns)
+	assert.NoError(err)
+	assert.True(failed)
+}
+
+// TestIsReleaseFailedError tests checking if a Helm release is in a failed state
+// GIVEN an error getting the state of the Helm release
+// WHEN I call IsReleaseFailed
+// THEN the function returns false and the error
+func TestIsReleaseFailedError(t *testing.T) {
+	assert := assert.New(t)
+	SetChartStateFunction(func(releaseName string, namespace string) (string, error) {
+		return "", fmt.Errorf("Unexpected error")
+	})
+	defer SetDefaultChartStateFunction()
+
+	failed, err := IsReleaseFailed(release1, ns)
+	assert.Error(err)
+	assert.False(failed)
+}
+
+// Test_getReleaseState tests the getReleaseState fn
+// GIVEN Helm releases in different states
+// WHEN I call getReleaseState
+// THEN the state of the latest revision of the release is returned, or an empty string if it does not exist
+func Test_getReleaseState(t *testing.T) {
+	SetActionConfigFunction(NewMemoryActionConfigFunction(
+		CreateRelease("weblogic-operator", "verrazzano-system", release.StatusDeployed, "1.0", nil),
+		CreateRelease("verrazzano", "verrazzano-system", release.StatusPendingInstall, "1.0", nil),
+		CreateRelease("keycloak", "keycloak", release.StatusFailed, "1.0", nil),
+	))
+	defer SetDefaultActionConfigFunction()
+
+	state, err := getReleaseState("weblogic-operator", "verrazzano-system")
+	assert.NoError(t, err)
+	assert.Equal(t, ChartStatusDeployed, state)
+
+	state, err = getReleaseState("verrazzano", "verrazzano-system")
+	assert.NoError(t, err)
+	assert.Equal(t, ChartStatusPendingInstall, state)
+
+	state, err = getReleaseState("keycloak", "keycloak")
+	assert.NoError(t, err)
+	assert.Equal(t, ChartStatusFailed, state)
+
+	state, err = getReleaseState("keycloak", "verrazzano-system")
+	assert.NoError(t, err)
+	assert.Equal(t, "", state)
+
+	SetActionConfigFunction(failingActionConfig)
+	_, err = getReleaseState("weblogic-operator", "verrazzano-system")
+	assert.Error(t, err)
+}
+
+// Test_getChartStatus tests the getChartStatus fn
+// GIVEN a deployed Helm release
+// WHEN I call getChartStatus for the release, for a release that does not exist and with a Helm error
+// THEN the status of the release, NotFound or an error is returned
+func Test_getChartStatus(t *testing.T) {
+	SetActionConfigFunction(NewMemoryActionConfigFunction(
+		CreateRelease("weblogic-operator", "verrazzano-system", release.StatusDeployed, "1.0", nil),
+	))
+	defer SetDefaultActionConfigFunction()
+
+	status, err := getChartStatus("weblogic-operator", "verrazzano-system")
+	assert.NoError(t, err)
+	assert.Equal(t, ChartStatusDeployed, status)
+
+	status, err = getChartStatus("weblogic-operator", "other-namespace")
+	assert.NoError(t, err)
+	assert.Equal(t, ChartNotFound, status)
+
+	SetActionConfigFunction(failingActionConfig)
+	status, err = getChartStatus("weblogic-operator", "verrazzano-system")
+	assert.Error(t, err)
+	assert.Empty(t, status)
+}
+
+// TestGetReleaseValue tests the GetReleaseValues fn
+// GIVEN a call to GetReleaseValues
+// WHEN a valid helm release and namespace are deployed
+// THEN the function returns the value/true/nil if the helm key exists, or ""/false/nil if it doesn't
+func TestGetReleaseValue(t *testing.T) {
+	SetActionConfigFunction(NewMemoryActionConfigFunction(
+		CreateRelease("external-dns", "cert-manager", release.StatusDeployed, "1.0", externalDNSValues()),
+	))
+	defer SetDefaultActionConfigFunction()
+
+	keys := []string{"txtOwnerId", "zoneIDFilters", "foo"}
+	value, err := GetReleaseValues(vzlog.DefaultLogger(), keys, "external-dns", "cert-manager")
+
+	expectedMap := map[string]interface{}{
+		"txtOwnerId":    "v8o-default-my-verrazzano-3201314693",
+		"zoneIDFilters": []interface{}{"ocid1.dns-zone.oc1..blahblahblah"},
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMap, value, "Map did not contain expected values")
+}
+
+// TestGetReleaseStringValue tests the GetReleaseStringValues fn
+// GIVEN a call to GetReleaseStringValues
+// WHEN a valid helm release and namespace are deployed
+// THEN the function returns the value/true/nil if the helm key exists, or ""/false/nil if it doesn't
+func TestGetReleaseStringValue(t *testing.T) {
+	SetActionConfigFunction(NewMemoryActionConfigFunction(
+		CreateRelease("external-dns", "cert-manager", release.StatusDeployed, "1.0", externalDNSValues()),
+	))
+	defer SetDefaultActionConfigFunction()
+
+	keys := []string{"txtOwnerId", "zoneIDFilters", "foo"}
+	value, err := GetReleaseStringValues(vzlog.DefaultLogger(), keys, "external-dns", "cert-manager")
+
+	expectedMap := map[string]string{
+		"txtOwnerId":    "v8o-default-my-verrazzano-3201314693",
+		"zoneIDFilters": "[ocid1.dns-zone.oc1..blahblahblah]",
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMap, value, "Map did not contain expected values")
+}
+
+// TestGetReleaseValueReleaseNotFound tests the GetReleaseValues fn
+// GIVEN a call to GetReleaseValues
+// WHEN a the helm release is NOT deployed
+// THEN the function returns the value/true/nil if the helm key exists, or ""/false/nil if it doesn't
+func TestGetReleaseValueReleaseNotFound(t *testing.T) {
+	SetActionConfigFunction(NewMemoryActionConfigFunction())
+	defer SetDefaultActionConfigFunction()
+
+	keys := []string{"txtOwnerId", "external-dns"}
+	values, err := GetReleaseValues(vzlog.DefaultLogger(), keys, "external-dns", "cert-manager")
+	assert.NoErrorf(t, err, "Unexpected error: %v", err)
+	assert.Equal(t, map[string]interface{}{}, values, "Found unexpected release value")
+
+	expectedErr := fmt.Errorf("Helm error")
+	SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
+		return ChartNotFound, expectedErr
+	})
+	defer SetDefaultChartStatusFunction()
+	values, helmErr := GetReleaseValues(vzlog.DefaultLogger(), keys, "external-dns", "cert-manager")
+	assert.Equal(t, map[string]interface{}{}, values, "Found unexpected release values")
+	assert.Error(t, helmErr, "Did not get expected error")
+	assert.Equal(t, expectedErr, helmErr)
+}
+
+// Test_maskSensitiveData tests the maskSensitiveData function
+func Test_maskSensitiveData(t *testing.T) {
+	// GIVEN a string with sensitive data
+	// WHEN the maskSensitiveData function is called
+	// THEN the returned string has sensitive values masked
+	str := `Running command: /usr/bin/helm upgrade mysql /verrazzano/platform-operator/thirdparty/charts/mysql
+		--wait --namespace keycloak --install -f /verrazzano/platform-operator/helm_config/overrides/mysql-values.yaml
+		-f /tmp/values-145495151.yaml
+		--set imageTag=8.0.26,image=ghcr.io/verrazzano/mysql,mysqlPassword=BgD2SBNaGm,mysqlRootPassword=ydqtBpasQ4`
+	expected := `Running command: /usr/bin/helm upgrade mysql /verrazzano/platform-operator/thirdparty/charts/mysql
+		--wait --namespace keycloak --install -f /verrazzano/platform-operator/helm_config/overrides/mysql-values.yaml
+		-f /tmp/values-145495151.yaml
+		--set imageTag=8.0.26,image=ghcr.io/verrazzano/mysql,mysqlPassword=*****,mysqlRootPassword=*****`
+	maskedStr := maskSensitiveData(str)
+	assert.Equal(t, expected, maskedStr)
+
+	// GIVEN a string without sensitive data
+	// WHEN the maskSensitiveData function is called
+	// THEN the returned string is unaltered
+	str = `Running command: /usr/bin/helm upgrade ingress-controller /verrazzano/platform-operator/thirdparty/charts/ingress-nginx
+		--wait --namespace ingress-nginx --install -f /verrazzano/platform-operator/helm_config/overrides/ingress-nginx-values.yaml
+		-f /tmp/values-037653479.yaml --set controller.image.tag=0.46.0-20211005200943-bd017fde2,
+		controller.image.repository=ghcr.io/verrazzano/nginx-ingress-controller,
+		defaultBackend.image.tag=0.46.0-20211005200943-bd017fde2,
+		defaultBackend.image.repository=ghcr.io/verrazzano/nginx-ingress-default-backend,controller.service.type=LoadBalancer`
+	maskedStr = maskSensitiveData(str)
+	assert.Equal(t, str, maskedStr)
+}
+
+// Test_describeOverrides tests the describeOverrides function
+// GIVEN Helm overrides with sensitive data
+// WHEN the describeOverrides function is called
+// THEN the overrides are described as Helm arguments with the sensitive values masked
+func Test_describeOverrides(t *testing.T) {
+	overrides := []HelmOverrides{
+		{FileOverride: "my-override.yaml"},
+		{SetOverrides: "image=mysql,mysqlPassword=BgD2SBNaGm"},
+		{SetStringOverrides: "key=true"},
+		{SetFileOverrides: "cert=/tmp/cert.pem"},
+	}
+	assert.Equal(t, "-f my-override.yaml --set image=mysql,mysqlPassword=***** --set-string key=true --set-file cert=/tmp/cert.pem",
+		describeOverrides(overrides))
+}
+
+// Test_GetReleaseAppVersion tests the GetReleaseAppVersion function
+// GIVEN a call to GetReleaseAppVersion
+// WHEN varying the inputs and underlying status
+// THEN test the expected result is returned
+func Test_GetReleaseAppVersion(t *testing.T) {
+	type args struct {
+		releaseName string
+		namespace   string
+		releases    []*release.Release
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Test GetReleaseAppVersion when app_version exists",
+			want: "1",
+			args: args{
+				releaseName: "verrazzano",
+				namespace:   "verrazzano-system",
+				releases:    []*release.Release{CreateRelease("verrazzano", "verrazzano-system", release.StatusDeployed, "1", nil)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test GetReleaseAppVersion when app_version does not exist",
+			want: "",
+			args: args{
+				releaseName: "verrazzano",
+				namespace:   "verrazzano-system",
+				releases:    []*release.Release{CreateRelease("unknown", "verrazzano-system", release.StatusDeployed, "1", nil)},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetActionConfigFunction(NewMemoryActionConfigFunction(tt.args.releases...))
+			defer SetDefaultActionConfigFunction()
+			got, err := GetReleaseAppVersion(tt.args.releaseName, tt.args.namespace)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equalf(t, tt.want, got, "GetReleaseAppVersion(%v, %v)", tt.args.releaseName, tt.args.namespace)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func externalDNSValues() map[string]interface{} {
+	return map[string]interface{}{
+		"domainFilters":      []interface{}{"my.domain.io"},
+		"triggerLoopOnEvent": true,
+		"txtOwnerId":         "v8o-default-my-verrazzano-3201314693",
+		"txtPrefix":          "_v8o-default-my-verrazzano-3201314693-",
+		"zoneIDFilters":      []interface{}{"ocid1.dns-zone.oc1..blahblahblah"},
+	}
+}
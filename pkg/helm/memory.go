@@ -0,0 +1,64 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	helmtime "helm.sh/helm/v3/pkg/time"
+)
+
+// NewMemoryActionConfigFunction returns a Helm action configuration function that stores the releases in memory
+// and does not talk to a cluster, seeded with the given releases.  It is intended for unit tests, for example
+// SetActionConfigFunction(NewMemoryActionConfigFunction(CreateRelease("foo", "bar", release.StatusDeployed, "1.0", nil)))
+func NewMemoryActionConfigFunction(releases ...*release.Release) ActionConfigFnType {
+	memory := driver.NewMemory()
+	store := storage.Init(memory)
+	for _, rel := range releases {
+		_ = store.Create(rel)
+	}
+	return func(log vzlog.VerrazzanoLogger, namespace string) (*action.Configuration, error) {
+		memory.SetNamespace(namespace)
+		return &action.Configuration{
+			Releases:     store,
+			KubeClient:   &kubefake.PrintingKubeClient{Out: ioutil.Discard},
+			Capabilities: chartutil.DefaultCapabilities,
+			Log:          debugLog(log),
+		}, nil
+	}
+}
+
+// CreateRelease creates the first revision of a Helm release with the given status, chart app version and
+// user supplied values, for use with NewMemoryActionConfigFunction
+func CreateRelease(releaseName string, namespace string, status release.Status, appVersion string, values map[string]interface{}) *release.Release {
+	now := helmtime.Time{Time: time.Now()}
+	return &release.Release{
+		Name:      releaseName,
+		Namespace: namespace,
+		Version:   1,
+		Info: &release.Info{
+			FirstDeployed: now,
+			LastDeployed:  now,
+			Status:        status,
+		},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				APIVersion: chart.APIVersionV2,
+				Name:       releaseName,
+				Version:    "0.1.0",
+				AppVersion: appVersion,
+			},
+		},
+		Config: values,
+	}
+}
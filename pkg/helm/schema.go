@@ -0,0 +1,171 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// globalKey is the key of the values that are shared by a chart and its dependencies
+const globalKey = "global"
+
+// freeFormKeys are the keys of values whose keys are not declared by the default values of a chart, for example
+// Kubernetes labels, resource requirements and scheduling constraints.  Keys that end with one of these keys,
+// ignoring case, are also free form, for example podAnnotations.
+var freeFormKeys = []string{
+	"affinity",
+	"annotations",
+	"args",
+	"env",
+	"labels",
+	"resources",
+	"securitycontext",
+	"selector",
+	"tolerations",
+}
+
+// valuesSchemaKey is the key of a loaded chart, with or without the generated values schemas
+type valuesSchemaKey struct {
+	chartDir  string
+	generated bool
+}
+
+// valuesSchemas are the loaded charts with their values schemas
+var valuesSchemas = map[valuesSchemaKey]*chart.Chart{}
+var valuesSchemasMutex sync.Mutex
+
+// ValidateValues validates Helm values against the values.schema.json of the chart in the chart directory and of
+// its dependencies, as Helm does when installing the chart.  Charts without a values.schema.json are not validated.
+// The values are merged with the default values of the chart before they are validated.
+func ValidateValues(chartDir string, values map[string]interface{}) error {
+	return validateValues(valuesSchemaKey{chartDir: chartDir}, values)
+}
+
+// CheckValues checks Helm values against the values.schema.json of the chart and of its dependencies, and against
+// schemas generated from the default values of the charts without a values.schema.json, which report unknown keys
+// and values whose type does not match the type of the default value.  The default values of a chart don't always
+// declare every value that the templates use, so the unknown keys and type mismatches may be false positives.
+func CheckValues(chartDir string, values map[string]interface{}) error {
+	return validateValues(valuesSchemaKey{chartDir: chartDir, generated: true}, values)
+}
+
+// validateValues validates Helm values against the values schemas of the loaded chart
+func validateValues(key valuesSchemaKey, values map[string]interface{}) error {
+	chrt, err := loadValuesSchema(key)
+	if err != nil {
+		return err
+	}
+	coalesced, err := chartutil.CoalesceValues(chrt, values)
+	if err != nil {
+		return err
+	}
+	return chartutil.ValidateAgainstSchema(chrt, coalesced)
+}
+
+// loadValuesSchema loads the chart in the chart directory, and optionally generates the values schemas of the chart
+// and of its dependencies that don't have a values.schema.json.  The charts are cached.
+func loadValuesSchema(key valuesSchemaKey) (*chart.Chart, error) {
+	valuesSchemasMutex.Lock()
+	defer valuesSchemasMutex.Unlock()
+	if chrt, ok := valuesSchemas[key]; ok {
+		return chrt, nil
+	}
+	chrt, err := loader.Load(key.chartDir)
+	if err != nil {
+		return nil, err
+	}
+	if key.generated {
+		if err := generateValuesSchemas(chrt); err != nil {
+			return nil, err
+		}
+	}
+	valuesSchemas[key] = chrt
+	return chrt, nil
+}
+
+// generateValuesSchemas generates the values schema of a chart, and of its dependencies, that does not have a
+// values.schema.json
+func generateValuesSchemas(chrt *chart.Chart) error {
+	if chrt.Schema == nil {
+		// The values of the dependencies are validated against the schemas of the dependencies
+		ignored := map[string]bool{globalKey: true}
+		for _, dependency := range chrt.Dependencies() {
+			ignored[dependency.Name()] = true
+		}
+		schema := GenerateValuesSchema(chrt.Values)
+		for key := range ignored {
+			schema["properties"].(map[string]interface{})[key] = map[string]interface{}{}
+		}
+		data, err := json.Marshal(schema)
+		if err != nil {
+			return err
+		}
+		chrt.Schema = data
+	}
+	for _, dependency := range chrt.Dependencies() {
+		if err := generateValuesSchemas(dependency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateValuesSchema generates a JSON schema from the default values of a chart.  A map of default values only
+// allows the keys that it declares, unless it is empty, and a free form map allows any keys and values.  A value must
+// have the type of its default value, where a string value also allows numbers and booleans, since templates
+// typically quote them, and a null default value allows any value.
+func GenerateValuesSchema(values map[string]interface{}) map[string]interface{} {
+	return generateObjectSchema("", values)
+}
+
+// generateSchema generates the JSON schema of a default value
+func generateSchema(key string, value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return generateObjectSchema(key, v)
+	case []interface{}:
+		return map[string]interface{}{"type": "array"}
+	case bool:
+		return map[string]interface{}{"type": "boolean"}
+	case string:
+		return map[string]interface{}{"type": []string{"string", "number", "boolean"}}
+	case nil:
+		return map[string]interface{}{}
+	default:
+		return map[string]interface{}{"type": "number"}
+	}
+}
+
+// generateObjectSchema generates the JSON schema of a map of default values
+func generateObjectSchema(key string, values map[string]interface{}) map[string]interface{} {
+	if isFreeFormKey(key) {
+		return map[string]interface{}{"type": "object"}
+	}
+	properties := map[string]interface{}{}
+	for k, v := range values {
+		properties[k] = generateSchema(k, v)
+	}
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": len(values) == 0,
+	}
+}
+
+// isFreeFormKey returns true if the keys of the values of the key are not declared by the default values
+func isFreeFormKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, freeForm := range freeFormKeys {
+		if strings.HasSuffix(key, freeForm) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+const schemaChartDir = "testdata/schemachart"
+
+// TestCheckValues tests checking values against the schema generated from the default values of a chart
+// GIVEN a chart without a values schema
+// WHEN values are checked
+// THEN values with known keys and matching types are valid, and unknown keys and type mismatches are reported
+func TestCheckValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		values string
+		errors []string
+	}{
+		{
+			name:   "valid values",
+			values: "replicas: 3\nimage:\n  tag: 2\nenabled: false\nextraConfig:\n  any: value\nnodes:\n- name: a\n",
+		},
+		{
+			name:   "free form values",
+			values: "podAnnotations:\n  a: b\nresources:\n  limits:\n    cpu: 1\n",
+		},
+		{
+			name:   "unknown keys",
+			values: "replica: 3\nimage:\n  tags: latest\n",
+			errors: []string{"Additional property replica is not allowed", "image: Additional property tags is not allowed"},
+		},
+		{
+			name:   "type mismatches",
+			values: "replicas: three\nenabled: yes-please\nimage: ghcr.io/verrazzano/test\n",
+			errors: []string{"replicas: Invalid type", "enabled: Invalid type", "image: Invalid type"},
+		},
+		{
+			name:   "global values",
+			values: "global:\n  registry: ghcr.io\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := map[string]interface{}{}
+			assert.NoError(t, yaml.Unmarshal([]byte(tt.values), &values))
+			err := CheckValues(schemaChartDir, values)
+			if len(tt.errors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			for _, msg := range tt.errors {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
+
+// TestValidateValues tests validating values against the values schemas shipped with a chart
+// GIVEN a chart without a values schema
+// WHEN values with unknown keys and type mismatches are validated
+// THEN no error is reported, only the values.schema.json files of the charts are enforced
+func TestValidateValues(t *testing.T) {
+	values := map[string]interface{}{}
+	assert.NoError(t, yaml.Unmarshal([]byte("replica: 3\nimage:\n  tags: latest\nenabled: yes-please\n"), &values))
+	assert.NoError(t, ValidateValues(schemaChartDir, values))
+}
+
+// TestValidateValuesSubchartSchema tests validating values against the values schema of a dependency
+// GIVEN a chart with a dependency that has a values.schema.json
+// WHEN values of the dependency are validated
+// THEN the values are validated against the schema of the dependency
+func TestValidateValuesSubchartSchema(t *testing.T) {
+	assert.NoError(t, ValidateValues(schemaChartDir, map[string]interface{}{"sub": map[string]interface{}{"port": 9090}}))
+	err := ValidateValues(schemaChartDir, map[string]interface{}{"sub": map[string]interface{}{"port": "http"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "port: Invalid type")
+	err = CheckValues(schemaChartDir, map[string]interface{}{"sub": map[string]interface{}{"port": "http"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "port: Invalid type")
+
+	err = ValidateValues("testdata/missing", map[string]interface{}{})
+	assert.Error(t, err)
+}
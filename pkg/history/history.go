@@ -0,0 +1,144 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// configMapSuffix is the suffix of the name of the ConfigMap that stores the history of a Verrazzano CR
+	configMapSuffix = "-history"
+
+	// EntriesKey is the key of the history entries in the history ConfigMap
+	EntriesKey = "history.yaml"
+
+	// EffectiveSpecKey is the key of the flattened effective spec of the last recorded generation, which the next
+	// generation is compared to
+	EffectiveSpecKey = "effective-spec.json"
+
+	// MaxEntries is the maximum number of generations kept in the history, the oldest entries are removed first
+	MaxEntries = 20
+)
+
+// Outcome is the outcome of the reconcile of a generation of a Verrazzano CR
+type Outcome string
+
+const (
+	// OutcomeInProgress means the generation is being reconciled
+	OutcomeInProgress Outcome = "InProgress"
+	// OutcomeSucceeded means all the enabled components reconciled the generation
+	OutcomeSucceeded Outcome = "Succeeded"
+	// OutcomeFailed means the reconcile of the generation failed
+	OutcomeFailed Outcome = "Failed"
+	// OutcomeSuperseded means a newer generation was created before the reconcile of the generation completed
+	OutcomeSuperseded Outcome = "Superseded"
+)
+
+// Entry is the history of a generation of the spec of a Verrazzano CR
+type Entry struct {
+	// Generation is the generation of the Verrazzano CR
+	Generation int64 `json:"generation"`
+	// Time is the time the generation was first reconciled
+	Time metav1.Time `json:"time"`
+	// ChangedBy is the field manager that last updated the spec, for example kubectl-edit
+	ChangedBy string `json:"changedBy,omitempty"`
+	// Changes are the fields of the effective CR that changed from the previous generation
+	Changes []FieldChange `json:"changes,omitempty"`
+	// Operations are the component operations triggered by the generation
+	Operations []Operation `json:"operations,omitempty"`
+	// Outcome is the outcome of the reconcile of the generation
+	Outcome Outcome `json:"outcome"`
+}
+
+// FieldChange is a change of a field of the effective CR.  The old value is empty for an added field and the new
+// value is empty for a removed field.
+type FieldChange struct {
+	Path string `json:"path"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+// Operation is a component operation, recorded as the component condition it set
+type Operation struct {
+	Component string `json:"component"`
+	Condition string `json:"condition"`
+	Time      string `json:"time"`
+}
+
+// ConfigMapName returns the name of the ConfigMap that stores the history of a Verrazzano CR
+func ConfigMapName(vzName string) string {
+	return vzName + configMapSuffix
+}
+
+// GetEntries returns the history entries stored in a history ConfigMap, oldest first
+func GetEntries(cm *corev1.ConfigMap) ([]Entry, error) {
+	var entries []Entry
+	data, ok := cm.Data[EntriesKey]
+	if !ok {
+		return entries, nil
+	}
+	if err := yaml.Unmarshal([]byte(data), &entries); err != nil {
+		return nil, fmt.Errorf("Failed to parse the history in ConfigMap %s/%s: %v", cm.Namespace, cm.Name, err)
+	}
+	return entries, nil
+}
+
+// SetEntries stores the history entries in a history ConfigMap, keeping the newest MaxEntries entries
+func SetEntries(cm *corev1.ConfigMap, entries []Entry) error {
+	if len(entries) > MaxEntries {
+		entries = entries[len(entries)-MaxEntries:]
+	}
+	data, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[EntriesKey] = string(data)
+	return nil
+}
+
+// DiffFields returns the changes between two sets of flattened fields keyed by path, sorted by path
+func DiffFields(oldFields map[string]interface{}, newFields map[string]interface{}) []FieldChange {
+	var changes []FieldChange
+	for path, newValue := range newFields {
+		oldValue, ok := oldFields[path]
+		if !ok {
+			changes = append(changes, FieldChange{Path: path, New: formatValue(newValue)})
+			continue
+		}
+		if o, n := formatValue(oldValue), formatValue(newValue); o != n {
+			changes = append(changes, FieldChange{Path: path, Old: o, New: n})
+		}
+	}
+	for path, oldValue := range oldFields {
+		if _, ok := newFields[path]; !ok {
+			changes = append(changes, FieldChange{Path: path, Old: formatValue(oldValue)})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+// formatValue formats a field value, strings are not quoted
+func formatValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
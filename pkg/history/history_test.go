@@ -0,0 +1,60 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TestDiffFields tests computing the changes between two sets of flattened fields
+// GIVEN fields that are added, changed, removed and unchanged
+// WHEN DiffFields is called
+// THEN the added, changed and removed fields are returned sorted by path, with values formatted as strings
+func TestDiffFields(t *testing.T) {
+	oldFields := map[string]interface{}{
+		"spec.a":        "same",
+		"spec.b":        float64(1),
+		"spec.removed":  true,
+		"spec.emptyMap": map[string]interface{}{},
+	}
+	newFields := map[string]interface{}{
+		"spec.a":        "same",
+		"spec.b":        float64(3),
+		"spec.added":    "value",
+		"spec.emptyMap": map[string]interface{}{},
+	}
+	assert.Equal(t, []FieldChange{
+		{Path: "spec.added", New: "value"},
+		{Path: "spec.b", Old: "1", New: "3"},
+		{Path: "spec.removed", Old: "true"},
+	}, DiffFields(oldFields, newFields))
+	assert.Empty(t, DiffFields(oldFields, oldFields))
+}
+
+// TestSetEntries tests storing history entries in a ConfigMap
+// GIVEN more than MaxEntries entries
+// WHEN the entries are set and read back
+// THEN only the newest MaxEntries entries are kept
+func TestSetEntries(t *testing.T) {
+	cm := &corev1.ConfigMap{}
+	entries, err := GetEntries(cm)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	for gen := int64(1); gen <= MaxEntries+5; gen++ {
+		entries = append(entries, Entry{Generation: gen, Outcome: OutcomeSucceeded})
+	}
+	assert.NoError(t, SetEntries(cm, entries))
+	entries, err = GetEntries(cm)
+	assert.NoError(t, err)
+	assert.Len(t, entries, MaxEntries)
+	assert.Equal(t, int64(6), entries[0].Generation)
+
+	cm.Data[EntriesKey] = "not: [valid"
+	_, err = GetEntries(cm)
+	assert.Error(t, err)
+}
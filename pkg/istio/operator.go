@@ -0,0 +1,777 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package istio
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzyaml "github.com/verrazzano/verrazzano/pkg/yaml"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/strvals"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// The native install renders the IstioOperator using the Helm charts and profiles of an Istio release, laid out the
+// same as the manifests directory of the release.
+const (
+	chartsDirName   = "charts"
+	profilesDirName = "profiles"
+	defaultProfile  = "default"
+
+	// FieldOwner is the field manager of the objects applied by the native install
+	FieldOwner = "verrazzano-platform-operator"
+
+	// InventoryConfigMapName is the name of the ConfigMap that records the objects applied by the native install
+	InventoryConfigMapName = "verrazzano-istio-install"
+	inventoryKey           = "objects"
+
+	ingressGatewaysKey = "ingressGateways"
+	egressGatewaysKey  = "egressGateways"
+
+	deploymentKind = "Deployment"
+	serviceKind    = "Service"
+	crdKind        = "CustomResourceDefinition"
+)
+
+// istioChart identifies the chart that renders an IstioOperator component
+type istioChart struct {
+	// path is the chart directory relative to the charts directory
+	path string
+	// gatewayValuesKey is the key of the gateway values in the chart, for gateway charts
+	gatewayValuesKey string
+}
+
+var (
+	baseChart    = istioChart{path: "base"}
+	pilotChart   = istioChart{path: "istio-control/istio-discovery"}
+	ingressChart = istioChart{path: "gateways/istio-ingress", gatewayValuesKey: "istio-ingressgateway"}
+	egressChart  = istioChart{path: "gateways/istio-egress", gatewayValuesKey: "istio-egressgateway"}
+)
+
+// supportedComponents are the IstioOperator components the native install renders, other components must be disabled
+var supportedComponents = map[string]bool{
+	"base":             true,
+	"pilot":            true,
+	ingressGatewaysKey: true,
+	egressGatewaysKey:  true,
+}
+
+// ApplyFuncSig is the signature of the function used to apply an object
+type ApplyFuncSig func(cli client.Client, obj *unstructured.Unstructured) error
+
+// applyFunc is the function used to apply objects, it is overridden by unit tests because the fake client does not
+// support server-side apply
+var applyFunc ApplyFuncSig = serverSideApply
+
+// SetApplyFunction sets the function used to apply objects, for unit testing
+func SetApplyFunction(f ApplyFuncSig) {
+	applyFunc = f
+}
+
+// SetDefaultApplyFunction sets the function used to apply objects to the server-side apply default
+func SetDefaultApplyFunction() {
+	applyFunc = serverSideApply
+}
+
+// inventoryEntry identifies an object applied by the native install
+type inventoryEntry struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// NativeInstall installs or upgrades Istio without istioctl.  The IstioOperator built from the override files and
+// --set strings is rendered in-process using the charts and profiles in manifestsDir, and the resulting objects are
+// applied with server-side apply.  Objects applied by a previous install that are no longer rendered are deleted.
+func NativeInstall(log vzlog.VerrazzanoLogger, cli client.Client, manifestsDir string, overrideStrings string, overridesFiles ...string) error {
+	objs, err := RenderManifests(manifestsDir, overrideStrings, overridesFiles...)
+	if err != nil {
+		return log.ErrorfNewErr("Failed rendering the Istio manifests: %v", err)
+	}
+
+	previous, err := getInventory(cli)
+	if err != nil {
+		return log.ErrorfNewErr("Failed getting the Istio install inventory: %v", err)
+	}
+
+	log.Progressf("Applying %d Istio objects", len(objs))
+	for _, obj := range objs {
+		if err := applyFunc(cli, obj); err != nil {
+			return log.ErrorfNewErr("Failed applying Istio %s %s: %v", obj.GetKind(), objectName(obj), err)
+		}
+	}
+
+	current := make([]inventoryEntry, 0, len(objs))
+	rendered := map[inventoryEntry]bool{}
+	for _, obj := range objs {
+		entry := toInventoryEntry(obj)
+		current = append(current, entry)
+		rendered[entry] = true
+	}
+	if err := saveInventory(cli, current); err != nil {
+		return log.ErrorfNewErr("Failed saving the Istio install inventory: %v", err)
+	}
+
+	// Prune the objects that are no longer part of the installation
+	var stale []inventoryEntry
+	for _, entry := range previous {
+		if !rendered[entry] {
+			stale = append(stale, entry)
+		}
+	}
+	if err := deleteEntries(log, cli, stale); err != nil {
+		return err
+	}
+	log.Debugf("Istio native install applied %d objects and pruned %d objects", len(objs), len(stale))
+	return nil
+}
+
+// NativeUninstall deletes the objects applied by NativeInstall.  As with Uninstall, the Istio CRDs are not removed.
+func NativeUninstall(log vzlog.VerrazzanoLogger, cli client.Client) error {
+	entries, err := getInventory(cli)
+	if err != nil {
+		return log.ErrorfNewErr("Failed getting the Istio install inventory: %v", err)
+	}
+	if err := deleteEntries(log, cli, entries); err != nil {
+		return err
+	}
+	cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: InventoryConfigMapName, Namespace: constants.IstioSystemNamespace}}
+	if err := cli.Delete(context.TODO(), &cm); err != nil && !errors.IsNotFound(err) {
+		return log.ErrorfNewErr("Failed deleting the Istio install inventory: %v", err)
+	}
+	return nil
+}
+
+// NativeVerifyInstall verifies an Istio installation done by NativeInstall, the equivalent of VerifyInstall.  It returns
+// true if every applied object exists and every applied Deployment has all of its replicas updated and available.
+func NativeVerifyInstall(log vzlog.VerrazzanoLogger, cli client.Client) (bool, error) {
+	entries, err := getInventory(cli)
+	if err != nil {
+		return false, err
+	}
+	if len(entries) == 0 {
+		log.Progressf("Istio native install has not applied any objects")
+		return false, nil
+	}
+	for _, entry := range entries {
+		obj := entry.toUnstructured()
+		err := cli.Get(context.TODO(), types.NamespacedName{Namespace: entry.Namespace, Name: entry.Name}, obj)
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			log.Progressf("Istio %s %s does not exist", entry.Kind, objectName(obj))
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if entry.Kind == deploymentKind && !isDeploymentReady(obj) {
+			log.Progressf("Istio deployment %s is not ready", objectName(obj))
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// VerifyManifestsDir checks that the Istio manifests directory contains the charts and profiles of the Istio release
+func VerifyManifestsDir(manifestsDir string) error {
+	for _, dir := range []string{"charts", "profiles"} {
+		info, err := os.Stat(filepath.Join(manifestsDir, dir))
+		if err != nil || !info.IsDir() {
+			return fmt.Errorf("the Istio %s directory was not found in %s, the operator image does not include the Istio manifests", dir, manifestsDir)
+		}
+	}
+	return nil
+}
+
+// RenderManifests renders the objects of the IstioOperator built from the override files and --set strings, in the
+// order they must be applied.  The --set strings are comma separated paths relative to the IstioOperator spec.
+func RenderManifests(manifestsDir string, overrideStrings string, overridesFiles ...string) ([]*unstructured.Unstructured, error) {
+	if err := VerifyManifestsDir(manifestsDir); err != nil {
+		return nil, err
+	}
+	spec, err := buildOperatorSpec(manifestsDir, overrideStrings, overridesFiles...)
+	if err != nil {
+		return nil, err
+	}
+	components := getMap(spec, "components")
+	for name, comp := range components {
+		if supportedComponents[name] {
+			continue
+		}
+		if isEnabled(comp, false) {
+			return nil, fmt.Errorf("IstioOperator component %s is not supported by the native install", name)
+		}
+	}
+
+	values := getMap(spec, "values")
+	if meshConfig, ok := spec["meshConfig"].(map[string]interface{}); ok {
+		values["meshConfig"] = meshConfig
+	}
+	global := getMap(values, "global")
+	// The hub and tag of the spec are defaults, the global values take precedence
+	for _, key := range []string{"hub", "tag"} {
+		if _, ok := global[key]; ok {
+			continue
+		}
+		if v, ok := spec[key]; ok {
+			global[key] = v
+		}
+	}
+	if v, ok := spec["revision"]; ok {
+		values["revision"] = v
+	}
+	namespace := constants.IstioSystemNamespace
+	if ns, ok := spec["namespace"].(string); ok && ns != "" {
+		namespace = ns
+	}
+	global["istioNamespace"] = namespace
+
+	chartsDir := filepath.Join(manifestsDir, chartsDirName)
+	var objs []*unstructured.Unstructured
+	if isEnabled(components["base"], true) {
+		rendered, err := renderComponent(chartsDir, baseChart, "base", namespace, values, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, rendered...)
+	}
+	if pilot := components["pilot"]; isEnabled(pilot, true) {
+		rendered, err := renderComponent(chartsDir, pilotChart, "istiod", namespace, values, pilot, false)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, rendered...)
+	}
+	for _, gw := range []struct {
+		key   string
+		chart istioChart
+	}{{ingressGatewaysKey, ingressChart}, {egressGatewaysKey, egressChart}} {
+		gateways, _ := components[gw.key].([]interface{})
+		for _, g := range gateways {
+			rendered, err := renderGateway(chartsDir, gw.chart, namespace, values, g)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, rendered...)
+		}
+	}
+	sortObjects(objs, releaseutil.InstallOrder)
+
+	// As with Helm, the CRDs are applied before all other objects
+	sort.SliceStable(objs, func(i, j int) bool {
+		return objs[i].GetKind() == crdKind && objs[j].GetKind() != crdKind
+	})
+	return objs, nil
+}
+
+// buildOperatorSpec builds the IstioOperator spec from the profile, the override files and the --set strings
+func buildOperatorSpec(manifestsDir string, overrideStrings string, overridesFiles ...string) (map[string]interface{}, error) {
+	spec := map[string]interface{}{}
+	for _, file := range overridesFiles {
+		fileSpec, err := readOperatorSpec(file)
+		if err != nil {
+			return nil, err
+		}
+		mergeOperatorSpecs(spec, fileSpec)
+	}
+	if len(overrideStrings) > 0 {
+		for _, s := range strings.Split(overrideStrings, ",") {
+			if err := strvals.ParseInto(s, spec); err != nil {
+				return nil, fmt.Errorf("Failed parsing IstioOperator override %s: %v", s, err)
+			}
+		}
+	}
+
+	// As with istioctl, every profile is an overlay of the default profile
+	profileSpec, err := readProfile(manifestsDir, defaultProfile)
+	if err != nil {
+		return nil, err
+	}
+	if profile, ok := spec["profile"].(string); ok && profile != "" && profile != defaultProfile {
+		overlay, err := readProfile(manifestsDir, profile)
+		if err != nil {
+			return nil, err
+		}
+		mergeOperatorSpecs(profileSpec, overlay)
+	}
+	mergeOperatorSpecs(profileSpec, spec)
+	return profileSpec, nil
+}
+
+// readProfile reads the spec of an IstioOperator profile
+func readProfile(manifestsDir string, profile string) (map[string]interface{}, error) {
+	profileFile := filepath.Join(manifestsDir, profilesDirName, profile+".yaml")
+	if _, err := os.Stat(profileFile); err != nil {
+		return nil, fmt.Errorf("IstioOperator profile %s is not available: %v", profile, err)
+	}
+	return readOperatorSpec(profileFile)
+}
+
+// readOperatorSpec reads the spec of an IstioOperator YAML file
+func readOperatorSpec(file string) (map[string]interface{}, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	iop := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &iop); err != nil {
+		return nil, fmt.Errorf("Failed parsing IstioOperator file %s: %v", file, err)
+	}
+	return getMap(iop, "spec"), nil
+}
+
+// mergeOperatorSpecs merges the overlay spec into the base spec.  Gateways are merged by name, the same as istioctl,
+// all other lists are replaced.
+func mergeOperatorSpecs(base map[string]interface{}, overlay map[string]interface{}) {
+	baseComponents := getMap(base, "components")
+	overlayComponents, _ := overlay["components"].(map[string]interface{})
+	for _, key := range []string{ingressGatewaysKey, egressGatewaysKey} {
+		overlayGateways, ok := overlayComponents[key].([]interface{})
+		if !ok {
+			continue
+		}
+		baseGateways, _ := baseComponents[key].([]interface{})
+		for _, og := range overlayGateways {
+			overlayGateway, ok := og.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			merged := false
+			for _, bg := range baseGateways {
+				if baseGateway, ok := bg.(map[string]interface{}); ok && baseGateway["name"] == overlayGateway["name"] {
+					_ = vzyaml.MergeMaps(baseGateway, overlayGateway)
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				baseGateways = append(baseGateways, overlayGateway)
+			}
+		}
+		baseComponents[key] = baseGateways
+	}
+
+	// Merge everything except the gateways, which have already been merged
+	rest := map[string]interface{}{}
+	for k, v := range overlay {
+		if k != "components" {
+			rest[k] = v
+		}
+	}
+	_ = vzyaml.MergeMaps(base, rest)
+	for k, v := range overlayComponents {
+		if k != ingressGatewaysKey && k != egressGatewaysKey {
+			_ = vzyaml.MergeMaps(baseComponents, map[string]interface{}{k: v})
+		}
+	}
+}
+
+// renderGateway renders an ingress or egress gateway component
+func renderGateway(chartsDir string, ic istioChart, namespace string, values map[string]interface{}, comp interface{}) ([]*unstructured.Unstructured, error) {
+	gateway, ok := comp.(map[string]interface{})
+	if !ok || !isEnabled(gateway, false) {
+		return nil, nil
+	}
+	name, _ := gateway["name"].(string)
+	if name == "" {
+		return nil, fmt.Errorf("IstioOperator %s gateway is missing a name", ic.gatewayValuesKey)
+	}
+	gatewayValues := deepCopyMap(values)
+	gwValues := getMap(getMap(gatewayValues, "gateways"), ic.gatewayValuesKey)
+	gwValues["name"] = name
+	if labels, ok := gateway["label"].(map[string]interface{}); ok {
+		_ = vzyaml.MergeMaps(getMap(gwValues, "labels"), labels)
+	}
+	if ns, ok := gateway["namespace"].(string); ok && ns != "" {
+		namespace = ns
+	}
+	return renderComponent(chartsDir, ic, name, namespace, gatewayValues, gateway, false)
+}
+
+// renderComponent renders the chart of a component and applies the component k8s settings to the rendered objects
+func renderComponent(chartsDir string, ic istioChart, deploymentName string, namespace string, values map[string]interface{}, comp interface{}, includeCRDs bool) ([]*unstructured.Unstructured, error) {
+	ch, err := loader.Load(filepath.Join(chartsDir, ic.path))
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading Istio chart %s: %v", ic.path, err)
+	}
+	objs, err := renderChart(ch, deploymentName, namespace, deepCopyMap(values), includeCRDs)
+	if err != nil {
+		return nil, fmt.Errorf("Failed rendering Istio chart %s: %v", ic.path, err)
+	}
+	if compMap, ok := comp.(map[string]interface{}); ok {
+		if k8s, ok := compMap["k8s"].(map[string]interface{}); ok {
+			if err := applyK8sSettings(objs, deploymentName, k8s); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return objs, nil
+}
+
+// renderChart renders a chart with the Helm template engine
+func renderChart(ch *chart.Chart, releaseName string, namespace string, values map[string]interface{}, includeCRDs bool) ([]*unstructured.Unstructured, error) {
+	options := chartutil.ReleaseOptions{Name: releaseName, Namespace: namespace, Revision: 1, IsInstall: true}
+	renderValues, err := chartutil.ToRenderValues(ch, values, options, chartutil.DefaultCapabilities)
+	if err != nil {
+		return nil, err
+	}
+	files, err := engine.Render(ch, renderValues)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []string
+	if includeCRDs {
+		for _, crd := range ch.CRDObjects() {
+			manifests = append(manifests, string(crd.File.Data))
+		}
+	}
+	// Sort by file name so the rendering is deterministic
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.HasSuffix(name, "NOTES.txt") || strings.HasPrefix(filepath.Base(name), "_") {
+			continue
+		}
+		manifests = append(manifests, files[name])
+	}
+
+	var objs []*unstructured.Unstructured
+	for _, manifest := range manifests {
+		docs := releaseutil.SplitManifests(manifest)
+		keys := make([]string, 0, len(docs))
+		for k := range docs {
+			keys = append(keys, k)
+		}
+		sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+		for _, k := range keys {
+			obj := map[string]interface{}{}
+			if err := yaml.Unmarshal([]byte(docs[k]), &obj); err != nil {
+				return nil, err
+			}
+			if len(obj) == 0 || obj["kind"] == nil {
+				continue
+			}
+			u := &unstructured.Unstructured{Object: obj}
+			objs = append(objs, u)
+		}
+	}
+	return objs, nil
+}
+
+// applyK8sSettings applies the k8s settings of an IstioOperator component to the Deployment and Service of the
+// component, the same as the istioctl overlays
+func applyK8sSettings(objs []*unstructured.Unstructured, name string, k8s map[string]interface{}) error {
+	for key := range k8s {
+		switch key {
+		case "replicaCount", "affinity", "nodeSelector", "tolerations", "priorityClassName", "securityContext",
+			"podAnnotations", "resources", "env", "strategy", "service", "serviceAnnotations", "imagePullPolicy":
+		default:
+			return fmt.Errorf("IstioOperator k8s setting %s of component %s is not supported by the native install", key, name)
+		}
+	}
+	for _, obj := range objs {
+		if obj.GetName() != name {
+			continue
+		}
+		switch obj.GetKind() {
+		case deploymentKind:
+			if err := applyDeploymentSettings(obj, k8s); err != nil {
+				return err
+			}
+		case serviceKind:
+			if err := applyServiceSettings(obj, k8s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyDeploymentSettings applies the k8s settings of a component to its Deployment
+func applyDeploymentSettings(obj *unstructured.Unstructured, k8s map[string]interface{}) error {
+	if v, ok := k8s["replicaCount"]; ok {
+		if err := unstructured.SetNestedField(obj.Object, v, "spec", "replicas"); err != nil {
+			return err
+		}
+	}
+	if v, ok := k8s["strategy"]; ok {
+		if err := unstructured.SetNestedField(obj.Object, v, "spec", "strategy"); err != nil {
+			return err
+		}
+	}
+	for _, key := range []string{"affinity", "nodeSelector", "tolerations", "priorityClassName", "securityContext"} {
+		if v, ok := k8s[key]; ok {
+			if err := unstructured.SetNestedField(obj.Object, v, "spec", "template", "spec", key); err != nil {
+				return err
+			}
+		}
+	}
+	if v, ok := k8s["podAnnotations"].(map[string]interface{}); ok {
+		annotations, _, _ := unstructured.NestedMap(obj.Object, "spec", "template", "metadata", "annotations")
+		if annotations == nil {
+			annotations = map[string]interface{}{}
+		}
+		_ = vzyaml.MergeMaps(annotations, v)
+		if err := unstructured.SetNestedMap(obj.Object, annotations, "spec", "template", "metadata", "annotations"); err != nil {
+			return err
+		}
+	}
+
+	// Container settings apply to the first container, which is the Istio container
+	containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+	if len(containers) == 0 {
+		return nil
+	}
+	container, ok := containers[0].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for _, key := range []string{"resources", "imagePullPolicy"} {
+		if v, ok := k8s[key]; ok {
+			container[key] = v
+		}
+	}
+	if env, ok := k8s["env"].([]interface{}); ok {
+		container["env"] = mergeEnv(container["env"], env)
+	}
+	return unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+}
+
+// applyServiceSettings applies the k8s settings of a component to its Service
+func applyServiceSettings(obj *unstructured.Unstructured, k8s map[string]interface{}) error {
+	if v, ok := k8s["service"].(map[string]interface{}); ok {
+		spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+		if spec == nil {
+			spec = map[string]interface{}{}
+		}
+		_ = vzyaml.MergeMaps(spec, v)
+		if err := unstructured.SetNestedMap(obj.Object, spec, "spec"); err != nil {
+			return err
+		}
+	}
+	if v, ok := k8s["serviceAnnotations"].(map[string]interface{}); ok {
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		for k, val := range v {
+			annotations[k] = fmt.Sprintf("%v", val)
+		}
+		obj.SetAnnotations(annotations)
+	}
+	return nil
+}
+
+// mergeEnv merges environment variables by name, the overlay variables replace variables of the same name
+func mergeEnv(base interface{}, overlay []interface{}) []interface{} {
+	baseEnv, _ := base.([]interface{})
+	for _, o := range overlay {
+		ov, ok := o.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		replaced := false
+		for i, b := range baseEnv {
+			if bv, ok := b.(map[string]interface{}); ok && bv["name"] == ov["name"] {
+				baseEnv[i] = ov
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			baseEnv = append(baseEnv, ov)
+		}
+	}
+	return baseEnv
+}
+
+// serverSideApply applies an object with server-side apply, taking ownership of conflicting fields
+func serverSideApply(cli client.Client, obj *unstructured.Unstructured) error {
+	return cli.Patch(context.TODO(), obj, client.Apply, client.ForceOwnership, client.FieldOwner(FieldOwner))
+}
+
+// deleteEntries deletes the objects of the inventory entries in uninstall order, except for CRDs
+func deleteEntries(log vzlog.VerrazzanoLogger, cli client.Client, entries []inventoryEntry) error {
+	objs := make([]*unstructured.Unstructured, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Kind == crdKind {
+			continue
+		}
+		objs = append(objs, entry.toUnstructured())
+	}
+	sortObjects(objs, releaseutil.UninstallOrder)
+	for _, obj := range objs {
+		err := cli.Delete(context.TODO(), obj)
+		if err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return log.ErrorfNewErr("Failed deleting Istio %s %s: %v", obj.GetKind(), objectName(obj), err)
+		}
+	}
+	return nil
+}
+
+// getInventory returns the objects applied by the last native install
+func getInventory(cli client.Client) ([]inventoryEntry, error) {
+	cm := corev1.ConfigMap{}
+	err := cli.Get(context.TODO(), types.NamespacedName{Name: InventoryConfigMapName, Namespace: constants.IstioSystemNamespace}, &cm)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []inventoryEntry
+	if err := yaml.Unmarshal([]byte(cm.Data[inventoryKey]), &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// saveInventory records the objects applied by the native install
+func saveInventory(cli client.Client, entries []inventoryEntry) error {
+	data, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	cm := corev1.ConfigMap{}
+	nsn := types.NamespacedName{Name: InventoryConfigMapName, Namespace: constants.IstioSystemNamespace}
+	err = cli.Get(context.TODO(), nsn, &cm)
+	if errors.IsNotFound(err) {
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: nsn.Name, Namespace: nsn.Namespace},
+			Data:       map[string]string{inventoryKey: string(data)},
+		}
+		return cli.Create(context.TODO(), &cm)
+	}
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[inventoryKey] = string(data)
+	return cli.Update(context.TODO(), &cm)
+}
+
+// isDeploymentReady returns true if all the replicas of a Deployment are updated and available
+func isDeploymentReady(obj *unstructured.Unstructured) bool {
+	replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if !found {
+		replicas = 1
+	}
+	updated, _, _ := unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+	available, _, _ := unstructured.NestedInt64(obj.Object, "status", "availableReplicas")
+	return updated >= replicas && available >= replicas
+}
+
+// sortObjects sorts objects by kind in the given order, kinds that are not in the order are sorted last
+func sortObjects(objs []*unstructured.Unstructured, order releaseutil.KindSortOrder) {
+	index := map[string]int{}
+	for i, kind := range order {
+		index[kind] = i
+	}
+	rank := func(kind string) int {
+		if i, ok := index[kind]; ok {
+			return i
+		}
+		return len(order)
+	}
+	sort.SliceStable(objs, func(i, j int) bool {
+		return rank(objs[i].GetKind()) < rank(objs[j].GetKind())
+	})
+}
+
+func toInventoryEntry(obj *unstructured.Unstructured) inventoryEntry {
+	return inventoryEntry{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
+
+func (e inventoryEntry) toUnstructured() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(e.APIVersion, e.Kind))
+	obj.SetNamespace(e.Namespace)
+	obj.SetName(e.Name)
+	return obj
+}
+
+func objectName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// isEnabled returns the enabled field of a component, or the default if the field is not set
+func isEnabled(comp interface{}, defaultEnabled bool) bool {
+	m, ok := comp.(map[string]interface{})
+	if !ok {
+		return defaultEnabled
+	}
+	enabled, ok := m["enabled"].(bool)
+	if !ok {
+		return defaultEnabled
+	}
+	return enabled
+}
+
+// getMap returns the nested map of a key, creating it if it does not exist
+func getMap(m map[string]interface{}, key string) map[string]interface{} {
+	if v, ok := m[key].(map[string]interface{}); ok {
+		return v
+	}
+	v := map[string]interface{}{}
+	m[key] = v
+	return v
+}
+
+// deepCopyMap copies a map of YAML values so the copy can be modified per chart
+func deepCopyMap(m map[string]interface{}) map[string]interface{} {
+	return deepCopyValue(m).(map[string]interface{})
+}
+
+func deepCopyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			c[k] = deepCopyValue(val)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(t))
+		for i, val := range t {
+			c[i] = deepCopyValue(val)
+		}
+		return c
+	default:
+		return v
+	}
+}
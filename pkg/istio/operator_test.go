@@ -0,0 +1,282 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package istio
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testManifestsDir = "testdata/manifests"
+
+const testOperatorYaml = `
+apiVersion: install.istio.io/v1alpha1
+kind: IstioOperator
+spec:
+  meshConfig:
+    enablePrometheusMerge: false
+  components:
+    egressGateways:
+      - name: istio-egressgateway
+        enabled: true
+        k8s:
+          replicaCount: 2
+    ingressGateways:
+      - name: istio-ingressgateway
+        k8s:
+          replicaCount: 3
+          podAnnotations:
+            sidecar.istio.io/inject: "false"
+          env:
+            - name: ISTIO_META_ROUTER_MODE
+              value: standard
+          service:
+            type: NodePort
+            externalIPs:
+              - 1.2.3.4
+          serviceAnnotations:
+            service.beta.kubernetes.io/oci-load-balancer-shape: flexible
+`
+
+// createApply is used in place of server-side apply, which the fake client does not support
+func createApply(cli client.Client, obj *unstructured.Unstructured) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, existing)
+	if errors.IsNotFound(err) {
+		return cli.Create(context.TODO(), obj.DeepCopy())
+	}
+	if err != nil {
+		return err
+	}
+	updated := obj.DeepCopy()
+	updated.SetResourceVersion(existing.GetResourceVersion())
+	return cli.Update(context.TODO(), updated)
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = k8scheme.AddToScheme(scheme)
+	_ = apiextensionsv1.AddToScheme(scheme)
+	return scheme
+}
+
+func writeOperatorFile(t *testing.T, content string) string {
+	f, err := os.CreateTemp("", "istio-*.yaml")
+	assert.NoError(t, err)
+	_, err = f.WriteString(content)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	return f.Name()
+}
+
+func findObject(objs []*unstructured.Unstructured, kind string, name string) *unstructured.Unstructured {
+	for _, obj := range objs {
+		if obj.GetKind() == kind && obj.GetName() == name {
+			return obj
+		}
+	}
+	return nil
+}
+
+// TestRenderManifests tests rendering the IstioOperator manifests in-process
+// GIVEN an IstioOperator file with gateway k8s settings and --set overrides
+// WHEN RenderManifests is called
+// THEN the objects of the enabled components are rendered in install order with the overrides and settings applied
+func TestRenderManifests(t *testing.T) {
+	file := writeOperatorFile(t, testOperatorYaml)
+	defer os.Remove(file)
+
+	objs, err := RenderManifests(testManifestsDir, "values.global.hub=ghcr.io/verrazzano,values.global.tag=1.14.3-1", file)
+	assert.NoError(t, err)
+
+	// The CRD is applied first
+	assert.Equal(t, crdKind, objs[0].GetKind())
+
+	istiod := findObject(objs, deploymentKind, "istiod")
+	assert.NotNil(t, istiod)
+	containers, _, _ := unstructured.NestedSlice(istiod.Object, "spec", "template", "spec", "containers")
+	assert.Equal(t, "ghcr.io/verrazzano/pilot:1.14.3-1", containers[0].(map[string]interface{})["image"])
+	assert.NotNil(t, findObject(objs, "ServiceAccount", "istio-reader-service-account"))
+
+	mesh := findObject(objs, "ConfigMap", "istio")
+	assert.Contains(t, mesh.Object["data"].(map[string]interface{})["mesh"], "enablePrometheusMerge: false")
+
+	ingress := findObject(objs, deploymentKind, "istio-ingressgateway")
+	assert.NotNil(t, ingress)
+	assert.Equal(t, "istio-system", ingress.GetNamespace())
+	replicas, _, _ := unstructured.NestedFieldNoCopy(ingress.Object, "spec", "replicas")
+	assert.EqualValues(t, 3, replicas)
+	annotations, _, _ := unstructured.NestedStringMap(ingress.Object, "spec", "template", "metadata", "annotations")
+	assert.Equal(t, "false", annotations["sidecar.istio.io/inject"])
+	containers, _, _ = unstructured.NestedSlice(ingress.Object, "spec", "template", "spec", "containers")
+	assert.Len(t, containers[0].(map[string]interface{})["env"], 1)
+
+	service := findObject(objs, serviceKind, "istio-ingressgateway")
+	serviceType, _, _ := unstructured.NestedString(service.Object, "spec", "type")
+	assert.Equal(t, "NodePort", serviceType)
+	externalIPs, _, _ := unstructured.NestedStringSlice(service.Object, "spec", "externalIPs")
+	assert.Equal(t, []string{"1.2.3.4"}, externalIPs)
+	ports, _, _ := unstructured.NestedSlice(service.Object, "spec", "ports")
+	assert.Len(t, ports, 1)
+	assert.Equal(t, "flexible", service.GetAnnotations()["service.beta.kubernetes.io/oci-load-balancer-shape"])
+
+	egress := findObject(objs, deploymentKind, "istio-egressgateway")
+	assert.NotNil(t, egress)
+	assert.Equal(t, "egressgateway", egress.GetLabels()["istio"])
+}
+
+// TestRenderManifestsMinimalProfile tests rendering the minimal profile
+// GIVEN an IstioOperator file that uses the minimal profile
+// WHEN RenderManifests is called
+// THEN the ingress gateway is not rendered
+func TestRenderManifestsMinimalProfile(t *testing.T) {
+	file := writeOperatorFile(t, "spec:\n  profile: minimal\n")
+	defer os.Remove(file)
+
+	objs, err := RenderManifests(testManifestsDir, "", file)
+	assert.NoError(t, err)
+	assert.NotNil(t, findObject(objs, deploymentKind, "istiod"))
+	assert.Nil(t, findObject(objs, deploymentKind, "istio-ingressgateway"))
+	assert.Nil(t, findObject(objs, deploymentKind, "istio-egressgateway"))
+}
+
+// TestVerifyManifestsDir tests checking the Istio manifests directory
+// GIVEN the test manifests directory and a directory without the Istio manifests
+// WHEN VerifyManifestsDir and RenderManifests are called
+// THEN an error is only returned for the directory without the charts and profiles
+func TestVerifyManifestsDir(t *testing.T) {
+	assert.NoError(t, VerifyManifestsDir(testManifestsDir))
+
+	emptyDir, err := os.MkdirTemp("", "istio-manifests")
+	assert.NoError(t, err)
+	defer os.RemoveAll(emptyDir)
+	assert.Error(t, VerifyManifestsDir(emptyDir))
+	_, err = RenderManifests(emptyDir, "")
+	assert.ErrorContains(t, err, "charts")
+
+	assert.NoError(t, os.Mkdir(filepath.Join(emptyDir, "charts"), 0700))
+	assert.ErrorContains(t, VerifyManifestsDir(emptyDir), "profiles")
+}
+
+// TestRenderManifestsUnsupported tests rendering IstioOperators the native install does not support
+// GIVEN IstioOperator files with an unknown profile, an unsupported component or an unsupported k8s setting
+// WHEN RenderManifests is called
+// THEN an error is returned
+func TestRenderManifestsUnsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{name: "profile", yaml: "spec:\n  profile: demo\n"},
+		{name: "component", yaml: "spec:\n  components:\n    cni:\n      enabled: true\n"},
+		{name: "k8s", yaml: "spec:\n  components:\n    pilot:\n      k8s:\n        overlays: []\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := writeOperatorFile(t, tt.yaml)
+			defer os.Remove(file)
+			_, err := RenderManifests(testManifestsDir, "", file)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestNativeInstallVerifyUninstall tests the native install lifecycle
+// GIVEN an IstioOperator file
+// WHEN NativeInstall, NativeVerifyInstall and NativeUninstall are called
+// THEN the objects are applied and recorded, the install is verified once the deployments are ready, and the
+// objects except for the CRDs are deleted on uninstall
+func TestNativeInstallVerifyUninstall(t *testing.T) {
+	SetApplyFunction(createApply)
+	defer SetDefaultApplyFunction()
+
+	file := writeOperatorFile(t, testOperatorYaml)
+	defer os.Remove(file)
+
+	log := vzlog.DefaultLogger()
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	assert.NoError(t, NativeInstall(log, cli, testManifestsDir, "", file))
+
+	entries, err := getInventory(cli)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, entries)
+
+	// The deployments have no ready replicas
+	verified, err := NativeVerifyInstall(log, cli)
+	assert.NoError(t, err)
+	assert.False(t, verified)
+
+	for _, name := range []string{"istiod", "istio-ingressgateway", "istio-egressgateway"} {
+		deployment := appsv1.Deployment{}
+		assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: name}, &deployment))
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		deployment.Status.UpdatedReplicas = replicas
+		deployment.Status.AvailableReplicas = replicas
+		assert.NoError(t, cli.Status().Update(context.TODO(), &deployment))
+	}
+	verified, err = NativeVerifyInstall(log, cli)
+	assert.NoError(t, err)
+	assert.True(t, verified)
+
+	assert.NoError(t, NativeUninstall(log, cli))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "istiod"}, &appsv1.Deployment{})
+	assert.True(t, errors.IsNotFound(err))
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: InventoryConfigMapName}, &corev1.ConfigMap{})
+	assert.True(t, errors.IsNotFound(err))
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Name: "gateways.networking.istio.io"}, &apiextensionsv1.CustomResourceDefinition{}))
+}
+
+// TestNativeInstallPrune tests that an upgrade deletes objects that are no longer rendered
+// GIVEN an installation with the egress gateway enabled
+// WHEN NativeInstall is called with the egress gateway disabled
+// THEN the egress gateway objects are deleted
+func TestNativeInstallPrune(t *testing.T) {
+	SetApplyFunction(createApply)
+	defer SetDefaultApplyFunction()
+
+	file := writeOperatorFile(t, testOperatorYaml)
+	defer os.Remove(file)
+
+	log := vzlog.DefaultLogger()
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	assert.NoError(t, NativeInstall(log, cli, testManifestsDir, "", file))
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "istio-egressgateway"}, &appsv1.Deployment{}))
+
+	assert.NoError(t, NativeInstall(log, cli, testManifestsDir, "components.egressGateways[0].name=istio-egressgateway,components.egressGateways[0].enabled=false", file))
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "istio-egressgateway"}, &appsv1.Deployment{})
+	assert.True(t, errors.IsNotFound(err))
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: "istio-ingressgateway"}, &appsv1.Deployment{}))
+}
+
+// TestNativeVerifyInstallNotInstalled tests verifying an installation that was not done
+// GIVEN a cluster without the native install inventory
+// WHEN NativeVerifyInstall is called
+// THEN false is returned
+func TestNativeVerifyInstallNotInstalled(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: constants.IstioSystemNamespace}}).Build()
+	verified, err := NativeVerifyInstall(vzlog.DefaultLogger(), cli)
+	assert.NoError(t, err)
+	assert.False(t, verified)
+}
@@ -5,6 +5,7 @@ package fake
 
 import (
 	"bytes"
+	"io/ioutil"
 	"net/url"
 
 	"k8s.io/client-go/rest"
@@ -14,6 +15,10 @@ import (
 // PodExecResult can be used to output arbitrary strings during unit testing
 var PodExecResult = func(url *url.URL) (string, string, error) { return "", "", nil }
 
+// PodExecStdin can be used to check the stdin of the remote commands during unit testing, it is called before
+// PodExecResult
+var PodExecStdin = func(url *url.URL, stdin string) {}
+
 //NewPodExecutor should be used instead of remotecommand.NewSPDYExecutor in unit tests
 func NewPodExecutor(config *rest.Config, method string, url *url.URL) (remotecommand.Executor, error) {
 	return &dummyExecutor{method: method, url: url}, nil
@@ -27,6 +32,13 @@ type dummyExecutor struct {
 
 //Stream on a dummyExecutor sets stdout to PodExecResult
 func (f *dummyExecutor) Stream(options remotecommand.StreamOptions) error {
+	if options.Stdin != nil {
+		stdin, err := ioutil.ReadAll(options.Stdin)
+		if err != nil {
+			return err
+		}
+		PodExecStdin(f.url, string(stdin))
+	}
 	stdout, stderr, err := PodExecResult(f.url)
 	if options.Stdout != nil {
 		buf := new(bytes.Buffer)
@@ -8,6 +8,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -27,7 +30,9 @@ import (
 	"k8s.io/client-go/rest"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
 	"k8s.io/client-go/util/homedir"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -263,6 +268,17 @@ var NewPodExecutor = remotecommand.NewSPDYExecutor
 
 //ExecPod runs a remote command a pod, returning the stdout and stderr of the command.
 func ExecPod(client kubernetes.Interface, cfg *rest.Config, pod *v1.Pod, container string, command []string) (string, string, error) {
+	return execPod(client, cfg, pod, container, command, nil)
+}
+
+// ExecPodWithStdin runs a remote command in a pod with the given stdin, returning the stdout and stderr of the command.
+// Input that must not be in the command line, like passwords, can be passed to the command through its stdin.
+func ExecPodWithStdin(client kubernetes.Interface, cfg *rest.Config, pod *v1.Pod, container string, command []string, stdin string) (string, string, error) {
+	return execPod(client, cfg, pod, container, command, strings.NewReader(stdin))
+}
+
+// execPod runs a remote command in a pod, with a TTY when there is no stdin
+func execPod(client kubernetes.Interface, cfg *rest.Config, pod *v1.Pod, container string, command []string, stdin io.Reader) (string, string, error) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
 	request := client.
@@ -276,16 +292,17 @@ func ExecPod(client kubernetes.Interface, cfg *rest.Config, pod *v1.Pod, contain
 		VersionedParams(&v1.PodExecOptions{
 			Container: container,
 			Command:   command,
-			Stdin:     false,
+			Stdin:     stdin != nil,
 			Stdout:    true,
 			Stderr:    true,
-			TTY:       true,
+			TTY:       stdin == nil,
 		}, scheme.ParameterCodec)
 	executor, err := NewPodExecutor(cfg, "POST", request.URL())
 	if err != nil {
 		return "", "", err
 	}
 	err = executor.Stream(remotecommand.StreamOptions{
+		Stdin:  stdin,
 		Stdout: stdout,
 		Stderr: stderr,
 	})
@@ -296,6 +313,45 @@ func ExecPod(client kubernetes.Interface, cfg *rest.Config, pod *v1.Pod, contain
 	return stdout.String(), stderr.String(), nil
 }
 
+// PortForwardPod forwards a local port, chosen by the system, to a port of a pod.  It returns the local port and a
+// function that stops forwarding, which must be called when the port is no longer used.
+func PortForwardPod(client kubernetes.Interface, cfg *rest.Config, pod *v1.Pod, port int) (int, func(), error) {
+	request := client.
+		CoreV1().
+		RESTClient().
+		Post().
+		Namespace(pod.Namespace).
+		Resource("pods").
+		Name(pod.Name).
+		SubResource("portforward")
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return 0, nil, err
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", request.URL())
+	stopChan := make(chan struct{})
+	readyChan := make(chan struct{})
+	forwarder, err := portforward.New(dialer, []string{fmt.Sprintf("0:%d", port)}, stopChan, readyChan, ioutil.Discard, ioutil.Discard)
+	if err != nil {
+		return 0, nil, err
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- forwarder.ForwardPorts()
+	}()
+	select {
+	case <-readyChan:
+	case err := <-errChan:
+		return 0, nil, fmt.Errorf("error forwarding port %d of %v/%v: %v", port, pod.Namespace, pod.Name, err)
+	}
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		close(stopChan)
+		return 0, nil, err
+	}
+	return int(ports[0].Local), func() { close(stopChan) }, nil
+}
+
 // GetGoClient returns a go-client
 func GetGoClient(log ...vzlog.VerrazzanoLogger) (kubernetes.Interface, error) {
 	var logger vzlog.VerrazzanoLogger
@@ -14,8 +14,17 @@ import (
 // MergeProfiles merges a list of v1alpha1.Verrazzano profile files with an existing Verrazzano CR.
 // The profiles must be in the Verrazzano CR format
 func MergeProfiles(actualCR *v1alpha1.Verrazzano, profileFiles ...string) (*v1alpha1.Verrazzano, error) {
+	profileDocuments, err := readProfileFiles(profileFiles...)
+	if err != nil {
+		return nil, err
+	}
+	return MergeProfileDocuments(actualCR, profileDocuments...)
+}
+
+// MergeProfileDocuments merges the profile YAML documents, in order, and then merges the Verrazzano CR on top of them
+func MergeProfileDocuments(actualCR *v1alpha1.Verrazzano, profileDocuments ...string) (*v1alpha1.Verrazzano, error) {
 	// First merge the profiles
-	profileStrings, err := appendProfileComponentOverrides(profileFiles...)
+	profileStrings, err := appendProfileComponentOverrides(profileDocuments...)
 	if err != nil {
 		return nil, err
 	}
@@ -56,8 +65,18 @@ func MergeProfiles(actualCR *v1alpha1.Verrazzano, profileFiles ...string) (*v1al
 // MergeProfilesForV1beta1 merges a list of v1beta1.Verrazzano profile files with an existing Verrazzano CR.
 // The profiles must be in the Verrazzano CR format
 func MergeProfilesForV1beta1(actualCR *v1beta1.Verrazzano, profileFiles ...string) (*v1beta1.Verrazzano, error) {
+	profileDocuments, err := readProfileFiles(profileFiles...)
+	if err != nil {
+		return nil, err
+	}
+	return MergeProfileDocumentsForV1beta1(actualCR, profileDocuments...)
+}
+
+// MergeProfileDocumentsForV1beta1 merges the profile YAML documents, in order, and then merges the Verrazzano CR on top
+// of them
+func MergeProfileDocumentsForV1beta1(actualCR *v1beta1.Verrazzano, profileDocuments ...string) (*v1beta1.Verrazzano, error) {
 	// First merge the profiles
-	profileStrings, err := appendProfileComponentOverridesV1beta1(profileFiles...)
+	profileStrings, err := appendProfileComponentOverridesV1beta1(profileDocuments...)
 	if err != nil {
 		return nil, err
 	}
@@ -94,15 +113,11 @@ func MergeProfilesForV1beta1(actualCR *v1beta1.Verrazzano, profileFiles ...strin
 	return &newCR, nil
 }
 
-func appendProfileComponentOverrides(profileFiles ...string) ([]string, error) {
+func appendProfileComponentOverrides(profileDocuments ...string) ([]string, error) {
 	var profileCR *v1alpha1.Verrazzano
 	var profileStrings []string
-	for i := range profileFiles {
-		profileFile := profileFiles[len(profileFiles)-1-i]
-		data, err := os.ReadFile(profileFile)
-		if err != nil {
-			return nil, err
-		}
+	for i := range profileDocuments {
+		data := []byte(profileDocuments[len(profileDocuments)-1-i])
 		cr := &v1alpha1.Verrazzano{}
 		if err := yaml.Unmarshal(data, cr); err != nil {
 			return nil, err
@@ -111,7 +126,8 @@ func appendProfileComponentOverrides(profileFiles ...string) ([]string, error) {
 			profileCR = cr
 		} else {
 			AppendComponentOverrides(profileCR, cr)
-			profileStrings = append(profileStrings, string(data))
+			// The profiles are iterated in reverse, so prepend to merge them in order
+			profileStrings = append([]string{string(data)}, profileStrings...)
 		}
 
 	}
@@ -123,15 +139,11 @@ func appendProfileComponentOverrides(profileFiles ...string) ([]string, error) {
 	return profileStrings, nil
 }
 
-func appendProfileComponentOverridesV1beta1(profileFiles ...string) ([]string, error) {
+func appendProfileComponentOverridesV1beta1(profileDocuments ...string) ([]string, error) {
 	var profileCR *v1beta1.Verrazzano
 	var profileStrings []string
-	for i := range profileFiles {
-		profileFile := profileFiles[len(profileFiles)-1-i]
-		data, err := os.ReadFile(profileFile)
-		if err != nil {
-			return nil, err
-		}
+	for i := range profileDocuments {
+		data := []byte(profileDocuments[len(profileDocuments)-1-i])
 		cr := &v1beta1.Verrazzano{}
 		if err := yaml.Unmarshal(data, cr); err != nil {
 			return nil, err
@@ -140,7 +152,8 @@ func appendProfileComponentOverridesV1beta1(profileFiles ...string) ([]string, e
 			profileCR = cr
 		} else {
 			AppendComponentOverridesV1beta1(profileCR, cr)
-			profileStrings = append(profileStrings, string(data))
+			// The profiles are iterated in reverse, so prepend to merge them in order
+			profileStrings = append([]string{string(data)}, profileStrings...)
 		}
 
 	}
@@ -290,6 +303,19 @@ func AppendComponentOverrides(actual, profile *v1alpha1.Verrazzano) {
 	}
 }
 
+// readProfileFiles returns the contents of the profile files
+func readProfileFiles(profileFiles ...string) ([]string, error) {
+	var profileDocuments []string
+	for _, profileFile := range profileFiles {
+		data, err := os.ReadFile(profileFile)
+		if err != nil {
+			return nil, err
+		}
+		profileDocuments = append(profileDocuments, string(data))
+	}
+	return profileDocuments, nil
+}
+
 // AppendComponentOverridesV1beta1 copies the profile overrides of v1beta1.Verrazzano over to the actual overrides. Any component that has overrides should be included here.
 // Because overrides lacks a proper merge key, a strategic merge will replace the array instead of merging it. This function stops that replacement from occurring.
 // The profile CR overrides must be appended to the actual CR overrides to preserve the precedence order in the way HelmComponent consumes them.
@@ -0,0 +1,155 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package profiles
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ExtendsField is the top level field of a profile that names the profiles it extends, as a comma separated list
+const ExtendsField = "extends"
+
+// ProfileSource returns the YAML document of the named profile, and false if the profile does not exist
+type ProfileSource func(name string) (string, bool, error)
+
+// FileProfileSource returns a ProfileSource that reads the <name>.yaml profile files from the first of the directories
+// that contains the profile
+func FileProfileSource(dirs ...string) ProfileSource {
+	return func(name string) (string, bool, error) {
+		for _, dir := range dirs {
+			if len(dir) == 0 {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(dir, name+".yaml"))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return "", false, err
+			}
+			return string(data), true, nil
+		}
+		return "", false, nil
+	}
+}
+
+// ResolveProfiles returns the YAML documents of the named profiles in the order that they are merged.  Each profile
+// is preceded by the profiles in its extends chain, so that a profile overrides the profiles it extends, and a profile
+// is only merged once, at its first position.  The extends field is removed from the documents.  An error is returned
+// if a profile, or a profile that it extends, does not exist, or if the extends chain has a cycle.
+func ResolveProfiles(source ProfileSource, names ...string) ([]string, error) {
+	profiles, err := ResolveNamedProfiles(source, names...)
+	if err != nil {
+		return nil, err
+	}
+	var documents []string
+	for _, profile := range profiles {
+		documents = append(documents, profile.Document)
+	}
+	return documents, nil
+}
+
+// ResolveNamedProfiles is like ResolveProfiles, but returns the name of each profile along with its document
+func ResolveNamedProfiles(source ProfileSource, names ...string) ([]NamedProfile, error) {
+	r := profileResolver{
+		source:   source,
+		resolved: map[string]bool{},
+		visiting: map[string]bool{},
+	}
+	for _, name := range names {
+		if err := r.resolve(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return r.profiles, nil
+}
+
+// SplitProfileNames splits a comma separated list of profile names
+func SplitProfileNames(names string) []string {
+	var split []string
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			split = append(split, name)
+		}
+	}
+	return split
+}
+
+// NamedProfile is the YAML document of a profile and its name
+type NamedProfile struct {
+	Name     string
+	Document string
+}
+
+// profileResolver resolves the extends chains of profiles
+type profileResolver struct {
+	source   ProfileSource
+	resolved map[string]bool
+	visiting map[string]bool
+	profiles []NamedProfile
+}
+
+// resolve appends the documents of the profile, preceded by the profiles it extends, if the profile has not already
+// been resolved.  The chain is the list of profiles that extend the profile, used to report cycles.
+func (r *profileResolver) resolve(name string, chain []string) error {
+	if r.resolved[name] {
+		return nil
+	}
+	chain = append(chain, name)
+	if r.visiting[name] {
+		return fmt.Errorf("Profile %s extends itself through %s", name, strings.Join(chain, " -> "))
+	}
+	r.visiting[name] = true
+	defer delete(r.visiting, name)
+
+	document, found, err := r.source(name)
+	if err != nil {
+		return fmt.Errorf("Failed to read profile %s: %v", name, err)
+	}
+	if !found {
+		if len(chain) > 1 {
+			return fmt.Errorf("Profile %s, extended by profile %s, does not exist", name, chain[len(chain)-2])
+		}
+		return fmt.Errorf("Profile %s does not exist", name)
+	}
+	document, extends, err := removeExtends(document)
+	if err != nil {
+		return fmt.Errorf("Failed to parse profile %s: %v", name, err)
+	}
+	for _, parent := range extends {
+		if err := r.resolve(parent, chain); err != nil {
+			return err
+		}
+	}
+	r.resolved[name] = true
+	r.profiles = append(r.profiles, NamedProfile{Name: name, Document: document})
+	return nil
+}
+
+// removeExtends returns the profile document without the extends field, and the names of the profiles it extends
+func removeExtends(document string) (string, []string, error) {
+	profile := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(document), &profile); err != nil {
+		return "", nil, err
+	}
+	extends, ok := profile[ExtendsField]
+	if !ok {
+		return document, nil, nil
+	}
+	names, ok := extends.(string)
+	if !ok {
+		return "", nil, fmt.Errorf("the %s field must be a comma separated list of profile names", ExtendsField)
+	}
+	delete(profile, ExtendsField)
+	data, err := yaml.Marshal(profile)
+	if err != nil {
+		return "", nil, err
+	}
+	return string(data), SplitProfileNames(names), nil
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package profiles
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+)
+
+const (
+	baseProfile = `spec:
+  environmentName: base
+  components:
+    console:
+      enabled: true
+`
+	prodProfile = `spec:
+  profile: prod
+  environmentName: prod
+`
+	edgeProfile = `extends: prod
+spec:
+  profile: edge
+  components:
+    console:
+      enabled: false
+`
+	perfProfile = `extends: base, edge
+spec:
+  profile: perf-test
+  environmentName: perf
+`
+)
+
+// mapProfileSource returns a ProfileSource for the profiles in the map
+func mapProfileSource(profiles map[string]string) ProfileSource {
+	return func(name string) (string, bool, error) {
+		document, ok := profiles[name]
+		return document, ok, nil
+	}
+}
+
+// TestResolveProfiles tests resolving the extends chains of profiles
+// GIVEN profiles that extend other profiles
+// WHEN ResolveProfiles is called
+// THEN the documents are returned with each profile after the profiles it extends, each profile once, and without
+// the extends field
+func TestResolveProfiles(t *testing.T) {
+	source := mapProfileSource(map[string]string{
+		"base":      baseProfile,
+		"prod":      prodProfile,
+		"edge":      edgeProfile,
+		"perf-test": perfProfile,
+	})
+
+	documents, err := ResolveProfiles(source, "base", "perf-test")
+	assert.NoError(t, err)
+	assert.Len(t, documents, 4)
+	assert.Equal(t, baseProfile, documents[0])
+	assert.Equal(t, prodProfile, documents[1])
+	assert.Contains(t, documents[2], "profile: edge")
+	assert.Contains(t, documents[3], "profile: perf-test")
+	for _, document := range documents {
+		assert.NotContains(t, document, ExtendsField)
+	}
+
+	profiles, err := ResolveNamedProfiles(source, "base", "perf-test")
+	assert.NoError(t, err)
+	assert.Len(t, profiles, 4)
+	for i, name := range []string{"base", "prod", "edge", "perf-test"} {
+		assert.Equal(t, name, profiles[i].Name)
+		assert.Equal(t, documents[i], profiles[i].Document)
+	}
+}
+
+// TestResolveProfilesMerge tests merging resolved profiles
+// GIVEN a profile that extends another profile
+// WHEN the resolved profiles are merged with a Verrazzano CR
+// THEN the profile overrides the profile it extends, and the CR overrides the profiles
+func TestResolveProfilesMerge(t *testing.T) {
+	source := mapProfileSource(map[string]string{
+		"base": baseProfile,
+		"prod": prodProfile,
+		"edge": edgeProfile,
+	})
+	documents, err := ResolveProfiles(source, "base", "edge")
+	assert.NoError(t, err)
+
+	cr := &v1alpha1.Verrazzano{Spec: v1alpha1.VerrazzanoSpec{Profile: "edge", Version: "1.4.0"}}
+	merged, err := MergeProfileDocuments(cr, documents...)
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", merged.Spec.EnvironmentName)
+	assert.Equal(t, v1alpha1.ProfileType("edge"), merged.Spec.Profile)
+	assert.Equal(t, "1.4.0", merged.Spec.Version)
+	assert.False(t, *merged.Spec.Components.Console.Enabled)
+}
+
+// TestResolveProfilesErrors tests resolving invalid profiles
+// GIVEN profiles that do not exist, extend profiles that do not exist, or extend each other
+// WHEN ResolveProfiles is called
+// THEN an error is returned
+func TestResolveProfilesErrors(t *testing.T) {
+	source := mapProfileSource(map[string]string{
+		"orphan": "extends: missing\n",
+		"a":      "extends: b\n",
+		"b":      "extends: a\n",
+		"list":   "extends:\n- a\n",
+	})
+
+	_, err := ResolveProfiles(source, "missing")
+	assert.EqualError(t, err, "Profile missing does not exist")
+
+	_, err = ResolveProfiles(source, "orphan")
+	assert.EqualError(t, err, "Profile missing, extended by profile orphan, does not exist")
+
+	_, err = ResolveProfiles(source, "a")
+	assert.EqualError(t, err, "Profile a extends itself through a -> b -> a")
+
+	_, err = ResolveProfiles(source, "list")
+	assert.Error(t, err)
+}
+
+// TestFileProfileSource tests reading profiles from directories
+// GIVEN directories of profile files
+// WHEN the profiles are read from a FileProfileSource
+// THEN a profile is read from the first directory that contains it
+func TestFileProfileSource(t *testing.T) {
+	first := t.TempDir()
+	second := t.TempDir()
+	assert.NoError(t, os.WriteFile(first+"/edge.yaml", []byte(edgeProfile), 0600))
+	assert.NoError(t, os.WriteFile(second+"/edge.yaml", []byte(prodProfile), 0600))
+	assert.NoError(t, os.WriteFile(second+"/prod.yaml", []byte(prodProfile), 0600))
+	source := FileProfileSource("", first, second)
+
+	document, found, err := source("edge")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, edgeProfile, document)
+
+	document, found, err = source("prod")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, prodProfile, document)
+
+	_, found, err = source("dev")
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+// TestSplitProfileNames tests splitting a comma separated list of profile names
+func TestSplitProfileNames(t *testing.T) {
+	assert.Equal(t, []string{"base", "edge"}, SplitProfileNames(" base, edge,,"))
+	assert.Nil(t, SplitProfileNames(""))
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1alpha1
+
+import (
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The VerrazzanoComponent custom resource declares a component that is not built into the
+// Verrazzano platform operator.  The operator installs, upgrades and reports the status of the
+// component in the Verrazzano resource, the same way it does for the built-in components.  The
+// name of the VerrazzanoComponent is used as the component name and the Helm release name.
+
+// VerrazzanoComponentSpec defines the desired state of VerrazzanoComponent
+type VerrazzanoComponentSpec struct {
+	// If true, then the component will be installed.  Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// The Helm chart used to install the component.
+	Chart ChartSource `json:"chart"`
+
+	// The namespace that the Helm chart is installed in.
+	TargetNamespace string `json:"targetNamespace"`
+
+	// The names of the components, built-in or declared by other VerrazzanoComponent resources, that must be
+	// ready before this component is installed.
+	// +optional
+	Dependencies []string `json:"dependencies,omitempty"`
+
+	// The workloads that must be ready for the component to be ready.
+	// +optional
+	Readiness ReadinessWorkloads `json:"readiness,omitempty"`
+
+	// List of overrides for the Helm chart values, in the same format as the overrides of the
+	// built-in components.  ConfigMap and Secret references are resolved in the namespace of the
+	// Verrazzano resource.
+	// +optional
+	ValueOverrides []installv1alpha1.Overrides `json:"overrides,omitempty"`
+}
+
+// ChartSource identifies the Helm chart of a component.  Only local charts are supported, charts
+// in a Helm repository or an OCI registry must be mounted into the platform operator container.
+type ChartSource struct {
+	// The path of the unpacked chart directory in the platform operator container, for example a
+	// chart that is mounted into the container from a volume.  Helm repository URLs, OCI references
+	// and chart archives are not supported.
+	Path string `json:"path"`
+}
+
+// ReadinessWorkloads identifies the workloads that are checked to determine if a component is ready
+type ReadinessWorkloads struct {
+	// The deployments that must be ready.
+	// +optional
+	Deployments []WorkloadName `json:"deployments,omitempty"`
+	// The stateful sets that must be ready.
+	// +optional
+	StatefulSets []WorkloadName `json:"statefulSets,omitempty"`
+	// The daemon sets that must be ready.
+	// +optional
+	DaemonSets []WorkloadName `json:"daemonSets,omitempty"`
+}
+
+// WorkloadName identifies a workload
+type WorkloadName struct {
+	// The namespace of the workload.
+	Namespace string `json:"namespace"`
+	// The name of the workload.
+	Name string `json:"name"`
+}
+
+// VerrazzanoComponent is the Schema for the verrazzanocomponents API
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=vzc;vzcs
+// +kubebuilder:printcolumn:name="Namespace",type="string",JSONPath=".spec.targetNamespace",description="The namespace that the component is installed in"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +genclient
+// +genclient:nonNamespaced
+type VerrazzanoComponent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VerrazzanoComponentSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VerrazzanoComponentList contains a list of VerrazzanoComponent
+type VerrazzanoComponentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VerrazzanoComponent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VerrazzanoComponent{}, &VerrazzanoComponentList{})
+}
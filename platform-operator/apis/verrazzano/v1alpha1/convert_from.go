@@ -8,7 +8,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 )
 
-//ConvertFrom converts from v1beta1.Verrazzano to v1alpha1.Verrazzano
+// ConvertFrom converts from v1beta1.Verrazzano to v1alpha1.Verrazzano
 func (in *Verrazzano) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*v1beta1.Verrazzano)
 	if src == nil {
@@ -24,6 +24,7 @@ func (in *Verrazzano) ConvertFrom(srcRaw conversion.Hub) error {
 	in.Spec.DefaultVolumeSource = src.Spec.DefaultVolumeSource
 	in.Spec.VolumeClaimSpecTemplates = convertVoumeClaimTemplatesFromV1Beta1(src.Spec.VolumeClaimSpecTemplates)
 	in.Spec.Security = convertSecuritySpecFromV1Beta1(src.Spec.Security)
+	in.Spec.HelmDrift = convertHelmDriftSpecFromV1Beta1(src.Spec.HelmDrift)
 
 	// Convert status
 	in.Status.State = VzStateType(src.Status.State)
@@ -31,6 +32,9 @@ func (in *Verrazzano) ConvertFrom(srcRaw conversion.Hub) error {
 	in.Status.Conditions = convertConditionsFromV1Beta1(src.Status.Conditions)
 	in.Status.Components = convertComponentStatusMapFromV1Beta1(src.Status.Components)
 	in.Status.VerrazzanoInstance = convertVerrazzanoInstanceFromV1Beta1(src.Status.VerrazzanoInstance)
+	in.Status.CredentialRotations = convertCredentialRotationsFromV1Beta1(src.Status.CredentialRotations)
+	in.Status.Certificates = convertCertificatesFromV1Beta1(src.Status.Certificates)
+	in.Status.KeycloakRealm = convertKeycloakRealmStatusFromV1Beta1(src.Status.KeycloakRealm)
 	return nil
 }
 
@@ -72,12 +76,90 @@ func convertComponentStatusMapFromV1Beta1(components v1beta1.ComponentStatusMap)
 				Version:                  detail.Version,
 				LastReconciledGeneration: detail.LastReconciledGeneration,
 				ReconcilingGeneration:    detail.ReconcilingGeneration,
+				ReadinessReport:          convertReadinessReportFromV1Beta1(detail.ReadinessReport),
 			}
 		}
 	}
 	return componentStatusMap
 }
 
+func convertReadinessReportFromV1Beta1(report *v1beta1.ReadinessReport) *ReadinessReport {
+	if report == nil {
+		return nil
+	}
+	out := &ReadinessReport{}
+	for _, workload := range report.Workloads {
+		outWorkload := WorkloadReadiness{
+			Kind:             workload.Kind,
+			Namespace:        workload.Namespace,
+			Name:             workload.Name,
+			ExpectedReplicas: workload.ExpectedReplicas,
+			ReadyReplicas:    workload.ReadyReplicas,
+			Message:          workload.Message,
+		}
+		for _, pod := range workload.UnreadyPods {
+			outPod := PodReadiness{
+				Name:    pod.Name,
+				Phase:   pod.Phase,
+				Reason:  pod.Reason,
+				Message: pod.Message,
+			}
+			for _, container := range pod.Containers {
+				outPod.Containers = append(outPod.Containers, ContainerReadiness(container))
+			}
+			outWorkload.UnreadyPods = append(outWorkload.UnreadyPods, outPod)
+		}
+		out.Workloads = append(out.Workloads, outWorkload)
+	}
+	return out
+}
+
+func convertCredentialRotationsFromV1Beta1(rotations map[string]*v1beta1.CredentialRotationStatus) map[string]*CredentialRotationStatus {
+	if rotations == nil {
+		return nil
+	}
+	out := map[string]*CredentialRotationStatus{}
+	for credential, rotation := range rotations {
+		if rotation != nil {
+			out[credential] = &CredentialRotationStatus{
+				Request:          rotation.Request,
+				State:            CredentialRotationStateType(rotation.State),
+				LastRotationTime: rotation.LastRotationTime,
+				Message:          rotation.Message,
+			}
+		}
+	}
+	return out
+}
+
+func convertKeycloakRealmStatusFromV1Beta1(realm *v1beta1.KeycloakRealmStatus) *KeycloakRealmStatus {
+	if realm == nil {
+		return nil
+	}
+	out := &KeycloakRealmStatus{
+		ObservedGeneration: realm.ObservedGeneration,
+		LastReconcileTime:  realm.LastReconcileTime,
+		Message:            realm.Message,
+	}
+	for _, object := range realm.Objects {
+		out.Objects = append(out.Objects, KeycloakRealmObjectStatus{
+			Kind:           object.Kind,
+			Name:           object.Name,
+			State:          KeycloakRealmObjectStateType(object.State),
+			LastUpdateTime: object.LastUpdateTime,
+		})
+	}
+	return out
+}
+
+func convertCertificatesFromV1Beta1(certificates []v1beta1.CertificateStatus) []CertificateStatus {
+	var out []CertificateStatus
+	for _, certificate := range certificates {
+		out = append(out, CertificateStatus(certificate))
+	}
+	return out
+}
+
 func convertVerrazzanoInstanceFromV1Beta1(instance *v1beta1.InstanceInfo) *InstanceInfo {
 	if instance == nil {
 		return nil
@@ -97,8 +179,38 @@ func convertVerrazzanoInstanceFromV1Beta1(instance *v1beta1.InstanceInfo) *Insta
 
 func convertSecuritySpecFromV1Beta1(security v1beta1.SecuritySpec) SecuritySpec {
 	return SecuritySpec{
-		AdminSubjects:   security.AdminSubjects,
-		MonitorSubjects: security.MonitorSubjects,
+		AdminSubjects:    security.AdminSubjects,
+		MonitorSubjects:  security.MonitorSubjects,
+		CredentialsStore: convertCredentialsStoreFromV1Beta1(security.CredentialsStore),
+	}
+}
+
+func convertCredentialsStoreFromV1Beta1(store *v1beta1.CredentialsStore) *CredentialsStore {
+	if store == nil {
+		return nil
+	}
+	out := &CredentialsStore{}
+	if store.Vault != nil {
+		out.Vault = &VaultCredentialsStore{
+			Address:     store.Vault.Address,
+			MountPath:   store.Vault.MountPath,
+			KVVersion:   store.Vault.KVVersion,
+			PathPrefix:  store.Vault.PathPrefix,
+			Namespace:   store.Vault.Namespace,
+			TokenSecret: store.Vault.TokenSecret,
+			CASecret:    store.Vault.CASecret,
+		}
+	}
+	return out
+}
+
+func convertHelmDriftSpecFromV1Beta1(helmDrift *v1beta1.HelmDriftSpec) *HelmDriftSpec {
+	if helmDrift == nil {
+		return nil
+	}
+	return &HelmDriftSpec{
+		Policy:   HelmDriftPolicy(helmDrift.Policy),
+		Interval: helmDrift.Interval,
 	}
 }
 
@@ -166,17 +278,51 @@ func convertCertManagerFromV1Beta1(in *v1beta1.CertManagerComponent) *CertManage
 	}
 }
 
+func convertAcmeExternalAccountBindingFromV1Beta1(eab *v1beta1.AcmeExternalAccountBinding) *AcmeExternalAccountBinding {
+	if eab == nil {
+		return nil
+	}
+	return &AcmeExternalAccountBinding{
+		KeyID:         eab.KeyID,
+		KeySecretName: eab.KeySecretName,
+		KeySecretKey:  eab.KeySecretKey,
+	}
+}
+
+func convertVaultIssuerFromV1Beta1(vault *v1beta1.VaultIssuer) *VaultIssuer {
+	if vault == nil {
+		return nil
+	}
+	return &VaultIssuer{
+		Server:         vault.Server,
+		Path:           vault.Path,
+		Namespace:      vault.Namespace,
+		CABundleSecret: vault.CABundleSecret,
+		Auth: VaultAuth{
+			TokenSecret: vault.Auth.TokenSecret,
+			AppRole:     (*VaultAppRoleAuth)(vault.Auth.AppRole),
+			Kubernetes:  (*VaultKubernetesAuth)(vault.Auth.Kubernetes),
+		},
+	}
+}
+
 func convertCertificateFromV1Beta1(certificate v1beta1.Certificate) Certificate {
 	return Certificate{
 		Acme: Acme{
-			Provider:     ProviderType(certificate.Acme.Provider),
-			EmailAddress: certificate.Acme.EmailAddress,
-			Environment:  certificate.Acme.Environment,
+			Provider:               ProviderType(certificate.Acme.Provider),
+			EmailAddress:           certificate.Acme.EmailAddress,
+			Environment:            certificate.Acme.Environment,
+			DirectoryURL:           certificate.Acme.DirectoryURL,
+			CABundleSecret:         certificate.Acme.CABundleSecret,
+			ExternalAccountBinding: convertAcmeExternalAccountBindingFromV1Beta1(certificate.Acme.ExternalAccountBinding),
+			Solver:                 AcmeSolverType(certificate.Acme.Solver),
 		},
 		CA: CA{
 			SecretName:               certificate.CA.SecretName,
 			ClusterResourceNamespace: certificate.CA.ClusterResourceNamespace,
 		},
+		Vault:     convertVaultIssuerFromV1Beta1(certificate.Vault),
+		IssuerRef: (*IssuerReference)(certificate.IssuerRef),
 	}
 }
 
@@ -239,6 +385,7 @@ func convertDNSFromV1Beta1(in *v1beta1.DNSComponent) *DNSComponent {
 		Wildcard:         convertWildcardDNSFromV1Beta1(in.Wildcard),
 		OCI:              convertOCIDNSFromV1Beta1(in.OCI),
 		External:         convertExternalDNSFromV1Beta1(in.External),
+		RFC2136:          (*RFC2136)(in.RFC2136),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
@@ -282,10 +429,61 @@ func convertFluentdFromV1Beta1(in *v1beta1.FluentdComponent) *FluentdComponent {
 		ElasticsearchURL:    in.OpenSearchURL,
 		ElasticsearchSecret: in.OpenSearchSecret,
 		OCI:                 convertOCILoggingConfigurationFromV1Beta1(in.OCI),
+		Outputs:             convertFluentdOutputsFromV1Beta1(in.Outputs),
+		LogRoutingClasses:   convertLogRoutingClassesFromV1Beta1(in.LogRoutingClasses),
 		InstallOverrides:    convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
 }
 
+func convertFluentdOutputsFromV1Beta1(in []v1beta1.FluentdOutput) []FluentdOutput {
+	var out []FluentdOutput
+	for _, output := range in {
+		converted := FluentdOutput{
+			Name:       output.Name,
+			Namespaces: output.Namespaces,
+		}
+		if output.Kafka != nil {
+			converted.Kafka = &FluentdKafkaOutput{
+				Brokers: output.Kafka.Brokers,
+				Topic:   output.Kafka.Topic,
+				Secret:  output.Kafka.Secret,
+			}
+		}
+		if output.Syslog != nil {
+			converted.Syslog = &FluentdSyslogOutput{
+				Host:   output.Syslog.Host,
+				Port:   output.Syslog.Port,
+				Secret: output.Syslog.Secret,
+			}
+		}
+		if output.S3 != nil {
+			converted.S3 = &FluentdS3Output{
+				Endpoint: output.S3.Endpoint,
+				Region:   output.S3.Region,
+				Bucket:   output.S3.Bucket,
+				Path:     output.S3.Path,
+				Secret:   output.S3.Secret,
+			}
+		}
+		out = append(out, converted)
+	}
+	return out
+}
+
+func convertLogRoutingClassesFromV1Beta1(in []v1beta1.LogRoutingClass) []LogRoutingClass {
+	var out []LogRoutingClass
+	for _, class := range in {
+		out = append(out, LogRoutingClass{
+			Name:        class.Name,
+			Destination: LogRoutingDestination(class.Destination),
+			OCILogID:    class.OCILogID,
+			Output:      class.Output,
+			Retention:   class.Retention,
+		})
+	}
+	return out
+}
+
 func convertVolumeMountsFromV1Beta1(mounts []v1beta1.VolumeMount) []VolumeMount {
 	var out []VolumeMount
 	for _, mount := range mounts {
@@ -345,10 +543,28 @@ func convertIstioFromV1Beta1(in *v1beta1.IstioComponent) *IstioComponent {
 		return nil
 	}
 	return &IstioComponent{
-		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
-		Enabled:          in.Enabled,
-		InjectionEnabled: in.InjectionEnabled,
+		InstallOverrides:      convertInstallOverridesFromV1Beta1(in.InstallOverrides),
+		Enabled:               in.Enabled,
+		InjectionEnabled:      in.InjectionEnabled,
+		IngressGateways:       convertIstioIngressGatewaysFromV1Beta1(in.IngressGateways),
+		OutboundTrafficPolicy: IstioOutboundTrafficPolicy(in.OutboundTrafficPolicy),
+		InstallMethod:         IstioInstallMethod(in.InstallMethod),
+	}
+}
+
+func convertIstioIngressGatewaysFromV1Beta1(in []v1beta1.IstioIngressGateway) []IstioIngressGateway {
+	var out []IstioIngressGateway
+	for _, gateway := range in {
+		out = append(out, IstioIngressGateway{
+			Name:               gateway.Name,
+			Type:               IngressType(gateway.Type),
+			Internal:           gateway.Internal,
+			ServiceAnnotations: gateway.ServiceAnnotations,
+			Ports:              gateway.Ports,
+			Replicas:           gateway.Replicas,
+		})
 	}
+	return out
 }
 
 func convertJaegerOperatorFromV1Beta1(in *v1beta1.JaegerOperatorComponent) *JaegerOperatorComponent {
@@ -382,9 +598,34 @@ func convertKeycloakFromV1Beta1(in *v1beta1.KeycloakComponent) *KeycloakComponen
 		},
 		Enabled:          in.Enabled,
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
+		Realm:            convertKeycloakRealmFromV1Beta1(in.Realm),
 	}
 }
 
+func convertKeycloakRealmFromV1Beta1(in *v1beta1.KeycloakRealmConfig) *KeycloakRealmConfig {
+	if in == nil {
+		return nil
+	}
+	out := &KeycloakRealmConfig{}
+	for _, role := range in.Roles {
+		out.Roles = append(out.Roles, KeycloakRole(role))
+	}
+	for _, group := range in.Groups {
+		out.Groups = append(out.Groups, KeycloakGroup(group))
+	}
+	for _, client := range in.Clients {
+		out.Clients = append(out.Clients, KeycloakClient(client))
+	}
+	for _, provider := range in.IdentityProviders {
+		out.IdentityProviders = append(out.IdentityProviders, KeycloakIdentityProvider{
+			Name: provider.Name,
+			OIDC: (*KeycloakOIDCProvider)(provider.OIDC),
+			LDAP: (*KeycloakLDAPProvider)(provider.LDAP),
+		})
+	}
+	return out
+}
+
 func convertOAMFromV1Beta1(in *v1beta1.OAMComponent) *OAMComponent {
 	if in == nil {
 		return nil
@@ -539,6 +780,7 @@ func convertValueOverridesFromV1Beta1(in []v1beta1.Overrides) []Overrides {
 			ConfigMapRef: oIn.ConfigMapRef,
 			SecretRef:    oIn.SecretRef,
 			Values:       oIn.Values,
+			Template:     oIn.Template,
 		})
 	}
 	return out
@@ -44,7 +44,7 @@ type expandInfo struct {
 	key        string
 }
 
-//ConvertTo converts a v1alpha1.Verrazzano to a v1beta1.Verrazzano
+// ConvertTo converts a v1alpha1.Verrazzano to a v1beta1.Verrazzano
 func (in *Verrazzano) ConvertTo(dstRaw conversion.Hub) error {
 	out := dstRaw.(*v1beta1.Verrazzano)
 	if out == nil || in == nil {
@@ -65,6 +65,7 @@ func (in *Verrazzano) ConvertTo(dstRaw conversion.Hub) error {
 	out.Spec.VolumeClaimSpecTemplates = ConvertVolumeClaimTemplateTo(in.Spec.VolumeClaimSpecTemplates)
 	out.Spec.Components = components
 	out.Spec.Security = convertSecuritySpecTo(in.Spec.Security)
+	out.Spec.HelmDrift = convertHelmDriftSpecTo(in.Spec.HelmDrift)
 
 	// Convert Status
 	out.Status.State = v1beta1.VzStateType(in.Status.State)
@@ -72,6 +73,9 @@ func (in *Verrazzano) ConvertTo(dstRaw conversion.Hub) error {
 	out.Status.Conditions = convertConditionsTo(in.Status.Conditions)
 	out.Status.Components = convertComponentStatusMapTo(in.Status.Components)
 	out.Status.VerrazzanoInstance = convertVerrazzanoInstanceTo(in.Status.VerrazzanoInstance)
+	out.Status.CredentialRotations = convertCredentialRotationsTo(in.Status.CredentialRotations)
+	out.Status.Certificates = convertCertificatesTo(in.Status.Certificates)
+	out.Status.KeycloakRealm = convertKeycloakRealmStatusTo(in.Status.KeycloakRealm)
 	return nil
 }
 
@@ -154,17 +158,51 @@ func ConvertCertManagerToV1Beta1(src *CertManagerComponent) *v1beta1.CertManager
 	}
 }
 
+func convertAcmeExternalAccountBindingToV1Beta1(eab *AcmeExternalAccountBinding) *v1beta1.AcmeExternalAccountBinding {
+	if eab == nil {
+		return nil
+	}
+	return &v1beta1.AcmeExternalAccountBinding{
+		KeyID:         eab.KeyID,
+		KeySecretName: eab.KeySecretName,
+		KeySecretKey:  eab.KeySecretKey,
+	}
+}
+
+func convertVaultIssuerToV1Beta1(vault *VaultIssuer) *v1beta1.VaultIssuer {
+	if vault == nil {
+		return nil
+	}
+	return &v1beta1.VaultIssuer{
+		Server:         vault.Server,
+		Path:           vault.Path,
+		Namespace:      vault.Namespace,
+		CABundleSecret: vault.CABundleSecret,
+		Auth: v1beta1.VaultAuth{
+			TokenSecret: vault.Auth.TokenSecret,
+			AppRole:     (*v1beta1.VaultAppRoleAuth)(vault.Auth.AppRole),
+			Kubernetes:  (*v1beta1.VaultKubernetesAuth)(vault.Auth.Kubernetes),
+		},
+	}
+}
+
 func convertCertificateToV1Beta1(certificate Certificate) v1beta1.Certificate {
 	return v1beta1.Certificate{
 		Acme: v1beta1.Acme{
-			Provider:     v1beta1.ProviderType(certificate.Acme.Provider),
-			EmailAddress: certificate.Acme.EmailAddress,
-			Environment:  certificate.Acme.Environment,
+			Provider:               v1beta1.ProviderType(certificate.Acme.Provider),
+			EmailAddress:           certificate.Acme.EmailAddress,
+			Environment:            certificate.Acme.Environment,
+			DirectoryURL:           certificate.Acme.DirectoryURL,
+			CABundleSecret:         certificate.Acme.CABundleSecret,
+			ExternalAccountBinding: convertAcmeExternalAccountBindingToV1Beta1(certificate.Acme.ExternalAccountBinding),
+			Solver:                 v1beta1.AcmeSolverType(certificate.Acme.Solver),
 		},
 		CA: v1beta1.CA{
 			SecretName:               certificate.CA.SecretName,
 			ClusterResourceNamespace: certificate.CA.ClusterResourceNamespace,
 		},
+		Vault:     convertVaultIssuerToV1Beta1(certificate.Vault),
+		IssuerRef: (*v1beta1.IssuerReference)(certificate.IssuerRef),
 	}
 }
 
@@ -247,6 +285,7 @@ func convertDNSToV1Beta1(src *DNSComponent) *v1beta1.DNSComponent {
 		Wildcard:         convertWildcardDNSToV1Beta1(src.Wildcard),
 		OCI:              convertOCIDNSToV1Beta1(src.OCI),
 		External:         convertExternalDNSToV1Beta1(src.External),
+		RFC2136:          (*v1beta1.RFC2136)(src.RFC2136),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
@@ -437,10 +476,61 @@ func convertFluentdToV1Beta1(src *FluentdComponent) *v1beta1.FluentdComponent {
 		OpenSearchURL:     src.ElasticsearchURL,
 		OpenSearchSecret:  src.ElasticsearchSecret,
 		OCI:               convertOCILoggingConfigurationToV1Beta1(src.OCI),
+		Outputs:           convertFluentdOutputsToV1Beta1(src.Outputs),
+		LogRoutingClasses: convertLogRoutingClassesToV1Beta1(src.LogRoutingClasses),
 		InstallOverrides:  convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
 }
 
+func convertFluentdOutputsToV1Beta1(src []FluentdOutput) []v1beta1.FluentdOutput {
+	var out []v1beta1.FluentdOutput
+	for _, output := range src {
+		converted := v1beta1.FluentdOutput{
+			Name:       output.Name,
+			Namespaces: output.Namespaces,
+		}
+		if output.Kafka != nil {
+			converted.Kafka = &v1beta1.FluentdKafkaOutput{
+				Brokers: output.Kafka.Brokers,
+				Topic:   output.Kafka.Topic,
+				Secret:  output.Kafka.Secret,
+			}
+		}
+		if output.Syslog != nil {
+			converted.Syslog = &v1beta1.FluentdSyslogOutput{
+				Host:   output.Syslog.Host,
+				Port:   output.Syslog.Port,
+				Secret: output.Syslog.Secret,
+			}
+		}
+		if output.S3 != nil {
+			converted.S3 = &v1beta1.FluentdS3Output{
+				Endpoint: output.S3.Endpoint,
+				Region:   output.S3.Region,
+				Bucket:   output.S3.Bucket,
+				Path:     output.S3.Path,
+				Secret:   output.S3.Secret,
+			}
+		}
+		out = append(out, converted)
+	}
+	return out
+}
+
+func convertLogRoutingClassesToV1Beta1(src []LogRoutingClass) []v1beta1.LogRoutingClass {
+	var out []v1beta1.LogRoutingClass
+	for _, class := range src {
+		out = append(out, v1beta1.LogRoutingClass{
+			Name:        class.Name,
+			Destination: v1beta1.LogRoutingDestination(class.Destination),
+			OCILogID:    class.OCILogID,
+			Output:      class.Output,
+			Retention:   class.Retention,
+		})
+	}
+	return out
+}
+
 func convertVolumeMountsToV1Beta1(mounts []VolumeMount) []v1beta1.VolumeMount {
 	var out []v1beta1.VolumeMount
 	for _, mount := range mounts {
@@ -517,12 +607,30 @@ func convertIstioToV1Beta1(src *IstioComponent) (*v1beta1.IstioComponent, error)
 		return nil, err
 	}
 	return &v1beta1.IstioComponent{
-		InstallOverrides: overrides,
-		Enabled:          src.Enabled,
-		InjectionEnabled: src.InjectionEnabled,
+		InstallOverrides:      overrides,
+		Enabled:               src.Enabled,
+		InjectionEnabled:      src.InjectionEnabled,
+		IngressGateways:       convertIstioIngressGatewaysToV1Beta1(src.IngressGateways),
+		OutboundTrafficPolicy: v1beta1.IstioOutboundTrafficPolicy(src.OutboundTrafficPolicy),
+		InstallMethod:         v1beta1.IstioInstallMethod(src.InstallMethod),
 	}, nil
 }
 
+func convertIstioIngressGatewaysToV1Beta1(src []IstioIngressGateway) []v1beta1.IstioIngressGateway {
+	var out []v1beta1.IstioIngressGateway
+	for _, gateway := range src {
+		out = append(out, v1beta1.IstioIngressGateway{
+			Name:               gateway.Name,
+			Type:               v1beta1.IngressType(gateway.Type),
+			Internal:           gateway.Internal,
+			ServiceAnnotations: gateway.ServiceAnnotations,
+			Ports:              gateway.Ports,
+			Replicas:           gateway.Replicas,
+		})
+	}
+	return out
+}
+
 func mergeIstioOverrides(override v1beta1.Overrides, overrides []v1beta1.Overrides) ([]v1beta1.Overrides, error) {
 	if !isOverrideValueUnset(override) {
 		if len(overrides) < 1 {
@@ -592,9 +700,34 @@ func convertKeycloakToV1Beta1(src *KeycloakComponent) (*v1beta1.KeycloakComponen
 		},
 		Enabled:          src.Enabled,
 		InstallOverrides: keycloakOverrides,
+		Realm:            convertKeycloakRealmToV1Beta1(src.Realm),
 	}, nil
 }
 
+func convertKeycloakRealmToV1Beta1(src *KeycloakRealmConfig) *v1beta1.KeycloakRealmConfig {
+	if src == nil {
+		return nil
+	}
+	out := &v1beta1.KeycloakRealmConfig{}
+	for _, role := range src.Roles {
+		out.Roles = append(out.Roles, v1beta1.KeycloakRole(role))
+	}
+	for _, group := range src.Groups {
+		out.Groups = append(out.Groups, v1beta1.KeycloakGroup(group))
+	}
+	for _, client := range src.Clients {
+		out.Clients = append(out.Clients, v1beta1.KeycloakClient(client))
+	}
+	for _, provider := range src.IdentityProviders {
+		out.IdentityProviders = append(out.IdentityProviders, v1beta1.KeycloakIdentityProvider{
+			Name: provider.Name,
+			OIDC: (*v1beta1.KeycloakOIDCProvider)(provider.OIDC),
+			LDAP: (*v1beta1.KeycloakLDAPProvider)(provider.LDAP),
+		})
+	}
+	return out
+}
+
 func convertMySQLOperatorToV1Beta1(src *MySQLOperatorComponent) *v1beta1.MySQLOperatorComponent {
 	if src == nil {
 		return nil
@@ -756,12 +889,90 @@ func convertComponentStatusMapTo(components ComponentStatusMap) v1beta1.Componen
 				Version:                  detail.Version,
 				LastReconciledGeneration: detail.LastReconciledGeneration,
 				ReconcilingGeneration:    detail.ReconcilingGeneration,
+				ReadinessReport:          convertReadinessReportTo(detail.ReadinessReport),
 			}
 		}
 	}
 	return componentStatusMap
 }
 
+func convertReadinessReportTo(report *ReadinessReport) *v1beta1.ReadinessReport {
+	if report == nil {
+		return nil
+	}
+	out := &v1beta1.ReadinessReport{}
+	for _, workload := range report.Workloads {
+		outWorkload := v1beta1.WorkloadReadiness{
+			Kind:             workload.Kind,
+			Namespace:        workload.Namespace,
+			Name:             workload.Name,
+			ExpectedReplicas: workload.ExpectedReplicas,
+			ReadyReplicas:    workload.ReadyReplicas,
+			Message:          workload.Message,
+		}
+		for _, pod := range workload.UnreadyPods {
+			outPod := v1beta1.PodReadiness{
+				Name:    pod.Name,
+				Phase:   pod.Phase,
+				Reason:  pod.Reason,
+				Message: pod.Message,
+			}
+			for _, container := range pod.Containers {
+				outPod.Containers = append(outPod.Containers, v1beta1.ContainerReadiness(container))
+			}
+			outWorkload.UnreadyPods = append(outWorkload.UnreadyPods, outPod)
+		}
+		out.Workloads = append(out.Workloads, outWorkload)
+	}
+	return out
+}
+
+func convertCredentialRotationsTo(rotations map[string]*CredentialRotationStatus) map[string]*v1beta1.CredentialRotationStatus {
+	if rotations == nil {
+		return nil
+	}
+	out := map[string]*v1beta1.CredentialRotationStatus{}
+	for credential, rotation := range rotations {
+		if rotation != nil {
+			out[credential] = &v1beta1.CredentialRotationStatus{
+				Request:          rotation.Request,
+				State:            v1beta1.CredentialRotationStateType(rotation.State),
+				LastRotationTime: rotation.LastRotationTime,
+				Message:          rotation.Message,
+			}
+		}
+	}
+	return out
+}
+
+func convertCertificatesTo(certificates []CertificateStatus) []v1beta1.CertificateStatus {
+	var out []v1beta1.CertificateStatus
+	for _, certificate := range certificates {
+		out = append(out, v1beta1.CertificateStatus(certificate))
+	}
+	return out
+}
+
+func convertKeycloakRealmStatusTo(realm *KeycloakRealmStatus) *v1beta1.KeycloakRealmStatus {
+	if realm == nil {
+		return nil
+	}
+	out := &v1beta1.KeycloakRealmStatus{
+		ObservedGeneration: realm.ObservedGeneration,
+		LastReconcileTime:  realm.LastReconcileTime,
+		Message:            realm.Message,
+	}
+	for _, object := range realm.Objects {
+		out.Objects = append(out.Objects, v1beta1.KeycloakRealmObjectStatus{
+			Kind:           object.Kind,
+			Name:           object.Name,
+			State:          v1beta1.KeycloakRealmObjectStateType(object.State),
+			LastUpdateTime: object.LastUpdateTime,
+		})
+	}
+	return out
+}
+
 func convertVerrazzanoInstanceTo(instance *InstanceInfo) *v1beta1.InstanceInfo {
 	if instance == nil {
 		return nil
@@ -781,8 +992,38 @@ func convertVerrazzanoInstanceTo(instance *InstanceInfo) *v1beta1.InstanceInfo {
 
 func convertSecuritySpecTo(security SecuritySpec) v1beta1.SecuritySpec {
 	return v1beta1.SecuritySpec{
-		AdminSubjects:   security.AdminSubjects,
-		MonitorSubjects: security.MonitorSubjects,
+		AdminSubjects:    security.AdminSubjects,
+		MonitorSubjects:  security.MonitorSubjects,
+		CredentialsStore: convertCredentialsStoreTo(security.CredentialsStore),
+	}
+}
+
+func convertCredentialsStoreTo(store *CredentialsStore) *v1beta1.CredentialsStore {
+	if store == nil {
+		return nil
+	}
+	out := &v1beta1.CredentialsStore{}
+	if store.Vault != nil {
+		out.Vault = &v1beta1.VaultCredentialsStore{
+			Address:     store.Vault.Address,
+			MountPath:   store.Vault.MountPath,
+			KVVersion:   store.Vault.KVVersion,
+			PathPrefix:  store.Vault.PathPrefix,
+			Namespace:   store.Vault.Namespace,
+			TokenSecret: store.Vault.TokenSecret,
+			CASecret:    store.Vault.CASecret,
+		}
+	}
+	return out
+}
+
+func convertHelmDriftSpecTo(helmDrift *HelmDriftSpec) *v1beta1.HelmDriftSpec {
+	if helmDrift == nil {
+		return nil
+	}
+	return &v1beta1.HelmDriftSpec{
+		Policy:   v1beta1.HelmDriftPolicy(helmDrift.Policy),
+		Interval: helmDrift.Interval,
 	}
 }
 
@@ -878,6 +1119,7 @@ func ConvertValueOverridesToV1Beta1(overrides []Overrides) []v1beta1.Overrides {
 			ConfigMapRef: override.ConfigMapRef,
 			SecretRef:    override.SecretRef,
 			Values:       override.Values.DeepCopy(),
+			Template:     override.Template,
 		})
 	}
 	return out
@@ -1422,6 +1422,25 @@ func TestValidateProfileInvalidProfile(t *testing.T) {
 	assert.Error(t, ValidateProfile("wrong-profile"))
 }
 
+// TestValidateProfileUserProfile Tests ValidateProfile() for user profiles
+// GIVEN a user profile validator
+// WHEN the profiles provided are user profiles
+// THEN an error is returned only for the user profiles that the validator rejects
+func TestValidateProfileUserProfile(t *testing.T) {
+	SetUserProfileValidator(func(profile string) error {
+		if profile == "edge" {
+			return nil
+		}
+		return fmt.Errorf("Profile %s does not exist", profile)
+	})
+	defer SetUserProfileValidator(nil)
+
+	assert.NoError(t, ValidateProfile("edge"))
+	assert.NoError(t, ValidateProfile("dev, edge"))
+	assert.EqualError(t, ValidateProfile("prod,wrong-profile"),
+		"Requested profile wrong-profile is invalid: Profile wrong-profile does not exist")
+}
+
 func TestValidateInstallOverrides(t *testing.T) {
 	assert := assert.New(t)
 
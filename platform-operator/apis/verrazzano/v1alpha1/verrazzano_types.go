@@ -61,7 +61,8 @@ type VerrazzanoSpec struct {
 	// Version is the Verrazzano version
 	// +optional
 	Version string `json:"version,omitempty"`
-	// Profile is the name of the profile to install.  Default is "prod".
+	// Profile is the name of the profile to install, either a built-in profile (dev, prod, or managed-cluster) or a
+	// user profile, or a comma separated list of profiles.  Default is "prod".
 	// +optional
 	Profile ProfileType `json:"profile,omitempty"`
 	// EnvironmentName identifies install environment.  Default environment name is "default".
@@ -87,6 +88,39 @@ type VerrazzanoSpec struct {
 	// +optional
 	// +patchStrategy=merge,retainKeys
 	VolumeClaimSpecTemplates []VolumeClaimSpecTemplate `json:"volumeClaimSpecTemplates,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+
+	// HelmDrift defines how the operator handles changes that are made to the Helm releases of components outside of Verrazzano
+	// +optional
+	HelmDrift *HelmDriftSpec `json:"helmDrift,omitempty"`
+}
+
+// HelmDriftPolicy identifies how the operator handles drift of the Helm releases of components
+type HelmDriftPolicy string
+
+const (
+	// HelmDriftPolicyDisabled means that the Helm releases are not checked for drift
+	HelmDriftPolicyDisabled HelmDriftPolicy = "Disabled"
+
+	// HelmDriftPolicyReport means that drift is reported in the component status and metrics, but not corrected
+	HelmDriftPolicyReport HelmDriftPolicy = "Report"
+
+	// HelmDriftPolicyReconcile means that drift is reported, and the Helm release of the component is upgraded to
+	// restore the values and objects generated by the operator, without reinstalling the component
+	HelmDriftPolicyReconcile HelmDriftPolicy = "Reconcile"
+)
+
+// HelmDriftSpec defines the periodic detection of drift of the Helm releases of components.  A Helm release has drifted
+// when the values of the release no longer match the values the operator generates for the component, or when the
+// live objects no longer match the rendered manifest of the release, for example when the release was upgraded or
+// its objects were edited outside of Verrazzano.
+type HelmDriftSpec struct {
+	// Policy is the drift policy, one of Disabled, Report or Reconcile.  Default is Report.
+	// +optional
+	// +kubebuilder:validation:Enum=Disabled;Report;Reconcile
+	Policy HelmDriftPolicy `json:"policy,omitempty"`
+	// Interval is how often the Helm releases are checked for drift.  Default is 5m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
 }
 
 // CommonKubernetesSpec - Kubernetes resources that are common to a subgroup of components
@@ -107,6 +141,44 @@ type SecuritySpec struct {
 	// MonitorSubjects specifies subjects that should be bound to the verrazzano-monitor role
 	// +optional
 	MonitorSubjects []rbacv1.Subject `json:"monitorSubjects,omitempty"`
+	// CredentialsStore specifies an external secrets store that the generated component credentials are read from
+	// and written to.  The credentials are still copied to Kubernetes Secrets for the components that use them.
+	// +optional
+	CredentialsStore *CredentialsStore `json:"credentialsStore,omitempty"`
+}
+
+// CredentialsStore specifies an external secrets store for the generated component credentials
+type CredentialsStore struct {
+	// Vault specifies a HashiCorp Vault KV secrets engine
+	// +optional
+	Vault *VaultCredentialsStore `json:"vault,omitempty"`
+}
+
+// VaultCredentialsStore specifies a HashiCorp Vault KV secrets engine.  Each credential is stored at the path
+// <pathPrefix>/<namespace>/<secret name> of the secrets engine.
+type VaultCredentialsStore struct {
+	// Address is the URL of the Vault server, for example https://vault.example.com:8200
+	// The egress of the operator to a server outside the cluster is limited to the IP address of the server only if
+	// the URL has an IP address, since network policies can't select DNS names, otherwise it is only limited by port.
+	Address string `json:"address"`
+	// MountPath is the path the KV secrets engine is mounted at, the default is secret
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+	// KVVersion is the version of the KV secrets engine, 1 or 2, the default is 2
+	// +optional
+	KVVersion int `json:"kvVersion,omitempty"`
+	// PathPrefix is the prefix of the paths of the credentials, the default is verrazzano
+	// +optional
+	PathPrefix string `json:"pathPrefix,omitempty"`
+	// Namespace is the Vault Enterprise namespace of the secrets engine
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// TokenSecret is the name of a Secret in the verrazzano-install namespace with the Vault token in the token key
+	TokenSecret string `json:"tokenSecret"`
+	// CASecret is the name of a Secret in the verrazzano-install namespace with the CA bundle of the Vault server in
+	// the ca.crt key
+	// +optional
+	CASecret string `json:"caSecret,omitempty"`
 }
 
 // VolumeClaimSpecTemplate Contains common PVC configuration that can be referenced from Components; these
@@ -154,6 +226,12 @@ type VerrazzanoStatus struct {
 	State VzStateType `json:"state,omitempty"`
 	// States of the individual installed components
 	Components ComponentStatusMap `json:"components,omitempty"`
+	// The rotations of the generated credentials, keyed by the name of the credential
+	CredentialRotations map[string]*CredentialRotationStatus `json:"credentialRotations,omitempty"`
+	// The certificates of the components and of the application ingresses, and when they expire
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+	// The status of the reconcile of the Keycloak realm configuration
+	KeycloakRealm *KeycloakRealmStatus `json:"keycloakRealm,omitempty"`
 }
 
 type ComponentStatusMap map[string]*ComponentStatusDetails
@@ -172,6 +250,142 @@ type ComponentStatusDetails struct {
 	LastReconciledGeneration int64 `json:"lastReconciledGeneration,omitempty"`
 	// The generation of the VZ resource the Component is currently being reconciled against
 	ReconcilingGeneration int64 `json:"reconcilingGeneration,omitempty"`
+	// Details about the workloads that are preventing the component from being ready
+	ReadinessReport *ReadinessReport `json:"readinessReport,omitempty"`
+}
+
+// ReadinessReport explains why a component is not ready
+type ReadinessReport struct {
+	// The workloads of the component that are not ready
+	Workloads []WorkloadReadiness `json:"workloads,omitempty"`
+}
+
+// WorkloadReadiness describes why a Deployment, StatefulSet or DaemonSet is not ready
+type WorkloadReadiness struct {
+	// The kind of the workload, one of Deployment, StatefulSet or DaemonSet
+	Kind string `json:"kind"`
+	// The namespace of the workload
+	Namespace string `json:"namespace"`
+	// The name of the workload
+	Name string `json:"name"`
+	// The number of replicas (nodes for a DaemonSet) that must be ready
+	ExpectedReplicas int32 `json:"expectedReplicas"`
+	// The number of replicas (nodes for a DaemonSet) that are ready
+	ReadyReplicas int32 `json:"readyReplicas"`
+	// A message explaining what the workload is waiting for
+	Message string `json:"message,omitempty"`
+	// The pods of the workload that are not ready
+	UnreadyPods []PodReadiness `json:"unreadyPods,omitempty"`
+}
+
+// PodReadiness describes why a pod is not ready
+type PodReadiness struct {
+	// The name of the pod
+	Name string `json:"name"`
+	// The phase of the pod
+	Phase string `json:"phase,omitempty"`
+	// The reason the pod is not ready, for example why it could not be scheduled
+	Reason string `json:"reason,omitempty"`
+	// A message with details about the reason
+	Message string `json:"message,omitempty"`
+	// The containers of the pod that are not ready
+	Containers []ContainerReadiness `json:"containers,omitempty"`
+}
+
+// ContainerReadiness describes why a container is not ready
+type ContainerReadiness struct {
+	// The name of the container
+	Name string `json:"name"`
+	// True if this is an init container
+	InitContainer bool `json:"initContainer,omitempty"`
+	// The reason the container is waiting or terminated, for example ImagePullBackOff or CrashLoopBackOff
+	Reason string `json:"reason,omitempty"`
+	// A message with details about the reason
+	Message string `json:"message,omitempty"`
+	// The number of times the container has been restarted
+	RestartCount int32 `json:"restartCount,omitempty"`
+}
+
+// CertificateStatus describes when a certificate of a component or of an application ingress expires
+type CertificateStatus struct {
+	// The name of the component that uses the certificate, or application for the certificate of an application
+	// ingress
+	Component string `json:"component"`
+	// The namespace of the certificate
+	Namespace string `json:"namespace"`
+	// The name of the certificate
+	Name string `json:"name"`
+	// True if the certificate is ready
+	Ready bool `json:"ready"`
+	// The time the certificate expires
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+	// The time the certificate is renewed
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+}
+
+// KeycloakRealmObjectStateType identifies what the last reconcile of the Keycloak realm configuration did to an object
+type KeycloakRealmObjectStateType string
+
+const (
+	// KeycloakRealmObjectCreated is the state of an object that was created
+	KeycloakRealmObjectCreated KeycloakRealmObjectStateType = "Created"
+
+	// KeycloakRealmObjectUpdated is the state of an object that was updated to correct its drift from the declaration
+	KeycloakRealmObjectUpdated KeycloakRealmObjectStateType = "Updated"
+
+	// KeycloakRealmObjectInSync is the state of an object that already matched the declaration
+	KeycloakRealmObjectInSync KeycloakRealmObjectStateType = "InSync"
+)
+
+// KeycloakRealmStatus is the status of the reconcile of the Keycloak realm configuration
+type KeycloakRealmStatus struct {
+	// The generation of the Verrazzano CR that was last reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The time of the last reconcile
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+	// The objects of the realm configuration
+	Objects []KeycloakRealmObjectStatus `json:"objects,omitempty"`
+	// A message with details about a failed reconcile
+	Message string `json:"message,omitempty"`
+}
+
+// KeycloakRealmObjectStatus describes what the last reconcile of the Keycloak realm configuration did to an object
+type KeycloakRealmObjectStatus struct {
+	// The kind of the object, one of Role, Group, Client or IdentityProvider
+	Kind string `json:"kind"`
+	// The name of the object
+	Name string `json:"name"`
+	// What the last reconcile did to the object
+	State KeycloakRealmObjectStateType `json:"state"`
+	// The time the object was last created or updated
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// CredentialRotationStateType identifies the state of the rotation of a generated credential
+type CredentialRotationStateType string
+
+const (
+	// CredentialRotationStateRotating is the state when the credential is being rotated
+	CredentialRotationStateRotating CredentialRotationStateType = "Rotating"
+
+	// CredentialRotationStateRotated is the state when the credential was rotated
+	CredentialRotationStateRotated CredentialRotationStateType = "Rotated"
+
+	// CredentialRotationStateFailed is the state when the last rotation attempt of the credential failed, the
+	// rotation is retried
+	CredentialRotationStateFailed CredentialRotationStateType = "Failed"
+)
+
+// CredentialRotationStatus is the status of the rotation of a generated credential
+type CredentialRotationStatus struct {
+	// The value of the rotate credentials annotation that requested the rotation
+	Request string `json:"request,omitempty"`
+	// The state of the rotation
+	State CredentialRotationStateType `json:"state,omitempty"`
+	// The time the credential was last rotated
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+	// A message with details about a failed rotation
+	Message string `json:"message,omitempty"`
 }
 
 // ConditionType identifies the condition of the install/uninstall/upgrade which can be checked with kubectl wait
@@ -210,6 +424,14 @@ const (
 
 	// CondUpgradeComplete means the upgrade has completed successfully
 	CondUpgradeComplete ConditionType = "UpgradeComplete"
+
+	// CondHelmDriftDetected means that the values of the Helm release of a component no longer match the values
+	// generated by the operator.
+	CondHelmDriftDetected ConditionType = "HelmDriftDetected"
+
+	// CondCertificatesExpiringSoon means that certificates of the components or of the application ingresses expire
+	// soon, which usually means that they could not be renewed.
+	CondCertificatesExpiringSoon ConditionType = "CertificatesExpiringSoon"
 )
 
 // Condition describes current state of an install.
@@ -414,7 +636,7 @@ type ElasticsearchComponent struct {
 	Nodes []OpenSearchNode `json:"nodes,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
 }
 
-//OpenSearchNode specifies a node group in the OpenSearch cluster
+// OpenSearchNode specifies a node group in the OpenSearch cluster
 type OpenSearchNode struct {
 	Name      string                       `json:"name,omitempty"`
 	Replicas  int32                        `json:"replicas,omitempty"`
@@ -572,7 +794,10 @@ type DNSComponent struct {
 	OCI *OCI `json:"oci,omitempty"`
 	// DNS type of external. For example, OLCNE uses this type.
 	// +optional
-	External         *External `json:"external,omitempty"`
+	External *External `json:"external,omitempty"`
+	// DNS type of RFC2136, the DNS records are created with dynamic updates of a DNS server such as BIND
+	// +optional
+	RFC2136          *RFC2136 `json:"rfc2136,omitempty"`
 	InstallOverrides `json:",inline"`
 }
 
@@ -615,6 +840,32 @@ type IstioEgressSection struct {
 	Kubernetes *IstioKubernetesSection `json:"kubernetes,omitempty"`
 }
 
+// IstioIngressGateway specifies an additional Istio ingress gateway, which is installed alongside the
+// istio-ingressgateway and can be selected by an IngressTrait.
+type IstioIngressGateway struct {
+	// Name of the gateway, which is the name of its Deployment and Service in the istio-system namespace.  The name
+	// must be a DNS-1123 label.  The gateway pods are labeled istio=<name>.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// Type of the gateway Service.  Default is LoadBalancer
+	// +optional
+	Type IngressType `json:"type,omitempty"`
+	// Internal requests a load balancer that is only reachable from the private network of the cluster, by setting
+	// the OCI internal load balancer annotations on the gateway Service.  Use ServiceAnnotations for other providers.
+	// +optional
+	Internal bool `json:"internal,omitempty"`
+	// Annotations of the gateway Service
+	// +optional
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+	// Ports of the gateway Service.  Default is the ports of the istio-ingressgateway chart.  Like the
+	// istio-ingressgateway, the network policy of the gateway pods only allows external traffic to target port 8443.
+	// +optional
+	Ports []corev1.ServicePort `json:"ports,omitempty"`
+	// Number of replicas of the gateway.  Default is 1
+	// +optional
+	Replicas uint32 `json:"replicas,omitempty"`
+}
+
 // IstioKubernetesSection specifies the Kubernetes resources that can be customized for Istio.
 type IstioKubernetesSection struct {
 	CommonKubernetesSpec `json:",inline"`
@@ -633,12 +884,51 @@ type IstioComponent struct {
 	Enabled *bool `json:"enabled,omitempty"`
 	// +optional
 	InjectionEnabled *bool `json:"injectionEnabled,omitempty"`
+	// Additional ingress gateways, such as an internal gateway alongside the public istio-ingressgateway
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	IngressGateways []IstioIngressGateway `json:"ingressGateways,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
 	// +optional
 	Ingress *IstioIngressSection `json:"ingress,omitempty"`
 	// +optional
 	Egress *IstioEgressSection `json:"egress,omitempty"`
+	// OutboundTrafficPolicy is the mesh outbound traffic policy mode.  REGISTRY_ONLY blocks the traffic to hosts that
+	// are not in the service registry of the mesh, such as the hosts allowed by the egress of a VerrazzanoProject.
+	// Default is ALLOW_ANY
+	// +optional
+	// +kubebuilder:validation:Enum=ALLOW_ANY;REGISTRY_ONLY
+	OutboundTrafficPolicy IstioOutboundTrafficPolicy `json:"outboundTrafficPolicy,omitempty"`
+	// InstallMethod is how Istio is installed and upgraded, either Istioctl or Native.  Native renders the
+	// IstioOperator manifests in the operator and applies them with server-side apply, and does not require
+	// istioctl.  Default is Istioctl.
+	// +optional
+	// +kubebuilder:validation:Enum=Istioctl;Native
+	InstallMethod IstioInstallMethod `json:"installMethod,omitempty"`
 }
 
+// IstioInstallMethod identifies how Istio is installed
+type IstioInstallMethod string
+
+const (
+	// IstioInstallMethodIstioctl means that Istio is installed using the istioctl command
+	IstioInstallMethodIstioctl IstioInstallMethod = "Istioctl"
+
+	// IstioInstallMethodNative means that the operator renders and applies the Istio manifests without istioctl
+	IstioInstallMethodNative IstioInstallMethod = "Native"
+)
+
+// IstioOutboundTrafficPolicy identifies the mesh outbound traffic policy mode
+type IstioOutboundTrafficPolicy string
+
+const (
+	// IstioOutboundTrafficPolicyAllowAny allows the traffic to any host outside of the mesh
+	IstioOutboundTrafficPolicyAllowAny IstioOutboundTrafficPolicy = "ALLOW_ANY"
+
+	// IstioOutboundTrafficPolicyRegistryOnly only allows the traffic to hosts in the service registry of the mesh
+	IstioOutboundTrafficPolicyRegistryOnly IstioOutboundTrafficPolicy = "REGISTRY_ONLY"
+)
+
 // IsInjectionEnabled is istio sidecar injection enabled check
 func (c *IstioComponent) IsInjectionEnabled() bool {
 	if c.Enabled == nil || *c.Enabled {
@@ -667,6 +957,129 @@ type KeycloakComponent struct {
 	// +optional
 	Enabled          *bool `json:"enabled,omitempty"`
 	InstallOverrides `json:",inline"`
+	// Realm declares additional objects of the verrazzano-system realm, which are kept in sync with the declaration
+	// +optional
+	Realm *KeycloakRealmConfig `json:"realm,omitempty"`
+}
+
+// KeycloakRealmConfig declares the additional objects of the verrazzano-system realm, which are created and kept in
+// sync with the declaration by the Verrazzano platform operator
+type KeycloakRealmConfig struct {
+	// The realm roles
+	// +optional
+	Roles []KeycloakRole `json:"roles,omitempty"`
+	// The groups, and the realm roles that are mapped to them
+	// +optional
+	Groups []KeycloakGroup `json:"groups,omitempty"`
+	// The OpenID Connect clients
+	// +optional
+	Clients []KeycloakClient `json:"clients,omitempty"`
+	// The identity providers and the LDAP user federation providers
+	// +optional
+	IdentityProviders []KeycloakIdentityProvider `json:"identityProviders,omitempty"`
+}
+
+// KeycloakRole is a realm role
+type KeycloakRole struct {
+	// The name of the role
+	Name string `json:"name"`
+	// The description of the role
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// KeycloakGroup is a group of the realm
+type KeycloakGroup struct {
+	// The name of the group
+	Name string `json:"name"`
+	// The name of the parent group, such as verrazzano-users.  The group is a top level group if not specified.
+	// +optional
+	Parent string `json:"parent,omitempty"`
+	// The realm roles that are mapped to the group, in addition to the roles that are already mapped to it
+	// +optional
+	RealmRoles []string `json:"realmRoles,omitempty"`
+}
+
+// KeycloakClient is an OpenID Connect client of the realm
+type KeycloakClient struct {
+	// The client ID
+	ClientID string `json:"clientId"`
+	// True if the client is a public client, which does not authenticate with a client secret
+	// +optional
+	PublicClient bool `json:"publicClient,omitempty"`
+	// True if the client can use the resource owner password credentials grant
+	// +optional
+	DirectAccessGrantsEnabled bool `json:"directAccessGrantsEnabled,omitempty"`
+	// The valid redirect URIs of the client
+	// +optional
+	RedirectURIs []string `json:"redirectUris,omitempty"`
+	// The allowed CORS origins of the client
+	// +optional
+	WebOrigins []string `json:"webOrigins,omitempty"`
+}
+
+// KeycloakIdentityProvider is an OpenID Connect identity provider, or an LDAP user federation provider, of the realm.
+// Exactly one of OIDC and LDAP must be specified.
+type KeycloakIdentityProvider struct {
+	// The alias of the identity provider, or the name of the user federation provider
+	Name string `json:"name"`
+	// The OpenID Connect identity provider
+	// +optional
+	OIDC *KeycloakOIDCProvider `json:"oidc,omitempty"`
+	// The LDAP user federation provider
+	// +optional
+	LDAP *KeycloakLDAPProvider `json:"ldap,omitempty"`
+}
+
+// KeycloakOIDCProvider configures an OpenID Connect identity provider
+type KeycloakOIDCProvider struct {
+	// The name that is displayed on the login page
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// The authorization URL of the provider
+	AuthorizationURL string `json:"authorizationUrl"`
+	// The token URL of the provider
+	TokenURL string `json:"tokenUrl"`
+	// The user info URL of the provider
+	// +optional
+	UserInfoURL string `json:"userInfoUrl,omitempty"`
+	// The issuer of the tokens of the provider
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+	// The client ID of the realm in the provider
+	ClientID string `json:"clientId"`
+	// The name of the secret in the verrazzano-install namespace with the client secret in the clientSecret key
+	ClientSecret string `json:"clientSecret"`
+	// The scopes that are requested from the provider
+	// +optional
+	DefaultScope string `json:"defaultScope,omitempty"`
+}
+
+// KeycloakLDAPProvider configures an LDAP user federation provider
+type KeycloakLDAPProvider struct {
+	// The URL of the LDAP server, such as ldaps://ldap.example.com:636
+	ConnectionURL string `json:"connectionUrl"`
+	// The DN of the LDAP tree where the users are
+	UsersDN string `json:"usersDn"`
+	// The DN of the LDAP user that Keycloak binds as.  The users are searched anonymously if not specified.
+	// +optional
+	BindDN string `json:"bindDn,omitempty"`
+	// The name of the secret in the verrazzano-install namespace with the password of the bind DN in the
+	// bindCredential key
+	// +optional
+	BindCredential string `json:"bindCredential,omitempty"`
+	// The vendor of the LDAP server, one of ad, rhds, tivoli, edirectory or other.  Defaults to other.
+	// +optional
+	Vendor string `json:"vendor,omitempty"`
+	// The LDAP attribute that is mapped to the Keycloak username.  Defaults to uid.
+	// +optional
+	UsernameLDAPAttribute string `json:"usernameLdapAttribute,omitempty"`
+	// The object classes of the LDAP users.  Defaults to inetOrgPerson and organizationalPerson.
+	// +optional
+	UserObjectClasses []string `json:"userObjectClasses,omitempty"`
+	// The edit mode of the users, one of READ_ONLY, WRITABLE or UNSYNCED.  Defaults to READ_ONLY.
+	// +optional
+	EditMode string `json:"editMode,omitempty"`
 }
 
 // MySQLComponent specifies the MySQL configuration
@@ -724,8 +1137,115 @@ type FluentdComponent struct {
 
 	// Configuration for integration with OCI (Oracle Cloud Infrastructure) Logging Service
 	// +optional
-	OCI              *OciLoggingConfiguration `json:"oci,omitempty"`
-	InstallOverrides `json:",inline"`
+	OCI *OciLoggingConfiguration `json:"oci,omitempty"`
+	// Additional outputs, such as Kafka, syslog and S3 compatible object storage
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	Outputs []FluentdOutput `json:"outputs,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+	// Log routing classes, which are selected by namespaces and projects to send their log records to a specific
+	// destination
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	LogRoutingClasses []LogRoutingClass `json:"logRoutingClasses,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+	InstallOverrides  `json:",inline"`
+}
+
+// LogRoutingDestination identifies the destination of a log routing class
+// +kubebuilder:validation:Enum=OpenSearch;OCI;Output
+type LogRoutingDestination string
+
+const (
+	// LogRoutingDestinationOpenSearch sends the log records to the verrazzano-logs-<class name> OpenSearch data stream
+	LogRoutingDestinationOpenSearch LogRoutingDestination = "OpenSearch"
+	// LogRoutingDestinationOCI sends the log records to an OCI Logging log
+	LogRoutingDestinationOCI LogRoutingDestination = "OCI"
+	// LogRoutingDestinationOutput sends the log records to an additional Fluentd output, whether or not the namespaces
+	// of the output include the namespaces of the class.  The records are not copied to the other outputs.
+	LogRoutingDestinationOutput LogRoutingDestination = "Output"
+)
+
+// LogRoutingClass specifies a named destination for the log records of the namespaces that select it with the
+// verrazzano.io/log-routing-class annotation, either on the namespace or on its VerrazzanoProject.  The records of
+// these namespaces are sent to the class destination instead of the default destination.  Changing the class of a
+// namespace reloads the Fluentd config, which briefly restarts all the Fluentd pipelines.
+type LogRoutingClass struct {
+	// Name of the class, which is the value of the verrazzano.io/log-routing-class annotation
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// Destination of the log records
+	Destination LogRoutingDestination `json:"destination"`
+	// OCID of the OCI Logging log, required for the OCI destination
+	// +optional
+	OCILogID string `json:"ociLogId,omitempty"`
+	// Name of an additional output, required for the Output destination.  The namespaces of the output do not apply
+	// to the records of the class.
+	// +optional
+	Output string `json:"output,omitempty"`
+	// Minimum age of the indices of the OpenSearch destination before they are deleted by an ISM policy.  Default is
+	// the retention of the verrazzano-logs-<class name> indices set by the OpenSearch policies.
+	// +kubebuilder:validation:Pattern:=^[0-9]+(d|h|m|s|ms|micros|nanos)$
+	// +optional
+	Retention *string `json:"retention,omitempty"`
+}
+
+// FluentdOutput specifies an additional Fluentd output, which receives the log records in parallel with the
+// OpenSearch or OCI Logging output, except the records of the namespaces with a log routing class.  Exactly one of Kafka, Syslog or S3 must be specified.
+type FluentdOutput struct {
+	// Name of the output, which is used in the names of its Fluentd label and buffer
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// Namespaces whose log records are routed to the output.  Default is the records of all the namespaces and the
+	// systemd journal
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// +optional
+	Kafka *FluentdKafkaOutput `json:"kafka,omitempty"`
+	// +optional
+	Syslog *FluentdSyslogOutput `json:"syslog,omitempty"`
+	// +optional
+	S3 *FluentdS3Output `json:"s3,omitempty"`
+}
+
+// FluentdKafkaOutput specifies a Kafka output, which sends the log records in JSON format to a topic
+type FluentdKafkaOutput struct {
+	// Brokers in the host:port format
+	Brokers []string `json:"brokers"`
+	// Topic of the log records
+	Topic string `json:"topic"`
+	// Name of a secret in the verrazzano-install namespace with the username, password and ca-bundle entries, which
+	// are used for SASL authentication over TLS.  Default is a plain text connection without authentication
+	// +optional
+	Secret string `json:"secret,omitempty"`
+}
+
+// FluentdSyslogOutput specifies a syslog output, which sends the log records in RFC5424 format over TLS
+type FluentdSyslogOutput struct {
+	// Host of the syslog server
+	Host string `json:"host"`
+	// Port of the syslog server.  Default is 6514
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// Name of a secret in the verrazzano-install namespace with the ca-bundle entry, which is used to verify the
+	// certificate of the syslog server.  Default is the CA certificates of the Fluentd image
+	// +optional
+	Secret string `json:"secret,omitempty"`
+}
+
+// FluentdS3Output specifies an S3 compatible object storage output, which stores the log records in JSON format
+type FluentdS3Output struct {
+	// Endpoint of the object storage, such as https://<namespace>.compat.objectstorage.<region>.oraclecloud.com
+	Endpoint string `json:"endpoint"`
+	// Region of the object storage
+	Region string `json:"region"`
+	// Bucket of the log records
+	Bucket string `json:"bucket"`
+	// Path prefix of the objects
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Name of a secret in the verrazzano-install namespace with the access_key_id and secret_access_key entries
+	Secret string `json:"secret"`
 }
 
 // WebLogicOperatorComponent specifies the WebLogic Operator configuration
@@ -777,6 +1297,18 @@ type ProviderType string
 const (
 	// LetsEncrypt is a Let's Encrypt provider
 	LetsEncrypt ProviderType = "LetsEncrypt"
+	// GenericACME is an ACME provider with a custom directory URL
+	GenericACME ProviderType = "ACME"
+)
+
+// AcmeSolverType identifies the type of challenge solver of the ACME cert issuer.
+type AcmeSolverType string
+
+const (
+	// AcmeSolverDNS01 solves DNS-01 challenges with OCI DNS
+	AcmeSolverDNS01 AcmeSolverType = "DNS01"
+	// AcmeSolverHTTP01 solves HTTP-01 challenges with the Verrazzano ingress controller
+	AcmeSolverHTTP01 AcmeSolverType = "HTTP01"
 )
 
 // Acme identifies the ACME cert issuer.
@@ -789,6 +1321,30 @@ type Acme struct {
 	// environment
 	// +optional
 	Environment string `json:"environment,omitempty"`
+	// URL of the ACME directory, required for the ACME provider
+	// +optional
+	DirectoryURL string `json:"directoryURL,omitempty"`
+	// Name of the secret in the cert-manager namespace with the CA bundle, in the ca.crt key, that is trusted for the
+	// TLS connections to the ACME server
+	// +optional
+	CABundleSecret string `json:"caBundleSecret,omitempty"`
+	// External account binding of the ACME account, for providers that require it
+	// +optional
+	ExternalAccountBinding *AcmeExternalAccountBinding `json:"externalAccountBinding,omitempty"`
+	// Type of challenge solver, DNS01 or HTTP01, defaults to DNS01
+	// +optional
+	Solver AcmeSolverType `json:"solver,omitempty"`
+}
+
+// AcmeExternalAccountBinding identifies the external account of the ACME account with the ACME provider.
+type AcmeExternalAccountBinding struct {
+	// Key ID of the external account
+	KeyID string `json:"keyID"`
+	// Name of the secret in the cert-manager namespace with the MAC key of the external account
+	KeySecretName string `json:"keySecretName"`
+	// Key of the MAC key in the secret, defaults to secret
+	// +optional
+	KeySecretKey string `json:"keySecretKey,omitempty"`
 }
 
 // CA identifies the CA cert issuer.
@@ -808,6 +1364,79 @@ type Certificate struct {
 	// CA cert issuer
 	// +optional
 	CA CA `json:"ca,omitempty"`
+	// HashiCorp Vault cert issuer
+	// +optional
+	Vault *VaultIssuer `json:"vault,omitempty"`
+	// Existing cert-manager Issuer or ClusterIssuer, the Verrazzano ClusterIssuer is configured with the spec of the
+	// issuer and is kept in sync when the spec of the issuer changes
+	// +optional
+	IssuerRef *IssuerReference `json:"issuerRef,omitempty"`
+}
+
+// VaultIssuer identifies the HashiCorp Vault cert issuer, which issues the certificates with the Vault PKI secrets engine.
+type VaultIssuer struct {
+	// URL of the Vault server
+	Server string `json:"server"`
+	// Path of the signing endpoint of the PKI secrets engine, for example pki_int/sign/verrazzano
+	Path string `json:"path"`
+	// Vault namespace, for Vault Enterprise
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the secret in the cert-manager namespace with the CA bundle, in the ca.crt key, that is trusted for the
+	// TLS connections to the Vault server
+	// +optional
+	CABundleSecret string `json:"caBundleSecret,omitempty"`
+	// Authentication with the Vault server
+	Auth VaultAuth `json:"auth"`
+}
+
+// VaultAuth identifies the method of authentication with the Vault server.
+// Only one of its members may be specified.
+type VaultAuth struct {
+	// Name of the secret in the cert-manager namespace with a Vault token, in the token key
+	// +optional
+	TokenSecret string `json:"tokenSecret,omitempty"`
+	// AppRole authentication
+	// +optional
+	AppRole *VaultAppRoleAuth `json:"appRole,omitempty"`
+	// Kubernetes service account authentication
+	// +optional
+	Kubernetes *VaultKubernetesAuth `json:"kubernetes,omitempty"`
+}
+
+// VaultAppRoleAuth identifies the AppRole for the authentication with the Vault server.
+type VaultAppRoleAuth struct {
+	// Path where the AppRole authentication method is mounted, defaults to approle
+	// +optional
+	Path string `json:"path,omitempty"`
+	// ID of the role
+	RoleID string `json:"roleID"`
+	// Name of the secret in the cert-manager namespace with the secret ID of the role, in the secretId key
+	SecretName string `json:"secretName"`
+}
+
+// VaultKubernetesAuth identifies the Vault role for the authentication with a Kubernetes service account token.
+type VaultKubernetesAuth struct {
+	// Path where the Kubernetes authentication method is mounted, defaults to /v1/auth/kubernetes
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+	// Vault role of the service account
+	Role string `json:"role"`
+	// Name of the secret in the cert-manager namespace with the service account token, in the token key
+	SecretName string `json:"secretName"`
+}
+
+// IssuerReference identifies an existing cert-manager Issuer or ClusterIssuer.
+type IssuerReference struct {
+	// Name of the issuer
+	Name string `json:"name"`
+	// Kind of the issuer, ClusterIssuer or Issuer, defaults to ClusterIssuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Namespace of an Issuer, where the secrets referenced by the Issuer are located.  The secrets are copied to the
+	// cert-manager namespace for the Verrazzano ClusterIssuer.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // OciPrivateKeyFileName is the private key file name
@@ -837,6 +1466,26 @@ type External struct {
 	Suffix string `json:"suffix"`
 }
 
+// RFC2136 DNS type, the DNS records are created with dynamic updates (RFC2136) that are signed with a TSIG key
+type RFC2136 struct {
+	// Host name or IP address of the DNS server
+	Host string `json:"host"`
+	// Port of the DNS server, defaults to 53
+	// +optional
+	Port int `json:"port,omitempty"`
+	// DNS zone that is updated, the zone name is appended to EnvironmentName to form DNS name
+	DNSZoneName string `json:"dnsZoneName"`
+	// Name of the TSIG key, the dynamic updates are not signed if no TSIG key is specified
+	// +optional
+	TSIGKeyName string `json:"tsigKeyName,omitempty"`
+	// Algorithm of the TSIG key, defaults to hmac-sha256
+	// +optional
+	TSIGAlgorithm string `json:"tsigAlgorithm,omitempty"`
+	// Name of the secret in the verrazzano-install namespace with the TSIG secret in the tsigSecret key
+	// +optional
+	TSIGSecret string `json:"tsigSecret,omitempty"`
+}
+
 // IngressType is the type of ingress.
 type IngressType string
 
@@ -862,4 +1511,7 @@ type Overrides struct {
 	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
 	SecretRef    *corev1.SecretKeySelector    `json:"secretRef,omitempty"`
 	Values       *apiextensionsv1.JSON        `json:"values,omitempty"`
+	// Template renders the override values as a Go template with the cluster facts, for example {{ .DNSDomain }},
+	// so that the same values can be shared across environments
+	Template bool `json:"template,omitempty"`
 }
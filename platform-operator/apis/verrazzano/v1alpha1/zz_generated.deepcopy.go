@@ -13,12 +13,18 @@ import (
 	"k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Acme) DeepCopyInto(out *Acme) {
 	*out = *in
+	if in.ExternalAccountBinding != nil {
+		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
+		*out = new(AcmeExternalAccountBinding)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Acme.
@@ -31,6 +37,21 @@ func (in *Acme) DeepCopy() *Acme {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AcmeExternalAccountBinding) DeepCopyInto(out *AcmeExternalAccountBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcmeExternalAccountBinding.
+func (in *AcmeExternalAccountBinding) DeepCopy() *AcmeExternalAccountBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(AcmeExternalAccountBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationOperatorComponent) DeepCopyInto(out *ApplicationOperatorComponent) {
 	*out = *in
@@ -112,7 +133,7 @@ func (in *CA) DeepCopy() *CA {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertManagerComponent) DeepCopyInto(out *CertManagerComponent) {
 	*out = *in
-	out.Certificate = in.Certificate
+	in.Certificate.DeepCopyInto(&out.Certificate)
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
@@ -134,8 +155,18 @@ func (in *CertManagerComponent) DeepCopy() *CertManagerComponent {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Certificate) DeepCopyInto(out *Certificate) {
 	*out = *in
-	out.Acme = in.Acme
+	in.Acme.DeepCopyInto(&out.Acme)
 	out.CA = in.CA
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultIssuer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(IssuerReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Certificate.
@@ -148,6 +179,29 @@ func (in *Certificate) DeepCopy() *Certificate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
+	*out = *in
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.RenewalTime != nil {
+		in, out := &in.RenewalTime, &out.RenewalTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CoherenceOperatorComponent) DeepCopyInto(out *CoherenceOperatorComponent) {
 	*out = *in
@@ -352,6 +406,11 @@ func (in *ComponentStatusDetails) DeepCopyInto(out *ComponentStatusDetails) {
 		*out = make([]Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReadinessReport != nil {
+		in, out := &in.ReadinessReport, &out.ReadinessReport
+		*out = new(ReadinessReport)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatusDetails.
@@ -429,6 +488,60 @@ func (in *ConsoleComponent) DeepCopy() *ConsoleComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerReadiness) DeepCopyInto(out *ContainerReadiness) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerReadiness.
+func (in *ContainerReadiness) DeepCopy() *ContainerReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialRotationStatus) DeepCopyInto(out *CredentialRotationStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotationStatus.
+func (in *CredentialRotationStatus) DeepCopy() *CredentialRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsStore) DeepCopyInto(out *CredentialsStore) {
+	*out = *in
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultCredentialsStore)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsStore.
+func (in *CredentialsStore) DeepCopy() *CredentialsStore {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSComponent) DeepCopyInto(out *DNSComponent) {
 	*out = *in
@@ -447,6 +560,11 @@ func (in *DNSComponent) DeepCopyInto(out *DNSComponent) {
 		*out = new(External)
 		**out = **in
 	}
+	if in.RFC2136 != nil {
+		in, out := &in.RFC2136, &out.RFC2136
+		*out = new(RFC2136)
+		**out = **in
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -551,6 +669,20 @@ func (in *FluentdComponent) DeepCopyInto(out *FluentdComponent) {
 		*out = new(OciLoggingConfiguration)
 		**out = **in
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]FluentdOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LogRoutingClasses != nil {
+		in, out := &in.LogRoutingClasses, &out.LogRoutingClasses
+		*out = make([]LogRoutingClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
 }
 
@@ -564,6 +696,91 @@ func (in *FluentdComponent) DeepCopy() *FluentdComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentdKafkaOutput) DeepCopyInto(out *FluentdKafkaOutput) {
+	*out = *in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdKafkaOutput.
+func (in *FluentdKafkaOutput) DeepCopy() *FluentdKafkaOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentdKafkaOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentdOutput) DeepCopyInto(out *FluentdOutput) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(FluentdKafkaOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Syslog != nil {
+		in, out := &in.Syslog, &out.Syslog
+		*out = new(FluentdSyslogOutput)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(FluentdS3Output)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdOutput.
+func (in *FluentdOutput) DeepCopy() *FluentdOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentdOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentdS3Output) DeepCopyInto(out *FluentdS3Output) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdS3Output.
+func (in *FluentdS3Output) DeepCopy() *FluentdS3Output {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentdS3Output)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentdSyslogOutput) DeepCopyInto(out *FluentdSyslogOutput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdSyslogOutput.
+func (in *FluentdSyslogOutput) DeepCopy() *FluentdSyslogOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentdSyslogOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrafanaComponent) DeepCopyInto(out *GrafanaComponent) {
 	*out = *in
@@ -594,6 +811,26 @@ func (in *GrafanaComponent) DeepCopy() *GrafanaComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmDriftSpec) DeepCopyInto(out *HelmDriftSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmDriftSpec.
+func (in *HelmDriftSpec) DeepCopy() *HelmDriftSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmDriftSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressNginxComponent) DeepCopyInto(out *IngressNginxComponent) {
 	*out = *in
@@ -741,6 +978,21 @@ func (in *InstanceInfo) DeepCopy() *InstanceInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerReference) DeepCopyInto(out *IssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerReference.
+func (in *IssuerReference) DeepCopy() *IssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IstioComponent) DeepCopyInto(out *IstioComponent) {
 	*out = *in
@@ -762,6 +1014,13 @@ func (in *IstioComponent) DeepCopyInto(out *IstioComponent) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IngressGateways != nil {
+		in, out := &in.IngressGateways, &out.IngressGateways
+		*out = make([]IstioIngressGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Ingress != nil {
 		in, out := &in.Ingress, &out.Ingress
 		*out = new(IstioIngressSection)
@@ -804,6 +1063,35 @@ func (in *IstioEgressSection) DeepCopy() *IstioEgressSection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioIngressGateway) DeepCopyInto(out *IstioIngressGateway) {
+	*out = *in
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]v1.ServicePort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioIngressGateway.
+func (in *IstioIngressGateway) DeepCopy() *IstioIngressGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(IstioIngressGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IstioIngressSection) DeepCopyInto(out *IstioIngressSection) {
 	*out = *in
@@ -868,6 +1156,31 @@ func (in *JaegerOperatorComponent) DeepCopy() *JaegerOperatorComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakClient) DeepCopyInto(out *KeycloakClient) {
+	*out = *in
+	if in.RedirectURIs != nil {
+		in, out := &in.RedirectURIs, &out.RedirectURIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WebOrigins != nil {
+		in, out := &in.WebOrigins, &out.WebOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClient.
+func (in *KeycloakClient) DeepCopy() *KeycloakClient {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClient)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakComponent) DeepCopyInto(out *KeycloakComponent) {
 	*out = *in
@@ -885,6 +1198,11 @@ func (in *KeycloakComponent) DeepCopyInto(out *KeycloakComponent) {
 		**out = **in
 	}
 	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+	if in.Realm != nil {
+		in, out := &in.Realm, &out.Realm
+		*out = new(KeycloakRealmConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakComponent.
@@ -897,6 +1215,187 @@ func (in *KeycloakComponent) DeepCopy() *KeycloakComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakGroup) DeepCopyInto(out *KeycloakGroup) {
+	*out = *in
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakGroup.
+func (in *KeycloakGroup) DeepCopy() *KeycloakGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakIdentityProvider) DeepCopyInto(out *KeycloakIdentityProvider) {
+	*out = *in
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(KeycloakOIDCProvider)
+		**out = **in
+	}
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(KeycloakLDAPProvider)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakIdentityProvider.
+func (in *KeycloakIdentityProvider) DeepCopy() *KeycloakIdentityProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakIdentityProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakLDAPProvider) DeepCopyInto(out *KeycloakLDAPProvider) {
+	*out = *in
+	if in.UserObjectClasses != nil {
+		in, out := &in.UserObjectClasses, &out.UserObjectClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakLDAPProvider.
+func (in *KeycloakLDAPProvider) DeepCopy() *KeycloakLDAPProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakLDAPProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakOIDCProvider) DeepCopyInto(out *KeycloakOIDCProvider) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakOIDCProvider.
+func (in *KeycloakOIDCProvider) DeepCopy() *KeycloakOIDCProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakOIDCProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmConfig) DeepCopyInto(out *KeycloakRealmConfig) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]KeycloakRole, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]KeycloakGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]KeycloakClient, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IdentityProviders != nil {
+		in, out := &in.IdentityProviders, &out.IdentityProviders
+		*out = make([]KeycloakIdentityProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmConfig.
+func (in *KeycloakRealmConfig) DeepCopy() *KeycloakRealmConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmObjectStatus) DeepCopyInto(out *KeycloakRealmObjectStatus) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmObjectStatus.
+func (in *KeycloakRealmObjectStatus) DeepCopy() *KeycloakRealmObjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmObjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmStatus) DeepCopyInto(out *KeycloakRealmStatus) {
+	*out = *in
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]KeycloakRealmObjectStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmStatus.
+func (in *KeycloakRealmStatus) DeepCopy() *KeycloakRealmStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRole) DeepCopyInto(out *KeycloakRole) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRole.
+func (in *KeycloakRole) DeepCopy() *KeycloakRole {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KialiComponent) DeepCopyInto(out *KialiComponent) {
 	*out = *in
@@ -964,6 +1463,26 @@ func (in *KubeStateMetricsComponent) DeepCopy() *KubeStateMetricsComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogRoutingClass) DeepCopyInto(out *LogRoutingClass) {
+	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogRoutingClass.
+func (in *LogRoutingClass) DeepCopy() *LogRoutingClass {
+	if in == nil {
+		return nil
+	}
+	out := new(LogRoutingClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MySQLComponent) DeepCopyInto(out *MySQLComponent) {
 	*out = *in
@@ -1139,6 +1658,26 @@ func (in *Overrides) DeepCopy() *Overrides {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodReadiness) DeepCopyInto(out *PodReadiness) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ContainerReadiness, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodReadiness.
+func (in *PodReadiness) DeepCopy() *PodReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(PodReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrometheusAdapterComponent) DeepCopyInto(out *PrometheusAdapterComponent) {
 	*out = *in
@@ -1243,6 +1782,21 @@ func (in *PrometheusPushgatewayComponent) DeepCopy() *PrometheusPushgatewayCompo
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RFC2136) DeepCopyInto(out *RFC2136) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RFC2136.
+func (in *RFC2136) DeepCopy() *RFC2136 {
+	if in == nil {
+		return nil
+	}
+	out := new(RFC2136)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RancherBackupComponent) DeepCopyInto(out *RancherBackupComponent) {
 	*out = *in
@@ -1290,6 +1844,28 @@ func (in *RancherComponent) DeepCopy() *RancherComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessReport) DeepCopyInto(out *ReadinessReport) {
+	*out = *in
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]WorkloadReadiness, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessReport.
+func (in *ReadinessReport) DeepCopy() *ReadinessReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecuritySpec) DeepCopyInto(out *SecuritySpec) {
 	*out = *in
@@ -1303,6 +1879,11 @@ func (in *SecuritySpec) DeepCopyInto(out *SecuritySpec) {
 		*out = make([]rbacv1.Subject, len(*in))
 		copy(*out, *in)
 	}
+	if in.CredentialsStore != nil {
+		in, out := &in.CredentialsStore, &out.CredentialsStore
+		*out = new(CredentialsStore)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuritySpec.
@@ -1315,6 +1896,92 @@ func (in *SecuritySpec) DeepCopy() *SecuritySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAppRoleAuth) DeepCopyInto(out *VaultAppRoleAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAppRoleAuth.
+func (in *VaultAppRoleAuth) DeepCopy() *VaultAppRoleAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAppRoleAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuth) DeepCopyInto(out *VaultAuth) {
+	*out = *in
+	if in.AppRole != nil {
+		in, out := &in.AppRole, &out.AppRole
+		*out = new(VaultAppRoleAuth)
+		**out = **in
+	}
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(VaultKubernetesAuth)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuth.
+func (in *VaultAuth) DeepCopy() *VaultAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultCredentialsStore) DeepCopyInto(out *VaultCredentialsStore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultCredentialsStore.
+func (in *VaultCredentialsStore) DeepCopy() *VaultCredentialsStore {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultCredentialsStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultIssuer) DeepCopyInto(out *VaultIssuer) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultIssuer.
+func (in *VaultIssuer) DeepCopy() *VaultIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKubernetesAuth) DeepCopyInto(out *VaultKubernetesAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubernetesAuth.
+func (in *VaultKubernetesAuth) DeepCopy() *VaultKubernetesAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultKubernetesAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VeleroComponent) DeepCopyInto(out *VeleroComponent) {
 	*out = *in
@@ -1440,6 +2107,11 @@ func (in *VerrazzanoSpec) DeepCopyInto(out *VerrazzanoSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HelmDrift != nil {
+		in, out := &in.HelmDrift, &out.HelmDrift
+		*out = new(HelmDriftSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerrazzanoSpec.
@@ -1480,6 +2152,33 @@ func (in *VerrazzanoStatus) DeepCopyInto(out *VerrazzanoStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.CredentialRotations != nil {
+		in, out := &in.CredentialRotations, &out.CredentialRotations
+		*out = make(map[string]*CredentialRotationStatus, len(*in))
+		for key, val := range *in {
+			var outVal *CredentialRotationStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(CredentialRotationStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KeycloakRealm != nil {
+		in, out := &in.KeycloakRealm, &out.KeycloakRealm
+		*out = new(KeycloakRealmStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerrazzanoStatus.
@@ -1564,3 +2263,25 @@ func (in *Wildcard) DeepCopy() *Wildcard {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadReadiness) DeepCopyInto(out *WorkloadReadiness) {
+	*out = *in
+	if in.UnreadyPods != nil {
+		in, out := &in.UnreadyPods, &out.UnreadyPods
+		*out = make([]PodReadiness, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadReadiness.
+func (in *WorkloadReadiness) DeepCopy() *WorkloadReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -9,17 +9,34 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/validators"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
 )
 
-// ValidateProfile check that requestedProfile is valid
+// UserProfileValidator validates that a user profile exists, and that the profiles it extends exist
+// +kubebuilder:object:generate=false
+type UserProfileValidator func(profile string) error
+
+var userProfileValidator UserProfileValidator = nil
+
+// SetUserProfileValidator sets the validator of user profiles, user profiles are invalid if there is no validator
+func SetUserProfileValidator(v UserProfileValidator) {
+	userProfileValidator = v
+}
+
+// ValidateProfile check that requestedProfile is valid, the requested profile is a comma separated list of profiles
 func ValidateProfile(requestedProfile ProfileType) error {
-	if len(requestedProfile) != 0 {
-		switch requestedProfile {
-		case Prod, Dev, ManagedCluster:
-			return nil
-		default:
+	for _, profile := range strings.Split(string(requestedProfile), ",") {
+		profile = strings.TrimSpace(profile)
+		switch ProfileType(profile) {
+		case "", Prod, Dev, ManagedCluster:
+			continue
+		}
+		if userProfileValidator == nil {
 			return fmt.Errorf("Requested profile %s is invalid, valid options are dev, prod, or managed-cluster",
-				requestedProfile)
+				profile)
+		}
+		if err := userProfileValidator(profile); err != nil {
+			return fmt.Errorf("Requested profile %s is invalid: %v", profile, err)
 		}
 	}
 	return nil
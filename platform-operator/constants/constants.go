@@ -213,6 +213,10 @@ const StorageForLabel = "verrazzano.io/storage-for"
 // PrometheusStorageLabelValue is the label value for Prometheus storage
 const PrometheusStorageLabelValue = "prometheus"
 
+// ProfileLabel is the label of the ConfigMaps in the verrazzano-install namespace that define user profiles, the label
+// value is the name of the profile
+const ProfileLabel = "install.verrazzano.io/profile"
+
 // VMISystemPrometheusVolumeClaim is the name of the VMO-managed Prometheus persistent volume claim
 const VMISystemPrometheusVolumeClaim = "vmi-system-prometheus"
 
@@ -109,7 +109,8 @@ func (r *VerrazzanoManagedClusterReconciler) getJaegerSpecConfig(vzList *vzapi.V
 			jsc.storageType = "elasticsearch"
 		}
 		overrides := vz.Spec.Components.JaegerOperator.ValueOverrides
-		overrideYAMLs, err := common.GetInstallOverridesYAMLUsingClient(r.Client, vzapi.ConvertValueOverridesToV1Beta1(overrides), jaegerNamespace)
+		overrideYAMLs, err := common.GetInstallOverridesYAMLUsingFacts(r.Client, vzapi.ConvertValueOverridesToV1Beta1(overrides), jaegerNamespace,
+			common.NewClusterFacts(r.Client, &vz))
 		if err != nil {
 			return jsc, err
 		}
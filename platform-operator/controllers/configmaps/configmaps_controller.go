@@ -22,6 +22,7 @@ import (
 	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
 
 	"go.uber.org/zap"
 )
@@ -48,6 +49,16 @@ func (r *VerrazzanoConfigMapsReconciler) Reconcile(ctx context.Context, req ctrl
 		ctx = context.TODO()
 	}
 
+	// Refresh the user profiles, since the ConfigMap may be a profile ConfigMap
+	if req.Namespace == constants.VerrazzanoInstallNamespace {
+		profiles, err := transform.ListUserProfiles(r.Client)
+		if err != nil {
+			zap.S().Errorf("Failed to list the profile ConfigMaps: %v", err)
+			return newRequeueWithDelay(), err
+		}
+		transform.SetUserProfiles(profiles)
+	}
+
 	// Get Verrazzano from the cluster
 	vzList := &installv1alpha1.VerrazzanoList{}
 	err := r.List(ctx, vzList)
@@ -15,6 +15,7 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/mocks"
 
@@ -54,6 +55,34 @@ func TestConfigMapReconciler(t *testing.T) {
 	asserts.Equal(int64(1), vz.Status.Components["prometheus-operator"].ReconcilingGeneration)
 }
 
+// TestProfileConfigMapReconciler tests the Reconcile loop for the following use case
+// GIVEN a request to reconcile a profile ConfigMap in the verrazzano-install namespace
+// WHEN the ConfigMap defines a user profile that extends a built-in profile
+// THEN the user profile can be resolved
+func TestProfileConfigMapReconciler(t *testing.T) {
+	asserts := assert.New(t)
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "edge-profile",
+			Namespace: constants.VerrazzanoInstallNamespace,
+			Labels:    map[string]string{constants.ProfileLabel: "edge"},
+		},
+		Data: map[string]string{"v1alpha1.yaml": "extends: dev\nspec:\n  environmentName: edge\n"},
+	}
+	cli := fake.NewClientBuilder().WithObjects(&cm).WithScheme(newScheme()).Build()
+
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	defer transform.SetUserProfiles(transform.UserProfiles{})
+
+	asserts.Error(transform.ValidateV1alpha1Profile("edge"))
+	reconciler := newConfigMapReconciler(cli)
+	_, err := reconciler.Reconcile(context.TODO(), newRequest(constants.VerrazzanoInstallNamespace, cm.Name))
+	asserts.NoError(err)
+	asserts.NoError(transform.ValidateV1alpha1Profile("edge"))
+	asserts.Error(transform.ValidateV1beta1Profile("edge"))
+}
+
 // TestAddFinalizer tests the Reconcile loop for the following use case
 // GIVEN a request to reconcile a ConfigMap that qualifies as an override
 // WHEN the ConfigMap is found without the overrides finalizer
@@ -0,0 +1,291 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	certapiv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	vzconst "github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
+	"github.com/verrazzano/verrazzano/platform-operator/metricsexporter"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// certExpiryCheckInterval is how often the certificates are checked for expiry
+	certExpiryCheckInterval = 10 * time.Minute
+
+	// certExpiringSoonThreshold is how long before a certificate expires it is reported as expiring soon, which is
+	// well after cert-manager should have renewed it
+	certExpiringSoonThreshold = 14 * 24 * time.Hour
+
+	// appCertificatesComponent is the component reported for the certificates of the application ingresses
+	appCertificatesComponent = "application"
+
+	// maxExpiringCertsReported limits the number of certificates included in the condition message
+	maxExpiringCertsReported = 10
+)
+
+// certExpiryChecked keeps track of the last time the certificates of each Verrazzano resource were checked for expiry
+var certExpiryChecked = make(map[string]time.Time)
+var certExpiryCheckedMutex sync.Mutex
+
+// certExpiryMetricSeries keeps track of the series of the certificate expiry metric, keyed by the certificate and
+// holding the component label, so that the series of deleted certificates are removed
+var certExpiryMetricSeries = make(map[string]string)
+var certExpiryMetricSeriesMutex sync.Mutex
+
+// checkCertificateExpiry tracks the expiry of the certificates of the enabled components and of the application
+// ingresses, at most once per check interval.  The certificates are recorded in the Verrazzano CR status, the days until
+// they expire are reported in a metric, and the certificates that expire soon are reported in the
+// CertificatesExpiringSoon condition and in events of the Verrazzano CR, as are the renewed certificates.
+func (r *Reconciler) checkCertificateExpiry(vzctx vzcontext.VerrazzanoContext) error {
+	cr := vzctx.ActualCR
+	log := vzctx.Log
+	if r.DryRun || !vzconfig.IsCertManagerEnabled(cr) {
+		return nil
+	}
+	if !isCertExpiryCheckDue(cr.Namespace + "/" + cr.Name) {
+		return nil
+	}
+
+	spiCtx, err := spi.NewContext(log, r.Client, cr, nil, r.DryRun)
+	if err != nil {
+		return err
+	}
+	certificates, err := r.getCertificateStatuses(spiCtx)
+	if err != nil {
+		// Failing to check the certificates does not block the reconcile, they are checked again after the interval
+		log.Errorf("Failed to check the certificates for expiry: %v", err)
+		return nil
+	}
+
+	now := time.Now()
+	setCertificateExpiryMetrics(log, certificates, now)
+	r.recordCertificateEvents(cr, cr.Status.Certificates, certificates, now)
+
+	changed := setCertificatesExpiringSoonCondition(cr, certificates, now)
+	if !reflect.DeepEqual(cr.Status.Certificates, certificates) {
+		cr.Status.Certificates = certificates
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return r.updateVerrazzanoStatus(log, cr)
+}
+
+// certExpiryRequeue returns the result that requeues the Verrazzano CR to check the certificates for expiry, unless the
+// given result already requeues it sooner
+func certExpiryRequeue(cr *installv1alpha1.Verrazzano, result ctrl.Result) ctrl.Result {
+	if !vzconfig.IsCertManagerEnabled(cr) {
+		return result
+	}
+	if result.RequeueAfter == 0 || result.RequeueAfter > certExpiryCheckInterval {
+		result.RequeueAfter = certExpiryCheckInterval
+	}
+	return result
+}
+
+// getCertificateStatuses returns the status of the certificates of the enabled components, and of the certificates
+// of the application ingresses in the istio-system namespace, sorted by namespace and name
+func (r *Reconciler) getCertificateStatuses(ctx spi.ComponentContext) ([]installv1alpha1.CertificateStatus, error) {
+	var certificates []installv1alpha1.CertificateStatus
+	found := map[string]bool{}
+	for _, comp := range registry.GetComponents() {
+		if !comp.IsEnabled(ctx.EffectiveCR()) {
+			continue
+		}
+		for _, name := range comp.GetCertificateNames(ctx) {
+			cert := certapiv1.Certificate{}
+			if err := r.Get(context.TODO(), name, &cert); err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			found[name.String()] = true
+			certificates = append(certificates, newCertificateStatus(comp.Name(), &cert))
+		}
+	}
+
+	// The certificates of the application ingresses are created in the istio-system namespace
+	certList := certapiv1.CertificateList{}
+	if err := r.List(context.TODO(), &certList, client.InNamespace(vzconst.IstioSystemNamespace)); err != nil {
+		return nil, err
+	}
+	for i := range certList.Items {
+		cert := &certList.Items[i]
+		if found[client.ObjectKeyFromObject(cert).String()] {
+			continue
+		}
+		certificates = append(certificates, newCertificateStatus(appCertificatesComponent, cert))
+	}
+
+	sort.Slice(certificates, func(i, j int) bool {
+		if certificates[i].Namespace != certificates[j].Namespace {
+			return certificates[i].Namespace < certificates[j].Namespace
+		}
+		return certificates[i].Name < certificates[j].Name
+	})
+	return certificates, nil
+}
+
+// newCertificateStatus returns the status of a cert-manager certificate
+func newCertificateStatus(component string, cert *certapiv1.Certificate) installv1alpha1.CertificateStatus {
+	status := installv1alpha1.CertificateStatus{
+		Component:   component,
+		Namespace:   cert.Namespace,
+		Name:        cert.Name,
+		NotAfter:    cert.Status.NotAfter,
+		RenewalTime: cert.Status.RenewalTime,
+	}
+	for _, condition := range cert.Status.Conditions {
+		if condition.Type == certapiv1.CertificateConditionReady {
+			status.Ready = condition.Status == cmmeta.ConditionTrue
+		}
+	}
+	return status
+}
+
+// isExpiringSoon returns true if a certificate expires within the expiring soon threshold
+func isExpiringSoon(certificate installv1alpha1.CertificateStatus, now time.Time) bool {
+	return certificate.NotAfter != nil && certificate.NotAfter.Time.Sub(now) < certExpiringSoonThreshold
+}
+
+// isCertExpiryCheckDue returns true if the certificates of the Verrazzano resource were not checked for expiry during
+// the check interval, and records the time of the check
+func isCertExpiryCheckDue(key string) bool {
+	certExpiryCheckedMutex.Lock()
+	defer certExpiryCheckedMutex.Unlock()
+	if lastChecked, ok := certExpiryChecked[key]; ok && time.Since(lastChecked) < certExpiryCheckInterval {
+		return false
+	}
+	certExpiryChecked[key] = time.Now()
+	return true
+}
+
+// deleteCertExpiryChecked forgets the last check of the certificates of a deleted Verrazzano resource
+func deleteCertExpiryChecked(key string) {
+	certExpiryCheckedMutex.Lock()
+	defer certExpiryCheckedMutex.Unlock()
+	delete(certExpiryChecked, key)
+}
+
+// setCertificateExpiryMetrics sets the certificate expiry metric of each certificate, and removes the series of the
+// certificates that no longer exist
+func setCertificateExpiryMetrics(log vzlog.VerrazzanoLogger, certificates []installv1alpha1.CertificateStatus, now time.Time) {
+	expiryMetric, err := metricsexporter.GetComponentGaugeMetric(metricsexporter.CertificateExpiryDays)
+	if err != nil {
+		log.Errorf("Failed to get the certificate expiry metric: %v", err)
+		return
+	}
+	certExpiryMetricSeriesMutex.Lock()
+	defer certExpiryMetricSeriesMutex.Unlock()
+	current := map[string]string{}
+	for _, certificate := range certificates {
+		if certificate.NotAfter == nil {
+			continue
+		}
+		name := certificate.Namespace + "/" + certificate.Name
+		current[name] = certificate.Component
+		expiryMetric.SetLabelled(certificate.Component, name, certificate.NotAfter.Time.Sub(now).Hours()/24)
+	}
+	for name, component := range certExpiryMetricSeries {
+		if current[name] != component {
+			expiryMetric.DeleteLabelled(component, name)
+		}
+	}
+	certExpiryMetricSeries = current
+}
+
+// recordCertificateEvents records a warning event on the Verrazzano CR for each certificate that started to expire
+// soon, and an event for each certificate that was renewed since the last check
+func (r *Reconciler) recordCertificateEvents(cr *installv1alpha1.Verrazzano, previous []installv1alpha1.CertificateStatus, certificates []installv1alpha1.CertificateStatus, now time.Time) {
+	if r.EventRecorder == nil {
+		return
+	}
+	previousByName := map[string]installv1alpha1.CertificateStatus{}
+	for _, certificate := range previous {
+		previousByName[certificate.Namespace+"/"+certificate.Name] = certificate
+	}
+	for _, certificate := range certificates {
+		name := certificate.Namespace + "/" + certificate.Name
+		old, found := previousByName[name]
+		if isExpiringSoon(certificate, now) && (!found || !isExpiringSoon(old, now)) {
+			r.EventRecorder.Eventf(cr, corev1.EventTypeWarning, string(installv1alpha1.CondCertificatesExpiringSoon),
+				"Certificate %s of component %s expires at %s", name, certificate.Component, certificate.NotAfter.UTC().Format(time.RFC3339))
+		}
+		if found && old.NotAfter != nil && certificate.NotAfter != nil && certificate.NotAfter.After(old.NotAfter.Time) {
+			r.EventRecorder.Eventf(cr, corev1.EventTypeNormal, "CertificateRenewed",
+				"Certificate %s of component %s was renewed, it expires at %s", name, certificate.Component, certificate.NotAfter.UTC().Format(time.RFC3339))
+		}
+	}
+}
+
+// setCertificatesExpiringSoonCondition sets the CertificatesExpiringSoon condition of the Verrazzano CR, which lists the
+// certificates that expire soon.  The condition is only added once a certificate expires soon, and it is not moved to
+// the end of the conditions, since the last condition is the state of the install or upgrade.  Returns true if the
+// conditions changed.
+func setCertificatesExpiringSoonCondition(cr *installv1alpha1.Verrazzano, certificates []installv1alpha1.CertificateStatus, now time.Time) bool {
+	var expiring []string
+	for _, certificate := range certificates {
+		if isExpiringSoon(certificate, now) {
+			expiring = append(expiring, certificate.Namespace+"/"+certificate.Name)
+		}
+	}
+	status := corev1.ConditionFalse
+	message := "No certificates expire soon"
+	if len(expiring) > 0 {
+		status = corev1.ConditionTrue
+		if len(expiring) > maxExpiringCertsReported {
+			expiring = append(expiring[:maxExpiringCertsReported:maxExpiringCertsReported], "...")
+		}
+		message = fmt.Sprintf("Certificates expire within %d days: %s", int(certExpiringSoonThreshold.Hours()/24), strings.Join(expiring, ", "))
+	}
+
+	for i, condition := range cr.Status.Conditions {
+		if condition.Type != installv1alpha1.CondCertificatesExpiringSoon {
+			continue
+		}
+		if condition.Status == status && condition.Message == message {
+			return false
+		}
+		cr.Status.Conditions[i] = newCertificatesExpiringSoonCondition(status, message)
+		return true
+	}
+	if status == corev1.ConditionFalse {
+		return false
+	}
+	cr.Status.Conditions = append([]installv1alpha1.Condition{newCertificatesExpiringSoonCondition(status, message)}, cr.Status.Conditions...)
+	return true
+}
+
+func newCertificatesExpiringSoonCondition(status corev1.ConditionStatus, message string) installv1alpha1.Condition {
+	t := time.Now().UTC()
+	return installv1alpha1.Condition{
+		Type:    installv1alpha1.CondCertificatesExpiringSoon,
+		Status:  status,
+		Message: message,
+		LastTransitionTime: fmt.Sprintf("%d-%02d-%02dT%02d:%02d:%02dZ",
+			t.Year(), t.Month(), t.Day(),
+			t.Hour(), t.Minute(), t.Second()),
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	certapiv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"github.com/verrazzano/verrazzano/platform-operator/metricsexporter"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const certComponentName = "cert-component"
+
+func newCertExpiryScheme() *runtime.Scheme {
+	scheme := newScheme()
+	_ = certapiv1.AddToScheme(scheme)
+	return scheme
+}
+
+func newTestCertificate(namespace string, name string, notAfter time.Time) *certapiv1.Certificate {
+	return &certapiv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status: certapiv1.CertificateStatus{
+			NotAfter:    &metav1.Time{Time: notAfter},
+			RenewalTime: &metav1.Time{Time: notAfter.Add(-30 * 24 * time.Hour)},
+			Conditions: []certapiv1.CertificateCondition{
+				{Type: certapiv1.CertificateConditionReady, Status: cmmeta.ConditionTrue},
+			},
+		},
+	}
+}
+
+func newCertExpiryTestVerrazzano() *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+		Status: vzapi.VerrazzanoStatus{
+			State: vzapi.VzStateReady,
+			Conditions: []vzapi.Condition{
+				{Type: vzapi.CondInstallComplete, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+// TestCheckCertificateExpiry tests checking the certificates for expiry
+// GIVEN a component certificate that expires soon and an application certificate that does not
+// WHEN checkCertificateExpiry is called
+// THEN the certificates are recorded in the status and metric, the CertificatesExpiringSoon condition is added before
+// the last condition, and a warning event is recorded
+func TestCheckCertificateExpiry(t *testing.T) {
+	defer registry.ResetGetComponentsFn()
+	defer func() { certExpiryChecked = make(map[string]time.Time) }()
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+
+	registry.OverrideGetComponentsFn(func() []spi.Component {
+		return []spi.Component{
+			fakeComponent{HelmComponent: helm.HelmComponent{
+				ReleaseName:  certComponentName,
+				Certificates: []types.NamespacedName{{Namespace: "comp-ns", Name: "comp-cert"}, {Namespace: "comp-ns", Name: "missing-cert"}},
+			}},
+		}
+	})
+
+	vz := newCertExpiryTestVerrazzano()
+	compCert := newTestCertificate("comp-ns", "comp-cert", time.Now().Add(5*24*time.Hour))
+	appCert := newTestCertificate("istio-system", "app-ns-trait-cert", time.Now().Add(60*24*time.Hour))
+	c := fake.NewClientBuilder().WithScheme(newCertExpiryScheme()).WithObjects(vz, compCert, appCert).Build()
+	r := newVerrazzanoReconciler(c)
+	recorder := record.NewFakeRecorder(10)
+	r.EventRecorder = recorder
+
+	vzctx := vzcontext.VerrazzanoContext{Ctx: context.TODO(), Log: vzlog.DefaultLogger(), Client: c, ActualCR: vz}
+	assert.NoError(t, r.checkCertificateExpiry(vzctx))
+
+	updated := vzapi.Verrazzano{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, &updated))
+	assert.Len(t, updated.Status.Certificates, 2)
+	assert.Equal(t, certComponentName, updated.Status.Certificates[0].Component)
+	assert.Equal(t, "comp-cert", updated.Status.Certificates[0].Name)
+	assert.True(t, updated.Status.Certificates[0].Ready)
+	assert.Equal(t, appCertificatesComponent, updated.Status.Certificates[1].Component)
+	assert.Equal(t, "app-ns-trait-cert", updated.Status.Certificates[1].Name)
+
+	conditions := updated.Status.Conditions
+	assert.Len(t, conditions, 2)
+	assert.Equal(t, vzapi.CondCertificatesExpiringSoon, conditions[0].Type)
+	assert.Equal(t, corev1.ConditionTrue, conditions[0].Status)
+	assert.Contains(t, conditions[0].Message, "comp-ns/comp-cert")
+	assert.NotContains(t, conditions[0].Message, "app-ns-trait-cert")
+	assert.Equal(t, vzapi.CondInstallComplete, conditions[1].Type)
+
+	expiryMetric, err := metricsexporter.GetComponentGaugeMetric(metricsexporter.CertificateExpiryDays)
+	assert.NoError(t, err)
+	days := testutil.ToFloat64(expiryMetric.Get().WithLabelValues(certComponentName, "comp-ns/comp-cert"))
+	assert.InDelta(t, 5, days, 0.1)
+
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "comp-ns/comp-cert")
+
+	// The certificates are not checked again until the interval has passed
+	assert.NoError(t, c.Delete(context.TODO(), appCert))
+	assert.NoError(t, r.checkCertificateExpiry(vzctx))
+	assert.Len(t, vz.Status.Certificates, 2)
+}
+
+// TestCheckCertificateExpiryRenewed tests checking a certificate that was renewed
+// GIVEN a certificate that was recorded as expiring soon and has been renewed
+// WHEN checkCertificateExpiry is called
+// THEN the CertificatesExpiringSoon condition is set to false and a renewed event is recorded
+func TestCheckCertificateExpiryRenewed(t *testing.T) {
+	defer registry.ResetGetComponentsFn()
+	defer func() { certExpiryChecked = make(map[string]time.Time) }()
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+
+	registry.OverrideGetComponentsFn(func() []spi.Component { return []spi.Component{} })
+
+	vz := newCertExpiryTestVerrazzano()
+	vz.Status.Certificates = []vzapi.CertificateStatus{
+		{Component: appCertificatesComponent, Namespace: "istio-system", Name: "app-cert", NotAfter: &metav1.Time{Time: time.Now().Add(24 * time.Hour)}},
+	}
+	vz.Status.Conditions = append([]vzapi.Condition{newCertificatesExpiringSoonCondition(corev1.ConditionTrue, "istio-system/app-cert")}, vz.Status.Conditions...)
+	cert := newTestCertificate("istio-system", "app-cert", time.Now().Add(90*24*time.Hour))
+	c := fake.NewClientBuilder().WithScheme(newCertExpiryScheme()).WithObjects(vz, cert).Build()
+	r := newVerrazzanoReconciler(c)
+	recorder := record.NewFakeRecorder(10)
+	r.EventRecorder = recorder
+
+	vzctx := vzcontext.VerrazzanoContext{Ctx: context.TODO(), Log: vzlog.DefaultLogger(), Client: c, ActualCR: vz}
+	assert.NoError(t, r.checkCertificateExpiry(vzctx))
+
+	assert.Len(t, vz.Status.Conditions, 2)
+	assert.Equal(t, vzapi.CondCertificatesExpiringSoon, vz.Status.Conditions[0].Type)
+	assert.Equal(t, corev1.ConditionFalse, vz.Status.Conditions[0].Status)
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "CertificateRenewed")
+}
+
+// TestSetCertificatesExpiringSoonCondition tests setting the CertificatesExpiringSoon condition
+// GIVEN a Verrazzano resource
+// WHEN setCertificatesExpiringSoonCondition is called with and without certificates that expire soon
+// THEN the condition is only added once a certificate expires soon, and is updated in place afterwards
+func TestSetCertificatesExpiringSoonCondition(t *testing.T) {
+	now := time.Now()
+	expiring := []vzapi.CertificateStatus{{Namespace: "ns", Name: "cert", NotAfter: &metav1.Time{Time: now.Add(time.Hour)}}}
+	valid := []vzapi.CertificateStatus{{Namespace: "ns", Name: "cert", NotAfter: &metav1.Time{Time: now.Add(30 * 24 * time.Hour)}}}
+
+	vz := newCertExpiryTestVerrazzano()
+	assert.False(t, setCertificatesExpiringSoonCondition(vz, valid, now))
+	assert.Len(t, vz.Status.Conditions, 1)
+
+	assert.True(t, setCertificatesExpiringSoonCondition(vz, expiring, now))
+	assert.Len(t, vz.Status.Conditions, 2)
+	assert.False(t, setCertificatesExpiringSoonCondition(vz, expiring, now))
+
+	assert.True(t, setCertificatesExpiringSoonCondition(vz, valid, now))
+	assert.Len(t, vz.Status.Conditions, 2)
+	assert.Equal(t, vzapi.CondCertificatesExpiringSoon, vz.Status.Conditions[0].Type)
+	assert.Equal(t, corev1.ConditionFalse, vz.Status.Conditions[0].Status)
+	assert.Equal(t, vzapi.CondInstallComplete, vz.Status.Conditions[1].Type)
+}
+
+// TestCertExpiryRequeue tests the requeue of the Verrazzano resource to check the certificates
+// GIVEN a Verrazzano resource with cert-manager enabled or disabled
+// WHEN certExpiryRequeue is called
+// THEN the resource is requeued after the check interval if cert-manager is enabled and it is not requeued sooner
+func TestCertExpiryRequeue(t *testing.T) {
+	vz := newCertExpiryTestVerrazzano()
+	assert.Equal(t, certExpiryCheckInterval, certExpiryRequeue(vz, ctrl.Result{}).RequeueAfter)
+	assert.Equal(t, certExpiryCheckInterval, certExpiryRequeue(vz, ctrl.Result{RequeueAfter: time.Hour}).RequeueAfter)
+	assert.Equal(t, time.Minute, certExpiryRequeue(vz, ctrl.Result{RequeueAfter: time.Minute}).RequeueAfter)
+
+	disabled := false
+	vz.Spec.Components.CertManager = &vzapi.CertManagerComponent{Enabled: &disabled}
+	assert.Equal(t, time.Duration(0), certExpiryRequeue(vz, ctrl.Result{}).RequeueAfter)
+}
+
+// TestIsCertExpiryCheckDue tests tracking the certificate expiry checks from concurrent reconciles
+// GIVEN a Verrazzano resource whose certificates were not checked
+// WHEN isCertExpiryCheckDue is called concurrently, and again after deleteCertExpiryChecked is called
+// THEN the check is due for a single call, and it is due again once the last check is deleted
+func TestIsCertExpiryCheckDue(t *testing.T) {
+	defer func() { certExpiryChecked = make(map[string]time.Time) }()
+	const calls = 10
+	due := make(chan bool, calls)
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			due <- isCertExpiryCheckDue("verrazzano-install/my-verrazzano")
+		}()
+	}
+	wg.Wait()
+	close(due)
+	dueCount := 0
+	for d := range due {
+		if d {
+			dueCount++
+		}
+	}
+	assert.Equal(t, 1, dueCount)
+
+	deleteCertExpiryChecked("verrazzano-install/my-verrazzano")
+	assert.True(t, isCertExpiryCheckDue("verrazzano-install/my-verrazzano"))
+}
@@ -112,7 +112,7 @@ func isApplicationOperatorReady(ctx spi.ComponentContext) bool {
 		},
 	}
 	prefix := fmt.Sprintf("Component %s", ctx.GetComponent())
-	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), deployments, 1, prefix)
+	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), deployments, 1, prefix)
 }
 
 // Add label/annotations required by Helm to the Verrazzano installed trait definitions.  Originally, the
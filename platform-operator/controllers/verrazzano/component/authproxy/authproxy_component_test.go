@@ -4,12 +4,11 @@
 package authproxy
 
 import (
-	"fmt"
 	"testing"
 
 	helmcli "github.com/verrazzano/verrazzano/pkg/helm"
-	"github.com/verrazzano/verrazzano/pkg/os"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"helm.sh/helm/v3/pkg/release"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/stretchr/testify/assert"
@@ -204,12 +203,10 @@ func TestValidateUpdateV1beta1(t *testing.T) {
 //  WHEN I call Uninstall with the Fluentd helm chart installed
 //  THEN no error is returned
 func TestUninstallHelmChartInstalled(t *testing.T) {
-	helmcli.SetCmdRunner(os.GenericTestRunner{
-		StdOut: []byte(""),
-		StdErr: []byte{},
-		Err:    nil,
-	})
-	defer helmcli.SetDefaultRunner()
+	helmcli.SetActionConfigFunction(helmcli.NewMemoryActionConfigFunction(
+		helmcli.CreateRelease(ComponentName, ComponentNamespace, release.StatusDeployed, "1.0", nil),
+	))
+	defer helmcli.SetDefaultActionConfigFunction()
 
 	err := NewComponent().Uninstall(spi.NewFakeContext(fake.NewClientBuilder().Build(), &vzapi.Verrazzano{}, nil, false))
 	assert.NoError(t, err)
@@ -220,12 +217,8 @@ func TestUninstallHelmChartInstalled(t *testing.T) {
 //  WHEN I call Uninstall with the Fluentd helm chart not installed
 //  THEN no error is returned
 func TestUninstallHelmChartNotInstalled(t *testing.T) {
-	helmcli.SetCmdRunner(os.GenericTestRunner{
-		StdOut: []byte(""),
-		StdErr: []byte{},
-		Err:    fmt.Errorf("Not installed"),
-	})
-	defer helmcli.SetDefaultRunner()
+	helmcli.SetActionConfigFunction(helmcli.NewMemoryActionConfigFunction())
+	defer helmcli.SetDefaultActionConfigFunction()
 
 	err := NewComponent().Uninstall(spi.NewFakeContext(fake.NewClientBuilder().Build(), &vzapi.Verrazzano{}, nil, false))
 	assert.NoError(t, err)
@@ -5,7 +5,6 @@ package authproxy
 
 import (
 	"context"
-	"fmt"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"io/fs"
 	"io/ioutil"
@@ -305,12 +304,8 @@ func TestRemoveResourcePolicyAnnotation(t *testing.T) {
 //  WHEN I call Uninstall with the Fluentd helm chart not installed
 //  THEN ensure that all Fluentd resources are explicity deleted
 func TestUninstallResources(t *testing.T) {
-	helmcli.SetCmdRunner(vzos.GenericTestRunner{
-		StdOut: []byte(""),
-		StdErr: []byte{},
-		Err:    fmt.Errorf("Not installed"),
-	})
-	defer helmcli.SetDefaultRunner()
+	helmcli.SetActionConfigFunction(helmcli.NewMemoryActionConfigFunction())
+	defer helmcli.SetDefaultActionConfigFunction()
 
 	clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "impersonate-api-user"}}
 	clusterRoleBinding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "impersonate-api-user"}}
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"io"
 	"k8s.io/apimachinery/pkg/runtime"
 	"net/mail"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -36,7 +38,9 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/status"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
@@ -56,6 +60,11 @@ const (
 	caCertificateName           = "verrazzano-ca-certificate"
 	caCertCommonName            = "verrazzano-root-ca"
 	verrazzanoClusterIssuerName = "verrazzano-cluster-issuer"
+
+	// issuerSecretPrefix is the prefix of the names of the copies of the secrets referenced by an Issuer, which are
+	// labeled with the issuerSecretLabel
+	issuerSecretPrefix = "verrazzano-issuer-"
+	issuerSecretLabel  = "verrazzano.io/issuer-secret"
 	clusterResourceNamespaceKey = "clusterResourceNamespace"
 
 	crdDirectory  = "/cert-manager/"
@@ -80,6 +89,25 @@ const (
 	extraArgsKey  = "extraArgs[0]"
 	acmeSolverArg = "--acme-http01-solver-image="
 
+	// Key of the MAC key in the external account binding secret, unless another key is specified
+	defaultEABSecretKey = "secret"
+
+	// The CA bundle of a generic ACME server is mounted in the directory of the system certificates of the
+	// cert-manager controller, which trusts all the certificates in the directory
+	acmeCABundleVolumeName = "acme-ca-bundle"
+	acmeCABundleKey        = "ca.crt"
+	acmeCABundleMountPath  = "/etc/ssl/certs/acme-ca-bundle.crt"
+
+	// Keys of the Vault authentication secrets, and the default path of the AppRole authentication method
+	vaultTokenKey           = "token"
+	vaultAppRoleSecretIDKey = "secretId"
+	defaultVaultAppRolePath = "approle"
+	vaultCABundleKey        = "ca.crt"
+
+	// Kinds of the existing issuers that can be referenced
+	issuerKind        = "Issuer"
+	clusterIssuerKind = "ClusterIssuer"
+
 	// Uninstall resources
 	controllerConfigMap = "cert-manager-controller"
 	caInjectorConfigMap = "cert-manager-cainjector-leader-election"
@@ -123,19 +151,34 @@ metadata:
   name: {{.ClusterIssuerName}}
 spec:
   acme:
+{{- if .Email}}
     email: {{.Email}}
+{{- end}}
     server: "{{.Server}}"
     preferredChain: ""
     privateKeySecretRef:
       name: {{.AcmeSecretName}}
+{{- if .EABKeyID}}
+    externalAccountBinding:
+      keyID: "{{.EABKeyID}}"
+      keySecretRef:
+        name: {{.EABSecretName}}
+        key: {{.EABSecretKey}}
+{{- end}}
     solvers:
+{{- if .HTTP01IngressClass}}
+      - http01:
+          ingress:
+            class: {{.HTTP01IngressClass}}
+{{- else}}
       - dns01:
           ocidns:
             useInstancePrincipals: {{ .UseInstancePrincipals}}
             serviceAccountSecretRef:
               name: {{.SecretName}}
               key: "oci.yaml"
-            ocizonename: {{.OCIZoneName}}`
+            ocizonename: {{.OCIZoneName}}
+{{- end}}`
 
 const snippetSubstring = "rfc2136:\n"
 
@@ -177,11 +220,22 @@ type templateData struct {
 	SecretName            string
 	OCIZoneName           string
 	UseInstancePrincipals bool
+	EABKeyID              string
+	EABSecretName         string
+	EABSecretKey          string
+	HTTP01IngressClass    string
 }
 
 // CertIssuerType identifies the certificate issuer type
 type CertIssuerType string
 
+const (
+	caIssuerType       CertIssuerType = "CA"
+	acmeIssuerType     CertIssuerType = "ACME"
+	vaultIssuerType    CertIssuerType = "Vault"
+	externalIssuerType CertIssuerType = "External"
+)
+
 type getCoreV1ClientFuncType func(log ...vzlog.VerrazzanoLogger) (corev1.CoreV1Interface, error)
 
 var getClientFunc getCoreV1ClientFuncType = k8sutil.GetCoreV1Client
@@ -204,7 +258,7 @@ func GetCertManagerClientset() (certv1client.CertmanagerV1Interface, error) {
 }
 
 // checkRenewAllCertificates Update the status field for each certificate generated by the Verrazzano ClusterIssuer
-func checkRenewAllCertificates(compContext spi.ComponentContext, isCAConfig bool) error {
+func checkRenewAllCertificates(compContext spi.ComponentContext, issuerType CertIssuerType) error {
 	cli := compContext.Client()
 	log := compContext.Log()
 
@@ -222,7 +276,7 @@ func checkRenewAllCertificates(compContext spi.ComponentContext, isCAConfig bool
 	}
 	// Obtain the CA Common Name for comparison
 	comp := vzapi.ConvertCertManagerToV1Beta1(compContext.EffectiveCR().Spec.Components.CertManager)
-	issuerCNs, err := findIssuerCommonName(comp.Certificate, isCAConfig)
+	issuerCNs, err := findIssuerCommonName(comp.Certificate, issuerType)
 	if err != nil {
 		return err
 	}
@@ -377,19 +431,37 @@ func AppendOverrides(compContext spi.ComponentContext, _ string, _ string, _ str
 		}
 	}
 
-	// Verify that we are using CA certs before appending override
-	isCAValue, err := isCA(compContext)
+	// Verify the issuer type before appending override
+	issuerType, err := getIssuerType(compContext)
 	if err != nil {
 		err = compContext.Log().ErrorfNewErr("Failed to verify the config type: %v", err)
 		return []bom.KeyValue{}, err
 	}
-	if isCAValue {
-		ns := compContext.EffectiveCR().Spec.Components.CertManager.Certificate.CA.ClusterResourceNamespace
-		kvs = append(kvs, bom.KeyValue{Key: clusterResourceNamespaceKey, Value: ns})
+	certificate := compContext.EffectiveCR().Spec.Components.CertManager.Certificate
+	switch issuerType {
+	case caIssuerType:
+		kvs = append(kvs, bom.KeyValue{Key: clusterResourceNamespaceKey, Value: certificate.CA.ClusterResourceNamespace})
+	case acmeIssuerType:
+		if len(certificate.Acme.CABundleSecret) > 0 {
+			kvs = appendACMECABundleOverrides(kvs, certificate.Acme.CABundleSecret)
+		}
 	}
 	return kvs, nil
 }
 
+// appendACMECABundleOverrides mounts the CA bundle of a generic ACME server in the cert-manager controller, so that the
+// controller trusts the TLS certificate of the server
+func appendACMECABundleOverrides(kvs []bom.KeyValue, caBundleSecret string) []bom.KeyValue {
+	return append(kvs,
+		bom.KeyValue{Key: "volumes[0].name", Value: acmeCABundleVolumeName},
+		bom.KeyValue{Key: "volumes[0].secret.secretName", Value: caBundleSecret},
+		bom.KeyValue{Key: "volumeMounts[0].name", Value: acmeCABundleVolumeName},
+		bom.KeyValue{Key: "volumeMounts[0].mountPath", Value: acmeCABundleMountPath},
+		bom.KeyValue{Key: "volumeMounts[0].subPath", Value: acmeCABundleKey},
+		bom.KeyValue{Key: "volumeMounts[0].readOnly", Value: "true"},
+	)
+}
+
 // isCertManagerReady checks the state of the expected cert-manager deployments and returns true if they are in a ready state
 func isCertManagerReady(context spi.ComponentContext) bool {
 	deployments := []types.NamespacedName{
@@ -407,7 +479,7 @@ func isCertManagerReady(context spi.ComponentContext) bool {
 		},
 	}
 	prefix := fmt.Sprintf("Component %s", context.GetComponent())
-	return status.DeploymentsAreReady(context.Log(), context.Client(), deployments, 1, prefix)
+	return status.DeploymentsAreReady(context.Log(), context.Client(), context.ActualCR(), deployments, 1, prefix)
 }
 
 //writeCRD writes out CertManager CRD manifests with OCI DNS specifications added
@@ -478,38 +550,56 @@ func createSnippetWithPadding(padding string) []byte {
 	return []byte(builder.String())
 }
 
-// Check if cert-type is CA, if not it is assumed to be Acme
+// Check if cert-type is CA
 func isCA(compContext spi.ComponentContext) (bool, error) {
+	issuerType, err := getIssuerType(compContext)
+	return issuerType == caIssuerType, err
+}
+
+// getIssuerType returns the type of the issuer that is configured in the effective CR
+func getIssuerType(compContext spi.ComponentContext) (CertIssuerType, error) {
 	comp := vzapi.ConvertCertManagerToV1Beta1(compContext.EffectiveCR().Spec.Components.CertManager)
 	return validateConfiguration(comp)
 }
 
-// validateConfiguration Checks if the configuration is valid and is a CA configuration
-// - returns true if it is a CA configuration, false if not
-// - returns an error if both CA and ACME settings are configured
-func validateConfiguration(comp *v1beta1.CertManagerComponent) (isCA bool, err error) {
+// validateConfiguration Checks if the configuration is valid and returns the issuer type
+// - returns an error if more than one of the CA, ACME, Vault and existing issuer settings are configured
+func validateConfiguration(comp *v1beta1.CertManagerComponent) (issuerType CertIssuerType, err error) {
 	if comp == nil {
 		// Is default CA configuration
-		return true, nil
+		return caIssuerType, nil
 	}
-	// Check if Ca or Acme is empty
-	caNotEmpty := comp.Certificate.CA != v1beta1.CA{}
-	acmeNotEmpty := comp.Certificate.Acme != v1beta1.Acme{}
-	if caNotEmpty && acmeNotEmpty {
-		return false, errors.New("Certificate object Acme and CA cannot be simultaneously populated")
+	var configured []CertIssuerType
+	if comp.Certificate.CA != (v1beta1.CA{}) {
+		configured = append(configured, caIssuerType)
 	}
-	if caNotEmpty {
-		if err := validateCAConfiguration(comp.Certificate.CA); err != nil {
-			return true, err
-		}
-		return true, nil
-	} else if acmeNotEmpty {
-		if err := validateAcmeConfiguration(comp.Certificate.Acme); err != nil {
-			return false, err
-		}
-		return false, nil
+	if comp.Certificate.Acme != (v1beta1.Acme{}) {
+		configured = append(configured, acmeIssuerType)
+	}
+	if comp.Certificate.Vault != nil {
+		configured = append(configured, vaultIssuerType)
+	}
+	if comp.Certificate.IssuerRef != nil {
+		configured = append(configured, externalIssuerType)
 	}
-	return false, errors.New("Either Acme or CA certificate authorities must be configured")
+	if len(configured) == 0 {
+		return "", errors.New("Either Acme, CA, Vault or an existing issuer must be configured")
+	}
+	if len(configured) > 1 {
+		return "", fmt.Errorf("Only one of the Acme, CA, Vault and existing issuer configurations can be populated, found %v", configured)
+	}
+	issuerType = configured[0]
+	switch issuerType {
+	case caIssuerType:
+		err = validateCAConfiguration(comp.Certificate.CA)
+	case acmeIssuerType:
+		err = validateAcmeConfiguration(comp.Certificate.Acme)
+	case vaultIssuerType:
+		err = validateVaultConfiguration(*comp.Certificate.Vault)
+	case externalIssuerType:
+		err = validateIssuerReference(*comp.Certificate.IssuerRef)
+	}
+	return issuerType, err
 }
 
 func validateCAConfiguration(ca v1beta1.CA) error {
@@ -538,14 +628,109 @@ func getSecret(namespace string, name string) (*v1.Secret, error) {
 
 //validateAcmeConfiguration Validate the ACME/LetsEncrypt values
 func validateAcmeConfiguration(acme v1beta1.Acme) error {
-	if !isLetsEncryptProvider(acme) {
+	if isLetsEncryptProvider(acme) {
+		if len(acme.Environment) > 0 && !isLetsEncryptProductionEnv(acme) && !isLetsEncryptStagingEnv(acme) {
+			return fmt.Errorf("Invalid Let's Encrypt environment: %s", acme.Environment)
+		}
+		if len(acme.DirectoryURL) > 0 || len(acme.CABundleSecret) > 0 {
+			return errors.New("The ACME directory URL and CA bundle secret can not be specified for the Let's Encrypt provider, specify the environment instead")
+		}
+		if _, err := mail.ParseAddress(acme.EmailAddress); err != nil {
+			return err
+		}
+	} else if isGenericACMEProvider(acme) {
+		if err := validateACMEDirectoryURL(acme.DirectoryURL); err != nil {
+			return err
+		}
+		if len(acme.Environment) > 0 {
+			return fmt.Errorf("The environment can not be specified for the %s provider", v1beta1.GenericACME)
+		}
+		// The email address is optional for generic ACME servers, they are not required to send expiry notices
+		if len(acme.EmailAddress) > 0 {
+			if _, err := mail.ParseAddress(acme.EmailAddress); err != nil {
+				return err
+			}
+		}
+	} else {
 		return fmt.Errorf("Invalid ACME certificate provider %v", acme.Provider)
 	}
-	if len(acme.Environment) > 0 && !isLetsEncryptProductionEnv(acme) && !isLetsEncryptStagingEnv(acme) {
-		return fmt.Errorf("Invalid Let's Encrypt environment: %s", acme.Environment)
+	if eab := acme.ExternalAccountBinding; eab != nil && (len(eab.KeyID) == 0 || len(eab.KeySecretName) == 0) {
+		return errors.New("The key ID and the key secret name of the ACME external account binding must be specified")
 	}
-	if _, err := mail.ParseAddress(acme.EmailAddress); err != nil {
-		return err
+	if acme.Solver != "" && acme.Solver != v1beta1.AcmeSolverDNS01 && acme.Solver != v1beta1.AcmeSolverHTTP01 {
+		return fmt.Errorf("Invalid ACME solver %s, must be %s or %s", acme.Solver, v1beta1.AcmeSolverDNS01, v1beta1.AcmeSolverHTTP01)
+	}
+	return nil
+}
+
+// validateACMEDirectoryURL validates the directory URL of a generic ACME server, which is required
+func validateACMEDirectoryURL(directoryURL string) error {
+	if len(directoryURL) == 0 {
+		return fmt.Errorf("The ACME directory URL must be specified for the %s provider", v1beta1.GenericACME)
+	}
+	u, err := url.Parse(directoryURL)
+	if err != nil {
+		return fmt.Errorf("Invalid ACME directory URL %s: %v", directoryURL, err)
+	}
+	if u.Scheme != "https" || len(u.Host) == 0 {
+		return fmt.Errorf("Invalid ACME directory URL %s, must be an https URL", directoryURL)
+	}
+	return nil
+}
+
+// validateVaultConfiguration validates the Vault issuer, which requires the server, the path of the signing endpoint
+// and exactly one authentication method
+func validateVaultConfiguration(vault v1beta1.VaultIssuer) error {
+	u, err := url.Parse(vault.Server)
+	if err != nil || len(vault.Server) == 0 || len(u.Host) == 0 || (u.Scheme != "https" && u.Scheme != "http") {
+		return fmt.Errorf("Invalid Vault server URL %s", vault.Server)
+	}
+	if len(vault.Path) == 0 {
+		return errors.New("The path of the Vault signing endpoint must be specified")
+	}
+	auth := vault.Auth
+	authMethods := 0
+	if len(auth.TokenSecret) > 0 {
+		authMethods++
+	}
+	if auth.AppRole != nil {
+		authMethods++
+		if len(auth.AppRole.RoleID) == 0 || len(auth.AppRole.SecretName) == 0 {
+			return errors.New("The role ID and the secret name of the Vault AppRole authentication must be specified")
+		}
+	}
+	if auth.Kubernetes != nil {
+		authMethods++
+		if len(auth.Kubernetes.Role) == 0 || len(auth.Kubernetes.SecretName) == 0 {
+			return errors.New("The role and the secret name of the Vault Kubernetes authentication must be specified")
+		}
+	}
+	if authMethods != 1 {
+		return errors.New("Exactly one of the token secret, AppRole and Kubernetes Vault authentication methods must be specified")
+	}
+	return nil
+}
+
+// validateIssuerReference validates the reference to an existing issuer, which can not be the Verrazzano ClusterIssuer
+// that is configured with its spec
+func validateIssuerReference(ref v1beta1.IssuerReference) error {
+	if len(ref.Name) == 0 {
+		return errors.New("The name of the existing issuer must be specified")
+	}
+	switch ref.Kind {
+	case "", clusterIssuerKind:
+		if ref.Name == verrazzanoClusterIssuerName {
+			return fmt.Errorf("The existing issuer can not be the %s ClusterIssuer", verrazzanoClusterIssuerName)
+		}
+		if len(ref.Namespace) > 0 {
+			return errors.New("The namespace can only be specified for an existing issuer of kind Issuer")
+		}
+	case issuerKind:
+		if len(ref.Namespace) == 0 {
+			return errors.New("The namespace of the existing Issuer must be specified")
+		}
+	default:
+		return fmt.Errorf("Invalid kind %s of the existing issuer, must be %s or %s", ref.Kind, clusterIssuerKind, issuerKind)
 	}
 	return nil
 }
@@ -554,6 +739,10 @@ func isLetsEncryptProvider(acme v1beta1.Acme) bool {
 	return strings.ToLower(string(acme.Provider)) == strings.ToLower(string(vzapi.LetsEncrypt))
 }
 
+func isGenericACMEProvider(acme v1beta1.Acme) bool {
+	return strings.EqualFold(string(acme.Provider), string(v1beta1.GenericACME))
+}
+
 func isLetsEncryptStagingEnv(acme v1beta1.Acme) bool {
 	return strings.ToLower(acme.Environment) == letsEncryptStaging
 }
@@ -562,6 +751,10 @@ func isLetsEncryptProductionEnv(acme v1beta1.Acme) bool {
 	return strings.ToLower(acme.Environment) == letsencryptProduction
 }
 
+func isGenericACME(compContext spi.ComponentContext) bool {
+	return strings.EqualFold(string(compContext.EffectiveCR().Spec.Components.CertManager.Certificate.Acme.Provider), string(vzapi.GenericACME))
+}
+
 func isLetsEncryptStaging(compContext spi.ComponentContext) bool {
 	acmeEnvironment := compContext.EffectiveCR().Spec.Components.CertManager.Certificate.Acme.Environment
 	return acmeEnvironment != "" && strings.ToLower(acmeEnvironment) != "production"
@@ -594,26 +787,14 @@ func createOrUpdateAcmeResources(compContext spi.ComponentContext) (opResult con
 }
 
 func createACMEIssuerObject(compContext spi.ComponentContext) (*unstructured.Unstructured, error) {
-	// Initialize Acme variables for the cluster issuer
-	var ociDNSConfigSecret string
-	var ociDNSZoneName string
-	vzDNS := compContext.EffectiveCR().Spec.Components.DNS
 	vzCertAcme := compContext.EffectiveCR().Spec.Components.CertManager.Certificate.Acme
-	if vzDNS != nil && vzDNS.OCI != nil {
-		ociDNSConfigSecret = vzDNS.OCI.OCIConfigSecret
-		ociDNSZoneName = vzDNS.OCI.DNSZoneName
-	}
-	// Verify that the secret exists
-	secret := v1.Secret{}
-	if err := compContext.Client().Get(context.TODO(), crtclient.ObjectKey{Name: ociDNSConfigSecret, Namespace: ComponentNamespace}, &secret); err != nil {
-		return nil, compContext.Log().ErrorfNewErr("Failed to retrieve the OCI DNS config secret: %v", err)
-	}
-
 	emailAddress := vzCertAcme.EmailAddress
 
-	// Verify the acme environment and set the server
+	// Verify the acme environment and set the server, generic ACME providers specify the directory URL of the server
 	acmeServer := letsEncryptProdEndpoint
-	if isLetsEncryptStaging(compContext) {
+	if isGenericACME(compContext) {
+		acmeServer = vzCertAcme.DirectoryURL
+	} else if isLetsEncryptStaging(compContext) {
 		acmeServer = letsEncryptStageEndpoint
 	}
 
@@ -623,8 +804,32 @@ func createACMEIssuerObject(compContext spi.ComponentContext) (*unstructured.Uns
 		AcmeSecretName:    caAcmeSecretName,
 		Email:             emailAddress,
 		Server:            acmeServer,
-		SecretName:        ociDNSConfigSecret,
-		OCIZoneName:       ociDNSZoneName,
+	}
+	if eab := vzCertAcme.ExternalAccountBinding; eab != nil {
+		clusterIssuerData.EABKeyID = eab.KeyID
+		clusterIssuerData.EABSecretName = eab.KeySecretName
+		clusterIssuerData.EABSecretKey = eab.KeySecretKey
+		if len(clusterIssuerData.EABSecretKey) == 0 {
+			clusterIssuerData.EABSecretKey = defaultEABSecretKey
+		}
+	}
+
+	// HTTP-01 challenges are solved by the ingress controller, and do not need the OCI DNS configuration
+	if vzCertAcme.Solver == vzapi.AcmeSolverHTTP01 {
+		clusterIssuerData.HTTP01IngressClass = vzconfig.GetIngressClassName(compContext.EffectiveCR())
+		return createAcmeClusterIssuer(compContext.Log(), clusterIssuerData)
+	}
+
+	// Initialize Acme variables for the cluster issuer
+	vzDNS := compContext.EffectiveCR().Spec.Components.DNS
+	if vzDNS != nil && vzDNS.OCI != nil {
+		clusterIssuerData.SecretName = vzDNS.OCI.OCIConfigSecret
+		clusterIssuerData.OCIZoneName = vzDNS.OCI.DNSZoneName
+	}
+	// Verify that the secret exists
+	secret := v1.Secret{}
+	if err := compContext.Client().Get(context.TODO(), crtclient.ObjectKey{Name: clusterIssuerData.SecretName, Namespace: ComponentNamespace}, &secret); err != nil {
+		return nil, compContext.Log().ErrorfNewErr("Failed to retrieve the OCI DNS config secret: %v", err)
 	}
 
 	for key := range secret.Data {
@@ -758,15 +963,251 @@ func createOrUpdateCAResources(compContext spi.ComponentContext) (controllerutil
 	return opResult, nil
 }
 
-func findIssuerCommonName(certificate v1beta1.Certificate, isCAValue bool) ([]string, error) {
-	if isCAValue {
+//createOrUpdateVaultResources Create or update the Vault ClusterIssuer
+// - returns OperationResultNone/error on error
+// - returns OperationResultCreated/nil if the CI is created (initial install)
+// - returns OperationResultUpdated/nil if the CI is updated
+func createOrUpdateVaultResources(compContext spi.ComponentContext) (controllerutil.OperationResult, error) {
+	vaultIssuer, err := createVaultIssuer(compContext)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	return createOrUpdateClusterIssuerSpec(compContext, certv1.IssuerSpec{
+		IssuerConfig: certv1.IssuerConfig{Vault: vaultIssuer},
+	})
+}
+
+// createVaultIssuer returns the Vault issuer config of the Verrazzano ClusterIssuer.  The CA bundle of the Vault server
+// is read from its secret, since the ClusterIssuer requires the bundle inline.
+func createVaultIssuer(compContext spi.ComponentContext) (*certv1.VaultIssuer, error) {
+	vzVault := compContext.EffectiveCR().Spec.Components.CertManager.Certificate.Vault
+	vaultIssuer := &certv1.VaultIssuer{
+		Server:    vzVault.Server,
+		Path:      vzVault.Path,
+		Namespace: vzVault.Namespace,
+	}
+	if len(vzVault.CABundleSecret) > 0 {
+		secret := v1.Secret{}
+		if err := compContext.Client().Get(context.TODO(), crtclient.ObjectKey{Name: vzVault.CABundleSecret, Namespace: ComponentNamespace}, &secret); err != nil {
+			return nil, compContext.Log().ErrorfNewErr("Failed to retrieve the Vault CA bundle secret %s/%s: %v", ComponentNamespace, vzVault.CABundleSecret, err)
+		}
+		caBundle, ok := secret.Data[vaultCABundleKey]
+		if !ok {
+			return nil, compContext.Log().ErrorfNewErr("Failed, the Vault CA bundle secret %s/%s has no %s key", ComponentNamespace, vzVault.CABundleSecret, vaultCABundleKey)
+		}
+		vaultIssuer.CABundle = caBundle
+	}
+
+	auth := vzVault.Auth
+	switch {
+	case len(auth.TokenSecret) > 0:
+		vaultIssuer.Auth.TokenSecretRef = &certmetav1.SecretKeySelector{
+			LocalObjectReference: certmetav1.LocalObjectReference{Name: auth.TokenSecret},
+			Key:                  vaultTokenKey,
+		}
+	case auth.AppRole != nil:
+		path := auth.AppRole.Path
+		if len(path) == 0 {
+			path = defaultVaultAppRolePath
+		}
+		vaultIssuer.Auth.AppRole = &certv1.VaultAppRole{
+			Path:   path,
+			RoleId: auth.AppRole.RoleID,
+			SecretRef: certmetav1.SecretKeySelector{
+				LocalObjectReference: certmetav1.LocalObjectReference{Name: auth.AppRole.SecretName},
+				Key:                  vaultAppRoleSecretIDKey,
+			},
+		}
+	case auth.Kubernetes != nil:
+		vaultIssuer.Auth.Kubernetes = &certv1.VaultKubernetesAuth{
+			Path: auth.Kubernetes.MountPath,
+			Role: auth.Kubernetes.Role,
+			SecretRef: certmetav1.SecretKeySelector{
+				LocalObjectReference: certmetav1.LocalObjectReference{Name: auth.Kubernetes.SecretName},
+				Key:                  vaultTokenKey,
+			},
+		}
+	}
+	return vaultIssuer, nil
+}
+
+//createOrUpdateExternalIssuerResources Configure the Verrazzano ClusterIssuer with the spec of an existing issuer.  The
+// ClusterIssuer is synced again when the spec of the existing issuer changes, see Reconcile.
+// - returns OperationResultNone/error on error
+// - returns OperationResultCreated/nil if the CI is created (initial install)
+// - returns OperationResultUpdated/nil if the CI is updated
+func createOrUpdateExternalIssuerResources(compContext spi.ComponentContext) (controllerutil.OperationResult, error) {
+	ref := compContext.EffectiveCR().Spec.Components.CertManager.Certificate.IssuerRef
+	var spec certv1.IssuerSpec
+	if ref.Kind == issuerKind {
+		issuer := certv1.Issuer{}
+		if err := compContext.Client().Get(context.TODO(), crtclient.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}, &issuer); err != nil {
+			return controllerutil.OperationResultNone, compContext.Log().ErrorfNewErr("Failed to get the Issuer %s/%s: %v", ref.Namespace, ref.Name, err)
+		}
+		var err error
+		if spec, err = copyIssuerSecrets(compContext, issuer); err != nil {
+			return controllerutil.OperationResultNone, compContext.Log().ErrorfNewErr("Failed to copy the secrets of the Issuer %s/%s: %v", ref.Namespace, ref.Name, err)
+		}
+	} else {
+		if err := deleteIssuerSecretCopies(compContext.Client(), nil); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+		clusterIssuer := certv1.ClusterIssuer{}
+		if err := compContext.Client().Get(context.TODO(), crtclient.ObjectKey{Name: ref.Name}, &clusterIssuer); err != nil {
+			return controllerutil.OperationResultNone, compContext.Log().ErrorfNewErr("Failed to get the ClusterIssuer %s: %v", ref.Name, err)
+		}
+		spec = clusterIssuer.Spec
+	}
+	return createOrUpdateClusterIssuerSpec(compContext, spec)
+}
+
+// copyIssuerSecrets copies the secrets referenced by the spec of an Issuer from its namespace to the cert-manager
+// namespace, which is the cluster resource namespace where cert-manager reads the secrets of a ClusterIssuer, and
+// returns the spec of the Issuer with the references to the copies.  The copies of the secrets that are no longer
+// referenced are deleted.  A referenced secret that doesn't exist yet, such as the ACME account key, is created by
+// cert-manager in the cert-manager namespace.
+func copyIssuerSecrets(compContext spi.ComponentContext, issuer certv1.Issuer) (certv1.IssuerSpec, error) {
+	data, err := json.Marshal(issuer.Spec)
+	if err != nil {
+		return issuer.Spec, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return issuer.Spec, err
+	}
+	secretNames := map[string]bool{}
+	renameIssuerSecretRefs(fields, secretNames)
+
+	cli := compContext.Client()
+	copies := map[string]bool{}
+	for name := range secretNames {
+		copies[issuerSecretCopyName(name)] = true
+		secret := v1.Secret{}
+		err := cli.Get(context.TODO(), types.NamespacedName{Namespace: issuer.Namespace, Name: name}, &secret)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return issuer.Spec, err
+		}
+		secretCopy := v1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: issuerSecretCopyName(name)}}
+		if _, err := controllerutil.CreateOrUpdate(context.TODO(), cli, &secretCopy, func() error {
+			if secretCopy.Labels == nil {
+				secretCopy.Labels = map[string]string{}
+			}
+			secretCopy.Labels[issuerSecretLabel] = issuer.Name
+			secretCopy.Type = secret.Type
+			secretCopy.Data = secret.Data
+			return nil
+		}); err != nil {
+			return issuer.Spec, err
+		}
+	}
+	if err := deleteIssuerSecretCopies(cli, copies); err != nil {
+		return issuer.Spec, err
+	}
+
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return issuer.Spec, err
+	}
+	spec := certv1.IssuerSpec{}
+	err = json.Unmarshal(data, &spec)
+	return spec, err
+}
+
+// renameIssuerSecretRefs renames the secrets referenced by the fields of an issuer spec to the names of their copies,
+// and adds the referenced secret names to the set.  The secrets are referenced by the secretName field of a CA issuer
+// and by the name of the secret selectors and the Venafi credentialsRef.
+func renameIssuerSecretRefs(fields map[string]interface{}, secretNames map[string]bool) {
+	for key, value := range fields {
+		switch v := value.(type) {
+		case string:
+			if key == "secretName" {
+				secretNames[v] = true
+				fields[key] = issuerSecretCopyName(v)
+			}
+		case map[string]interface{}:
+			name, ok := v["name"].(string)
+			if ok && (strings.HasSuffix(strings.ToLower(key), "secretref") || key == "credentialsRef") {
+				secretNames[name] = true
+				v["name"] = issuerSecretCopyName(name)
+			}
+			renameIssuerSecretRefs(v, secretNames)
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					renameIssuerSecretRefs(m, secretNames)
+				}
+			}
+		}
+	}
+}
+
+// issuerSecretCopyName returns the name of the copy of a secret referenced by an Issuer
+func issuerSecretCopyName(name string) string {
+	return issuerSecretPrefix + name
+}
+
+// deleteIssuerSecretCopies deletes the copies of the secrets referenced by an Issuer that are not in the set to keep
+func deleteIssuerSecretCopies(cli crtclient.Client, keep map[string]bool) error {
+	secrets := v1.SecretList{}
+	if err := cli.List(context.TODO(), &secrets, crtclient.InNamespace(ComponentNamespace), crtclient.HasLabels{issuerSecretLabel}); err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		if keep[secrets.Items[i].Name] {
+			continue
+		}
+		if err := cli.Delete(context.TODO(), &secrets.Items[i]); crtclient.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createOrUpdateClusterIssuerSpec creates or updates the Verrazzano ClusterIssuer with the given spec
+func createOrUpdateClusterIssuerSpec(compContext spi.ComponentContext, spec certv1.IssuerSpec) (controllerutil.OperationResult, error) {
+	compContext.Log().Debug("Applying ClusterIssuer")
+	clusterIssuer := certv1.ClusterIssuer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: verrazzanoClusterIssuerName,
+		},
+	}
+	opResult, err := controllerutil.CreateOrUpdate(context.TODO(), compContext.Client(), &clusterIssuer, func() error {
+		clusterIssuer.Spec = spec
+		return nil
+	})
+	if err != nil {
+		return opResult, compContext.Log().ErrorfNewErr("Failed to create or update the ClusterIssuer: %v", err)
+	}
+	return opResult, nil
+}
+
+// findIssuerCommonName returns the CNs of the CAs that sign the certificates of the configured issuer.  The signing CAs
+// of Vault and existing issuers are not known, so none are returned and all the certificates are renewed.
+func findIssuerCommonName(certificate v1beta1.Certificate, issuerType CertIssuerType) ([]string, error) {
+	switch issuerType {
+	case caIssuerType:
 		return extractCACommonName(certificate.CA)
+	case acmeIssuerType:
+		return getACMEIssuerName(certificate.Acme)
 	}
-	return getACMEIssuerName(certificate.Acme)
+	return []string{}, nil
+}
+
+// hasKnownSigningCAs returns true if the CAs that sign the certificates of the issuer are known, so that only the
+// certificates signed by other CAs need to be renewed
+func hasKnownSigningCAs(compContext spi.ComponentContext, issuerType CertIssuerType) bool {
+	return issuerType == caIssuerType || (issuerType == acmeIssuerType && !isGenericACME(compContext))
 }
 
-//getACMEIssuerName Let's encrypt certificates are published, and the intermediate signing CA CNs are well-known
+//getACMEIssuerName Let's encrypt certificates are published, and the intermediate signing CA CNs are well-known.  The
+// signing CA CNs of generic ACME servers are not known, so none are returned and all the certificates are renewed.
 func getACMEIssuerName(acme v1beta1.Acme) ([]string, error) {
+	if isGenericACMEProvider(acme) {
+		return []string{}, nil
+	}
 	if isLetsEncryptProductionEnv(acme) {
 		return letsEncryptProductionCACommonNames, nil
 	}
@@ -807,20 +1248,26 @@ func extractCommonNameFromCertSecret(secret *v1.Secret) (string, error) {
 	return cert.Issuer.CommonName, nil
 }
 
-func cleanupUnusedResources(compContext spi.ComponentContext, isCAValue bool) error {
+func cleanupUnusedResources(compContext spi.ComponentContext, issuerType CertIssuerType) error {
 	defaultCANotUsed := func() bool {
-		// We're not using the default CA if we're either configured for ACME or it's a Customer-provided CA
-		return !isCAValue || compContext.EffectiveCR().Spec.Components.CertManager.Certificate.CA.SecretName != defaultCACertificateSecretName
+		// We're not using the default CA if we're configured for another issuer type or it's a Customer-provided CA
+		return issuerType != caIssuerType || compContext.EffectiveCR().Spec.Components.CertManager.Certificate.CA.SecretName != defaultCACertificateSecretName
 	}
 	client := compContext.Client()
 	log := compContext.Log()
-	if isCAValue {
+	if issuerType != acmeIssuerType {
 		log.Oncef("Clean up ACME issuer secret")
 		// clean up ACME secret if present
 		if err := deleteObject(client, caAcmeSecretName, ComponentNamespace, &v1.Secret{}); err != nil {
 			return err
 		}
 	}
+	if issuerType != externalIssuerType {
+		log.Oncef("Clean up the copies of the secrets of an existing Issuer")
+		if err := deleteIssuerSecretCopies(client, nil); err != nil {
+			return err
+		}
+	}
 	if defaultCANotUsed() {
 		// Issuer is either the default or Custom issuer; clean up the default Verrazzano issuer resources
 		// - self-signed Issuer object
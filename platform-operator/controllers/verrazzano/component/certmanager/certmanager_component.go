@@ -32,6 +32,9 @@ const ComponentNamespace = vzconst.CertManagerNamespace
 // ComponentJSONName is the josn name of the verrazzano component in CRD
 const ComponentJSONName = "certManager"
 
+// ClusterIssuerName is the name of the Verrazzano ClusterIssuer
+const ClusterIssuerName = verrazzanoClusterIssuerName
+
 // certManagerComponent represents an CertManager component
 type certManagerComponent struct {
 	helm.HelmComponent
@@ -177,6 +180,20 @@ func (c certManagerComponent) PostUpgrade(compContext spi.ComponentContext) erro
 	return c.createOrUpdateClusterIssuer(compContext)
 }
 
+// Reconcile keeps the Verrazzano ClusterIssuer in sync with the existing issuer it is configured from.  The component
+// is reconciled when the spec of an issuer changes.
+func (c certManagerComponent) Reconcile(compContext spi.ComponentContext) error {
+	if compContext.IsDryRun() {
+		compContext.Log().Debug("cert-manager Reconcile dry run")
+		return nil
+	}
+	issuerType, err := getIssuerType(compContext)
+	if err != nil || issuerType != externalIssuerType {
+		return err
+	}
+	return c.createOrUpdateClusterIssuer(compContext)
+}
+
 // PostUninstall removes cert-manager objects that are created outside of Helm
 func (c certManagerComponent) PostUninstall(compContext spi.ComponentContext) error {
 	if compContext.IsDryRun() {
@@ -187,17 +204,26 @@ func (c certManagerComponent) PostUninstall(compContext spi.ComponentContext) er
 }
 
 func (c certManagerComponent) createOrUpdateClusterIssuer(compContext spi.ComponentContext) error {
-	isCAValue, err := isCA(compContext)
+	issuerType, err := getIssuerType(compContext)
 	if err != nil {
 		return compContext.Log().ErrorfNewErr("Failed to verify the config type: %v", err)
 	}
 	var opResult controllerutil.OperationResult
-	if !isCAValue {
+	switch issuerType {
+	case acmeIssuerType:
 		// Create resources needed for Acme certificates
 		if opResult, err = createOrUpdateAcmeResources(compContext); err != nil {
 			return compContext.Log().ErrorfNewErr("Failed creating Acme resources: %v", err)
 		}
-	} else {
+	case vaultIssuerType:
+		if opResult, err = createOrUpdateVaultResources(compContext); err != nil {
+			return compContext.Log().ErrorfNewErr("Failed creating Vault resources: %v", err)
+		}
+	case externalIssuerType:
+		if opResult, err = createOrUpdateExternalIssuerResources(compContext); err != nil {
+			return compContext.Log().ErrorfNewErr("Failed configuring the ClusterIssuer from the existing issuer: %v", err)
+		}
+	default:
 		// Create resources needed for CA certificates
 		if opResult, err = createOrUpdateCAResources(compContext); err != nil {
 			msg := fmt.Sprintf("Failed creating CA resources: %v", err)
@@ -211,12 +237,17 @@ func (c certManagerComponent) createOrUpdateClusterIssuer(compContext spi.Compon
 		compContext.Log().Oncef("Initial install, skipping certificate renewal checks")
 		return nil
 	}
+	if opResult == controllerutil.OperationResultNone && !hasKnownSigningCAs(compContext, issuerType) {
+		// The signing CAs of generic ACME servers, Vault and existing issuers are not known, so the certificates are
+		// only renewed when the ClusterIssuer is updated
+		return nil
+	}
 	// CertManager configuration was updated, cleanup any old resources from previous configuration
 	// and renew certificates against the new ClusterIssuer
-	if err := cleanupUnusedResources(compContext, isCAValue); err != nil {
+	if err := cleanupUnusedResources(compContext, issuerType); err != nil {
 		return err
 	}
-	if err := checkRenewAllCertificates(compContext, isCAValue); err != nil {
+	if err := checkRenewAllCertificates(compContext, issuerType); err != nil {
 		compContext.Log().Errorf("Error requesting certificate renewal: %s", err.Error())
 		return err
 	}
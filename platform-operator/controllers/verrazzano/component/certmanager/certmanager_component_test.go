@@ -9,8 +9,10 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/json"
 	"encoding/pem"
 	cmutil "github.com/jetstack/cert-manager/pkg/api/util"
+	acmev1 "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	certv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	certv1fake "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/fake"
@@ -31,6 +33,7 @@ import (
 	"math/big"
 	"net"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"testing"
 	"time"
 
@@ -38,8 +41,11 @@ import (
 )
 
 const (
-	testDNSDomain  = "example.dns.io"
-	testOCIDNSName = "ociDNS"
+	testDNSDomain        = "example.dns.io"
+	testOCIDNSName       = "ociDNS"
+	testACMEDirectoryURL = "https://ca.example.com/acme/acme/directory"
+	testVaultServer      = "https://vault.example.com:8200"
+	testVaultPath        = "pki_int/sign/verrazzano"
 )
 
 // TestValidateUpdate tests the ValidateUpdate function
@@ -258,6 +264,201 @@ func runAcmeUpdateTest(t *testing.T, upgrade bool) {
 
 }
 
+// TestCreateGenericACMEIssuerObject tests the createACMEIssuerObject function
+// GIVEN a generic ACME provider with an external account binding and the HTTP-01 solver
+//  WHEN createACMEIssuerObject is called
+//  THEN the ClusterIssuer uses the directory URL, the external account binding and an HTTP-01 solver, without the
+//       OCI DNS configuration
+func TestCreateGenericACMEIssuerObject(t *testing.T) {
+	localvz := defaultVZConfig.DeepCopy()
+	localvz.Spec.Components.CertManager.Certificate.Acme = vzapi.Acme{
+		Provider:               vzapi.GenericACME,
+		DirectoryURL:           testACMEDirectoryURL,
+		ExternalAccountBinding: &vzapi.AcmeExternalAccountBinding{KeyID: "kid", KeySecretName: "eab-secret"},
+		Solver:                 vzapi.AcmeSolverHTTP01,
+	}
+	client := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	ciObject, err := createACMEIssuerObject(spi.NewFakeContext(client, localvz, nil, false))
+	assert.NoError(t, err)
+
+	issuer := certv1.ClusterIssuer{}
+	assert.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(ciObject.Object, &issuer))
+	acmeIssuer := issuer.Spec.ACME
+	assert.NotNil(t, acmeIssuer)
+	assert.Equal(t, testACMEDirectoryURL, acmeIssuer.Server)
+	assert.Empty(t, acmeIssuer.Email)
+	assert.Equal(t, "kid", acmeIssuer.ExternalAccountBinding.KeyID)
+	assert.Equal(t, "eab-secret", acmeIssuer.ExternalAccountBinding.Key.Name)
+	assert.Equal(t, defaultEABSecretKey, acmeIssuer.ExternalAccountBinding.Key.Key)
+	assert.Len(t, acmeIssuer.Solvers, 1)
+	assert.Nil(t, acmeIssuer.Solvers[0].DNS01)
+	assert.Equal(t, "verrazzano-nginx", *acmeIssuer.Solvers[0].HTTP01.Ingress.Class)
+}
+
+// TestCreateVaultIssuer tests the createOrUpdateVaultResources function
+// GIVEN a Vault issuer with AppRole authentication and a CA bundle secret
+//  WHEN createOrUpdateVaultResources is called
+//  THEN the Verrazzano ClusterIssuer is created with the Vault server, the inline CA bundle and the AppRole secret
+func TestCreateVaultIssuer(t *testing.T) {
+	localvz := getCertificateCR(vzapi.Certificate{Vault: &vzapi.VaultIssuer{
+		Server:         testVaultServer,
+		Path:           testVaultPath,
+		CABundleSecret: "vault-ca",
+		Auth:           vzapi.VaultAuth{AppRole: &vzapi.VaultAppRoleAuth{RoleID: "role", SecretName: "vault-approle"}},
+	}})
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-ca", Namespace: ComponentNamespace},
+		Data:       map[string][]byte{vaultCABundleKey: []byte("ca-bundle")},
+	}
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(caSecret).Build()
+	opResult, err := createOrUpdateVaultResources(spi.NewFakeContext(client, localvz, nil, false))
+	assert.NoError(t, err)
+	assert.Equal(t, controllerutil.OperationResultCreated, opResult)
+
+	issuer := certv1.ClusterIssuer{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: verrazzanoClusterIssuerName}, &issuer))
+	vault := issuer.Spec.Vault
+	assert.NotNil(t, vault)
+	assert.Equal(t, testVaultServer, vault.Server)
+	assert.Equal(t, testVaultPath, vault.Path)
+	assert.Equal(t, []byte("ca-bundle"), vault.CABundle)
+	assert.Nil(t, vault.Auth.TokenSecretRef)
+	assert.Equal(t, defaultVaultAppRolePath, vault.Auth.AppRole.Path)
+	assert.Equal(t, "role", vault.Auth.AppRole.RoleId)
+	assert.Equal(t, "vault-approle", vault.Auth.AppRole.SecretRef.Name)
+	assert.Equal(t, vaultAppRoleSecretIDKey, vault.Auth.AppRole.SecretRef.Key)
+
+	// The CA bundle secret must exist
+	client = fake.NewClientBuilder().WithScheme(testScheme).Build()
+	_, err = createOrUpdateVaultResources(spi.NewFakeContext(client, localvz, nil, false))
+	assert.Error(t, err)
+}
+
+// TestCreateExternalIssuer tests the createOrUpdateExternalIssuerResources function
+// GIVEN a reference to an existing Issuer
+//  WHEN createOrUpdateExternalIssuerResources is called
+//  THEN the Verrazzano ClusterIssuer is configured with the spec of the Issuer, which references the copies of the
+//  secrets of the Issuer in the cert-manager namespace, and it is updated when the Issuer changes
+func TestCreateExternalIssuer(t *testing.T) {
+	localvz := getCertificateCR(vzapi.Certificate{IssuerRef: &vzapi.IssuerReference{Name: "my-issuer", Kind: issuerKind, Namespace: "issuer-ns"}})
+	issuer := &certv1.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-issuer", Namespace: "issuer-ns"},
+		Spec: certv1.IssuerSpec{
+			IssuerConfig: certv1.IssuerConfig{CA: &certv1.CAIssuer{SecretName: "my-ca"}},
+		},
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-ca", Namespace: "issuer-ns"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert"), corev1.TLSPrivateKeyKey: []byte("key")},
+	}
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(issuer, caSecret).Build()
+	ctx := spi.NewFakeContext(client, localvz, nil, false)
+	opResult, err := createOrUpdateExternalIssuerResources(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, controllerutil.OperationResultCreated, opResult)
+
+	clusterIssuer := certv1.ClusterIssuer{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: verrazzanoClusterIssuerName}, &clusterIssuer))
+	assert.Equal(t, issuerSecretPrefix+"my-ca", clusterIssuer.Spec.CA.SecretName)
+	secretCopy := corev1.Secret{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: issuerSecretPrefix + "my-ca"}, &secretCopy))
+	assert.Equal(t, caSecret.Data, secretCopy.Data)
+	assert.Equal(t, corev1.SecretTypeTLS, secretCopy.Type)
+
+	opResult, err = createOrUpdateExternalIssuerResources(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, controllerutil.OperationResultNone, opResult)
+
+	// The Issuer references another secret, the copy of the previous secret is deleted
+	issuer.Spec.CA.SecretName = "my-new-ca"
+	assert.NoError(t, client.Update(context.TODO(), issuer))
+	opResult, err = createOrUpdateExternalIssuerResources(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, controllerutil.OperationResultUpdated, opResult)
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: verrazzanoClusterIssuerName}, &clusterIssuer))
+	assert.Equal(t, issuerSecretPrefix+"my-new-ca", clusterIssuer.Spec.CA.SecretName)
+	err = client.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: issuerSecretPrefix + "my-ca"}, &secretCopy)
+	assert.True(t, errors.IsNotFound(err))
+
+	// The referenced ClusterIssuer does not exist
+	localvz.Spec.Components.CertManager.Certificate.IssuerRef = &vzapi.IssuerReference{Name: "missing-issuer"}
+	_, err = createOrUpdateExternalIssuerResources(spi.NewFakeContext(client, localvz, nil, false))
+	assert.Error(t, err)
+}
+
+// TestRenameIssuerSecretRefs tests the renameIssuerSecretRefs function
+// GIVEN the spec of a Vault issuer and of an ACME issuer with a DNS01 solver
+//  WHEN renameIssuerSecretRefs is called
+//  THEN the names of the referenced secrets are renamed to the names of their copies, and the other names are kept
+func TestRenameIssuerSecretRefs(t *testing.T) {
+	spec := certv1.IssuerSpec{IssuerConfig: certv1.IssuerConfig{
+		Vault: &certv1.VaultIssuer{
+			Auth: certv1.VaultAuth{TokenSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "vault-token"}, Key: "token"}},
+		},
+		ACME: &acmev1.ACMEIssuer{
+			PrivateKey: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "acme-key"}},
+			Solvers: []acmev1.ACMEChallengeSolver{{DNS01: &acmev1.ACMEChallengeSolverDNS01{
+				Cloudflare: &acmev1.ACMEIssuerDNS01ProviderCloudflare{
+					Email:    "admin@example.com",
+					APIToken: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "cloudflare-token"}},
+				},
+			}}},
+		},
+	}}
+	data, err := json.Marshal(spec)
+	assert.NoError(t, err)
+	fields := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(data, &fields))
+	secretNames := map[string]bool{}
+	renameIssuerSecretRefs(fields, secretNames)
+	assert.Equal(t, map[string]bool{"vault-token": true, "acme-key": true, "cloudflare-token": true}, secretNames)
+
+	data, err = json.Marshal(fields)
+	assert.NoError(t, err)
+	renamed := certv1.IssuerSpec{}
+	assert.NoError(t, json.Unmarshal(data, &renamed))
+	assert.Equal(t, issuerSecretPrefix+"vault-token", renamed.Vault.Auth.TokenSecretRef.Name)
+	assert.Equal(t, "token", renamed.Vault.Auth.TokenSecretRef.Key)
+	assert.Equal(t, issuerSecretPrefix+"acme-key", renamed.ACME.PrivateKey.Name)
+	assert.Equal(t, issuerSecretPrefix+"cloudflare-token", renamed.ACME.Solvers[0].DNS01.Cloudflare.APIToken.Name)
+	assert.Equal(t, "admin@example.com", renamed.ACME.Solvers[0].DNS01.Cloudflare.Email)
+}
+
+// TestReconcileExternalIssuer tests the Reconcile function
+// GIVEN a Verrazzano ClusterIssuer configured from an existing ClusterIssuer whose spec changed
+//  WHEN Reconcile is called
+//  THEN the Verrazzano ClusterIssuer is synced with the spec of the existing ClusterIssuer
+func TestReconcileExternalIssuer(t *testing.T) {
+	localvz := getCertificateCR(vzapi.Certificate{IssuerRef: &vzapi.IssuerReference{Name: "my-issuer"}})
+	existing := &certv1.ClusterIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-issuer"},
+		Spec:       certv1.IssuerSpec{IssuerConfig: certv1.IssuerConfig{CA: &certv1.CAIssuer{SecretName: "new-ca"}}},
+	}
+	verrazzanoIssuer := &certv1.ClusterIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: verrazzanoClusterIssuerName},
+		Spec:       certv1.IssuerSpec{IssuerConfig: certv1.IssuerConfig{CA: &certv1.CAIssuer{SecretName: "old-ca"}}},
+	}
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(existing, verrazzanoIssuer).Build()
+	assert.NoError(t, NewComponent().Reconcile(spi.NewFakeContext(client, localvz, nil, false)))
+
+	clusterIssuer := certv1.ClusterIssuer{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: verrazzanoClusterIssuerName}, &clusterIssuer))
+	assert.Equal(t, "new-ca", clusterIssuer.Spec.CA.SecretName)
+}
+
+// TestFindIssuerCommonNameUnknownSigningCAs tests the findIssuerCommonName function
+// GIVEN a Vault or an existing issuer
+//  WHEN findIssuerCommonName is called
+//  THEN no CNs are returned, so that all the certificates are renewed
+func TestFindIssuerCommonNameUnknownSigningCAs(t *testing.T) {
+	for _, issuerType := range []CertIssuerType{vaultIssuerType, externalIssuerType} {
+		cns, err := findIssuerCommonName(v1beta1.Certificate{}, issuerType)
+		assert.NoError(t, err)
+		assert.Empty(t, cns)
+	}
+}
+
 // TestClusterIssuerUpdated tests the createOrUpdateClusterIssuer function
 // GIVEN a call to createOrUpdateClusterIssuer
 // WHEN the ClusterIssuer is updated and there are existing certificates with failed and successful CertificateRequests
@@ -721,6 +922,151 @@ var tests = []validationTestStruct{
 		new:     getAcmeCR(vzapi.LetsEncrypt, "joeblow", letsEncryptStaging),
 		wantErr: true,
 	},
+	{
+		name: "validGenericACME",
+		old:  &vzapi.Verrazzano{},
+		new: getGenericAcmeCR(vzapi.Acme{
+			Provider:               vzapi.GenericACME,
+			DirectoryURL:           testACMEDirectoryURL,
+			CABundleSecret:         "acme-ca",
+			ExternalAccountBinding: &vzapi.AcmeExternalAccountBinding{KeyID: "kid", KeySecretName: "eab-secret"},
+			Solver:                 vzapi.AcmeSolverHTTP01,
+		}),
+		wantErr: false,
+	},
+	{
+		name:    "genericACMENoDirectoryURL",
+		old:     &vzapi.Verrazzano{},
+		new:     getGenericAcmeCR(vzapi.Acme{Provider: vzapi.GenericACME, EmailAddress: emailAddress}),
+		wantErr: true,
+	},
+	{
+		name:    "genericACMEInsecureDirectoryURL",
+		old:     &vzapi.Verrazzano{},
+		new:     getGenericAcmeCR(vzapi.Acme{Provider: vzapi.GenericACME, DirectoryURL: "http://ca.example.com/acme/directory"}),
+		wantErr: true,
+	},
+	{
+		name:    "genericACMEEnvironment",
+		old:     &vzapi.Verrazzano{},
+		new:     getGenericAcmeCR(vzapi.Acme{Provider: vzapi.GenericACME, DirectoryURL: testACMEDirectoryURL, Environment: letsEncryptStaging}),
+		wantErr: true,
+	},
+	{
+		name:    "genericACMEInvalidEmail",
+		old:     &vzapi.Verrazzano{},
+		new:     getGenericAcmeCR(vzapi.Acme{Provider: vzapi.GenericACME, DirectoryURL: testACMEDirectoryURL, EmailAddress: "joeblow"}),
+		wantErr: true,
+	},
+	{
+		name:    "letsEncryptDirectoryURL",
+		old:     &vzapi.Verrazzano{},
+		new:     getGenericAcmeCR(vzapi.Acme{Provider: vzapi.LetsEncrypt, EmailAddress: emailAddress, DirectoryURL: testACMEDirectoryURL}),
+		wantErr: true,
+	},
+	{
+		name: "invalidExternalAccountBinding",
+		old:  &vzapi.Verrazzano{},
+		new: getGenericAcmeCR(vzapi.Acme{Provider: vzapi.GenericACME, DirectoryURL: testACMEDirectoryURL,
+			ExternalAccountBinding: &vzapi.AcmeExternalAccountBinding{KeySecretName: "eab-secret"}}),
+		wantErr: true,
+	},
+	{
+		name:    "invalidACMESolver",
+		old:     &vzapi.Verrazzano{},
+		new:     getGenericAcmeCR(vzapi.Acme{Provider: vzapi.GenericACME, DirectoryURL: testACMEDirectoryURL, Solver: "TLSALPN01"}),
+		wantErr: true,
+	},
+	{
+		name: "validVaultTokenAuth",
+		old:  &vzapi.Verrazzano{},
+		new: getCertificateCR(vzapi.Certificate{Vault: &vzapi.VaultIssuer{Server: testVaultServer, Path: testVaultPath,
+			Auth: vzapi.VaultAuth{TokenSecret: "vault-token"}}}),
+		wantErr: false,
+	},
+	{
+		name: "validVaultAppRoleAuth",
+		old:  &vzapi.Verrazzano{},
+		new: getCertificateCR(vzapi.Certificate{Vault: &vzapi.VaultIssuer{Server: testVaultServer, Path: testVaultPath,
+			Auth: vzapi.VaultAuth{AppRole: &vzapi.VaultAppRoleAuth{RoleID: "role", SecretName: "vault-approle"}}}}),
+		wantErr: false,
+	},
+	{
+		name: "invalidVaultServer",
+		old:  &vzapi.Verrazzano{},
+		new: getCertificateCR(vzapi.Certificate{Vault: &vzapi.VaultIssuer{Server: "vault", Path: testVaultPath,
+			Auth: vzapi.VaultAuth{TokenSecret: "vault-token"}}}),
+		wantErr: true,
+	},
+	{
+		name: "missingVaultPath",
+		old:  &vzapi.Verrazzano{},
+		new: getCertificateCR(vzapi.Certificate{Vault: &vzapi.VaultIssuer{Server: testVaultServer,
+			Auth: vzapi.VaultAuth{TokenSecret: "vault-token"}}}),
+		wantErr: true,
+	},
+	{
+		name:    "missingVaultAuth",
+		old:     &vzapi.Verrazzano{},
+		new:     getCertificateCR(vzapi.Certificate{Vault: &vzapi.VaultIssuer{Server: testVaultServer, Path: testVaultPath}}),
+		wantErr: true,
+	},
+	{
+		name: "multipleVaultAuth",
+		old:  &vzapi.Verrazzano{},
+		new: getCertificateCR(vzapi.Certificate{Vault: &vzapi.VaultIssuer{Server: testVaultServer, Path: testVaultPath,
+			Auth: vzapi.VaultAuth{TokenSecret: "vault-token", Kubernetes: &vzapi.VaultKubernetesAuth{Role: "role", SecretName: "sa-token"}}}}),
+		wantErr: true,
+	},
+	{
+		name: "incompleteVaultKubernetesAuth",
+		old:  &vzapi.Verrazzano{},
+		new: getCertificateCR(vzapi.Certificate{Vault: &vzapi.VaultIssuer{Server: testVaultServer, Path: testVaultPath,
+			Auth: vzapi.VaultAuth{Kubernetes: &vzapi.VaultKubernetesAuth{Role: "role"}}}}),
+		wantErr: true,
+	},
+	{
+		name:    "vaultAndAcme",
+		old:     &vzapi.Verrazzano{},
+		new:     getCertificateCR(vzapi.Certificate{Acme: acme, Vault: &vzapi.VaultIssuer{Server: testVaultServer, Path: testVaultPath, Auth: vzapi.VaultAuth{TokenSecret: "vault-token"}}}),
+		wantErr: true,
+	},
+	{
+		name:    "validClusterIssuerRef",
+		old:     &vzapi.Verrazzano{},
+		new:     getCertificateCR(vzapi.Certificate{IssuerRef: &vzapi.IssuerReference{Name: "my-issuer"}}),
+		wantErr: false,
+	},
+	{
+		name:    "validIssuerRef",
+		old:     &vzapi.Verrazzano{},
+		new:     getCertificateCR(vzapi.Certificate{IssuerRef: &vzapi.IssuerReference{Name: "my-issuer", Kind: issuerKind, Namespace: "issuer-ns"}}),
+		wantErr: false,
+	},
+	{
+		name:    "issuerRefWithoutNamespace",
+		old:     &vzapi.Verrazzano{},
+		new:     getCertificateCR(vzapi.Certificate{IssuerRef: &vzapi.IssuerReference{Name: "my-issuer", Kind: issuerKind}}),
+		wantErr: true,
+	},
+	{
+		name:    "clusterIssuerRefWithNamespace",
+		old:     &vzapi.Verrazzano{},
+		new:     getCertificateCR(vzapi.Certificate{IssuerRef: &vzapi.IssuerReference{Name: "my-issuer", Namespace: "issuer-ns"}}),
+		wantErr: true,
+	},
+	{
+		name:    "verrazzanoClusterIssuerRef",
+		old:     &vzapi.Verrazzano{},
+		new:     getCertificateCR(vzapi.Certificate{IssuerRef: &vzapi.IssuerReference{Name: verrazzanoClusterIssuerName}}),
+		wantErr: true,
+	},
+	{
+		name:    "invalidIssuerRefKind",
+		old:     &vzapi.Verrazzano{},
+		new:     getCertificateCR(vzapi.Certificate{IssuerRef: &vzapi.IssuerReference{Name: "my-issuer", Kind: "Certificate"}}),
+		wantErr: true,
+	},
 	{
 		name: "singleOverride",
 		new:  getSingleOverrideCR(),
@@ -806,6 +1152,28 @@ func getAcmeCR(provider vzapi.ProviderType, emailAddr string, env string) *vzapi
 	}
 }
 
+func getGenericAcmeCR(acme vzapi.Acme) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				CertManager: &vzapi.CertManagerComponent{
+					Certificate: vzapi.Certificate{Acme: acme},
+				},
+			},
+		},
+	}
+}
+
+func getCertificateCR(certificate vzapi.Certificate) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				CertManager: &vzapi.CertManagerComponent{Certificate: certificate},
+			},
+		},
+	}
+}
+
 func getCaSecretCR() *vzapi.Verrazzano {
 	return &vzapi.Verrazzano{
 		Spec: vzapi.VerrazzanoSpec{
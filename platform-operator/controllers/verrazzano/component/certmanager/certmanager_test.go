@@ -176,6 +176,40 @@ func TestAppendCertManagerOverridesWithInstallArgs(t *testing.T) {
 	assert.Contains(t, kvs, bom.KeyValue{Key: clusterResourceNamespaceKey, Value: testNamespace})
 }
 
+// TestAppendCertManagerOverridesWithACMECABundle tests the AppendOverrides fn
+// GIVEN a call to AppendOverrides
+// WHEN a VZ spec is passed with a generic ACME provider with a CA bundle secret
+// THEN the CA bundle secret is mounted in the cert-manager controller
+func TestAppendCertManagerOverridesWithACMECABundle(t *testing.T) {
+	config.SetDefaultBomFilePath(testBomFile)
+	localvz := defaultVZConfig.DeepCopy()
+	localvz.Spec.Components.CertManager.Certificate.Acme = vzapi.Acme{
+		Provider:       vzapi.GenericACME,
+		DirectoryURL:   "https://ca.example.com/acme/acme/directory",
+		CABundleSecret: "acme-ca",
+	}
+	kvs, err := AppendOverrides(spi.NewFakeContext(nil, localvz, nil, false, profileDir), ComponentName, ComponentNamespace, "", []bom.KeyValue{})
+	assert.NoError(t, err)
+	assert.Contains(t, kvs, bom.KeyValue{Key: "volumes[0].secret.secretName", Value: "acme-ca"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "volumeMounts[0].mountPath", Value: acmeCABundleMountPath})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "volumeMounts[0].subPath", Value: acmeCABundleKey})
+}
+
+// TestAppendCertManagerOverridesWithIssuerRef tests the AppendOverrides fn
+// GIVEN a call to AppendOverrides
+// WHEN a VZ spec is passed with a reference to an existing Issuer
+// THEN the cluster resource namespace of cert-manager is not changed
+func TestAppendCertManagerOverridesWithIssuerRef(t *testing.T) {
+	config.SetDefaultBomFilePath(testBomFile)
+	localvz := defaultVZConfig.DeepCopy()
+	localvz.Spec.Components.CertManager.Certificate.IssuerRef = &vzapi.IssuerReference{Name: "my-issuer", Kind: issuerKind, Namespace: "issuer-ns"}
+	kvs, err := AppendOverrides(spi.NewFakeContext(nil, localvz, nil, false, profileDir), ComponentName, ComponentNamespace, "", []bom.KeyValue{})
+	assert.NoError(t, err)
+	for _, kv := range kvs {
+		assert.NotEqual(t, clusterResourceNamespaceKey, kv.Key)
+	}
+}
+
 // TestCertManagerPreInstall tests the PreInstall fn
 // GIVEN a call to this fn
 // WHEN I call PreInstall with dry-run = true
@@ -361,7 +395,7 @@ func TestCreateCAResources(t *testing.T) {
 func TestRenewAllCertificatesNoCertsPresent(t *testing.T) {
 	client := fake.NewClientBuilder().WithScheme(testScheme).Build()
 	fakeContext := spi.NewFakeContext(client, defaultVZConfig, nil, false)
-	assert.NoError(t, checkRenewAllCertificates(fakeContext, true))
+	assert.NoError(t, checkRenewAllCertificates(fakeContext, caIssuerType))
 }
 
 // TestDeleteObject tests the deleteObject function
@@ -404,7 +438,7 @@ func TestCleanupUnusedACMEResources(t *testing.T) {
 		Build()
 
 	fakeContext := spi.NewFakeContext(client, vz, nil, false, profileDir)
-	assert.NoError(t, cleanupUnusedResources(fakeContext, true))
+	assert.NoError(t, cleanupUnusedResources(fakeContext, caIssuerType))
 	assertFound(t, client, verrazzanoClusterIssuerName, ComponentNamespace, &certv1.ClusterIssuer{})
 	assertNotFound(t, client, caAcmeSecretName, ComponentNamespace, &v1.Secret{})
 	assertFound(t, client, defaultCACertificateSecretName, ComponentNamespace, &v1.Secret{})
@@ -428,7 +462,7 @@ func TestCleanupUnusedDefaultCAResources(t *testing.T) {
 		Build()
 
 	fakeContext := spi.NewFakeContext(client, vz, nil, false, profileDir)
-	assert.NoError(t, cleanupUnusedResources(fakeContext, false))
+	assert.NoError(t, cleanupUnusedResources(fakeContext, acmeIssuerType))
 	assertFound(t, client, caAcmeSecretName, ComponentNamespace, &v1.Secret{})
 	assertFound(t, client, verrazzanoClusterIssuerName, ComponentNamespace, &certv1.ClusterIssuer{})
 	assertNotFound(t, client, defaultCACertificateSecretName, ComponentNamespace, &v1.Secret{})
@@ -459,7 +493,7 @@ func TestCustomCAConfigCleanupUnusedResources(t *testing.T) {
 		Build()
 
 	fakeContext := spi.NewFakeContext(client, vz, nil, false, profileDir)
-	assert.NoError(t, cleanupUnusedResources(fakeContext, true))
+	assert.NoError(t, cleanupUnusedResources(fakeContext, caIssuerType))
 	assertFound(t, client, customCAName, customCANamespace, &v1.Secret{})
 	assertFound(t, client, verrazzanoClusterIssuerName, ComponentNamespace, &certv1.ClusterIssuer{})
 	assertNotFound(t, client, caAcmeSecretName, ComponentNamespace, &v1.Secret{})
@@ -468,6 +502,31 @@ func TestCustomCAConfigCleanupUnusedResources(t *testing.T) {
 	assertNotFound(t, client, caSelfSignedIssuerName, ComponentNamespace, &certv1.Issuer{})
 }
 
+// TestVaultConfigCleanupUnusedResources tests the cleanupUnusedResources function
+// GIVEN a call to cleanupUnusedResources
+// WHEN a Vault issuer is configured and there are leftover default and ACME issuer resources
+// THEN no error is returned and all leftover default and ACME issuer resources are deleted
+func TestVaultConfigCleanupUnusedResources(t *testing.T) {
+	vz := defaultVZConfig.DeepCopy()
+	vz.Spec.Components.CertManager.Certificate = vzapi.Certificate{
+		Vault: &vzapi.VaultIssuer{Server: "https://vault:8200", Path: "pki/sign/vz", Auth: vzapi.VaultAuth{TokenSecret: "vault-token"}},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(testScheme).
+		WithObjects(createClusterIssuerResources()...).
+		WithObjects(createACMEResources()...).
+		WithObjects(createDefaultIssuerResources()...).
+		Build()
+
+	fakeContext := spi.NewFakeContext(client, vz, nil, false, profileDir)
+	assert.NoError(t, cleanupUnusedResources(fakeContext, vaultIssuerType))
+	assertFound(t, client, verrazzanoClusterIssuerName, ComponentNamespace, &certv1.ClusterIssuer{})
+	assertNotFound(t, client, caAcmeSecretName, ComponentNamespace, &v1.Secret{})
+	assertNotFound(t, client, defaultCACertificateSecretName, ComponentNamespace, &v1.Secret{})
+	assertNotFound(t, client, caCertificateName, ComponentNamespace, &certv1.Certificate{})
+	assertNotFound(t, client, caSelfSignedIssuerName, ComponentNamespace, &certv1.Issuer{})
+}
+
 // TestUninstallCertManager tests the cert-manager uninstall process
 // GIVEN a call to uninstallCertManager
 // WHEN the objects exist in the cluster
@@ -23,7 +23,7 @@ func isCoherenceOperatorReady(ctx spi.ComponentContext) bool {
 		},
 	}
 	prefix := fmt.Sprintf("Component %s", ctx.GetComponent())
-	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), deployments, 1, prefix)
+	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), deployments, 1, prefix)
 }
 
 // GetOverrides gets the install overrides
@@ -0,0 +1,124 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OverrideFacts are the cluster facts that templated override values can reference, for example
+// {{ .DNSDomain }}.  A template that references any other variable fails to render.
+type OverrideFacts interface {
+	// DNSDomain is the DNS domain of the Verrazzano endpoints, for example default.10.0.0.1.nip.io
+	DNSDomain() (string, error)
+	// EnvironmentName is the environment name of the Verrazzano installation
+	EnvironmentName() string
+	// IngressClassName is the class of the Verrazzano ingresses
+	IngressClassName() string
+	// IngressIP is the IP address of the Verrazzano ingress controller
+	IngressIP() (string, error)
+	// ClusterName is the name of a managed cluster, or local for a cluster that is not registered
+	ClusterName() (string, error)
+}
+
+// clusterFacts are the facts of the cluster of a Verrazzano CR, computed when they are referenced
+type clusterFacts struct {
+	client client.Client
+	vz     *v1alpha1.Verrazzano
+}
+
+var _ OverrideFacts = clusterFacts{}
+
+// NewClusterFacts returns the facts of the cluster of a Verrazzano CR
+func NewClusterFacts(client client.Client, vz *v1alpha1.Verrazzano) OverrideFacts {
+	return clusterFacts{client: client, vz: vz}
+}
+
+// DNSDomain returns the DNS domain of the Verrazzano endpoints
+func (f clusterFacts) DNSDomain() (string, error) {
+	return vzconfig.BuildDNSDomain(f.client, f.vz)
+}
+
+// EnvironmentName returns the environment name of the Verrazzano installation
+func (f clusterFacts) EnvironmentName() string {
+	return vzconfig.GetEnvName(f.vz)
+}
+
+// IngressClassName returns the class of the Verrazzano ingresses
+func (f clusterFacts) IngressClassName() string {
+	return vzconfig.GetIngressClassName(f.vz)
+}
+
+// IngressIP returns the IP address of the Verrazzano ingress controller
+func (f clusterFacts) IngressIP() (string, error) {
+	return vzconfig.GetIngressIP(f.client, f.vz)
+}
+
+// ClusterName returns the name of the managed cluster from the cluster registration secret, or local if the cluster
+// is not registered
+func (f clusterFacts) ClusterName() (string, error) {
+	secret := corev1.Secret{}
+	nsn := types.NamespacedName{Namespace: constants.VerrazzanoSystemNamespace, Name: constants.MCRegistrationSecret}
+	if err := f.client.Get(context.TODO(), nsn, &secret); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return constants.MCLocalCluster, nil
+		}
+		return "", err
+	}
+	return string(secret.Data[constants.ClusterNameData]), nil
+}
+
+// placeholderFacts are example facts used to validate templated override values before the facts are known
+type placeholderFacts struct{}
+
+var _ OverrideFacts = placeholderFacts{}
+
+// DNSDomain returns an example DNS domain
+func (placeholderFacts) DNSDomain() (string, error) {
+	return "default.0.0.0.0.nip.io", nil
+}
+
+// EnvironmentName returns the default environment name
+func (placeholderFacts) EnvironmentName() string {
+	return "default"
+}
+
+// IngressClassName returns the default ingress class
+func (placeholderFacts) IngressClassName() string {
+	return vzconfig.GetIngressClassName(&v1alpha1.Verrazzano{})
+}
+
+// IngressIP returns an example IP address
+func (placeholderFacts) IngressIP() (string, error) {
+	return "0.0.0.0", nil
+}
+
+// ClusterName returns the name of the local cluster
+func (placeholderFacts) ClusterName() (string, error) {
+	return constants.MCLocalCluster, nil
+}
+
+// renderOverrideTemplate renders templated override values with the cluster facts
+func renderOverrideTemplate(values string, facts OverrideFacts, source string) (string, error) {
+	tmpl, err := template.New(source).Option("missingkey=error").Parse(values)
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse the template of the override values from %s: %v", source, err)
+	}
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, facts); err != nil {
+		return "", fmt.Errorf("Failed to render the template of the override values from %s: %v", source, err)
+	}
+	return buf.String(), nil
+}
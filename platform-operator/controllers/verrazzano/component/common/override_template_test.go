@@ -0,0 +1,108 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const templatedValues = "host: api.{{ .DNSDomain }}\nenv: {{ .EnvironmentName }}\nclass: {{ .IngressClassName }}\ncluster: {{ .ClusterName }}\n"
+
+// newTemplateOverride returns a templated override of the values in the overrides ConfigMap
+func newTemplateOverride(key string) v1alpha1.Overrides {
+	return v1alpha1.Overrides{
+		ConfigMapRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "overrides"},
+			Key:                  key,
+		},
+		Template: true,
+	}
+}
+
+// TestGetInstallOverridesYAMLTemplate tests rendering templated override values
+// GIVEN a templated override in a ConfigMap, a templated inline override, and an override that is not templated
+// WHEN GetInstallOverridesYAML is called for a managed cluster with an external DNS suffix
+// THEN the templated overrides are rendered with the cluster facts, and the other override is unchanged
+func TestGetInstallOverridesYAMLTemplate(t *testing.T) {
+	vz := &v1alpha1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+		Spec: v1alpha1.VerrazzanoSpec{
+			EnvironmentName: "dev",
+			Components: v1alpha1.ComponentSpec{
+				DNS: &v1alpha1.DNSComponent{External: &v1alpha1.External{Suffix: "example.com"}},
+			},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "overrides", Namespace: "default"},
+			Data:       map[string]string{"templated": templatedValues, "static": "host: {{ .DNSDomain }}\n"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.MCRegistrationSecret, Namespace: constants.VerrazzanoSystemNamespace},
+			Data:       map[string][]byte{constants.ClusterNameData: []byte("managed1")},
+		},
+	).Build()
+	ctx := spi.NewFakeContext(cli, vz, nil, false)
+
+	yamls, err := GetInstallOverridesYAML(ctx, []v1alpha1.Overrides{
+		newTemplateOverride("templated"),
+		{Values: &apiextensionsv1.JSON{Raw: []byte(`{"ingress": {"className": "{{ .IngressClassName }}"}}`)}, Template: true},
+		{ConfigMapRef: newTemplateOverride("static").ConfigMapRef},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"host: api.dev.example.com\nenv: dev\nclass: verrazzano-nginx\ncluster: managed1\n",
+		"ingress:\n  className: 'verrazzano-nginx'\n",
+		"host: {{ .DNSDomain }}\n",
+	}, yamls)
+}
+
+// TestGetInstallOverridesYAMLTemplateErrors tests rendering invalid templated override values
+// GIVEN templated overrides that reference an unknown variable or are not valid templates
+// WHEN the overrides are rendered
+// THEN an error is returned that names the override source
+func TestGetInstallOverridesYAMLTemplateErrors(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "overrides", Namespace: "default"},
+		Data:       map[string]string{"unknown": "host: {{ .Domain }}\n", "invalid": "host: {{ .DNSDomain\n"},
+	}).Build()
+	ctx := spi.NewFakeContext(cli, &v1alpha1.Verrazzano{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}, nil, false)
+
+	_, err := GetInstallOverridesYAML(ctx, []v1alpha1.Overrides{newTemplateOverride("unknown")})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to render the template of the override values from ConfigMap default/overrides key unknown")
+	assert.Contains(t, err.Error(), "Domain")
+
+	// The webhook validation reports unknown variables before the facts of the cluster are known
+	_, err = GetInstallOverridesYAMLUsingClient(cli, v1alpha1.ConvertValueOverridesToV1Beta1([]v1alpha1.Overrides{newTemplateOverride("unknown")}), "default")
+	assert.Error(t, err)
+
+	_, err = GetInstallOverridesYAMLUsingClient(cli, v1alpha1.ConvertValueOverridesToV1Beta1([]v1alpha1.Overrides{newTemplateOverride("invalid")}), "default")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to parse the template of the override values from ConfigMap default/overrides key invalid")
+}
+
+// TestGetInstallOverridesYAMLUsingClientTemplate tests rendering templated override values with placeholder facts
+// GIVEN a templated inline override
+// WHEN GetInstallOverridesYAMLUsingClient is called
+// THEN the override is rendered with the placeholder facts
+func TestGetInstallOverridesYAMLUsingClientTemplate(t *testing.T) {
+	yamls, err := GetInstallOverridesYAMLUsingClient(nil, []v1beta1.Overrides{
+		{Values: &apiextensionsv1.JSON{Raw: []byte(`{"host": "{{ .DNSDomain }}", "ip": "{{ .IngressIP }}"}`)}, Template: true},
+	}, "default")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"host: 'default.0.0.0.0.nip.io'\nip: '0.0.0.0'\n"}, yamls)
+}
@@ -5,6 +5,7 @@ package common
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Jeffail/gabs/v2"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
@@ -20,18 +21,39 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
-// GetInstallOverridesYAML takes the list of Overrides and returns a string array of YAMLs
+// GetInstallOverridesYAML takes the list of Overrides and returns a string array of YAMLs, where templated values are
+// rendered with the facts of the cluster of the effective CR
 func GetInstallOverridesYAML(ctx spi.ComponentContext, overrides []v1alpha1.Overrides) ([]string, error) {
-	return getInstallOverridesYAML(ctx.Log(), ctx.Client(), v1alpha1.ConvertValueOverridesToV1Beta1(overrides), ctx.EffectiveCR().Namespace)
+	return getInstallOverridesYAML(ctx.Log(), ctx.Client(), v1alpha1.ConvertValueOverridesToV1Beta1(overrides), ctx.EffectiveCR().Namespace,
+		NewClusterFacts(ctx.Client(), ctx.EffectiveCR()))
 }
 
 // GetInstallOverridesYAMLUsingClient takes the list of Overrides and returns a string array of YAMLs using the
-// specified client
+// specified client.  Templated values are rendered with placeholder facts, so that they can be validated before the
+// facts of the cluster are known.
 func GetInstallOverridesYAMLUsingClient(client client.Client, overrides []v1beta1.Overrides, namespace string) ([]string, error) {
+	return GetInstallOverridesYAMLUsingFacts(client, overrides, namespace, placeholderFacts{})
+}
+
+// GetInstallOverridesYAMLUsingFacts takes the list of Overrides and returns a string array of YAMLs using the
+// specified client, where templated values are rendered with the specified facts
+func GetInstallOverridesYAMLUsingFacts(client client.Client, overrides []v1beta1.Overrides, namespace string, facts OverrideFacts) ([]string, error) {
 	// DefaultLogger is used since this is invoked from validateInstall and validateUpdate functions and
 	// any actual logging isn't being performed
 	log := vzlog.DefaultLogger()
-	return getInstallOverridesYAML(log, client, overrides, namespace)
+	return getInstallOverridesYAML(log, client, overrides, namespace, facts)
+}
+
+// GetOverrideSource returns a description of the source of the values of an override, the ConfigMap or Secret key
+// that it references, or its inline values
+func GetOverrideSource(override v1beta1.Overrides, namespace string) string {
+	if override.ConfigMapRef != nil {
+		return fmt.Sprintf("ConfigMap %s/%s key %s", namespace, override.ConfigMapRef.Name, override.ConfigMapRef.Key)
+	}
+	if override.SecretRef != nil {
+		return fmt.Sprintf("Secret %s/%s key %s", namespace, override.SecretRef.Name, override.SecretRef.Key)
+	}
+	return "inline values"
 }
 
 // ExtractValueFromOverrideString is a helper function to extract a given value from override.
@@ -47,43 +69,51 @@ func ExtractValueFromOverrideString(overrideStr string, field string) (interface
 	return jsonString.Path(field).Data(), nil
 }
 
-// getInstallOverridesYAML takes the list of Overrides and returns a string array of YAMLs
+// getInstallOverridesYAML takes the list of Overrides and returns a string array of YAMLs, where templated values are
+// rendered with the facts
 func getInstallOverridesYAML(log vzlog.VerrazzanoLogger, client client.Client, overrides []v1beta1.Overrides,
-	namespace string) ([]string, error) {
+	namespace string, facts OverrideFacts) ([]string, error) {
 	var overrideStrings []string
 	for _, override := range overrides {
-		// Check if ConfigMapRef is populated and gather data
-		if override.ConfigMapRef != nil {
-			// Get the ConfigMap data
-			data, err := getConfigMapOverrides(log, client, override.ConfigMapRef, namespace)
-			if err != nil {
-				return overrideStrings, err
-			}
-			overrideStrings = append(overrideStrings, data)
-			continue
+		data, ok, err := getOverrideValues(log, client, override, namespace)
+		if err != nil {
+			return overrideStrings, err
 		}
-		// Check if SecretRef is populated and gather data
-		if override.SecretRef != nil {
-			// Get the Secret data
-			data, err := getSecretOverrides(log, client, override.SecretRef, namespace)
-			if err != nil {
-				return overrideStrings, err
-			}
-			overrideStrings = append(overrideStrings, data)
+		if !ok {
 			continue
 		}
-		if override.Values != nil {
-			overrideValuesData, err := yaml.Marshal(override.Values)
-			if err != nil {
+		if override.Template {
+			if data, err = renderOverrideTemplate(data, facts, GetOverrideSource(override, namespace)); err != nil {
 				return overrideStrings, err
 			}
-			overrideStrings = append(overrideStrings, string(overrideValuesData))
 		}
-
+		overrideStrings = append(overrideStrings, data)
 	}
 	return overrideStrings, nil
 }
 
+// getOverrideValues returns the YAML values of an override, from the ConfigMap or Secret that it references, or its
+// inline values, and false if the override has no values
+func getOverrideValues(log vzlog.VerrazzanoLogger, client client.Client, override v1beta1.Overrides, namespace string) (string, bool, error) {
+	// Check if ConfigMapRef is populated and gather data
+	if override.ConfigMapRef != nil {
+		// Get the ConfigMap data
+		data, err := getConfigMapOverrides(log, client, override.ConfigMapRef, namespace)
+		return data, true, err
+	}
+	// Check if SecretRef is populated and gather data
+	if override.SecretRef != nil {
+		// Get the Secret data
+		data, err := getSecretOverrides(log, client, override.SecretRef, namespace)
+		return data, true, err
+	}
+	if override.Values != nil {
+		overrideValuesData, err := yaml.Marshal(override.Values)
+		return string(overrideValuesData), true, err
+	}
+	return "", false, nil
+}
+
 // getConfigMapOverrides takes a ConfigMap selector and returns the YAML data and handles k8s api errors appropriately
 func getConfigMapOverrides(log vzlog.VerrazzanoLogger, client client.Client, selector *v1.ConfigMapKeySelector,
 	namespace string) (string, error) {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/verrazzano/verrazzano/pkg/constants"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
@@ -71,7 +72,14 @@ func (c *certBuilder) buildLetsEncryptStagingChain() error {
 }
 
 func useAdditionalCAs(acme vzapi.Acme) bool {
-	return acme != vzapi.Acme{} && acme.Environment != "production"
+	return IsLetsEncryptIssuer(acme) && acme.Environment != "production"
+}
+
+// IsLetsEncryptIssuer returns true if the certificates are issued by Let's Encrypt, which Rancher requests its own
+// certificate from.  The certificates of generic ACME providers are issued by the Verrazzano ClusterIssuer, like those
+// of CA issuers.
+func IsLetsEncryptIssuer(acme vzapi.Acme) bool {
+	return acme != vzapi.Acme{} && !strings.EqualFold(string(acme.Provider), string(vzapi.GenericACME))
 }
 
 func ProcessAdditionalCertificates(log vzlog.VerrazzanoLogger, cli client.Client, vz *vzapi.Verrazzano) error {
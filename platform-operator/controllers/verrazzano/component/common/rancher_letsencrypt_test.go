@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 )
 
 // TestCertBuilder verifies downloading certs from the web
@@ -82,3 +83,14 @@ func TestBuildLetsEncryptChain(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "certcertcert", string(builder.cert))
 }
+
+// TestIsLetsEncryptIssuer verifies detecting the Let's Encrypt issuer
+// GIVEN an ACME configuration
+//  WHEN IsLetsEncryptIssuer is called
+//  THEN IsLetsEncryptIssuer returns true only for the Let's Encrypt provider
+func TestIsLetsEncryptIssuer(t *testing.T) {
+	assert.False(t, IsLetsEncryptIssuer(vzapi.Acme{}))
+	assert.True(t, IsLetsEncryptIssuer(vzapi.Acme{Provider: vzapi.LetsEncrypt, Environment: "staging"}))
+	assert.False(t, IsLetsEncryptIssuer(vzapi.Acme{Provider: vzapi.GenericACME, DirectoryURL: "https://ca.example.com/directory"}))
+	assert.False(t, useAdditionalCAs(vzapi.Acme{Provider: "acme", DirectoryURL: "https://ca.example.com/directory"}))
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	vzsecret "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/secret"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/credentials"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/namespace"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/status"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
@@ -110,54 +111,28 @@ func CreateOrUpdateVMI(ctx spi.ComponentContext, updateFunc VMIMutateFunc) error
 	return nil
 }
 
-// EnsureVMISecret creates or updates the VMI secret
-func EnsureVMISecret(cli client.Client) error {
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      constants.VMISecret,
-			Namespace: globalconst.VerrazzanoSystemNamespace,
-		},
-		Data: map[string][]byte{},
-	}
-	if _, err := controllerruntime.CreateOrUpdate(context.TODO(), cli, secret, func() error {
-		if secret.Data["username"] == nil || secret.Data["password"] == nil {
-			secret.Data["username"] = []byte(constants.VMISecret)
-			pw, err := password.GeneratePassword(16)
-			if err != nil {
-				return err
-			}
-			secret.Data["password"] = []byte(pw)
+// EnsureVMISecret creates or updates the VMI secret, using the credentials store if one is configured
+func EnsureVMISecret(cli client.Client, vz *vzapi.Verrazzano) error {
+	nsn := types.NamespacedName{Name: constants.VMISecret, Namespace: globalconst.VerrazzanoSystemNamespace}
+	return credentials.EnsureSecret(cli, vz, nsn, func() (map[string][]byte, error) {
+		pw, err := password.GeneratePassword(16)
+		if err != nil {
+			return nil, err
 		}
-		return nil
-	}); err != nil {
-		return err
-	}
-	return nil
+		return map[string][]byte{"username": []byte(constants.VMISecret), "password": []byte(pw)}, nil
+	})
 }
 
-// EnsureGrafanaAdminSecret creates or updates the Grafana admin secret
-func EnsureGrafanaAdminSecret(cli client.Client) error {
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      constants.GrafanaSecret,
-			Namespace: globalconst.VerrazzanoSystemNamespace,
-		},
-		Data: map[string][]byte{},
-	}
-	if _, err := controllerruntime.CreateOrUpdate(context.TODO(), cli, secret, func() error {
-		if secret.Data["username"] == nil || secret.Data["password"] == nil {
-			secret.Data["username"] = []byte(constants.VMISecret)
-			pw, err := password.GeneratePassword(32)
-			if err != nil {
-				return err
-			}
-			secret.Data["password"] = []byte(pw)
+// EnsureGrafanaAdminSecret creates or updates the Grafana admin secret, using the credentials store if one is configured
+func EnsureGrafanaAdminSecret(cli client.Client, vz *vzapi.Verrazzano) error {
+	nsn := types.NamespacedName{Name: constants.GrafanaSecret, Namespace: globalconst.VerrazzanoSystemNamespace}
+	return credentials.EnsureSecret(cli, vz, nsn, func() (map[string][]byte, error) {
+		pw, err := password.GeneratePassword(32)
+		if err != nil {
+			return nil, err
 		}
-		return nil
-	}); err != nil {
-		return err
-	}
-	return nil
+		return map[string][]byte{"username": []byte(constants.VMISecret), "password": []byte(pw)}, nil
+	})
 }
 
 // EnsureGrafanaDatabaseSecret ensures that the DB login secret provided in the verrazzano-install namespace is copied
@@ -327,7 +302,7 @@ func CheckIngressesAndCerts(ctx spi.ComponentContext, comp spi.Component) error
 	return nil
 }
 
-//IsMultiNodeOpenSearch returns true if the VZ OpenSearch has more than 1 node.
+// IsMultiNodeOpenSearch returns true if the VZ OpenSearch has more than 1 node.
 func IsMultiNodeOpenSearch(vz *vzapi.Verrazzano) (bool, error) {
 	opensearch := vz.Spec.Components.Elasticsearch
 	var replicas int32
@@ -343,14 +318,14 @@ func IsMultiNodeOpenSearch(vz *vzapi.Verrazzano) (bool, error) {
 	return replicas > 1, nil
 }
 
-//addNodeGroupReplicas iterates through each OpenSearch node and sums the replicas
+// addNodeGroupReplicas iterates through each OpenSearch node and sums the replicas
 func addNodeGroupReplicas(os *vzapi.ElasticsearchComponent, replicas *int32) {
 	for _, node := range os.Nodes {
 		*replicas += node.Replicas
 	}
 }
 
-//addInstallArgReplicas sums the replicas from master, data, and ingest node install args.
+// addInstallArgReplicas sums the replicas from master, data, and ingest node install args.
 func addInstallArgReplicas(os *vzapi.ElasticsearchComponent, replicas *int32) error {
 	addStr := func(v string) error {
 		var val int32
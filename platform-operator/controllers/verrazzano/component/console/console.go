@@ -70,6 +70,7 @@ func isConsoleReady(ctx spi.ComponentContext) bool {
 	return status.DeploymentsAreReady(
 		ctx.Log(),
 		ctx.Client(),
+		ctx.ActualCR(),
 		[]types.NamespacedName{
 			{
 				Namespace: ComponentNamespace,
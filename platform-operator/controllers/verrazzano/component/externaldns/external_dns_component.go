@@ -70,11 +70,36 @@ func (e externalDNSComponent) PostUninstall(ctx spi.ComponentContext) error {
 	return postUninstall(ctx.Log(), ctx.Client())
 }
 
+// ValidateInstall checks if the specified Verrazzano CR is valid for this component to be installed
+func (e externalDNSComponent) ValidateInstall(vz *vzapi.Verrazzano) error {
+	if vz.Spec.Components.DNS != nil {
+		if err := validateRFC2136((*installv1beta1.RFC2136)(vz.Spec.Components.DNS.RFC2136)); err != nil {
+			return err
+		}
+	}
+	return e.HelmComponent.ValidateInstall(vz)
+}
+
+// ValidateInstallV1Beta1 checks if the specified Verrazzano CR is valid for this component to be installed
+func (e externalDNSComponent) ValidateInstallV1Beta1(vz *installv1beta1.Verrazzano) error {
+	if vz.Spec.Components.DNS != nil {
+		if err := validateRFC2136(vz.Spec.Components.DNS.RFC2136); err != nil {
+			return err
+		}
+	}
+	return e.HelmComponent.ValidateInstallV1Beta1(vz)
+}
+
 // ValidateUpdate checks if the specified new Verrazzano CR is valid for this component to be updated
 func (e externalDNSComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.Verrazzano) error {
 	// Do not allow any changes except to enable the component post-install
 	if e.IsEnabled(old) && !e.IsEnabled(new) {
-		return fmt.Errorf("Disabling an existing OCI DNS configuration is not allowed")
+		return fmt.Errorf("Disabling an existing OCI or RFC2136 DNS configuration is not allowed")
+	}
+	if new.Spec.Components.DNS != nil {
+		if err := validateRFC2136((*installv1beta1.RFC2136)(new.Spec.Components.DNS.RFC2136)); err != nil {
+			return err
+		}
 	}
 	return e.HelmComponent.ValidateUpdate(old, new)
 }
@@ -83,7 +108,12 @@ func (e externalDNSComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.V
 func (e externalDNSComponent) ValidateUpdateV1Beta1(old *installv1beta1.Verrazzano, new *installv1beta1.Verrazzano) error {
 	// Do not allow any changes except to enable the component post-install
 	if e.IsEnabled(old) && !e.IsEnabled(new) {
-		return fmt.Errorf("Disabling an existing OCI DNS configuration is not allowed")
+		return fmt.Errorf("Disabling an existing OCI or RFC2136 DNS configuration is not allowed")
+	}
+	if new.Spec.Components.DNS != nil {
+		if err := validateRFC2136(new.Spec.Components.DNS.RFC2136); err != nil {
+			return err
+		}
 	}
 	return e.HelmComponent.ValidateUpdateV1Beta1(old, new)
 }
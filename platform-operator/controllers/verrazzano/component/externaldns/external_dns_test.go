@@ -4,11 +4,12 @@
 package externaldns
 
 import (
+	"encoding/json"
 	"github.com/verrazzano/verrazzano/pkg/helm"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"helm.sh/helm/v3/pkg/release"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"os/exec"
 	"strings"
 	"testing"
 
@@ -78,18 +79,6 @@ func init() {
 	_ = vzapi.AddToScheme(testScheme)
 }
 
-// genericTestRunner is used to run generic OS commands with expected results
-type genericTestRunner struct {
-	stdOut []byte
-	stdErr []byte
-	err    error
-}
-
-// Run genericTestRunner executor
-func (r genericTestRunner) Run(_ *exec.Cmd) (stdout []byte, stderr []byte, err error) {
-	return r.stdOut, r.stdErr, r.err
-}
-
 // TestIsExternalDNSEnabled tests the IsEnabled fn
 // GIVEN a call to IsEnabled
 // WHEN OCI DNS is enabled
@@ -138,12 +127,8 @@ func TestAppendExternalDNSOverrides(t *testing.T) {
 	localvz := vz.DeepCopy()
 	localvz.Spec.Components.DNS.OCI = oci
 
-	helm.SetCmdRunner(genericTestRunner{
-		stdOut: []byte(""),
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction())
+	defer helm.SetDefaultActionConfigFunction()
 
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
 		return helm.ChartNotFound, nil
@@ -152,7 +137,8 @@ func TestAppendExternalDNSOverrides(t *testing.T) {
 
 	kvs, err := AppendOverrides(spi.NewFakeContext(nil, localvz, nil, false, profileDir), ComponentName, ComponentNamespace, "", []bom.KeyValue{})
 	assert.NoError(t, err)
-	assert.Len(t, kvs, 9)
+	assert.Len(t, kvs, 10)
+	assert.Contains(t, kvs, bom.KeyValue{Key: "provider", Value: ociProviderName})
 }
 
 // TestExternalDNSPreInstallDryRun tests the PreInstall fn
@@ -248,12 +234,12 @@ func TestOwnerIDTextPrefix_HelmValueExists(t *testing.T) {
 }
 `)
 
-	helm.SetCmdRunner(genericTestRunner{
-		stdOut: jsonOut,
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer helm.SetDefaultRunner()
+	var values map[string]interface{}
+	assert.NoError(t, json.Unmarshal(jsonOut, &values))
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction(
+		helm.CreateRelease(ComponentName, ComponentNamespace, release.StatusDeployed, "1.0", values),
+	))
+	defer helm.SetDefaultActionConfigFunction()
 
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
 		return helm.ChartStatusDeployed, nil
@@ -281,12 +267,8 @@ func TestOwnerIDTextPrefix_HelmValueExists(t *testing.T) {
 //  WHEN no stored helm values exist
 //  THEN the function returns the generated values and no error
 func Test_getOrBuildOwnerID_NoHelmValueExists(t *testing.T) {
-	helm.SetCmdRunner(genericTestRunner{
-		stdOut: []byte(""),
-		stdErr: []byte{},
-		err:    nil,
-	})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction())
+	defer helm.SetDefaultActionConfigFunction()
 
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
 		return helm.ChartNotFound, nil
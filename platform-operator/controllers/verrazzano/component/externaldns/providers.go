@@ -0,0 +1,200 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package externaldns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/verrazzano/verrazzano/pkg/bom"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	ociProviderName     = "oci"
+	rfc2136ProviderName = "rfc2136"
+
+	// Key of the TSIG secret in the RFC2136 secret, and the environment variable of external-dns that it is read from
+	rfc2136TSIGSecretKey    = "tsigSecret"
+	rfc2136TSIGSecretEnvVar = "EXTERNAL_DNS_RFC2136_TSIG_SECRET"
+)
+
+// dnsProvider is a DNS provider of external-dns.  Each provider creates the resources it needs, such as the secret with
+// its credentials, and builds its own helm overrides, so that providers can be added without changing the component.
+type dnsProvider interface {
+	// name returns the name of the provider in external-dns
+	name() string
+	// zoneName returns the name of the DNS zone that the records are created in
+	zoneName() string
+	// preInstall creates the resources of the provider in the component namespace
+	preInstall(compContext spi.ComponentContext) error
+	// overrides returns the helm overrides of the provider
+	overrides() []bom.KeyValue
+}
+
+// getDNSProvider returns the external-dns provider of the DNS type configured in the Verrazzano CR
+func getDNSProvider(vz *vzapi.Verrazzano) (dnsProvider, error) {
+	dns := vz.Spec.Components.DNS
+	// Should never fail the next error checks if IsEnabled() is correct, but can't hurt to check
+	if dns == nil {
+		return nil, fmt.Errorf("DNS not configured for component %s", ComponentName)
+	}
+	if dns.OCI != nil {
+		return ociProvider{oci: dns.OCI}, nil
+	}
+	if dns.RFC2136 != nil {
+		return rfc2136Provider{rfc2136: dns.RFC2136}, nil
+	}
+	return nil, fmt.Errorf("OCI or RFC2136 DNS must be configured for component %s", ComponentName)
+}
+
+// copyInstallSecret copies a secret from the verrazzano-install namespace to the component namespace, with the data
+// built by the given function
+func copyInstallSecret(compContext spi.ComponentContext, name string, buildData func(data map[string][]byte) (map[string][]byte, error)) error {
+	dnsSecret := v1.Secret{}
+	if err := compContext.Client().Get(context.TODO(), client.ObjectKey{Name: name, Namespace: constants.VerrazzanoInstallNamespace}, &dnsSecret); err != nil {
+		return compContext.Log().ErrorfNewErr("Failed to find secret %s in the %s namespace: %v", name, constants.VerrazzanoInstallNamespace, err)
+	}
+
+	externalDNSSecret := v1.Secret{}
+	compContext.Log().Debug("Creating the external DNS secret")
+	externalDNSSecret.Namespace = ComponentNamespace
+	externalDNSSecret.Name = dnsSecret.Name
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), compContext.Client(), &externalDNSSecret, func() error {
+		data, err := buildData(dnsSecret.Data)
+		if err != nil {
+			return err
+		}
+		externalDNSSecret.Data = data
+		return nil
+	}); err != nil {
+		return compContext.Log().ErrorfNewErr("Failed to create or update the external DNS secret: %v", err)
+	}
+	return nil
+}
+
+// ociProvider is the OCI DNS provider
+type ociProvider struct {
+	oci *vzapi.OCI
+}
+
+func (p ociProvider) name() string {
+	return ociProviderName
+}
+
+func (p ociProvider) zoneName() string {
+	return p.oci.DNSZoneName
+}
+
+// preInstall validates the DNS scope and creates the OCI config secret in the component namespace, with the
+// compartment of the DNS zone
+func (p ociProvider) preInstall(compContext spi.ComponentContext) error {
+	//check if scope value is valid
+	scope := p.oci.DNSScope
+	if scope != dnsGlobal && scope != dnsPrivate && scope != "" {
+		return compContext.Log().ErrorfNewErr("Failed, invalid OCI DNS scope value: %s. If set, value can only be 'GLOBAL' or 'PRIVATE", p.oci.DNSScope)
+	}
+
+	// Attach compartment field to secret and apply it in the external DNS namespace
+	return copyInstallSecret(compContext, p.oci.OCIConfigSecret, func(data map[string][]byte) (map[string][]byte, error) {
+		// Verify that the oci secret has one value
+		if len(data) != 1 {
+			return nil, compContext.Log().ErrorNewErr("Failed, OCI secret for OCI DNS should be created from one file")
+		}
+
+		// Extract data and create secret in the external DNS namespace
+		ociData := make(map[string][]byte)
+		for k := range data {
+			ociData[ociSecretFileName] = append(data[k], []byte(fmt.Sprintf("compartment: %s", p.oci.DNSZoneCompartmentOCID))...)
+		}
+		return ociData, nil
+	})
+}
+
+func (p ociProvider) overrides() []bom.KeyValue {
+	return []bom.KeyValue{
+		{Key: "zoneIDFilters[0]", Value: p.oci.DNSZoneOCID},
+		{Key: "ociDnsScope", Value: p.oci.DNSScope},
+		{Key: "extraVolumes[0].name", Value: "config"},
+		{Key: "extraVolumes[0].secret.secretName", Value: p.oci.OCIConfigSecret},
+		{Key: "extraVolumeMounts[0].name", Value: "config"},
+		{Key: "extraVolumeMounts[0].mountPath", Value: "/etc/kubernetes/"},
+	}
+}
+
+// rfc2136Provider is the RFC2136 provider, which creates the DNS records with dynamic updates of a DNS server
+type rfc2136Provider struct {
+	rfc2136 *vzapi.RFC2136
+}
+
+func (p rfc2136Provider) name() string {
+	return rfc2136ProviderName
+}
+
+func (p rfc2136Provider) zoneName() string {
+	return p.rfc2136.DNSZoneName
+}
+
+// preInstall creates the TSIG secret in the component namespace, if the updates are signed with a TSIG key
+func (p rfc2136Provider) preInstall(compContext spi.ComponentContext) error {
+	if len(p.rfc2136.TSIGSecret) == 0 {
+		return nil
+	}
+	return copyInstallSecret(compContext, p.rfc2136.TSIGSecret, func(data map[string][]byte) (map[string][]byte, error) {
+		tsigSecret, ok := data[rfc2136TSIGSecretKey]
+		if !ok {
+			return nil, compContext.Log().ErrorfNewErr("Failed, the RFC2136 secret %s has no %s key", p.rfc2136.TSIGSecret, rfc2136TSIGSecretKey)
+		}
+		return map[string][]byte{rfc2136TSIGSecretKey: tsigSecret}, nil
+	})
+}
+
+// overrides returns the RFC2136 overrides.  The TSIG secret is read from the copied secret, instead of being passed
+// as a helm value.  Without a TSIG key name the chart configures insecure updates.
+func (p rfc2136Provider) overrides() []bom.KeyValue {
+	kvs := []bom.KeyValue{
+		{Key: "rfc2136.host", Value: p.rfc2136.Host},
+		{Key: "rfc2136.zone", Value: p.rfc2136.DNSZoneName},
+		{Key: "rfc2136.tsigKeyname", Value: p.rfc2136.TSIGKeyName},
+	}
+	if p.rfc2136.Port > 0 {
+		kvs = append(kvs, bom.KeyValue{Key: "rfc2136.port", Value: strconv.Itoa(p.rfc2136.Port)})
+	}
+	if len(p.rfc2136.TSIGAlgorithm) > 0 {
+		kvs = append(kvs, bom.KeyValue{Key: "rfc2136.tsigSecretAlg", Value: p.rfc2136.TSIGAlgorithm})
+	}
+	if len(p.rfc2136.TSIGSecret) > 0 {
+		kvs = append(kvs,
+			bom.KeyValue{Key: "extraEnv[0].name", Value: rfc2136TSIGSecretEnvVar},
+			bom.KeyValue{Key: "extraEnv[0].valueFrom.secretKeyRef.name", Value: p.rfc2136.TSIGSecret},
+			bom.KeyValue{Key: "extraEnv[0].valueFrom.secretKeyRef.key", Value: rfc2136TSIGSecretKey},
+		)
+	}
+	return kvs
+}
+
+// validateRFC2136 validates the RFC2136 DNS configuration, the TSIG key name and secret are specified together
+func validateRFC2136(rfc2136 *installv1beta1.RFC2136) error {
+	if rfc2136 == nil {
+		return nil
+	}
+	if len(rfc2136.Host) == 0 || len(rfc2136.DNSZoneName) == 0 {
+		return errors.New("The host and the DNS zone name must be specified for RFC2136 DNS")
+	}
+	if rfc2136.Port < 0 || rfc2136.Port > 65535 {
+		return fmt.Errorf("Invalid port %d of the RFC2136 DNS server", rfc2136.Port)
+	}
+	if (len(rfc2136.TSIGKeyName) == 0) != (len(rfc2136.TSIGSecret) == 0) {
+		return errors.New("The TSIG key name and the TSIG secret must be specified together for RFC2136 DNS")
+	}
+	return nil
+}
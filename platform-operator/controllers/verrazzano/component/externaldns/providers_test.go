@@ -0,0 +1,157 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package externaldns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/bom"
+	"github.com/verrazzano/verrazzano/pkg/helm"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const tsigSecretName = "rfc2136-tsig"
+
+var rfc2136 = &vzapi.RFC2136{
+	Host:          "bind.dns.svc.cluster.local",
+	Port:          5353,
+	DNSZoneName:   "zone.name.io",
+	TSIGKeyName:   "externaldns-key",
+	TSIGAlgorithm: "hmac-sha512",
+	TSIGSecret:    tsigSecretName,
+}
+
+// TestAppendRFC2136Overrides tests the AppendOverrides fn
+// GIVEN a call to AppendOverrides
+// WHEN a VZ spec is passed with RFC2136 DNS with a TSIG key
+// THEN the RFC2136 provider is configured and the TSIG secret is read from the secret
+func TestAppendRFC2136Overrides(t *testing.T) {
+	localvz := vz.DeepCopy()
+	localvz.Spec.Components.DNS.RFC2136 = rfc2136
+
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction())
+	defer helm.SetDefaultActionConfigFunction()
+
+	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
+		return helm.ChartNotFound, nil
+	})
+	defer helm.SetDefaultChartStatusFunction()
+
+	kvs, err := AppendOverrides(spi.NewFakeContext(nil, localvz, nil, false, profileDir), ComponentName, ComponentNamespace, "", []bom.KeyValue{})
+	assert.NoError(t, err)
+	assert.Contains(t, kvs, bom.KeyValue{Key: "provider", Value: rfc2136ProviderName})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "domainFilters[0]", Value: "zone.name.io"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.host", Value: "bind.dns.svc.cluster.local"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.port", Value: "5353"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.zone", Value: "zone.name.io"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.tsigKeyname", Value: "externaldns-key"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.tsigSecretAlg", Value: "hmac-sha512"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "extraEnv[0].name", Value: rfc2136TSIGSecretEnvVar})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "extraEnv[0].valueFrom.secretKeyRef.name", Value: tsigSecretName})
+	for _, kv := range kvs {
+		assert.NotEqual(t, "zoneIDFilters[0]", kv.Key)
+		assert.NotEqual(t, "rfc2136.tsigSecret", kv.Key)
+	}
+}
+
+// TestRFC2136OverridesInsecure tests the overrides of the RFC2136 provider
+// GIVEN RFC2136 DNS without a TSIG key
+// WHEN the overrides are built
+// THEN the TSIG key name is empty, so that the updates are not signed, and no TSIG secret is read
+func TestRFC2136OverridesInsecure(t *testing.T) {
+	kvs := rfc2136Provider{rfc2136: &vzapi.RFC2136{Host: "10.0.0.53", DNSZoneName: "zone.name.io"}}.overrides()
+	assert.Equal(t, []bom.KeyValue{
+		{Key: "rfc2136.host", Value: "10.0.0.53"},
+		{Key: "rfc2136.zone", Value: "zone.name.io"},
+		{Key: "rfc2136.tsigKeyname", Value: ""},
+	}, kvs)
+}
+
+// TestRFC2136PreInstall tests the PreInstall fn
+// GIVEN RFC2136 DNS with a TSIG secret in the verrazzano-install namespace
+// WHEN I call PreInstall
+// THEN the TSIG secret is created in the component namespace, and an error is returned if the secret has no TSIG secret
+func TestRFC2136PreInstall(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: tsigSecretName, Namespace: constants.VerrazzanoInstallNamespace},
+		Data:       map[string][]byte{rfc2136TSIGSecretKey: []byte("c2VjcmV0"), "other": []byte("other")},
+	}
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(secret).Build()
+	localvz := vz.DeepCopy()
+	localvz.Spec.Components.DNS.RFC2136 = rfc2136
+	assert.NoError(t, fakeComponent.PreInstall(spi.NewFakeContext(client, localvz, nil, false)))
+
+	copied := v1.Secret{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: tsigSecretName, Namespace: ComponentNamespace}, &copied))
+	assert.Equal(t, map[string][]byte{rfc2136TSIGSecretKey: []byte("c2VjcmV0")}, copied.Data)
+
+	delete(secret.Data, rfc2136TSIGSecretKey)
+	assert.NoError(t, client.Update(context.TODO(), secret))
+	assert.Error(t, fakeComponent.PreInstall(spi.NewFakeContext(client, localvz, nil, false)))
+
+	// No secret is needed without a TSIG key
+	client = fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	localvz.Spec.Components.DNS.RFC2136 = &vzapi.RFC2136{Host: "10.0.0.53", DNSZoneName: "zone.name.io"}
+	assert.NoError(t, fakeComponent.PreInstall(spi.NewFakeContext(client, localvz, nil, false)))
+}
+
+// TestValidateRFC2136 tests the validateRFC2136 fn
+// GIVEN RFC2136 DNS configurations
+// WHEN validateRFC2136 is called
+// THEN an error is returned if the host or zone is missing, the port is invalid, or only one of the TSIG key name and
+// secret is specified
+func TestValidateRFC2136(t *testing.T) {
+	tests := []struct {
+		name    string
+		rfc2136 *v1beta1.RFC2136
+		wantErr bool
+	}{
+		{name: "notConfigured"},
+		{name: "insecure", rfc2136: &v1beta1.RFC2136{Host: "10.0.0.53", DNSZoneName: "zone.io"}},
+		{name: "tsig", rfc2136: &v1beta1.RFC2136{Host: "10.0.0.53", DNSZoneName: "zone.io", TSIGKeyName: "key", TSIGSecret: "secret"}},
+		{name: "missingHost", rfc2136: &v1beta1.RFC2136{DNSZoneName: "zone.io"}, wantErr: true},
+		{name: "missingZone", rfc2136: &v1beta1.RFC2136{Host: "10.0.0.53"}, wantErr: true},
+		{name: "invalidPort", rfc2136: &v1beta1.RFC2136{Host: "10.0.0.53", DNSZoneName: "zone.io", Port: 70000}, wantErr: true},
+		{name: "missingTSIGSecret", rfc2136: &v1beta1.RFC2136{Host: "10.0.0.53", DNSZoneName: "zone.io", TSIGKeyName: "key"}, wantErr: true},
+		{name: "missingTSIGKeyName", rfc2136: &v1beta1.RFC2136{Host: "10.0.0.53", DNSZoneName: "zone.io", TSIGSecret: "secret"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRFC2136(tt.rfc2136)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+// TestGetDNSProvider tests the getDNSProvider fn
+// GIVEN Verrazzano CRs with different DNS types
+// WHEN getDNSProvider is called
+// THEN the provider of the DNS type is returned, or an error if the DNS type has no external-dns provider
+func TestGetDNSProvider(t *testing.T) {
+	localvz := vz.DeepCopy()
+	_, err := getDNSProvider(localvz)
+	assert.Error(t, err)
+
+	localvz.Spec.Components.DNS.OCI = oci
+	provider, err := getDNSProvider(localvz)
+	assert.NoError(t, err)
+	assert.Equal(t, ociProviderName, provider.name())
+
+	localvz.Spec.Components.DNS.OCI = nil
+	localvz.Spec.Components.DNS.RFC2136 = rfc2136
+	provider, err = getDNSProvider(localvz)
+	assert.NoError(t, err)
+	assert.Equal(t, rfc2136ProviderName, provider.name())
+	assert.Equal(t, "zone.name.io", provider.zoneName())
+}
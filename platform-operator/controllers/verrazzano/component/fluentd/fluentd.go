@@ -10,6 +10,7 @@ import (
 	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
 	ctrlerrors "github.com/verrazzano/verrazzano/pkg/controller/errors"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
@@ -72,11 +73,33 @@ func loggingPreInstall(ctx spi.ComponentContext) error {
 					return err
 				}
 			}
+			// Copy the secrets of the additional outputs
+			for _, secretName := range getOutputSecrets(fluentdConfig) {
+				if err := common.CopySecret(ctx, secretName, constants.VerrazzanoSystemNamespace, "Fluentd output"); err != nil {
+					return err
+				}
+			}
 		}
 	}
 	return nil
 }
 
+// getOutputSecrets returns the names of the secrets of the additional outputs
+func getOutputSecrets(fluentd *vzapi.FluentdComponent) []string {
+	var secrets []string
+	for _, output := range fluentd.Outputs {
+		switch {
+		case output.Kafka != nil && len(output.Kafka.Secret) > 0:
+			secrets = append(secrets, output.Kafka.Secret)
+		case output.Syslog != nil && len(output.Syslog.Secret) > 0:
+			secrets = append(secrets, output.Syslog.Secret)
+		case output.S3 != nil && len(output.S3.Secret) > 0:
+			secrets = append(secrets, output.S3.Secret)
+		}
+	}
+	return secrets
+}
+
 // isFluentdReady Fluentd component ready-check
 func isFluentdReady(ctx spi.ComponentContext) bool {
 	prefix := fmt.Sprintf("Component %s", ctx.GetComponent())
@@ -89,7 +112,7 @@ func isFluentdReady(ctx spi.ComponentContext) bool {
 				Name:      ComponentName,
 				Namespace: ComponentNamespace,
 			})
-		return status.DaemonSetsAreReady(ctx.Log(), ctx.Client(), daemonsets, 1, prefix)
+		return status.DaemonSetsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), daemonsets, 1, prefix)
 	}
 	return false
 }
@@ -271,6 +271,100 @@ func TestLoggingPreInstall(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// TestLoggingPreInstallOutputSecrets tests the Fluentd loggingPreInstall call with additional outputs
+// GIVEN a Fluentd component with Kafka, syslog and S3 outputs
+//  WHEN I call loggingPreInstall
+//  THEN no error is returned and the secrets of the outputs have been copied
+func TestLoggingPreInstallOutputSecrets(t *testing.T) {
+	trueValue := true
+	secretNames := []string{"kafka-secret", "syslog-secret", "s3-secret"} //nolint:gosec //#gosec G101
+	var objs []clipkg.Object
+	for _, name := range secretNames {
+		objs = append(objs, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: vpoconst.VerrazzanoInstallNamespace, Name: name},
+		})
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(objs...).Build()
+	ctx := spi.NewFakeContext(c, &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Fluentd: &vzapi.FluentdComponent{
+					Enabled: &trueValue,
+					Outputs: []vzapi.FluentdOutput{
+						{Name: "kafka", Kafka: &vzapi.FluentdKafkaOutput{Brokers: []string{"kafka:9093"}, Topic: "logs", Secret: secretNames[0]}},
+						{Name: "syslog", Syslog: &vzapi.FluentdSyslogOutput{Host: "syslog", Secret: secretNames[1]}},
+						{Name: "s3", S3: &vzapi.FluentdS3Output{Endpoint: "https://s3", Region: "r", Bucket: "b", Secret: secretNames[2]}},
+					},
+				},
+			},
+		},
+	}, nil, false)
+	err := loggingPreInstall(ctx)
+	assert.NoError(t, err)
+
+	for _, name := range secretNames {
+		secret := &corev1.Secret{}
+		err = c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: ComponentNamespace}, secret)
+		assert.NoError(t, err)
+	}
+}
+
+// TestAppendFluentdOverridesOutputs tests the overrides of the additional outputs
+// GIVEN a Fluentd component with a syslog output without a port and an S3 output
+//  WHEN I call appendFluentdOverrides
+//  THEN the outputs are in the overrides and the syslog port defaults to 6514
+func TestAppendFluentdOverridesOutputs(t *testing.T) {
+	vz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Fluentd: &vzapi.FluentdComponent{
+					Outputs: []vzapi.FluentdOutput{
+						{Name: "syslog", Namespaces: []string{"ns1"}, Syslog: &vzapi.FluentdSyslogOutput{Host: "syslog.example.com"}},
+						{Name: "s3", S3: &vzapi.FluentdS3Output{Endpoint: "https://s3.example.com", Region: "r", Bucket: "b", Path: "logs/", Secret: "s3-secret"}},
+					},
+				},
+			},
+		},
+	}
+	overrides := fluentdComponentValues{}
+	appendFluentdOverrides(vz, &overrides)
+
+	assert.Len(t, overrides.Fluentd.Outputs, 2)
+	assert.Equal(t, "syslog", overrides.Fluentd.Outputs[0].Name)
+	assert.Equal(t, []string{"ns1"}, overrides.Fluentd.Outputs[0].Namespaces)
+	assert.Equal(t, &syslogSettings{Host: "syslog.example.com", Port: 6514}, overrides.Fluentd.Outputs[0].Syslog)
+	assert.Nil(t, overrides.Fluentd.Outputs[0].S3)
+	assert.Equal(t, &s3Settings{Endpoint: "https://s3.example.com", Region: "r", Bucket: "b", Path: "logs/", Secret: "s3-secret"},
+		overrides.Fluentd.Outputs[1].S3)
+}
+
+// TestAppendFluentdOverridesLogRoutingClasses tests the overrides of the log routing classes
+// GIVEN a Fluentd component with OpenSearch and OCI log routing classes
+//  WHEN I call appendFluentdOverrides
+//  THEN the classes are in the overrides without their retention
+func TestAppendFluentdOverridesLogRoutingClasses(t *testing.T) {
+	retention := "7d"
+	vz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Fluentd: &vzapi.FluentdComponent{
+					LogRoutingClasses: []vzapi.LogRoutingClass{
+						{Name: "gold", Destination: vzapi.LogRoutingDestinationOpenSearch, Retention: &retention},
+						{Name: "oci", Destination: vzapi.LogRoutingDestinationOCI, OCILogID: "ocid1.log.oc1.test"},
+					},
+				},
+			},
+		},
+	}
+	overrides := fluentdComponentValues{}
+	appendFluentdOverrides(vz, &overrides)
+
+	assert.Equal(t, []logRoutingClass{
+		{Name: "gold", Destination: "OpenSearch"},
+		{Name: "oci", Destination: "OCI", OCILogID: "ocid1.log.oc1.test"},
+	}, overrides.Fluentd.LogRoutingClasses)
+}
+
 // TestLoggingPreInstallSecretNotFound tests the Verrazzano loggingPreInstall call
 // GIVEN a Verrazzano component
 //  WHEN I call loggingPreInstall with fluentd overrides for ES and a custom ES secret and the secret does not exist
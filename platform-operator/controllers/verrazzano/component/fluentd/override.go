@@ -23,6 +23,8 @@ const (
 	tmpSuffix            = "yaml"
 	tmpFileCreatePattern = tmpFilePrefix + "*." + tmpSuffix
 	tmpFileCleanPattern  = tmpFilePrefix + ".*\\." + tmpSuffix
+
+	defaultSyslogPort = 6514
 )
 
 type fluentdComponentValues struct {
@@ -42,6 +44,8 @@ type fluentdValues struct {
 	Enabled           bool                `json:"enabled"` // Always write
 	ExtraVolumeMounts []volumeMount       `json:"extraVolumeMounts,omitempty"`
 	OCI               *ociLoggingSettings `json:"oci,omitempty"`
+	Outputs           []outputSettings    `json:"outputs,omitempty"`
+	LogRoutingClasses []logRoutingClass   `json:"logRoutingClasses,omitempty"`
 }
 
 type volumeMount struct {
@@ -56,6 +60,41 @@ type ociLoggingSettings struct {
 	APISecret       string `json:"apiSecret,omitempty"`
 }
 
+type outputSettings struct {
+	Name       string          `json:"name"`
+	Namespaces []string        `json:"namespaces,omitempty"`
+	Kafka      *kafkaSettings  `json:"kafka,omitempty"`
+	Syslog     *syslogSettings `json:"syslog,omitempty"`
+	S3         *s3Settings     `json:"s3,omitempty"`
+}
+
+type kafkaSettings struct {
+	Brokers []string `json:"brokers"`
+	Topic   string   `json:"topic"`
+	Secret  string   `json:"secret,omitempty"`
+}
+
+type syslogSettings struct {
+	Host   string `json:"host"`
+	Port   int32  `json:"port"`
+	Secret string `json:"secret,omitempty"`
+}
+
+type s3Settings struct {
+	Endpoint string `json:"endpoint"`
+	Region   string `json:"region"`
+	Bucket   string `json:"bucket"`
+	Path     string `json:"path,omitempty"`
+	Secret   string `json:"secret"`
+}
+
+type logRoutingClass struct {
+	Name        string `json:"name"`
+	Destination string `json:"destination"`
+	OCILogID    string `json:"ociLogId,omitempty"`
+	Output      string `json:"output,omitempty"`
+}
+
 type Monitoring struct {
 	Enabled       bool `json:"enabled,omitempty"`
 	UseIstioCerts bool `json:"useIstioCerts,omitempty"`
@@ -139,6 +178,19 @@ func appendFluentdOverrides(effectiveCR *vzapi.Verrazzano, overrides *fluentdCom
 				APISecret:       fluentd.OCI.APISecret,
 			}
 		}
+		// Overrides for the additional outputs
+		for _, output := range fluentd.Outputs {
+			overrides.Fluentd.Outputs = append(overrides.Fluentd.Outputs, buildOutputSettings(output))
+		}
+		// Overrides for the log routing classes
+		for _, class := range fluentd.LogRoutingClasses {
+			overrides.Fluentd.LogRoutingClasses = append(overrides.Fluentd.LogRoutingClasses, logRoutingClass{
+				Name:        class.Name,
+				Destination: string(class.Destination),
+				OCILogID:    class.OCILogID,
+				Output:      class.Output,
+			})
+		}
 	}
 
 	// Force the override to be the internal ES secret if the legacy ES secret is being used.
@@ -155,6 +207,39 @@ func appendFluentdOverrides(effectiveCR *vzapi.Verrazzano, overrides *fluentdCom
 	}
 }
 
+// buildOutputSettings returns the chart settings of an additional output
+func buildOutputSettings(output vzapi.FluentdOutput) outputSettings {
+	settings := outputSettings{Name: output.Name, Namespaces: output.Namespaces}
+	if output.Kafka != nil {
+		settings.Kafka = &kafkaSettings{
+			Brokers: output.Kafka.Brokers,
+			Topic:   output.Kafka.Topic,
+			Secret:  output.Kafka.Secret,
+		}
+	}
+	if output.Syslog != nil {
+		port := output.Syslog.Port
+		if port == 0 {
+			port = defaultSyslogPort
+		}
+		settings.Syslog = &syslogSettings{
+			Host:   output.Syslog.Host,
+			Port:   port,
+			Secret: output.Syslog.Secret,
+		}
+	}
+	if output.S3 != nil {
+		settings.S3 = &s3Settings{
+			Endpoint: output.S3.Endpoint,
+			Region:   output.S3.Region,
+			Bucket:   output.S3.Bucket,
+			Path:     output.S3.Path,
+			Secret:   output.S3.Secret,
+		}
+	}
+	return settings
+}
+
 func generateOverridesFile(ctx spi.ComponentContext, overrides *fluentdComponentValues) (string, error) {
 	bytes, err := yaml.Marshal(overrides)
 	if err != nil {
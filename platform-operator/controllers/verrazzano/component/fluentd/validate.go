@@ -6,6 +6,9 @@ package fluentd
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/url"
+
 	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
@@ -14,6 +17,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -23,6 +27,13 @@ const (
 	// ES secret keys
 	esUsernameKey = "username"
 	esPasswordKey = "password"
+
+	// Output secret keys
+	outputUsernameKey        = "username"
+	outputPasswordKey        = "password"
+	outputCABundleKey        = "ca-bundle"
+	outputAccessKeyIDKey     = "access_key_id"
+	outputSecretAccessKeyKey = "secret_access_key"
 )
 
 // existing Fluentd mount paths can be found at platform-operator/helm_config/charts/verrazzano/templates/daemonset.yaml
@@ -43,6 +54,12 @@ func validateFluentd(vz *v1beta1.Verrazzano) error {
 	if err := validateLogCollector(fluentd); err != nil {
 		return err
 	}
+	if err := validateOutputs(fluentd); err != nil {
+		return err
+	}
+	if err := validateLogRoutingClasses(fluentd); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -91,6 +108,141 @@ func validateLogCollectorSecret(fluentd *v1beta1.FluentdComponent) error {
 	return nil
 }
 
+// validateOutputs validates the additional outputs and the entries of their secrets
+func validateOutputs(fluentd *v1beta1.FluentdComponent) error {
+	names := make(map[string]bool)
+	for _, output := range fluentd.Outputs {
+		if names[output.Name] {
+			return fmt.Errorf("Fluentd output %s is specified more than once", output.Name)
+		}
+		names[output.Name] = true
+		for _, ns := range output.Namespaces {
+			if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+				return fmt.Errorf("invalid namespace %s of Fluentd output %s: %v", ns, output.Name, errs)
+			}
+		}
+		if err := validateOutput(output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateOutput validates an additional output, which must have exactly one destination
+func validateOutput(output v1beta1.FluentdOutput) error {
+	count := 0
+	var err error
+	if output.Kafka != nil {
+		count++
+		err = validateKafkaOutput(output.Name, output.Kafka)
+	}
+	if output.Syslog != nil {
+		count++
+		err = validateSyslogOutput(output.Name, output.Syslog)
+	}
+	if output.S3 != nil {
+		count++
+		err = validateS3Output(output.Name, output.S3)
+	}
+	if count != 1 {
+		return fmt.Errorf("Fluentd output %s must have exactly one of kafka, syslog or s3", output.Name)
+	}
+	return err
+}
+
+func validateKafkaOutput(name string, kafka *v1beta1.FluentdKafkaOutput) error {
+	if len(kafka.Brokers) == 0 || kafka.Topic == "" {
+		return fmt.Errorf("Kafka output %s must have brokers and a topic", name)
+	}
+	for _, broker := range kafka.Brokers {
+		if _, _, err := net.SplitHostPort(broker); err != nil {
+			return fmt.Errorf("invalid broker %s of Kafka output %s: %v", broker, name, err)
+		}
+	}
+	if kafka.Secret == "" {
+		return nil
+	}
+	return validateOutputSecret(kafka.Secret, outputUsernameKey, outputPasswordKey, outputCABundleKey)
+}
+
+func validateSyslogOutput(name string, syslog *v1beta1.FluentdSyslogOutput) error {
+	if syslog.Host == "" {
+		return fmt.Errorf("syslog output %s must have a host", name)
+	}
+	if syslog.Port < 0 || syslog.Port > 65535 {
+		return fmt.Errorf("invalid port %d of syslog output %s", syslog.Port, name)
+	}
+	if syslog.Secret == "" {
+		return nil
+	}
+	return validateOutputSecret(syslog.Secret, outputCABundleKey)
+}
+
+func validateS3Output(name string, s3 *v1beta1.FluentdS3Output) error {
+	if s3.Bucket == "" || s3.Region == "" || s3.Secret == "" {
+		return fmt.Errorf("S3 output %s must have a bucket, a region and a secret", name)
+	}
+	if u, err := url.Parse(s3.Endpoint); err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("endpoint %s of S3 output %s must be an https URL", s3.Endpoint, name)
+	}
+	return validateOutputSecret(s3.Secret, outputAccessKeyIDKey, outputSecretAccessKeyKey)
+}
+
+// validateOutputSecret validates that the secret of an output exists in the verrazzano-install namespace with the
+// given entries
+func validateOutputSecret(secretName string, entries ...string) error {
+	cli, err := getControllerRuntimeClient()
+	if err != nil {
+		return err
+	}
+	secret := &corev1.Secret{}
+	if err := getInstallSecret(cli, secretName, secret); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := validateEntryExist(secret, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateLogRoutingClasses validates that the log routing classes are unique and have the settings of their
+// destination
+func validateLogRoutingClasses(fluentd *v1beta1.FluentdComponent) error {
+	outputs := make(map[string]bool)
+	for _, output := range fluentd.Outputs {
+		outputs[output.Name] = true
+	}
+	names := make(map[string]bool)
+	for _, class := range fluentd.LogRoutingClasses {
+		if names[class.Name] {
+			return fmt.Errorf("log routing class %s is specified more than once", class.Name)
+		}
+		names[class.Name] = true
+		if class.Retention != nil && class.Destination != v1beta1.LogRoutingDestinationOpenSearch {
+			return fmt.Errorf("log routing class %s can only have a retention with the OpenSearch destination", class.Name)
+		}
+		switch class.Destination {
+		case v1beta1.LogRoutingDestinationOpenSearch:
+			if fluentd.OCI != nil {
+				return fmt.Errorf("log routing class %s can not have the OpenSearch destination when OCI Logging is configured", class.Name)
+			}
+		case v1beta1.LogRoutingDestinationOCI:
+			if class.OCILogID == "" {
+				return fmt.Errorf("log routing class %s must have an OCI log id", class.Name)
+			}
+		case v1beta1.LogRoutingDestinationOutput:
+			if !outputs[class.Output] {
+				return fmt.Errorf("log routing class %s refers to the output %s, which does not exist", class.Name, class.Output)
+			}
+		default:
+			return fmt.Errorf("log routing class %s has an invalid destination %s", class.Name, class.Destination)
+		}
+	}
+	return nil
+}
+
 func validateEntryExist(secret *corev1.Secret, entry string) error {
 	secretName := secret.Name
 	_, ok := secret.Data[entry]
@@ -134,3 +134,162 @@ func getFakeSecret(secName string) corev1.Secret {
 	}
 	return sec
 }
+
+// TestValidateOutputs tests the validation of the additional outputs
+// GIVEN Verrazzano CRs with Kafka, syslog and S3 outputs
+// WHEN validateFluentd is called
+// THEN an error is returned for the invalid outputs and for the outputs whose secrets are missing entries
+func TestValidateOutputs(t *testing.T) {
+	kafkaSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-sec", Namespace: constants.VerrazzanoInstallNamespace},
+		Data: map[string][]byte{
+			outputUsernameKey: []byte("user"),
+			outputPasswordKey: []byte("pass"),
+			outputCABundleKey: []byte("ca"),
+		},
+	}
+	s3Secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-sec", Namespace: constants.VerrazzanoInstallNamespace},
+		Data: map[string][]byte{
+			outputAccessKeyIDKey: []byte("id"),
+		},
+	}
+	getControllerRuntimeClient = func() (client.Client, error) {
+		return fake.NewClientBuilder().WithScheme(newScheme()).WithRuntimeObjects(&kafkaSecret, &s3Secret).Build(), nil
+	}
+	defer func() { getControllerRuntimeClient = getClient }()
+
+	kafka := &v1beta1.FluentdKafkaOutput{Brokers: []string{"kafka.example.com:9093"}, Topic: "logs", Secret: "kafka-sec"}
+	syslog := &v1beta1.FluentdSyslogOutput{Host: "syslog.example.com"}
+	s3 := &v1beta1.FluentdS3Output{Endpoint: "https://s3.example.com", Region: "us-ashburn-1", Bucket: "logs", Secret: "s3-sec"}
+	tests := []struct {
+		name    string
+		outputs []v1beta1.FluentdOutput
+		wantErr bool
+	}{{
+		name:    "kafka and syslog",
+		outputs: []v1beta1.FluentdOutput{{Name: "kafka", Namespaces: []string{"ns1"}, Kafka: kafka}, {Name: "syslog", Syslog: syslog}},
+		wantErr: false,
+	}, {
+		name:    "duplicate name",
+		outputs: []v1beta1.FluentdOutput{{Name: "out", Kafka: kafka}, {Name: "out", Syslog: syslog}},
+		wantErr: true,
+	}, {
+		name:    "no destination",
+		outputs: []v1beta1.FluentdOutput{{Name: "out"}},
+		wantErr: true,
+	}, {
+		name:    "two destinations",
+		outputs: []v1beta1.FluentdOutput{{Name: "out", Kafka: kafka, Syslog: syslog}},
+		wantErr: true,
+	}, {
+		name:    "invalid namespace",
+		outputs: []v1beta1.FluentdOutput{{Name: "out", Namespaces: []string{"NS_1"}, Syslog: syslog}},
+		wantErr: true,
+	}, {
+		name:    "invalid broker",
+		outputs: []v1beta1.FluentdOutput{{Name: "out", Kafka: &v1beta1.FluentdKafkaOutput{Brokers: []string{"kafka.example.com"}, Topic: "logs"}}},
+		wantErr: true,
+	}, {
+		name:    "missing kafka secret",
+		outputs: []v1beta1.FluentdOutput{{Name: "out", Kafka: &v1beta1.FluentdKafkaOutput{Brokers: []string{"kafka.example.com:9093"}, Topic: "logs", Secret: "missing"}}},
+		wantErr: true,
+	}, {
+		name:    "syslog secret without ca-bundle",
+		outputs: []v1beta1.FluentdOutput{{Name: "out", Syslog: &v1beta1.FluentdSyslogOutput{Host: "syslog.example.com", Secret: "s3-sec"}}},
+		wantErr: true,
+	}, {
+		name:    "http s3 endpoint",
+		outputs: []v1beta1.FluentdOutput{{Name: "out", S3: &v1beta1.FluentdS3Output{Endpoint: "http://s3.example.com", Region: "r", Bucket: "b", Secret: "s3-sec"}}},
+		wantErr: true,
+	}, {
+		name:    "s3 secret without secret_access_key",
+		outputs: []v1beta1.FluentdOutput{{Name: "out", S3: s3}},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vz := &v1beta1.Verrazzano{
+				Spec: v1beta1.VerrazzanoSpec{
+					Components: v1beta1.ComponentSpec{
+						Fluentd: &v1beta1.FluentdComponent{Outputs: tt.outputs},
+					},
+				},
+			}
+			if err := validateFluentd(vz); (err != nil) != tt.wantErr {
+				t.Errorf("validateFluentd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestValidateLogRoutingClasses tests the validation of the log routing classes
+// GIVEN Verrazzano CRs with log routing classes
+// WHEN validateFluentd is called
+// THEN an error is returned for the duplicate classes and the classes without the settings of their destination
+func TestValidateLogRoutingClasses(t *testing.T) {
+	retention := "7d"
+	outputs := []v1beta1.FluentdOutput{{Name: "audit", Syslog: &v1beta1.FluentdSyslogOutput{Host: "syslog.example.com"}}}
+	tests := []struct {
+		name    string
+		fluentd *v1beta1.FluentdComponent
+		wantErr bool
+	}{{
+		name: "valid classes",
+		fluentd: &v1beta1.FluentdComponent{
+			Outputs: outputs,
+			LogRoutingClasses: []v1beta1.LogRoutingClass{
+				{Name: "gold", Destination: v1beta1.LogRoutingDestinationOpenSearch, Retention: &retention},
+				{Name: "oci", Destination: v1beta1.LogRoutingDestinationOCI, OCILogID: "ocid1.log.oc1.test"},
+				{Name: "audit", Destination: v1beta1.LogRoutingDestinationOutput, Output: "audit"},
+			},
+		},
+		wantErr: false,
+	}, {
+		name: "duplicate class",
+		fluentd: &v1beta1.FluentdComponent{
+			LogRoutingClasses: []v1beta1.LogRoutingClass{
+				{Name: "gold", Destination: v1beta1.LogRoutingDestinationOpenSearch},
+				{Name: "gold", Destination: v1beta1.LogRoutingDestinationOCI, OCILogID: "ocid1.log.oc1.test"},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "OpenSearch with OCI Logging",
+		fluentd: &v1beta1.FluentdComponent{
+			OCI:               &v1beta1.OciLoggingConfiguration{},
+			LogRoutingClasses: []v1beta1.LogRoutingClass{{Name: "gold", Destination: v1beta1.LogRoutingDestinationOpenSearch}},
+		},
+		wantErr: true,
+	}, {
+		name: "OCI without log id",
+		fluentd: &v1beta1.FluentdComponent{
+			LogRoutingClasses: []v1beta1.LogRoutingClass{{Name: "oci", Destination: v1beta1.LogRoutingDestinationOCI}},
+		},
+		wantErr: true,
+	}, {
+		name: "missing output",
+		fluentd: &v1beta1.FluentdComponent{
+			LogRoutingClasses: []v1beta1.LogRoutingClass{{Name: "audit", Destination: v1beta1.LogRoutingDestinationOutput, Output: "audit"}},
+		},
+		wantErr: true,
+	}, {
+		name: "retention without OpenSearch",
+		fluentd: &v1beta1.FluentdComponent{
+			LogRoutingClasses: []v1beta1.LogRoutingClass{{Name: "oci", Destination: v1beta1.LogRoutingDestinationOCI, OCILogID: "ocid1.log.oc1.test", Retention: &retention}},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vz := &v1beta1.Verrazzano{
+				Spec: v1beta1.VerrazzanoSpec{
+					Components: v1beta1.ComponentSpec{Fluentd: tt.fluentd},
+				},
+			}
+			if err := validateFluentd(vz); (err != nil) != tt.wantErr {
+				t.Errorf("validateFluentd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
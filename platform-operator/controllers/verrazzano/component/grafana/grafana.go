@@ -26,7 +26,7 @@ func isGrafanaInstalled(ctx spi.ComponentContext) bool {
 func isGrafanaReady(ctx spi.ComponentContext) bool {
 	prefix := newPrefix(ctx.GetComponent())
 	deployments := newDeployments()
-	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), deployments, 1, prefix) && common.IsGrafanaAdminSecretReady(ctx)
+	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), deployments, 1, prefix) && common.IsGrafanaAdminSecretReady(ctx)
 }
 
 // newPrefix creates a component prefix string
@@ -140,9 +140,17 @@ func (g grafanaComponent) ValidateInstallV1Beta1(vz *installv1beta1.Verrazzano)
 	return nil
 }
 
+// EnsureCredentials ensures the VMI and Grafana admin secrets exist and are in sync with the credentials store
+func (g grafanaComponent) EnsureCredentials(ctx spi.ComponentContext) error {
+	if err := common.EnsureVMISecret(ctx.Client(), ctx.EffectiveCR()); err != nil {
+		return err
+	}
+	return common.EnsureGrafanaAdminSecret(ctx.Client(), ctx.EffectiveCR())
+}
+
 // PreInstall ensures that preconditions are met before installing the Grafana component
 func (g grafanaComponent) PreInstall(ctx spi.ComponentContext) error {
-	if err := common.EnsureVMISecret(ctx.Client()); err != nil {
+	if err := common.EnsureVMISecret(ctx.Client(), ctx.EffectiveCR()); err != nil {
 		return err
 	}
 	if err := common.EnsureBackupSecret(ctx.Client()); err != nil {
@@ -151,7 +159,7 @@ func (g grafanaComponent) PreInstall(ctx spi.ComponentContext) error {
 	if err := common.CreateAndLabelVMINamespaces(ctx); err != nil {
 		return err
 	}
-	if err := common.EnsureGrafanaAdminSecret(ctx.Client()); err != nil {
+	if err := common.EnsureGrafanaAdminSecret(ctx.Client(), ctx.EffectiveCR()); err != nil {
 		return err
 	}
 
@@ -189,10 +197,10 @@ func (g grafanaComponent) PostUninstall(context spi.ComponentContext) error {
 
 // PreUpgrade ensures that preconditions are met before upgrading the Grafana component
 func (g grafanaComponent) PreUpgrade(ctx spi.ComponentContext) error {
-	if err := common.EnsureVMISecret(ctx.Client()); err != nil {
+	if err := common.EnsureVMISecret(ctx.Client(), ctx.EffectiveCR()); err != nil {
 		return err
 	}
-	if err := common.EnsureGrafanaAdminSecret(ctx.Client()); err != nil {
+	if err := common.EnsureGrafanaAdminSecret(ctx.Client(), ctx.EffectiveCR()); err != nil {
 		return err
 	}
 
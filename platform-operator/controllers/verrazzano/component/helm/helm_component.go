@@ -158,6 +158,11 @@ func (h HelmComponent) ShouldInstallBeforeUpgrade() bool {
 	return h.InstallBeforeUpgrade
 }
 
+// GetChartDir returns the Helm chart directory
+func (h HelmComponent) GetChartDir() string {
+	return h.ChartDir
+}
+
 // GetJsonName returns the josn name of the verrazzano component in CRD
 func (h HelmComponent) GetJSONName() string {
 	return h.JSONName
@@ -7,7 +7,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -21,6 +20,8 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/mocks"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -32,19 +33,8 @@ import (
 // Needed for unit tests
 var fakeOverrides []string
 
-// helmFakeRunner is used to test helm without actually running an OS exec command
-type helmFakeRunner struct {
-}
-
 const testBomFilePath = "../../testdata/test_bom.json"
 
-// genericHelmTestRunner is used to run generic OS commands with expected results
-type genericHelmTestRunner struct {
-	stdOut []byte
-	stdErr []byte
-	err    error
-}
-
 var testScheme = runtime.NewScheme()
 
 func init() {
@@ -55,11 +45,6 @@ func init() {
 	// +kubebuilder:scaffold:testScheme
 }
 
-// Run genericHelmTestRunner executor
-func (r genericHelmTestRunner) Run(cmd *exec.Cmd) (stdout []byte, stderr []byte, err error) {
-	return r.stdOut, r.stdErr, r.err
-}
-
 // TestGetName tests the component name
 // GIVEN a Verrazzano component
 //  WHEN I call Name
@@ -97,8 +82,10 @@ func TestUpgrade(t *testing.T) {
 	}
 
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helm.SetCmdRunner(helmFakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction(
+		helm.CreateRelease("rancher", "chartNS", release.StatusDeployed, "1.0", nil),
+	))
+	defer helm.SetDefaultActionConfigFunction()
 	SetUpgradeFunc(fakeUpgrade)
 	defer SetDefaultUpgradeFunc()
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
@@ -123,19 +110,17 @@ func newFakeClient() clipkg.Client {
 func TestUpgradeIsInstalledUnexpectedError(t *testing.T) {
 	a := assert.New(t)
 
-	comp := HelmComponent{}
+	comp := HelmComponent{ReleaseName: "rancher"}
 
 	SetUpgradeFunc(func(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []helm.HelmOverrides) (stdout []byte, stderr []byte, err error) {
 		return nil, nil, nil
 	})
 	defer SetDefaultUpgradeFunc()
 
-	helm.SetCmdRunner(genericHelmTestRunner{
-		stdOut: []byte(""),
-		stdErr: []byte("What happened?"),
-		err:    fmt.Errorf("Unexpected error"),
+	helm.SetActionConfigFunction(func(_ vzlog.VerrazzanoLogger, _ string) (*action.Configuration, error) {
+		return nil, fmt.Errorf("Unexpected error")
 	})
-	defer helm.SetDefaultRunner()
+	defer helm.SetDefaultActionConfigFunction()
 
 	err := comp.Upgrade(spi.NewFakeContext(nil, &v1alpha1.Verrazzano{ObjectMeta: v1.ObjectMeta{Namespace: "foo"}}, nil, false))
 	a.Error(err)
@@ -148,13 +133,13 @@ func TestUpgradeIsInstalledUnexpectedError(t *testing.T) {
 func TestUpgradeReleaseNotInstalled(t *testing.T) {
 	a := assert.New(t)
 
-	comp := HelmComponent{}
+	comp := HelmComponent{ReleaseName: "rancher"}
 
 	SetUpgradeFunc(func(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, chartDir string, wait bool, dryRun bool, overrides []helm.HelmOverrides) (stdout []byte, stderr []byte, err error) {
 		return nil, nil, nil
 	})
-	helm.SetCmdRunner(helmFakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction())
+	defer helm.SetDefaultActionConfigFunction()
 	config.SetDefaultBomFilePath(testBomFilePath)
 	defer config.SetDefaultBomFilePath("")
 
@@ -201,8 +186,10 @@ func TestUpgradeWithEnvOverrides(t *testing.T) {
 	}
 
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helm.SetCmdRunner(helmFakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction(
+		helm.CreateRelease("rancher", "chartNS", release.StatusDeployed, "1.0", nil),
+	))
+	defer helm.SetDefaultActionConfigFunction()
 	SetUpgradeFunc(fakeUpgrade)
 	defer SetDefaultUpgradeFunc()
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
@@ -238,8 +225,8 @@ func TestInstall(t *testing.T) {
 	}
 
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helm.SetCmdRunner(helmFakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction())
+	defer helm.SetDefaultActionConfigFunction()
 	SetUpgradeFunc(fakeUpgrade)
 	defer SetDefaultUpgradeFunc()
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
@@ -286,8 +273,8 @@ func TestInstallWithAllOverride(t *testing.T) {
 	}
 
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helm.SetCmdRunner(helmFakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction())
+	defer helm.SetDefaultActionConfigFunction()
 
 	SetUpgradeFunc(fakeUpgrade)
 	defer SetDefaultUpgradeFunc()
@@ -332,8 +319,8 @@ func TestInstallPreviousFailure(t *testing.T) {
 	}
 
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helm.SetCmdRunner(helmFakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction())
+	defer helm.SetDefaultActionConfigFunction()
 	SetUpgradeFunc(fakeUpgrade)
 	defer SetDefaultUpgradeFunc()
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
@@ -375,8 +362,8 @@ func TestInstallWithPreInstallFunc(t *testing.T) {
 	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
 
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helm.SetCmdRunner(helmFakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction())
+	defer helm.SetDefaultActionConfigFunction()
 	SetUpgradeFunc(fakeUpgrade)
 	defer SetDefaultUpgradeFunc()
 	helm.SetChartStatusFunction(func(releaseName string, namespace string) (string, error) {
@@ -426,24 +413,18 @@ func TestGetDependencies(t *testing.T) {
 func TestIsInstalled(t *testing.T) {
 	a := assert.New(t)
 
-	comp := HelmComponent{}
+	comp := HelmComponent{ReleaseName: "rancher"}
 	defer helm.SetDefaultChartStatusFunction()
 	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
 
-	helm.SetCmdRunner(genericHelmTestRunner{
-		stdOut: []byte(""),
-		stdErr: []byte(""),
-		err:    nil,
-	})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction(
+		helm.CreateRelease("rancher", "foo", release.StatusDeployed, "1.0", nil),
+	))
+	defer helm.SetDefaultActionConfigFunction()
 	config.SetDefaultBomFilePath(testBomFilePath)
 	defer config.SetDefaultBomFilePath("")
 	a.True(comp.IsInstalled(spi.NewFakeContext(nil, &v1alpha1.Verrazzano{ObjectMeta: v1.ObjectMeta{Namespace: "foo"}}, nil, false)))
-	helm.SetCmdRunner(genericHelmTestRunner{
-		stdOut: []byte(""),
-		stdErr: []byte(""),
-		err:    fmt.Errorf("Not installed"),
-	})
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction())
 	a.False(comp.IsInstalled(spi.NewFakeContext(client, &v1alpha1.Verrazzano{ObjectMeta: v1.ObjectMeta{Namespace: "foo"}}, nil, false)))
 }
 
@@ -776,11 +757,6 @@ func fakeUpgrade(_ vzlog.VerrazzanoLogger, releaseName string, namespace string,
 	return []byte("success"), []byte(""), nil
 }
 
-// helmFakeRunner overrides the helm run command
-func (r helmFakeRunner) Run(cmd *exec.Cmd) (stdout []byte, stderr []byte, err error) {
-	return []byte("success"), []byte(""), nil
-}
-
 func fakePreUpgrade(log vzlog.VerrazzanoLogger, client clipkg.Client, release string, namespace string, chartDir string) error {
 	if release != "rancher" {
 		return fmt.Errorf("Incorrect release name %s", release)
@@ -0,0 +1,370 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/verrazzano/verrazzano/pkg/bom"
+	"github.com/verrazzano/verrazzano/pkg/helm"
+	vzos "github.com/verrazzano/verrazzano/pkg/os"
+	vzyaml "github.com/verrazzano/verrazzano/pkg/yaml"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/secret"
+	"helm.sh/helm/v3/pkg/strvals"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// manifestIgnoredPaths are the paths of the rendered fields that are not compared with the live objects, a list index
+// is written as [*].  The status is owned by the controllers, the API server does not persist the string data of
+// secrets, and the other fields are commonly mutated by controllers and webhooks, such as the replicas scaled by an
+// autoscaler, the CA bundles injected by cert-manager and the restart annotations of pod templates.
+var manifestIgnoredPaths = []string{
+	"status",
+	"stringData",
+	"metadata.annotations",
+	"spec.replicas",
+	"spec.template.metadata.annotations",
+	"spec.caBundle",
+	"spec.conversion.webhook.clientConfig.caBundle",
+	"webhooks[*].clientConfig.caBundle",
+}
+
+// listIndexRegexp matches the list indexes of a field path
+var listIndexRegexp = regexp.MustCompile(`\[[0-9]+\]`)
+
+// DriftDetector is implemented by components that can check their Helm release for drift
+type DriftDetector interface {
+	// DetectHelmDrift returns the paths of the Helm release values and the rendered object fields that no longer
+	// match the values generated by the operator and the live objects
+	DetectHelmDrift(context spi.ComponentContext) ([]string, error)
+}
+
+// Verify that HelmComponent implements DriftDetector
+var _ DriftDetector = HelmComponent{}
+
+// DetectHelmDrift compares the values of the installed Helm release with the values the operator generates for
+// the component, and the objects in the rendered manifest of the release with the live objects.  The paths of the
+// generated values that are missing or different in the release, and the paths of the rendered fields that are
+// missing or different in the live objects, are returned.  Values and fields the operator does not render are
+// not considered drift.
+func (h HelmComponent) DetectHelmDrift(context spi.ComponentContext) ([]string, error) {
+	if h.SkipUpgrade {
+		return nil, nil
+	}
+
+	resolvedNamespace := h.resolveNamespace(context)
+	installed, err := helm.IsReleaseInstalled(h.ReleaseName, resolvedNamespace)
+	if err != nil || !installed {
+		return nil, err
+	}
+
+	var kvs []bom.KeyValue
+	kvs, err = secret.AddGlobalImagePullSecretHelmOverride(context.Log(), context.Client(), resolvedNamespace, kvs, h.ImagePullSecretKeyname)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := h.buildCustomHelmOverrides(context, resolvedNamespace, kvs...)
+	defer vzos.RemoveTempFiles(context.Log().GetZapLogger(), `helm-overrides.*\.yaml`)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := mergeHelmOverrides(overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, err := helm.GetValuesMap(context.Log(), h.ReleaseName, resolvedNamespace)
+	if err != nil {
+		return nil, err
+	}
+	actual, err = normalizeValues(actual)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := findDrift("", expected, actual)
+	manifestDrift, err := detectManifestDrift(context.Client(), h.ReleaseName, resolvedNamespace)
+	if err != nil {
+		return nil, err
+	}
+	drift = append(drift, manifestDrift...)
+	sort.Strings(drift)
+	return drift, nil
+}
+
+// detectManifestDrift compares the objects in the rendered manifest of the Helm release with the live objects.  The
+// drift paths are prefixed with the kind, namespace and name of the object, a missing object is reported without
+// a path.  Fields that are added to the live objects by the API server or by controllers are not drift.
+func detectManifestDrift(client clipkg.Client, releaseName string, namespace string) ([]string, error) {
+	manifest, err := helm.GetReleaseManifest(releaseName, namespace)
+	if err != nil || len(manifest) == 0 {
+		return nil, err
+	}
+	objects, err := parseManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var drift []string
+	for i := range objects {
+		expected := &objects[i]
+		if len(expected.GetNamespace()) == 0 {
+			expected.SetNamespace(namespace)
+		}
+		name := fmt.Sprintf("%s/%s/%s", expected.GetKind(), expected.GetNamespace(), expected.GetName())
+		actual := unstructured.Unstructured{}
+		actual.SetGroupVersionKind(expected.GroupVersionKind())
+		err := client.Get(context.TODO(), types.NamespacedName{Namespace: expected.GetNamespace(), Name: expected.GetName()}, &actual)
+		if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			continue
+		}
+		if errors.IsNotFound(err) {
+			drift = append(drift, name)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		expectedObject, err := normalizeValues(expected.Object)
+		if err != nil {
+			return nil, err
+		}
+		actualObject, err := normalizeValues(actual.Object)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range findObjectDrift("", expectedObject, actualObject) {
+			drift = append(drift, name+":"+path)
+		}
+	}
+	return drift, nil
+}
+
+// parseManifest returns the objects of a multi-document YAML manifest
+func parseManifest(manifest string) ([]unstructured.Unstructured, error) {
+	var objects []unstructured.Unstructured
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(strings.NewReader(manifest)))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		obj := unstructured.Unstructured{Object: map[string]interface{}{}}
+		if err := yaml.Unmarshal(doc, &obj.Object); err != nil {
+			return nil, err
+		}
+		if len(obj.Object) > 0 {
+			objects = append(objects, obj)
+		}
+	}
+}
+
+// findObjectDrift returns the paths of the rendered fields that are missing or different in the live object.  Only the
+// rendered fields are compared, the fields of manifestIgnoredPaths are skipped, and the values are compared
+// semantically since the API server normalizes them.  Empty and zero rendered values are not persisted by the API
+// server, and the live list elements may have additional defaulted fields, so only the rendered fields of each list
+// element are compared.
+func findObjectDrift(path string, expected interface{}, actual interface{}) []string {
+	if isIgnoredPath(path) {
+		return nil
+	}
+	switch expectedValue := expected.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		actualMap, ok := actual.(map[string]interface{})
+		if !ok {
+			if len(expectedValue) == 0 {
+				return nil
+			}
+			return []string{path}
+		}
+		var drift []string
+		for key, value := range expectedValue {
+			keyPath := key
+			if len(path) > 0 {
+				keyPath = path + "." + key
+			}
+			drift = append(drift, findObjectDrift(keyPath, value, actualMap[key])...)
+		}
+		return drift
+	case []interface{}:
+		actualList, ok := actual.([]interface{})
+		if !ok {
+			if len(expectedValue) == 0 {
+				return nil
+			}
+			return []string{path}
+		}
+		if len(expectedValue) != len(actualList) {
+			return []string{path}
+		}
+		var drift []string
+		for i := range expectedValue {
+			drift = append(drift, findObjectDrift(fmt.Sprintf("%s[%d]", path, i), expectedValue[i], actualList[i])...)
+		}
+		return drift
+	}
+	// Zero values are omitted from the live object
+	if actual == nil && reflect.ValueOf(expected).IsZero() {
+		return nil
+	}
+	if !isSemanticallyEqual(expected, actual) {
+		return []string{path}
+	}
+	return nil
+}
+
+// isIgnoredPath returns true if the field path is one of manifestIgnoredPaths, or is within one of them
+func isIgnoredPath(path string) bool {
+	path = listIndexRegexp.ReplaceAllString(path, "[*]")
+	for _, ignored := range manifestIgnoredPaths {
+		if path == ignored || strings.HasPrefix(path, ignored+".") || strings.HasPrefix(path, ignored+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// isSemanticallyEqual returns true if a rendered scalar value has the same meaning as the live value.  The API server
+// converts the values to the types of the fields, for example a port rendered as a string, and normalizes quantities,
+// for example 0.5 CPU is stored as 500m.
+func isSemanticallyEqual(expected interface{}, actual interface{}) bool {
+	if reflect.DeepEqual(expected, actual) {
+		return true
+	}
+	if actual == nil {
+		return false
+	}
+	expectedString := fmt.Sprint(expected)
+	actualString := fmt.Sprint(actual)
+	if expectedString == actualString {
+		return true
+	}
+	expectedQuantity, err := resource.ParseQuantity(expectedString)
+	if err != nil {
+		return false
+	}
+	actualQuantity, err := resource.ParseQuantity(actualString)
+	if err != nil {
+		return false
+	}
+	return expectedQuantity.Cmp(actualQuantity) == 0
+}
+
+// mergeHelmOverrides merges the Helm overrides into a single values map, using the same precedence as the Helm
+// command line: value files in order, then --set, --set-string and --set-file values
+func mergeHelmOverrides(overrides []helm.HelmOverrides) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for _, override := range overrides {
+		if len(override.FileOverride) == 0 {
+			continue
+		}
+		data, err := os.ReadFile(override.FileOverride)
+		if err != nil {
+			return nil, err
+		}
+		fileValues := map[string]interface{}{}
+		if err := yaml.Unmarshal(data, &fileValues); err != nil {
+			return nil, err
+		}
+		if err := vzyaml.MergeMaps(values, fileValues); err != nil {
+			return nil, err
+		}
+	}
+	for _, override := range overrides {
+		if len(override.SetOverrides) > 0 {
+			if err := strvals.ParseInto(override.SetOverrides, values); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, override := range overrides {
+		if len(override.SetStringOverrides) > 0 {
+			if err := strvals.ParseIntoString(override.SetStringOverrides, values); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, override := range overrides {
+		if len(override.SetFileOverrides) > 0 {
+			reader := func(rs []rune) (interface{}, error) {
+				data, err := os.ReadFile(string(rs))
+				return string(data), err
+			}
+			if err := strvals.ParseIntoFile(override.SetFileOverrides, values, reader); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return normalizeValues(values)
+}
+
+// normalizeValues round trips the values through JSON, so that values parsed from different sources
+// have the same types
+func normalizeValues(values map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	normalized := map[string]interface{}{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// findDrift returns the paths of the expected values that are missing or different in the actual values
+func findDrift(path string, expected map[string]interface{}, actual map[string]interface{}) []string {
+	var drift []string
+	for key, expectedValue := range expected {
+		keyPath := key
+		if len(path) > 0 {
+			keyPath = path + "." + key
+		}
+		// A null value removes a key from the chart defaults, there is nothing to compare
+		if expectedValue == nil {
+			continue
+		}
+		actualValue, ok := actual[key]
+		if !ok {
+			drift = append(drift, keyPath)
+			continue
+		}
+		if expectedMap, ok := expectedValue.(map[string]interface{}); ok {
+			actualMap, ok := actualValue.(map[string]interface{})
+			if !ok {
+				drift = append(drift, keyPath)
+				continue
+			}
+			drift = append(drift, findDrift(keyPath, expectedMap, actualMap)...)
+			continue
+		}
+		if !reflect.DeepEqual(expectedValue, actualValue) {
+			drift = append(drift, keyPath)
+		}
+	}
+	return drift
+}
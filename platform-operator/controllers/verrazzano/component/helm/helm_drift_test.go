@@ -0,0 +1,219 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/bom"
+	"github.com/verrazzano/verrazzano/pkg/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"helm.sh/helm/v3/pkg/release"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestDetectHelmDrift tests detecting drift of a Helm release
+// GIVEN a component with a values file and additional overrides
+//  WHEN DetectHelmDrift is called and the release values differ from the generated values
+//  THEN the paths of the values that differ or are missing are returned
+func TestDetectHelmDrift(t *testing.T) {
+	valuesFile, err := os.CreateTemp("", "drift-values-*.yaml")
+	assert.NoError(t, err)
+	defer os.Remove(valuesFile.Name())
+	_, err = valuesFile.WriteString("replicas: 2\nimage:\n  tag: v1\n  pullPolicy: IfNotPresent\n")
+	assert.NoError(t, err)
+	assert.NoError(t, valuesFile.Close())
+
+	comp := HelmComponent{
+		ReleaseName:          "release1",
+		ChartNamespace:       "ns1",
+		IgnoreImageOverrides: true,
+		ValuesFile:           valuesFile.Name(),
+		AppendOverridesFunc: func(context spi.ComponentContext, releaseName string, namespace string, chartDir string, kvs []bom.KeyValue) ([]bom.KeyValue, error) {
+			return append(kvs, bom.KeyValue{Key: "logging.level", Value: "info"}), nil
+		},
+	}
+
+	releaseValues := map[string]interface{}{
+		"replicas": 2,
+		"image": map[string]interface{}{
+			"tag":        "v2",
+			"pullPolicy": "IfNotPresent",
+		},
+		"extra": "not generated by the operator",
+	}
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction(
+		helm.CreateRelease("release1", "", release.StatusDeployed, "1.0", releaseValues),
+	))
+	defer helm.SetDefaultActionConfigFunction()
+
+	client := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	drift, err := comp.DetectHelmDrift(spi.NewFakeContext(client, &v1alpha1.Verrazzano{}, nil, false))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"image.tag", "logging"}, drift)
+}
+
+// TestDetectHelmDriftNoDrift tests detecting drift of a Helm release that has not drifted
+// GIVEN a component with a values file
+//  WHEN DetectHelmDrift is called and the release values match the generated values
+//  THEN no drift is returned
+func TestDetectHelmDriftNoDrift(t *testing.T) {
+	valuesFile, err := os.CreateTemp("", "drift-values-*.yaml")
+	assert.NoError(t, err)
+	defer os.Remove(valuesFile.Name())
+	_, err = valuesFile.WriteString("replicas: 2\nimage:\n  tag: v1\n")
+	assert.NoError(t, err)
+	assert.NoError(t, valuesFile.Close())
+
+	comp := HelmComponent{
+		ReleaseName:          "release1",
+		ChartNamespace:       "ns1",
+		IgnoreImageOverrides: true,
+		ValuesFile:           valuesFile.Name(),
+	}
+
+	releaseValues := map[string]interface{}{
+		"replicas": 2,
+		"image":    map[string]interface{}{"tag": "v1"},
+	}
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction(
+		helm.CreateRelease("release1", "", release.StatusDeployed, "1.0", releaseValues),
+	))
+	defer helm.SetDefaultActionConfigFunction()
+
+	client := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	drift, err := comp.DetectHelmDrift(spi.NewFakeContext(client, &v1alpha1.Verrazzano{}, nil, false))
+	assert.NoError(t, err)
+	assert.Empty(t, drift)
+}
+
+// testDriftManifest is the rendered manifest of a release with a deployment and a config map
+const testDriftManifest = `---
+# Source: test/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app1
+  labels:
+    app: app1
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: app1
+  template:
+    metadata:
+      labels:
+        app: app1
+      annotations:
+        checksum/config: abc
+    spec:
+      hostNetwork: false
+      containers:
+        - name: app1
+          image: app1:v1
+          resources:
+            requests:
+              cpu: 0.5
+              memory: 1024Mi
+---
+# Source: test/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config1
+data:
+  key: value
+`
+
+// TestDetectHelmDriftManifest tests detecting drift of the objects of a Helm release
+// GIVEN a Helm release with a rendered deployment and config map
+//  WHEN DetectHelmDrift is called and the live deployment has a different image, replica count and template
+//       annotation, normalized resource quantities and additional defaulted fields, and the config map is missing
+//  THEN the path of the image and the missing config map are returned, the mutated and normalized fields are not drift
+func TestDetectHelmDriftManifest(t *testing.T) {
+	comp := HelmComponent{
+		ReleaseName:          "release1",
+		ChartNamespace:          "ns1",
+		IgnoreNamespaceOverride: true,
+		IgnoreImageOverrides:    true,
+	}
+
+	rel := helm.CreateRelease("release1", "ns1", release.StatusDeployed, "1.0", nil)
+	rel.Manifest = testDriftManifest
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction(rel))
+	defer helm.SetDefaultActionConfigFunction()
+
+	replicas := int32(3)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns1",
+			Name:        "app1",
+			Labels:      map[string]string{"app": "app1"},
+			Annotations: map[string]string{"meta.helm.sh/release-name": "release1"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "app1"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "app1"},
+					Annotations: map[string]string{"checksum/config": "def"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:                     "app1",
+						Image:                    "app1:v2",
+						ImagePullPolicy:          corev1.PullIfNotPresent,
+						TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(deployment).Build()
+	drift, err := comp.DetectHelmDrift(spi.NewFakeContext(client, &v1alpha1.Verrazzano{}, nil, false))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"ConfigMap/ns1/config1",
+		"Deployment/ns1/app1:spec.template.spec.containers[0].image",
+	}, drift)
+
+	// Restore the live deployment and create the config map, there is no drift
+	deployment.Spec.Template.Spec.Containers[0].Image = "app1:v1"
+	client = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(deployment, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "config1"},
+		Data:       map[string]string{"key": "value"},
+	}).Build()
+	drift, err = comp.DetectHelmDrift(spi.NewFakeContext(client, &v1alpha1.Verrazzano{}, nil, false))
+	assert.NoError(t, err)
+	assert.Empty(t, drift)
+}
+
+// TestMergeHelmOverrides tests merging Helm overrides into a values map
+// GIVEN --set and --set-string overrides of the same key
+//  WHEN mergeHelmOverrides is called
+//  THEN the --set-string value takes precedence, the same as the Helm command line
+func TestMergeHelmOverrides(t *testing.T) {
+	values, err := mergeHelmOverrides([]helm.HelmOverrides{
+		{SetStringOverrides: "a.b=1"},
+		{SetOverrides: "a.b=2"},
+		{SetOverrides: "a.c=true"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": map[string]interface{}{"b": "1", "c": true}}, values)
+}
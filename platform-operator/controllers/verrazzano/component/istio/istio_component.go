@@ -28,6 +28,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -175,6 +176,9 @@ func (i istioComponent) PreUninstall(_ spi.ComponentContext) error {
 
 // Uninstall processing for Istio
 func (i istioComponent) Uninstall(context spi.ComponentContext) error {
+	if isNativeInstall(context.EffectiveCR()) {
+		return nativeUninstallFunc(context.Log(), context.Client())
+	}
 	_, _, err := istioUninstallFunc(context.Log())
 	return err
 }
@@ -255,6 +259,9 @@ func (i istioComponent) ValidateInstall(vz *vzapi.Verrazzano) error {
 		if err := vzapi.ValidateInstallOverrides(vz.Spec.Components.Istio.ValueOverrides); err != nil {
 			return err
 		}
+		if err := validateIngressGateways(getIngressGatewayNames(vz.Spec.Components.Istio)); err != nil {
+			return err
+		}
 	}
 
 	return i.validateForExternalIPSWithNodePort(&vz.Spec)
@@ -270,6 +277,9 @@ func (i istioComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.Verrazz
 		if err := vzapi.ValidateInstallOverrides(new.Spec.Components.Istio.ValueOverrides); err != nil {
 			return err
 		}
+		if err := validateIngressGateways(getIngressGatewayNames(new.Spec.Components.Istio)); err != nil {
+			return err
+		}
 	}
 	return i.validateForExternalIPSWithNodePort(&new.Spec)
 }
@@ -284,6 +294,9 @@ func (i istioComponent) ValidateUpdateV1Beta1(old *installv1beta1.Verrazzano, ne
 		if err := vzapi.ValidateInstallOverridesV1Beta1(new.Spec.Components.Istio.ValueOverrides); err != nil {
 			return err
 		}
+		if err := validateIngressGateways(getIngressGatewayNamesV1Beta1(new.Spec.Components.Istio)); err != nil {
+			return err
+		}
 	}
 
 	return i.validateForExternalIPSWithNodePortV1Beta1(&new.Spec)
@@ -295,10 +308,50 @@ func (i istioComponent) ValidateInstallV1Beta1(vz *installv1beta1.Verrazzano) er
 		if err := vzapi.ValidateInstallOverridesV1Beta1(vz.Spec.Components.Istio.ValueOverrides); err != nil {
 			return err
 		}
+		if err := validateIngressGateways(getIngressGatewayNamesV1Beta1(vz.Spec.Components.Istio)); err != nil {
+			return err
+		}
 	}
 	return i.validateForExternalIPSWithNodePortV1Beta1(&vz.Spec)
 }
 
+// validateIngressGateways checks that the names of the additional ingress gateways are DNS-1123 labels, are unique,
+// and are not the names of the default gateways
+func validateIngressGateways(names []string) error {
+	found := map[string]bool{}
+	for _, name := range names {
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return fmt.Errorf("invalid Istio ingress gateway name %s: %v", name, errs)
+		}
+		if name == IstioIngressgatewayDeployment || name == IstioEgressgatewayDeployment {
+			return fmt.Errorf("Istio ingress gateway name %s is reserved for the default gateways", name)
+		}
+		if found[name] {
+			return fmt.Errorf("Istio ingress gateway name %s is not unique", name)
+		}
+		found[name] = true
+	}
+	return nil
+}
+
+// getIngressGatewayNames returns the names of the additional ingress gateways
+func getIngressGatewayNames(istio *vzapi.IstioComponent) []string {
+	var names []string
+	for _, gateway := range istio.IngressGateways {
+		names = append(names, gateway.Name)
+	}
+	return names
+}
+
+// getIngressGatewayNamesV1Beta1 returns the names of the additional ingress gateways
+func getIngressGatewayNamesV1Beta1(istio *installv1beta1.IstioComponent) []string {
+	var names []string
+	for _, gateway := range istio.IngressGateways {
+		names = append(names, gateway.Name)
+	}
+	return names
+}
+
 // validateForExternalIPSWithNodePort checks that externalIPs are set when Type=NodePort
 func (i istioComponent) validateForExternalIPSWithNodePort(vz *vzapi.VerrazzanoSpec) error {
 	// good if istio or istio.ingress is not set
@@ -337,6 +390,9 @@ func (i istioComponent) validateForExternalIPSWithNodePortV1Beta1(vz *installv1b
 }
 
 func (i istioComponent) Upgrade(context spi.ComponentContext) error {
+	if isNativeInstall(context.EffectiveCR()) {
+		return i.nativeInstall(context)
+	}
 	log := context.Log()
 
 	// build list of temp files
@@ -376,18 +432,23 @@ func (i istioComponent) IsReady(context spi.ComponentContext) bool {
 			Namespace: IstioNamespace,
 		},
 	}
-	ready := status.DeploymentsAreReady(context.Log(), context.Client(), deployments, 1, prefix)
+	if istio := context.EffectiveCR().Spec.Components.Istio; istio != nil {
+		for _, name := range getIngressGatewayNames(istio) {
+			deployments = append(deployments, types.NamespacedName{Name: name, Namespace: IstioNamespace})
+		}
+	}
+	ready := status.DeploymentsAreReady(context.Log(), context.Client(), context.ActualCR(), deployments, 1, prefix)
 	if !ready {
 		return false
 	}
 
-	verified, err := isInstalledFunc(context.Log())
+	verified, err := isInstallVerified(context)
 	if err != nil && !isIstioManifestNotInstalledError(err) {
 		context.Log().ErrorfThrottled("Unexpected error checking Istio status: %s", err)
 		return false
 	}
 	if !verified {
-		context.Log().Progressf("%s is waiting for istioctl verify-install to successfully complete", prefix)
+		context.Log().Progressf("%s is waiting for the Istio installation to be verified", prefix)
 		return false
 	}
 
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"github.com/verrazzano/verrazzano/pkg/istio"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"helm.sh/helm/v3/pkg/release"
 	"io/ioutil"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -320,8 +321,10 @@ func TestPostUpgrade(t *testing.T) {
 	k8sutil.SetFakeClient(clientSet)
 
 	config.SetDefaultBomFilePath(testBomFilePath)
-	helm.SetCmdRunner(fakeRunner{})
-	defer helm.SetDefaultRunner()
+	helm.SetActionConfigFunction(helm.NewMemoryActionConfigFunction(
+		helm.CreateRelease(IstioCoreDNSReleaseName, constants.IstioSystemNamespace, release.StatusDeployed, "1.0", nil),
+	))
+	defer helm.SetDefaultActionConfigFunction()
 	SetHelmUninstallFunction(fakeHelmUninstall)
 	SetDefaultHelmUninstallFunction()
 	err := comp.PostUpgrade(spi.NewFakeContext(getMock(t), crInstall, nil, false))
@@ -1278,3 +1281,37 @@ func TestGetOverrides(t *testing.T) {
 		})
 	}
 }
+
+// TestValidateIngressGateways tests the validation of the additional ingress gateways
+// GIVEN Verrazzano CRs with unique, invalid, reserved and duplicate ingress gateway names
+// WHEN ValidateInstall, ValidateUpdate and their v1beta1 variants are called
+// THEN an error is returned for invalid, reserved and duplicate names
+func TestValidateIngressGateways(t *testing.T) {
+	tests := []struct {
+		name     string
+		gateways []v1alpha1.IstioIngressGateway
+		wantErr  bool
+	}{
+		{name: "unique", gateways: []v1alpha1.IstioIngressGateway{{Name: "internal-gateway"}, {Name: "partner-gateway"}}},
+		{name: "uppercase", gateways: []v1alpha1.IstioIngressGateway{{Name: "Internal-Gateway"}}, wantErr: true},
+		{name: "too long", gateways: []v1alpha1.IstioIngressGateway{{Name: strings.Repeat("a", 64)}}, wantErr: true},
+		{name: "empty", gateways: []v1alpha1.IstioIngressGateway{{Name: ""}}, wantErr: true},
+		{name: "reserved", gateways: []v1alpha1.IstioIngressGateway{{Name: IstioIngressgatewayDeployment}}, wantErr: true},
+		{name: "duplicate", gateways: []v1alpha1.IstioIngressGateway{{Name: "internal-gateway"}, {Name: "internal-gateway"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vz := &v1alpha1.Verrazzano{Spec: v1alpha1.VerrazzanoSpec{Components: v1alpha1.ComponentSpec{
+				Istio: &v1alpha1.IstioComponent{IngressGateways: tt.gateways},
+			}}}
+			vzV1Beta1 := &v1beta1.Verrazzano{}
+			assert.NoError(t, vz.ConvertTo(vzV1Beta1))
+			assert.Equal(t, len(tt.gateways), len(vzV1Beta1.Spec.Components.Istio.IngressGateways))
+
+			assert.Equal(t, tt.wantErr, NewComponent().ValidateInstall(vz) != nil)
+			assert.Equal(t, tt.wantErr, NewComponent().ValidateUpdate(vz, vz) != nil)
+			assert.Equal(t, tt.wantErr, NewComponent().ValidateInstallV1Beta1(vzV1Beta1) != nil)
+			assert.Equal(t, tt.wantErr, NewComponent().ValidateUpdateV1Beta1(vzV1Beta1, vzV1Beta1) != nil)
+		})
+	}
+}
@@ -32,6 +32,9 @@ const (
 	//meshConfigTracingTLSMode is the TLS mode for Istio-Jaeger communication
 	meshConfigTracingTLSMode = "meshConfig.defaultConfig.tracing.tlsSettings.mode"
 
+	//meshConfigOutboundTrafficPolicyMode is the mesh outbound traffic policy mode
+	meshConfigOutboundTrafficPolicyMode = "meshConfig.outboundTrafficPolicy.mode"
+
 	leftMargin      = 0
 	leftMarginExtIP = 12
 )
@@ -90,8 +93,34 @@ spec:
           {{- end}}
           affinity:
 {{ multiLineIndent 12 .IngressAffinity }}
+{{- range .IngressGateways }}
+      - name: {{.Name}}
+        enabled: true
+        label:
+          app: {{.Name}}
+          istio: {{.Name}}
+          verrazzano.io/ingress-gateway: "true"
+        k8s:
+          replicaCount: {{.ReplicaCount}}
+          {{- if .ServiceAnnotations }}
+          serviceAnnotations:
+{{ multiLineIndent 12 .ServiceAnnotations }}
+          {{- end}}
+          service:
+            type: {{.ServiceType}}
+            {{- if .ServicePorts }}
+            ports:
+{{ multiLineIndent 12 .ServicePorts }}
+            {{- end}}
+{{- end }}
 `
 
+// internalLoadBalancerAnnotations are the annotations of an internal OCI load balancer or network load balancer
+var internalLoadBalancerAnnotations = map[string]string{
+	"service.beta.kubernetes.io/oci-load-balancer-internal": "true",
+	"oci-network-load-balancer.oraclecloud.com/internal":    "true",
+}
+
 type ReplicaData struct {
 	IngressReplicaCount uint32
 	EgressReplicaCount  uint32
@@ -100,6 +129,16 @@ type ReplicaData struct {
 	IngressServiceType  string
 	IngressServicePorts string
 	ExternalIps         string
+	IngressGateways     []IngressGatewayData
+}
+
+// IngressGatewayData is the template data of an additional ingress gateway
+type IngressGatewayData struct {
+	Name               string
+	ReplicaCount       uint32
+	ServiceType        string
+	ServiceAnnotations string
+	ServicePorts       string
 }
 
 // BuildIstioOperatorYaml builds the IstioOperator CR YAML that will be passed as an override to istioctl
@@ -117,7 +156,16 @@ func BuildIstioOperatorYaml(ctx spi.ComponentContext, comp *vzapi.IstioComponent
 		return "", err
 	}
 
-	for _, arg := range append(jaegerArgs, comp.IstioInstallArgs...) {
+	// the outbound traffic policy is added before the user install args, which take precedence
+	installArgs := jaegerArgs
+	if comp.OutboundTrafficPolicy != "" {
+		installArgs = append(installArgs, vzapi.InstallArgs{
+			Name:  meshConfigOutboundTrafficPolicyMode,
+			Value: string(comp.OutboundTrafficPolicy),
+		})
+	}
+
+	for _, arg := range append(installArgs, comp.IstioInstallArgs...) {
 		values := arg.ValueList
 		if len(values) == 0 {
 			values = []string{arg.Value}
@@ -229,6 +277,14 @@ func configureGateways(istioComponent *vzapi.IstioComponent, externalIP string)
 		data.ExternalIps = externalIP
 	}
 
+	for _, gateway := range istioComponent.IngressGateways {
+		gatewayData, err := buildIngressGatewayData(gateway)
+		if err != nil {
+			return "", err
+		}
+		data.IngressGateways = append(data.IngressGateways, gatewayData)
+	}
+
 	// use template to get populate template with data
 	var b bytes.Buffer
 	t, err := template.New("istioGateways").Funcs(template.FuncMap{
@@ -255,3 +311,44 @@ func configureGateways(istioComponent *vzapi.IstioComponent, externalIP string)
 
 	return b.String(), nil
 }
+
+// buildIngressGatewayData returns the template data of an additional ingress gateway
+func buildIngressGatewayData(gateway vzapi.IstioIngressGateway) (IngressGatewayData, error) {
+	data := IngressGatewayData{
+		Name:         gateway.Name,
+		ReplicaCount: gateway.Replicas,
+		ServiceType:  string(vzapi.LoadBalancer),
+	}
+	if data.ReplicaCount == 0 {
+		data.ReplicaCount = 1
+	}
+	if gateway.Type == vzapi.NodePort {
+		data.ServiceType = string(vzapi.NodePort)
+	}
+
+	annotations := map[string]string{}
+	if gateway.Internal {
+		for k, v := range internalLoadBalancerAnnotations {
+			annotations[k] = v
+		}
+	}
+	for k, v := range gateway.ServiceAnnotations {
+		annotations[k] = v
+	}
+	if len(annotations) > 0 {
+		y, err := yaml.Marshal(annotations)
+		if err != nil {
+			return data, err
+		}
+		data.ServiceAnnotations = string(y)
+	}
+
+	if len(gateway.Ports) > 0 {
+		y, err := yaml.Marshal(gateway.Ports)
+		if err != nil {
+			return data, err
+		}
+		data.ServicePorts = string(y)
+	}
+	return data, nil
+}
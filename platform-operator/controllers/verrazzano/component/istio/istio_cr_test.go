@@ -12,6 +12,7 @@ import (
 	istioclisec "istio.io/client-go/pkg/apis/security/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
 
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -746,3 +747,99 @@ func TestBuildIstioOperatorYaml(t *testing.T) {
 		})
 	}
 }
+
+// TestBuildIstioOperatorYamlIngressGateways tests the BuildIstioOperatorYaml function
+// GIVEN an Istio component with an internal ingress gateway and a NodePort ingress gateway
+// WHEN BuildIstioOperatorYaml is called
+// THEN the gateways are added to the ingress gateways, labeled with their name and the label selected by their network
+// policy, with the internal load balancer annotations, the service type, the ports and the replicas
+func TestBuildIstioOperatorYamlIngressGateways(t *testing.T) {
+	comp := &vzapi.IstioComponent{
+		Enabled: &enabled,
+		Ingress: &vzapi.IstioIngressSection{Kubernetes: &vzapi.IstioKubernetesSection{CommonKubernetesSpec: vzapi.CommonKubernetesSpec{Replicas: 1}}},
+		Egress:  &vzapi.IstioEgressSection{Kubernetes: &vzapi.IstioKubernetesSection{CommonKubernetesSpec: vzapi.CommonKubernetesSpec{Replicas: 1}}},
+		IngressGateways: []vzapi.IstioIngressGateway{
+			{Name: "internal-gateway", Internal: true, ServiceAnnotations: map[string]string{"foo": "bar"}},
+			{Name: "nodeport-gateway", Type: vzapi.NodePort, Replicas: 2, Ports: []corev1.ServicePort{{Name: "https", Port: 443, NodePort: 32443}}},
+		},
+	}
+	s, err := BuildIstioOperatorYaml(spi.NewFakeContext(fake.NewClientBuilder().WithScheme(testScheme).Build(), &vzapi.Verrazzano{}, nil, false), comp)
+	assert.NoError(t, err)
+
+	operator := struct {
+		Spec struct {
+			Components struct {
+				IngressGateways []struct {
+					Name    string            `json:"name"`
+					Enabled bool              `json:"enabled"`
+					Label   map[string]string `json:"label"`
+					K8s     struct {
+						ReplicaCount       uint32            `json:"replicaCount"`
+						ServiceAnnotations map[string]string `json:"serviceAnnotations"`
+						Service            struct {
+							Type  string               `json:"type"`
+							Ports []corev1.ServicePort `json:"ports"`
+						} `json:"service"`
+					} `json:"k8s"`
+				} `json:"ingressGateways"`
+			} `json:"components"`
+		} `json:"spec"`
+	}{}
+	assert.NoError(t, yaml.Unmarshal([]byte(s), &operator))
+	gateways := operator.Spec.Components.IngressGateways
+	assert.Len(t, gateways, 3)
+	assert.Equal(t, IstioIngressgatewayDeployment, gateways[0].Name)
+
+	internal := gateways[1]
+	assert.Equal(t, "internal-gateway", internal.Name)
+	assert.True(t, internal.Enabled)
+	assert.Equal(t, map[string]string{"app": "internal-gateway", "istio": "internal-gateway", "verrazzano.io/ingress-gateway": "true"}, internal.Label)
+	assert.Equal(t, uint32(1), internal.K8s.ReplicaCount)
+	assert.Equal(t, "true", internal.K8s.ServiceAnnotations["service.beta.kubernetes.io/oci-load-balancer-internal"])
+	assert.Equal(t, "bar", internal.K8s.ServiceAnnotations["foo"])
+	assert.Equal(t, string(vzapi.LoadBalancer), internal.K8s.Service.Type)
+	assert.Empty(t, internal.K8s.Service.Ports)
+
+	nodePort := gateways[2]
+	assert.Equal(t, "nodeport-gateway", nodePort.Name)
+	assert.Equal(t, uint32(2), nodePort.K8s.ReplicaCount)
+	assert.Empty(t, nodePort.K8s.ServiceAnnotations)
+	assert.Equal(t, string(vzapi.NodePort), nodePort.K8s.Service.Type)
+	assert.Equal(t, int32(32443), nodePort.K8s.Service.Ports[0].NodePort)
+}
+
+// TestBuildIstioOperatorYamlOutboundTrafficPolicy tests the BuildIstioOperatorYaml function
+// GIVEN an Istio component with the REGISTRY_ONLY outbound traffic policy
+// WHEN BuildIstioOperatorYaml is called
+// THEN the outbound traffic policy mode is set in the mesh config, and can be overridden by an install arg
+func TestBuildIstioOperatorYamlOutboundTrafficPolicy(t *testing.T) {
+	comp := &vzapi.IstioComponent{
+		Enabled:               &enabled,
+		Ingress:               &vzapi.IstioIngressSection{Kubernetes: &vzapi.IstioKubernetesSection{CommonKubernetesSpec: vzapi.CommonKubernetesSpec{Replicas: 1}}},
+		Egress:                &vzapi.IstioEgressSection{Kubernetes: &vzapi.IstioKubernetesSection{CommonKubernetesSpec: vzapi.CommonKubernetesSpec{Replicas: 1}}},
+		OutboundTrafficPolicy: vzapi.IstioOutboundTrafficPolicyRegistryOnly,
+	}
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(testScheme).Build(), &vzapi.Verrazzano{}, nil, false)
+
+	operator := struct {
+		Spec struct {
+			Values struct {
+				MeshConfig struct {
+					OutboundTrafficPolicy struct {
+						Mode string `json:"mode"`
+					} `json:"outboundTrafficPolicy"`
+				} `json:"meshConfig"`
+			} `json:"values"`
+		} `json:"spec"`
+	}{}
+	s, err := BuildIstioOperatorYaml(ctx, comp)
+	assert.NoError(t, err)
+	assert.NoError(t, yaml.Unmarshal([]byte(s), &operator))
+	assert.Equal(t, "REGISTRY_ONLY", operator.Spec.Values.MeshConfig.OutboundTrafficPolicy.Mode)
+
+	comp.IstioInstallArgs = []vzapi.InstallArgs{{Name: meshConfigOutboundTrafficPolicyMode, Value: "ALLOW_ANY"}}
+	s, err = BuildIstioOperatorYaml(ctx, comp)
+	assert.NoError(t, err)
+	assert.NoError(t, yaml.Unmarshal([]byte(s), &operator))
+	assert.Equal(t, "ALLOW_ANY", operator.Spec.Values.MeshConfig.OutboundTrafficPolicy.Mode)
+}
@@ -200,6 +200,10 @@ func (i istioComponent) Install(compContext spi.ComponentContext) error {
 		compContext.Log().Debug("Error during istio install, retrying")
 	}
 
+	if isNativeInstall(compContext.EffectiveCR()) {
+		return i.nativeInstall(compContext)
+	}
+
 	// build list of temp files
 	istioTempFiles, err := i.createIstioTempFiles(compContext)
 	if err != nil {
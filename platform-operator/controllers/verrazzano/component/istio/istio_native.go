@@ -0,0 +1,69 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package istio
+
+import (
+	"path/filepath"
+
+	"github.com/verrazzano/verrazzano/pkg/istio"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// istioManifestsDirName is the directory of the Istio charts and profiles used by the native install, relative to
+// the third party manifests directory
+const istioManifestsDirName = "istio"
+
+// create func vars for unit tests
+type nativeInstallFuncSig func(log vzlog.VerrazzanoLogger, cli clipkg.Client, manifestsDir string, overrideStrings string, overridesFiles ...string) error
+
+var nativeInstallFunc nativeInstallFuncSig = istio.NativeInstall
+
+type nativeUninstallFuncSig func(log vzlog.VerrazzanoLogger, cli clipkg.Client) error
+
+var nativeUninstallFunc nativeUninstallFuncSig = istio.NativeUninstall
+
+type nativeVerifyInstallFuncSig func(log vzlog.VerrazzanoLogger, cli clipkg.Client) (bool, error)
+
+var nativeVerifyInstallFunc nativeVerifyInstallFuncSig = istio.NativeVerifyInstall
+
+// isNativeInstall returns true if Istio is installed without istioctl
+func isNativeInstall(cr *vzapi.Verrazzano) bool {
+	if cr == nil || cr.Spec.Components.Istio == nil {
+		return false
+	}
+	return cr.Spec.Components.Istio.InstallMethod == vzapi.IstioInstallMethodNative
+}
+
+// getIstioManifestsDir returns the directory of the Istio charts and profiles
+func getIstioManifestsDir() string {
+	return filepath.Join(config.GetThirdPartyManifestsDir(), istioManifestsDirName)
+}
+
+// nativeInstall installs or upgrades Istio by rendering the IstioOperator in-process and applying the manifests.
+// Unlike istioctl the apply does not block waiting for the installation, so it is not run in the background.
+func (i istioComponent) nativeInstall(compContext spi.ComponentContext) error {
+	istioTempFiles, err := i.createIstioTempFiles(compContext)
+	if err != nil {
+		return err
+	}
+	defer removeTempFiles(compContext.Log())
+
+	overrideStrings, err := getOverridesString(compContext)
+	if err != nil {
+		return err
+	}
+	return nativeInstallFunc(compContext.Log(), compContext.Client(), getIstioManifestsDir(), overrideStrings, istioTempFiles...)
+}
+
+// isInstallVerified verifies the Istio installation using the install method of the effective CR
+func isInstallVerified(compContext spi.ComponentContext) (bool, error) {
+	if isNativeInstall(compContext.EffectiveCR()) {
+		return nativeVerifyInstallFunc(compContext.Log(), compContext.Client())
+	}
+	return isInstalledFunc(compContext.Log())
+}
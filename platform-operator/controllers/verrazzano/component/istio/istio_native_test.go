@@ -0,0 +1,125 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package istio
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/istio"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newNativeCR() *v1alpha1.Verrazzano {
+	return &v1alpha1.Verrazzano{
+		Spec: v1alpha1.VerrazzanoSpec{
+			Components: v1alpha1.ComponentSpec{
+				Istio: &v1alpha1.IstioComponent{
+					InstallMethod: v1alpha1.IstioInstallMethodNative,
+				},
+			},
+		},
+	}
+}
+
+// TestIsNativeInstall tests the isNativeInstall function
+// GIVEN Verrazzano CRs with and without the Native install method
+// WHEN isNativeInstall is called
+// THEN true is returned only for the Native install method
+func TestIsNativeInstall(t *testing.T) {
+	assert.False(t, isNativeInstall(nil))
+	assert.False(t, isNativeInstall(&v1alpha1.Verrazzano{}))
+	assert.False(t, isNativeInstall(&v1alpha1.Verrazzano{Spec: v1alpha1.VerrazzanoSpec{Components: v1alpha1.ComponentSpec{
+		Istio: &v1alpha1.IstioComponent{InstallMethod: v1alpha1.IstioInstallMethodIstioctl},
+	}}}))
+	assert.True(t, isNativeInstall(newNativeCR()))
+}
+
+// TestNativeInstallUpgrade tests installing and upgrading Istio with the Native install method
+// GIVEN a Verrazzano CR with the Native install method
+// WHEN Install and Upgrade are called
+// THEN the native install is called synchronously with the Istio manifests dir and the IstioOperator files, and
+// istioctl is not used
+func TestNativeInstallUpgrade(t *testing.T) {
+	config.SetDefaultBomFilePath(testBomFilePath)
+	defer config.SetDefaultBomFilePath("")
+
+	calls := 0
+	nativeInstallFunc = func(log vzlog.VerrazzanoLogger, cli clipkg.Client, manifestsDir string, overrideStrings string, overridesFiles ...string) error {
+		calls++
+		if manifestsDir != getIstioManifestsDir() {
+			return fmt.Errorf("unexpected manifests dir %s", manifestsDir)
+		}
+		if len(overridesFiles) < 2 || overridesFiles[0] != "test-values-file.yaml" {
+			return fmt.Errorf("unexpected override files %v", overridesFiles)
+		}
+		return nil
+	}
+	defer func() { nativeInstallFunc = istio.NativeInstall }()
+	SetIstioUpgradeFunction(func(log vzlog.VerrazzanoLogger, imageOverrideString string, overridesFiles ...string) ([]byte, []byte, error) {
+		return nil, nil, fmt.Errorf("istioctl should not be used")
+	})
+	defer SetDefaultIstioUpgradeFunction()
+
+	comp := istioComponent{
+		ValuesFile: "test-values-file.yaml",
+		monitor:    &fakeMonitor{},
+	}
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	ctx := spi.NewFakeContext(client, newNativeCR(), nil, false, profilesRelativePath)
+	assert.NoError(t, comp.Install(ctx))
+	assert.NoError(t, comp.Upgrade(ctx))
+	assert.Equal(t, 2, calls)
+}
+
+// TestNativeUninstall tests uninstalling Istio with the Native install method
+// GIVEN a Verrazzano CR with the Native install method
+// WHEN Uninstall is called
+// THEN the native uninstall is called instead of istioctl
+func TestNativeUninstall(t *testing.T) {
+	called := false
+	nativeUninstallFunc = func(log vzlog.VerrazzanoLogger, cli clipkg.Client) error {
+		called = true
+		return nil
+	}
+	defer func() { nativeUninstallFunc = istio.NativeUninstall }()
+	SetIstioUninstallFunction(func(log vzlog.VerrazzanoLogger) ([]byte, []byte, error) {
+		return nil, nil, fmt.Errorf("istioctl should not be used")
+	})
+	defer SetDefaultIstioUninstallFunction()
+
+	var comp istioComponent
+	assert.NoError(t, comp.Uninstall(spi.NewFakeContext(nil, newNativeCR(), nil, false)))
+	assert.True(t, called)
+}
+
+// TestIsInstallVerified tests verifying the Istio installation for each install method
+// GIVEN Verrazzano CRs with the Istioctl and Native install methods
+// WHEN isInstallVerified is called
+// THEN the verification of the install method is used
+func TestIsInstallVerified(t *testing.T) {
+	isInstalledFunc = func(log vzlog.VerrazzanoLogger) (bool, error) {
+		return false, nil
+	}
+	defer func() { isInstalledFunc = istio.IsInstalled }()
+	nativeVerifyInstallFunc = func(log vzlog.VerrazzanoLogger, cli clipkg.Client) (bool, error) {
+		return true, nil
+	}
+	defer func() { nativeVerifyInstallFunc = istio.NativeVerifyInstall }()
+
+	verified, err := isInstallVerified(spi.NewFakeContext(nil, &v1alpha1.Verrazzano{}, nil, false))
+	assert.NoError(t, err)
+	assert.False(t, verified)
+
+	verified, err = isInstallVerified(spi.NewFakeContext(nil, newNativeCR(), nil, false))
+	assert.NoError(t, err)
+	assert.True(t, verified)
+}
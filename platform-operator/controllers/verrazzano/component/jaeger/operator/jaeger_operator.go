@@ -136,13 +136,13 @@ func isJaegerOperatorReady(ctx spi.ComponentContext) bool {
 		},
 	}
 	prefix := fmt.Sprintf(componentPrefixFmt, ctx.GetComponent())
-	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), deployments, 1, prefix)
+	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), deployments, 1, prefix)
 }
 
 // isDefaultJaegerInstanceReady checks if the deployments of default Jaeger instance managed by VZ are in ready state
 func isDefaultJaegerInstanceReady(ctx spi.ComponentContext) bool {
 	prefix := fmt.Sprintf(componentPrefixFmt, ctx.GetComponent())
-	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), getJaegerComponentDeployments(), 1, prefix)
+	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), getJaegerComponentDeployments(), 1, prefix)
 }
 
 // PreInstall implementation for the Jaeger Operator Component
@@ -131,6 +131,18 @@ func (c KeycloakComponent) PreInstall(ctx spi.ComponentContext) error {
 	return nil
 }
 
+// EnsureCredentials ensures the secrets of the keycloakadmin user and of the internal users exist and are in sync with
+// the credentials store
+func (c KeycloakComponent) EnsureCredentials(ctx spi.ComponentContext) error {
+	if err := createAuthSecret(ctx, ComponentNamespace, "keycloak-http", "keycloakadmin"); err != nil {
+		return err
+	}
+	if err := createAuthSecret(ctx, constants.VerrazzanoSystemNamespace, "verrazzano-prom-internal", "verrazzano-prom-internal"); err != nil {
+		return err
+	}
+	return createAuthSecret(ctx, constants.VerrazzanoSystemNamespace, "verrazzano-es-internal", "verrazzano-es-internal")
+}
+
 func (c KeycloakComponent) PostInstall(ctx spi.ComponentContext) error {
 	// Create secret for the verrazzano-prom-internal user
 	err := createAuthSecret(ctx, constants.VerrazzanoSystemNamespace, "verrazzano-prom-internal", "verrazzano-prom-internal")
@@ -202,6 +214,23 @@ func (c KeycloakComponent) IsReady(ctx spi.ComponentContext) bool {
 	return false
 }
 
+// ValidateInstall checks if the specified Verrazzano CR is valid for this component to be installed
+func (c KeycloakComponent) ValidateInstall(vz *vzapi.Verrazzano) error {
+	convertedVZ := installv1beta1.Verrazzano{}
+	if err := common.ConvertVerrazzanoCR(vz, &convertedVZ); err != nil {
+		return err
+	}
+	return c.ValidateInstallV1Beta1(&convertedVZ)
+}
+
+// ValidateInstallV1Beta1 checks if the specified Verrazzano CR is valid for this component to be installed
+func (c KeycloakComponent) ValidateInstallV1Beta1(vz *installv1beta1.Verrazzano) error {
+	if err := validateKeycloakRealm(vz); err != nil {
+		return err
+	}
+	return c.HelmComponent.ValidateInstallV1Beta1(vz)
+}
+
 // ValidateUpdate checks if the specified new Verrazzano CR is valid for this component to be updated
 func (c KeycloakComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.Verrazzano) error {
 	// Do not allow any changes except to enable the component post-install
@@ -212,6 +241,13 @@ func (c KeycloakComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.Verr
 	if err := common.CompareInstallArgs(c.getInstallArgs(old), c.getInstallArgs(new)); err != nil {
 		return fmt.Errorf("Updates to InstallArgs not allowed for %s", ComponentJSONName)
 	}
+	convertedVZ := installv1beta1.Verrazzano{}
+	if err := common.ConvertVerrazzanoCR(new, &convertedVZ); err != nil {
+		return err
+	}
+	if err := validateKeycloakRealm(&convertedVZ); err != nil {
+		return err
+	}
 	return c.HelmComponent.ValidateUpdate(old, new)
 }
 
@@ -221,9 +257,20 @@ func (c KeycloakComponent) ValidateUpdateV1Beta1(old *installv1beta1.Verrazzano,
 	if c.IsEnabled(old) && !c.IsEnabled(new) {
 		return fmt.Errorf("Disabling component %s is not allowed", ComponentJSONName)
 	}
+	if err := validateKeycloakRealm(new); err != nil {
+		return err
+	}
 	return c.HelmComponent.ValidateUpdateV1Beta1(old, new)
 }
 
+// validateKeycloakRealm validates the realm configuration of the Keycloak component
+func validateKeycloakRealm(vz *installv1beta1.Verrazzano) error {
+	if vz.Spec.Components.Keycloak == nil {
+		return nil
+	}
+	return validateRealmConfig(vz.Spec.Components.Keycloak.Realm)
+}
+
 func (c KeycloakComponent) getInstallArgs(vz *vzapi.Verrazzano) []vzapi.InstallArgs {
 	if vz != nil && vz.Spec.Components.Keycloak != nil {
 		return vz.Spec.Components.Keycloak.KeycloakInstallArgs
@@ -5,25 +5,26 @@ package keycloak
 
 import (
 	"context"
-	"fmt"
-	"net/url"
+	"net/http"
 	"strings"
 	"testing"
 
 	certmanager "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/verrazzano/verrazzano/pkg/bom"
-	"github.com/verrazzano/verrazzano/pkg/k8sutil"
 	k8sutilfake "github.com/verrazzano/verrazzano/pkg/k8sutil/fake"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/kcadmin"
+	kcfake "github.com/verrazzano/verrazzano/platform-operator/internal/kcadmin/fake"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	networkv1 "k8s.io/api/networking/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
@@ -104,249 +105,102 @@ func createTestKeycloakAuthConfig() unstructured.Unstructured {
 	return authConfig
 }
 
-func fakeConfigureRealmCommands(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		command := commands[2]
-		if strings.Contains(command, "create groups") {
-			return "Created new group with id 'quick-brown-fox'", "", nil
-		}
-
-		if strings.Contains(command, "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"jump-window\"}]", "", nil
-		}
-
-		if strings.Contains(command, "create clients/") {
-			return "Created client secret blah", "", nil
-		}
-
-		if strings.Contains(command, "create clients") {
-			return "Created client 'blahblah'", "", nil
-		}
-
-	}
-
-	return "", "", nil
-}
-
-func fakeConfigureRealmCommandsUpdateKeycloakURIFailed(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "create groups") {
-			return "Created new group with id 'quick-brown-fox'", "", nil
-		}
-	}
-
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"rancher\"},{\"id\" : \"quick-fox-1\",\"clientId\" : \"verrazzano-pg\"}]", "", nil
-		}
-	}
-
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "update clients") {
-			return "", "", fmt.Errorf("failed")
-		}
-	}
-	return "", "", nil
-}
-
-func fakeCreateUserGroupCommand(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "create groups") {
-			return "Created new group with id 'quick-brown-fox'", "", nil
-		}
-	}
-	return "", "", nil
-}
-
-func fakeCreateUserGroupCommandFail(url *url.URL) (string, string, error) {
-	return "", "", nil
-}
-
-func fakeCreateUserGroupParseCommandFail(url *url.URL) (string, string, error) {
-	return "invalidoutput", "", nil
-}
-
-func fakeGetRancherClientSecretFromKeycloak(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "client-secret") {
-			return "{\"type\":\"secret\",\"value\":\"abcdef\"}", "", nil
-		}
-
-		if strings.Contains(commands[2], "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"rancher\"}]", "", nil
-		}
-	}
-
-	return "", "", nil
-}
-
-func fakeGetRancherClientSecretFromKeycloakGetClientsFails(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get clients") {
-			return "", "", fmt.Errorf("failed")
-		}
-
-	}
-
-	return "", "", nil
-}
-
-func fakeGetRancherClientSecretFromKeycloakNoRancherClient(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"norancher\"}]", "", nil
-		}
-	}
-
-	return "", "", nil
-}
-
-func fakeGetRancherClientSecretFromKeycloakClientSecretFailed(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "client-secret") {
-			return "", "", fmt.Errorf("failed")
-		}
-
-		if strings.Contains(commands[2], "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"rancher\"}]", "", nil
-		}
-	}
-
-	return "", "", nil
-}
-
-func fakeGetRancherClientSecretFromKeycloakClientSecretResultEmpty(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "client-secret") {
-			return "", "", nil
-		}
-
-		if strings.Contains(commands[2], "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"rancher\"}]", "", nil
-		}
-	}
-
-	return "", "", nil
-}
-
-func fakeGetRancherClientSecretFromKeycloakClientSecretResultInvalid(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "client-secret") {
-			return "invalid", "", nil
-		}
-
-		if strings.Contains(commands[2], "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"rancher\"}]", "", nil
-		}
-	}
-
-	return "", "", nil
-}
-
-func fakeGetRancherClientSecretFromKeycloakClientSecretEmpty(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "client-secret") {
-			return "{\"type\":\"secret\",\"value\":\"\"}", "", nil
-		}
-
-		if strings.Contains(commands[2], "get clients") {
-			return "[{\"id\" : \"quick-fox\",\"clientId\" : \"rancher\"}]", "", nil
-		}
-	}
-
-	return "", "", nil
+// newFakeKeycloak starts a fake Keycloak server, with the admin password of the test login secret, and uses it as the
+// Keycloak admin REST API of the component
+func newFakeKeycloak() *kcfake.Server {
+	server := kcfake.NewServer(keycloakAdminUser, "password")
+	SetAdminURLFunction(func(_ spi.ComponentContext) (string, func(), error) {
+		return server.BaseURL(), func() {}, nil
+	})
+	return server
 }
 
-func fakeGetVerrazzanoUserFromKeycloak(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get users") {
-			return "[{\"id\" : \"quick-fox\",\"username\" : \"verrazzano\"}]", "", nil
-		}
-	}
-
-	return "", "", nil
+// stopFakeKeycloak stops a fake Keycloak server, and restores the Keycloak admin REST API of the component
+func stopFakeKeycloak(server *kcfake.Server) {
+	SetDefaultAdminURLFunction()
+	server.Close()
 }
 
-func fakeGetVerrazzanoUserFromKeycloakFails(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get users") {
-			return "", "", fmt.Errorf("failed")
-		}
-
-	}
-
-	return "", "", nil
+// newTestAdminClient returns a client of a fake Keycloak server that is logged in as the admin user
+func newTestAdminClient(t *testing.T, server *kcfake.Server) *kcadmin.Client {
+	kc := kcadmin.NewClient(server.BaseURL(), nil)
+	assert.NoError(t, kc.Login(keycloakAdminRealm, keycloakAdminUser, "password"))
+	return kc
 }
 
-func fakeGetVerrazzanoUserFromKeycloakResultEmpty(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get users") {
-			return "", "", nil
+// failRequests returns a function that fails the requests to the fake Keycloak server with the given method and path
+// suffix
+func failRequests(method string, pathSuffix string) func(r *http.Request) int {
+	return func(r *http.Request) int {
+		if r.Method == method && strings.HasSuffix(r.URL.Path, pathSuffix) {
+			return http.StatusInternalServerError
 		}
+		return 0
 	}
-
-	return "", "", nil
 }
 
-func fakeGetVerrazzanoUserFromKeycloakResultInvalid(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get users") {
-			return "invalid", "", nil
-		}
+// createTestUserSecrets returns the secrets of the Verrazzano users
+func createTestUserSecrets() []client.Object {
+	var secrets []client.Object
+	for _, name := range []string{"verrazzano", "verrazzano-prom-internal", "verrazzano-es-internal"} {
+		secrets = append(secrets, &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "verrazzano-system",
+			},
+			Data: map[string][]byte{
+				"password": []byte("blah di blah"),
+			},
+		})
 	}
-
-	return "", "", nil
+	return secrets
 }
 
-func fakeGetVerrazzanoUserFromKeycloakNoVerrazzanoUser(url *url.URL) (string, string, error) {
-	var commands []string
-	if commands = url.Query()["command"]; len(commands) == 3 {
-		if strings.Contains(commands[2], "get users") {
-			return "[{\"id\" : \"quick-fox\",\"username\" : \"notverrazzano\"}]", "", nil
-		}
+// createTestKeycloakPod returns a ready Keycloak pod
+func createTestKeycloakPod() *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      keycloakPodName,
+			Namespace: ComponentNamespace,
+		},
+		Status: v1.PodStatus{
+			Conditions: []v1.PodCondition{
+				{
+					Type:   v1.PodReady,
+					Status: v1.ConditionTrue,
+				},
+			},
+		},
 	}
-
-	return "", "", nil
 }
 
+// TestUpdateKeycloakURIs tests updating the URIs of a Keycloak client
+// GIVEN a client in the Verrazzano system realm
+// WHEN I call updateKeycloakUris
+// THEN the redirect URIs of the client are updated, and its other settings are kept, otherwise an error is returned if
+// the template or the environment is invalid
 func TestUpdateKeycloakURIs(t *testing.T) {
-	k8sutil.ClientConfig = fakeRESTConfig
-	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
-	cfg, cli, _ := fakeRESTConfig()
-	clientID := "client"
+	server := newFakeKeycloak()
+	defer stopFakeKeycloak(server)
+	realm := server.AddRealm(vzSysRealm)
+	realm.Clients = []kcadmin.ClientRepresentation{{ID: "client-id", ClientID: "client", PublicClient: true}}
+	kc := newTestAdminClient(t, server)
 	uriTemplate := "\"redirectUris\": [\"https://client.{{.DNSSubDomain}}/verify-auth\"]"
 	tests := []struct {
 		name        string
 		ctx         spi.ComponentContext
-		clientID    string
 		uriTemplate string
 		wantErr     bool
 	}{
 		{
 			name:        "testUpdateKeycloakURIs",
 			ctx:         spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret(), createTestNginxService()).Build(), testVZ, nil, false),
-			clientID:    clientID,
 			uriTemplate: uriTemplate,
 			wantErr:     false,
 		},
 		{
 			name:        "testFailForInvalidUriTemplate",
 			ctx:         spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret(), createTestNginxService()).Build(), testVZ, nil, false),
-			clientID:    clientID,
 			uriTemplate: "test.{{{.DNSSubDomain}}",
 			wantErr:     true,
 		},
@@ -354,92 +208,61 @@ func TestUpdateKeycloakURIs(t *testing.T) {
 			name:        "testFailForNoIngress",
 			ctx:         spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret()).Build(), testVZ, nil, false),
 			wantErr:     true,
-			clientID:    clientID,
 			uriTemplate: uriTemplate,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			podExecResult := k8sutilfake.PodExecResult
-			if tt.wantErr && tt.name == "testFailForNoKeycloakClientsReturned" {
-				k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) {
-					return "", "[]", nil
-				}
-			}
-			defer func() { k8sutilfake.PodExecResult = podExecResult }()
-			if err := updateKeycloakUris(tt.ctx, cfg, cli, keycloakPod(), tt.clientID, tt.uriTemplate); (err != nil) != tt.wantErr {
+			if err := updateKeycloakUris(tt.ctx, kc, realm.Clients[0], tt.uriTemplate); (err != nil) != tt.wantErr {
 				t.Errorf("updateKeycloakUris() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
+	assert.Equal(t, []string{"https://client..192.132.111.122.nip.io/verify-auth"}, realm.Clients[0].RedirectURIs)
+	assert.True(t, realm.Clients[0].PublicClient)
 }
 
 // TestConfigureKeycloakRealms tests configuration of the Keycloak realms
-// GIVEN a client, and a k8s environment
+// GIVEN a client, a k8s environment and a Keycloak server
 // WHEN I call configureKeycloakRealms
 // THEN configure the Keycloak realms, otherwise returning an error if the environment is invalid
 func TestConfigureKeycloakRealms(t *testing.T) {
 	loginSecret := createTestLoginSecret()
 	nginxService := createTestNginxService()
-	k8sutil.ClientConfig = fakeRESTConfig
-	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
-	podExecFunc := k8sutilfake.PodExecResult
 	authConfig := createTestKeycloakAuthConfig()
-
-	keycloakPod := &v1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      keycloakPodName,
-			Namespace: ComponentNamespace,
-		},
-		Status: v1.PodStatus{
-			Conditions: []v1.PodCondition{
-				{
-					Type:   v1.PodReady,
-					Status: v1.ConditionTrue,
-				},
-			},
-		},
-	}
+	keycloakPod := createTestKeycloakPod()
 
 	var tests = []struct {
 		name        string
 		c           client.Client
-		stdout      string
 		isErr       bool
 		errContains string
-		execFunc    func(url *url.URL) (string, string, error)
+		fail        func(r *http.Request) int
+		seed        func(server *kcfake.Server)
 	}{
 		{
 			"should fail when login fails",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(keycloakPod).Build(),
-			"blahblah",
 			true,
 			"secrets \"keycloak-http\" not found",
-			fakeCreateUserGroupCommand,
-		},
-		{
-			"should fail to retrieve user group ID from Keycloak when stdout is empty",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			"",
-			true,
-			"Component Keycloak failed; verrazzano-users group ID from Keycloak is zero length",
-			fakeCreateUserGroupCommandFail,
+			nil,
+			nil,
 		},
 		{
-			"should fail to retrieve user group ID from Keycloak when stdout is incorrect",
+			"should fail when the user group cannot be created",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			"",
 			true,
-			"failed parsing output returned from verrazzano-users Group",
-			fakeCreateUserGroupParseCommandFail,
+			"failed with status code 500",
+			failRequests(http.MethodPost, "/groups"),
+			nil,
 		},
 		{
 			"should fail when Verrazzano secret is not present",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			"blahblah'id",
 			true,
 			"secrets \"verrazzano\" not found",
-			fakeCreateUserGroupCommand,
+			nil,
+			nil,
 		},
 		{
 			"should fail when Verrazzano secret has no password",
@@ -453,123 +276,53 @@ func TestConfigureKeycloakRealms(t *testing.T) {
 						"password": []byte(""),
 					},
 				}).Build(),
-			"blahblah'id",
 			true,
 			"password field empty in secret",
-			fakeCreateUserGroupCommand,
+			nil,
+			nil,
 		},
 		{
 			"should fail when nginx service is not present",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod,
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				},
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano-prom-internal",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				},
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano-es-internal",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				}).Build(),
-			"blahblah'id",
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).WithObjects(createTestUserSecrets()...).Build(),
 			true,
 			"services \"ingress-controller-ingress-nginx-controller\" not found",
-			fakeConfigureRealmCommands,
+			nil,
+			nil,
 		},
 		{
 			"fails during updateKeycloakURIs",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, nginxService, keycloakPod,
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				},
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano-prom-internal",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				},
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano-es-internal",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				}).Build(),
-			"blahblah'id",
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, nginxService, keycloakPod).WithObjects(createTestUserSecrets()...).Build(),
 			true,
-			"keycloak/keycloak-0: failed",
-			fakeConfigureRealmCommandsUpdateKeycloakURIFailed,
+			"failed with status code 500",
+			func(r *http.Request) int {
+				if r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/clients/") {
+					return http.StatusInternalServerError
+				}
+				return 0
+			},
+			func(server *kcfake.Server) {
+				server.AddRealm(vzSysRealm).Clients = []kcadmin.ClientRepresentation{{ID: "pkce-id", ClientID: "verrazzano-pkce"}}
+			},
 		},
 		{
-			"should pass when able to successfully exec commands on the keycloak pod and all k8s objects are present",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, nginxService, keycloakPod, &authConfig,
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				},
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano-prom-internal",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				},
-				&v1.Secret{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "verrazzano-es-internal",
-						Namespace: "verrazzano-system",
-					},
-					Data: map[string][]byte{
-						"password": []byte("blah di blah"),
-					},
-				}).Build(),
-			"blahblah'id",
+			"should pass when all the Keycloak requests succeed and all k8s objects are present",
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, nginxService, keycloakPod, &authConfig).WithObjects(createTestUserSecrets()...).Build(),
 			false,
 			"",
-			fakeConfigureRealmCommands,
+			nil,
+			nil,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeKeycloak()
+			defer stopFakeKeycloak(server)
+			if tt.seed != nil {
+				tt.seed(server)
+			}
+			server.Fail = tt.fail
 			ctx := spi.NewFakeContext(tt.c, testVZ, nil, false)
-			k8sutilfake.PodExecResult = tt.execFunc
-			defer func() { k8sutilfake.PodExecResult = podExecFunc }()
 			err := configureKeycloakRealms(ctx)
 			if tt.isErr {
 				assert.Error(t, err)
@@ -581,6 +334,79 @@ func TestConfigureKeycloakRealms(t *testing.T) {
 	}
 }
 
+// TestConfigureKeycloakRealmsState tests the objects created by the configuration of the Keycloak realms
+// GIVEN a Keycloak server without the Verrazzano system realm
+// WHEN I call configureKeycloakRealms twice
+// THEN the realm, groups, role, users and clients are created once, the realms are configured, and the rancher client
+// secret is set in the Rancher auth config
+func TestConfigureKeycloakRealmsState(t *testing.T) {
+	server := newFakeKeycloak()
+	defer stopFakeKeycloak(server)
+	authConfig := createTestKeycloakAuthConfig()
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret(), createTestNginxService(),
+		createTestKeycloakPod(), &authConfig).WithObjects(createTestUserSecrets()...).Build()
+	ctx := spi.NewFakeContext(c, testVZ, nil, false)
+
+	assert.NoError(t, configureKeycloakRealms(ctx))
+	assert.NoError(t, configureKeycloakRealms(ctx))
+
+	realm := server.Realms[vzSysRealm]
+	assert.NotNil(t, realm)
+	assert.True(t, *realm.Enabled)
+	assert.Equal(t, "length(8) and notUsername", realm.PasswordPolicy)
+	assert.Equal(t, "oracle", realm.LoginTheme)
+	assert.Equal(t, "length(8) and notUsername", server.Realms["master"].PasswordPolicy)
+	assert.Equal(t, "oracle", server.Realms["master"].LoginTheme)
+
+	assert.Len(t, realm.Groups, 1)
+	assert.Len(t, realm.Groups[0].SubGroups, 3)
+	for _, group := range []string{vzAdminGroup, vzMonitorGroup, vzSystemGroup} {
+		assert.Equal(t, "/"+vzUsersGroup+"/"+group, realm.FindGroup(group).Path)
+	}
+	assert.Len(t, realm.Roles, 1)
+	assert.Equal(t, vzAPIAccessRole, realm.GroupRoles[realm.FindGroup(vzUsersGroup).ID][0].Name)
+
+	assert.Len(t, realm.Users, 3)
+	assert.Equal(t, []string{"/verrazzano-users/verrazzano-admins"}, realm.FindUser(vzUserName).Groups)
+	assert.Equal(t, "Verrazzano", realm.FindUser(vzUserName).FirstName)
+	assert.Equal(t, "blah di blah", realm.Passwords[vzUserName])
+
+	assert.Len(t, realm.Clients, 3)
+	pkce := realm.FindClient("verrazzano-pkce")
+	assert.True(t, pkce.PublicClient)
+	assert.Contains(t, pkce.RedirectURIs, "https://verrazzano..192.132.111.122.nip.io/*")
+	assert.Len(t, pkce.ProtocolMappers, 2)
+	pg := realm.FindClient("verrazzano-pg")
+	assert.True(t, pg.DirectAccessGrantsEnabled)
+	assert.NotEmpty(t, realm.ClientSecrets[pg.ID])
+	rancher := realm.FindClient("rancher")
+	assert.False(t, rancher.PublicClient)
+	rancherSecret := realm.ClientSecrets[rancher.ID]
+	assert.NotEmpty(t, rancherSecret)
+
+	err := c.Get(context.TODO(), client.ObjectKey{Name: common.AuthConfigKeycloak}, &authConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, rancherSecret, authConfig.Object[common.AuthConfigKeycloakAttributeClientSecret])
+}
+
+// TestConfigureKeycloakRealmsRecyclePod tests that the Keycloak pod is recycled when the login fails with ephemeral
+// storage
+// GIVEN a Keycloak server that rejects the admin password, and ephemeral MySQL storage
+// WHEN I call configureKeycloakRealms
+// THEN an error is returned and the Keycloak pod is deleted
+func TestConfigureKeycloakRealmsRecyclePod(t *testing.T) {
+	server := newFakeKeycloak()
+	defer stopFakeKeycloak(server)
+	loginSecret := createTestLoginSecret()
+	loginSecret.Data["password"] = []byte("wrong")
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, createTestKeycloakPod()).Build()
+
+	err := configureKeycloakRealms(spi.NewFakeContext(c, testVZ, nil, false))
+	assert.Error(t, err)
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: keycloakPodName}, &v1.Pod{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
 // TestAppendKeycloakOverrides tests that the Keycloak overrides are generated correctly.
 // GIVEN a Verrazzano BOM
 // WHEN I call AppendKeycloakOverrides
@@ -673,15 +499,17 @@ func TestGetEnvironmentName(t *testing.T) {
 }
 
 // TestLoginKeycloak tests the login to keycloak interacts with k8s resources as expected
-// GIVEN a client
+// GIVEN a client and a Keycloak server
 // WHEN I call loginKeycloak
-// THEN throw an error if the k8s environment is invalid (bad secret)
+// THEN throw an error if the k8s environment is invalid (bad secret) or the password is rejected
 func TestLoginKeycloak(t *testing.T) {
+	server := newFakeKeycloak()
+	defer stopFakeKeycloak(server)
 	httpSecret := createTestLoginSecret()
 	httpSecretEmptyPassword := createTestLoginSecret()
 	httpSecretEmptyPassword.Data["password"] = []byte("")
-	cfg, restclient, _ := fakeRESTConfig()
-	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
+	httpSecretWrongPassword := createTestLoginSecret()
+	httpSecretWrongPassword.Data["password"] = []byte("wrong")
 
 	var tests = []struct {
 		name  string
@@ -698,6 +526,11 @@ func TestLoginKeycloak(t *testing.T) {
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(httpSecretEmptyPassword).Build(),
 			true,
 		},
+		{
+			"should fail when keycloak rejects the password",
+			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(httpSecretWrongPassword).Build(),
+			true,
+		},
 		{
 			"should log into keycloak when the password is present",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(httpSecret).Build(),
@@ -707,7 +540,7 @@ func TestLoginKeycloak(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := loginKeycloak(spi.NewFakeContext(tt.c, testVZ, nil, false), cfg, restclient)
+			err := loginKeycloak(spi.NewFakeContext(tt.c, testVZ, nil, false), kcadmin.NewClient(server.BaseURL(), nil))
 			if tt.isErr {
 				assert.Error(t, err)
 			} else {
@@ -813,55 +646,60 @@ func getBoolPtr(b bool) *bool {
 	return &b
 }
 
-// TestGetClientId tests that the function returns the Id whether client exists
+// TestGetClient tests that the function returns the client whether client exists
 // GIVEN an array of keycloak Clients
-// WHEN I call getClientId
-// THEN return the Id of the client if the client exists in the array of clients
-func TestGetClientId(t *testing.T) {
+// WHEN I call getClient
+// THEN return the client if the client exists in the array of clients
+func TestGetClient(t *testing.T) {
 	var tests = []struct {
 		name string
-		in   KeycloakClients
+		in   []kcadmin.ClientRepresentation
 		out  string
 	}{
 		{"testEmptyClients",
-			KeycloakClients{},
+			[]kcadmin.ClientRepresentation{},
 			"",
 		},
 		{"testClientNotFound",
-			KeycloakClients{
+			[]kcadmin.ClientRepresentation{
 				{
-					"973973",
-					"thisClient",
+					ID:       "973973",
+					ClientID: "thisClient",
 				},
 				{
-					"973974",
-					"thatClient",
+					ID:       "973974",
+					ClientID: "thatClient",
 				},
 			},
 			"",
 		},
 		{"testClientFound",
-			KeycloakClients{
+			[]kcadmin.ClientRepresentation{
 				{
-					"973973",
-					"thisClient",
+					ID:       "973973",
+					ClientID: "thisClient",
 				},
 				{
-					"973974",
-					"thatClient",
+					ID:       "973974",
+					ClientID: "thatClient",
 				},
 				{
-					"973974",
-					"someClient",
+					ID:       "973975",
+					ClientID: "someClient",
 				},
 			},
-			"973974",
+			"973975",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equal(t, tt.out, getClientID(tt.in, "someClient"))
+			kcClient := getClient(tt.in, "someClient")
+			if tt.out == "" {
+				assert.Nil(t, kcClient)
+			} else {
+				assert.Equal(t, tt.out, kcClient.ID)
+			}
 		})
 	}
 }
@@ -873,15 +711,15 @@ func TestGetClientId(t *testing.T) {
 func TestUserExists(t *testing.T) {
 	var tests = []struct {
 		name string
-		in   []KeycloakUser
+		in   []kcadmin.User
 		out  bool
 	}{
 		{"testEmptyUsers",
-			[]KeycloakUser{},
+			[]kcadmin.User{},
 			false,
 		},
 		{"testUserNotFound",
-			[]KeycloakUser{
+			[]kcadmin.User{
 				{
 					ID:       "955995",
 					Username: "thisUser",
@@ -894,7 +732,7 @@ func TestUserExists(t *testing.T) {
 			false,
 		},
 		{"testUserFound",
-			[]KeycloakUser{
+			[]kcadmin.User{
 				{
 					ID:       "955995",
 					Username: "thisUser",
@@ -926,15 +764,15 @@ func TestUserExists(t *testing.T) {
 func TestRoleExists(t *testing.T) {
 	var tests = []struct {
 		name string
-		in   KeycloakRoles
+		in   []kcadmin.Role
 		out  bool
 	}{
 		{"testEmptyRoles",
-			KeycloakRoles{},
+			[]kcadmin.Role{},
 			false,
 		},
 		{"testRoleNotFound",
-			KeycloakRoles{
+			[]kcadmin.Role{
 				{
 					ID:   "955995",
 					Name: "thisRole",
@@ -947,7 +785,7 @@ func TestRoleExists(t *testing.T) {
 			false,
 		},
 		{"testRoleFound",
-			KeycloakRoles{
+			[]kcadmin.Role{
 				{
 					ID:   "955995",
 					Name: "thisRole",
@@ -972,108 +810,6 @@ func TestRoleExists(t *testing.T) {
 	}
 }
 
-// TestGroupExists tests that the function returns false/true whether group exists
-// GIVEN an array of keycloak Groups
-// WHEN I call groupExists
-// THEN return true/false whether the group exists in the Keycloak Group structure
-func TestGroupExists(t *testing.T) {
-
-	var (
-		tests = []struct {
-			name string
-			in   KeycloakGroups
-			out  bool
-		}{
-			{"testEmptyGroups",
-				KeycloakGroups{},
-				false,
-			},
-			{"testGroupNotFound",
-				KeycloakGroups{
-					{
-						ID:        "955995",
-						Name:      "thisGroup",
-						SubGroups: nil,
-					},
-					{
-						ID:   "",
-						Name: "",
-						SubGroups: []SubGroup{
-							{
-								ID:   "333333",
-								Name: "subGroup1",
-							},
-							{
-								ID:   "444444",
-								Name: "subGroup2",
-							},
-						},
-					},
-				},
-				false,
-			},
-			{"testGroupFound",
-				KeycloakGroups{
-					{
-						ID:        "955995",
-						Name:      "foundGroup",
-						SubGroups: nil,
-					},
-					{
-						ID:   "",
-						Name: "",
-						SubGroups: []SubGroup{
-							{
-								ID:   "333333",
-								Name: "subGroup1",
-							},
-							{
-								ID:   "444444",
-								Name: "subGroup2",
-							},
-						},
-					},
-				},
-				true,
-			},
-			{"testSubGroupFound",
-				KeycloakGroups{
-					{
-						ID:        "955995",
-						Name:      "someGroup",
-						SubGroups: nil,
-					},
-					{
-						ID:   "",
-						Name: "",
-						SubGroups: []SubGroup{
-							{
-								ID:   "333333",
-								Name: "subGroup1",
-							},
-							{
-								ID:   "444444",
-								Name: "subGroup2",
-							},
-							{
-								ID:   "555555",
-								Name: "foundGroup",
-							},
-						},
-					},
-				},
-				true,
-			},
-		}
-	)
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			assert.Equal(t, tt.out, groupExists(tt.in, "foundGroup"))
-		})
-	}
-}
-
 // TestGetGroupID tests that the function returns the correct groupID for the group name
 // GIVEN an array of keycloak Groups
 // WHEN I call getGroupID
@@ -1083,15 +819,15 @@ func TestGetGroupID(t *testing.T) {
 	var (
 		tests = []struct {
 			name string
-			in   KeycloakGroups
+			in   []kcadmin.Group
 			out  string
 		}{
 			{"testEmptyGroups",
-				KeycloakGroups{},
+				[]kcadmin.Group{},
 				"",
 			},
 			{"testGroupIDNotFound",
-				KeycloakGroups{
+				[]kcadmin.Group{
 					{
 						ID:        "955995",
 						Name:      "thisGroup",
@@ -1100,7 +836,7 @@ func TestGetGroupID(t *testing.T) {
 					{
 						ID:   "",
 						Name: "",
-						SubGroups: []SubGroup{
+						SubGroups: []kcadmin.Group{
 							{
 								ID:   "333333",
 								Name: "subGroup1",
@@ -1115,7 +851,7 @@ func TestGetGroupID(t *testing.T) {
 				"",
 			},
 			{"testGroupIDFound",
-				KeycloakGroups{
+				[]kcadmin.Group{
 					{
 						ID:        "999999",
 						Name:      "foundGroup",
@@ -1124,7 +860,7 @@ func TestGetGroupID(t *testing.T) {
 					{
 						ID:   "",
 						Name: "",
-						SubGroups: []SubGroup{
+						SubGroups: []kcadmin.Group{
 							{
 								ID:   "333333",
 								Name: "subGroup1",
@@ -1139,7 +875,7 @@ func TestGetGroupID(t *testing.T) {
 				"999999",
 			},
 			{"testSubGroupIDFound",
-				KeycloakGroups{
+				[]kcadmin.Group{
 					{
 						ID:        "955995",
 						Name:      "someGroup",
@@ -1148,7 +884,7 @@ func TestGetGroupID(t *testing.T) {
 					{
 						ID:   "",
 						Name: "",
-						SubGroups: []SubGroup{
+						SubGroups: []kcadmin.Group{
 							{
 								ID:   "333333",
 								Name: "subGroup1",
@@ -1166,6 +902,27 @@ func TestGetGroupID(t *testing.T) {
 				},
 				"999999",
 			},
+			{"testNestedSubGroupIDFound",
+				[]kcadmin.Group{
+					{
+						ID:   "955995",
+						Name: "someGroup",
+						SubGroups: []kcadmin.Group{
+							{
+								ID:   "333333",
+								Name: "subGroup1",
+								SubGroups: []kcadmin.Group{
+									{
+										ID:   "999999",
+										Name: "foundGroup",
+									},
+								},
+							},
+						},
+					},
+				},
+				"999999",
+			},
 		}
 	)
 
@@ -1401,184 +1158,156 @@ func TestUpgradeStatefulSet(t *testing.T) {
 }
 
 // TestGetRancherClientSecretFromKeycloak tests getting rancher client secrets
-// GIVEN a client, and a k8s environment
+// GIVEN a client, a k8s environment and a Keycloak server
 // WHEN I call GetRancherClientSecretFromKeycloak
 // THEN returns an rancher client secret, otherwise returning an error if the environment is invalid
 func TestGetRancherClientSecretFromKeycloak(t *testing.T) {
 	loginSecret := createTestLoginSecret()
-	k8sutil.ClientConfig = fakeRESTConfig
-	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
-	podExecFunc := k8sutilfake.PodExecResult
-
-	keycloakPod := &v1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      keycloakPodName,
-			Namespace: ComponentNamespace,
-		},
-		Status: v1.PodStatus{
-			Conditions: []v1.PodCondition{
-				{
-					Type:   v1.PodReady,
-					Status: v1.ConditionTrue,
-				},
-			},
-		},
-	}
+	keycloakPod := createTestKeycloakPod()
 
 	var tests = []struct {
 		name        string
 		c           client.Client
 		isErr       bool
 		errContains string
-		execFunc    func(url *url.URL) (string, string, error)
+		secret      string
+		fail        func(r *http.Request) int
 	}{
 		{
 			"should fail when login fails",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(keycloakPod).Build(),
 			true,
 			"secrets \"keycloak-http\" not found",
-			fakeGetRancherClientSecretFromKeycloak,
+			"abcdef",
+			nil,
 		},
 		{
 			"should fail when fails to get clients",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
 			true,
-			"keycloak/keycloak-0: failed",
-			fakeGetRancherClientSecretFromKeycloakGetClientsFails,
-		},
-		{
-			"should not fail when rancher client id does not exist",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			false,
-			"",
-			fakeGetRancherClientSecretFromKeycloakNoRancherClient,
+			"failed with status code 500",
+			"abcdef",
+			failRequests(http.MethodGet, "/clients"),
 		},
 		{
 			"should fail when fetching client secret fails",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
 			true,
-			"",
-			fakeGetRancherClientSecretFromKeycloakClientSecretFailed,
-		},
-		{
-			"should fail when client secret result is empty",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			true,
-			"",
-			fakeGetRancherClientSecretFromKeycloakClientSecretResultEmpty,
+			"failed with status code 500",
+			"abcdef",
+			failRequests(http.MethodGet, "/client-secret"),
 		},
 		{
-			"should fail when client secret result is invalid",
+			"should return the client secret",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			true,
+			false,
 			"",
-			fakeGetRancherClientSecretFromKeycloakClientSecretResultInvalid,
-		},
-		{
-			"should fail when client secret is empty",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			true,
-			"client secret is empty",
-			fakeGetRancherClientSecretFromKeycloakClientSecretEmpty,
+			"abcdef",
+			nil,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeKeycloak()
+			defer stopFakeKeycloak(server)
+			realm := server.AddRealm(vzSysRealm)
+			realm.Clients = []kcadmin.ClientRepresentation{{ID: "rancher-id", ClientID: "rancher"}}
+			realm.ClientSecrets["rancher-id"] = tt.secret
+			server.Fail = tt.fail
 			ctx := spi.NewFakeContext(tt.c, testVZ, nil, false)
-			k8sutilfake.PodExecResult = tt.execFunc
-			defer func() { k8sutilfake.PodExecResult = podExecFunc }()
-			_, err := GetRancherClientSecretFromKeycloak(ctx)
+			secret, err := GetRancherClientSecretFromKeycloak(ctx)
 			if tt.isErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errContains)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.secret, secret)
 			}
 		})
 	}
 }
 
+// TestGetRancherClientSecretNoRancherClient tests getting the rancher client secret when there is no rancher client
+// GIVEN a Keycloak server without the rancher client
+// WHEN I call GetRancherClientSecretFromKeycloak
+// THEN an empty secret is returned without an error
+func TestGetRancherClientSecretNoRancherClient(t *testing.T) {
+	server := newFakeKeycloak()
+	defer stopFakeKeycloak(server)
+	server.AddRealm(vzSysRealm)
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret()).Build()
+	secret, err := GetRancherClientSecretFromKeycloak(spi.NewFakeContext(c, testVZ, nil, false))
+	assert.NoError(t, err)
+	assert.Empty(t, secret)
+}
+
 // TestGetVerrazzanoUserFromKeycloak tests getting verrazzano user
-// GIVEN a client, and a k8s environment
+// GIVEN a client, a k8s environment and a Keycloak server
 // WHEN I call GetVerrazzanoUserFromKeycloak
 // THEN returns a verrazzano user struct, otherwise returning an error if the environment is invalid
 func TestGetVerrazzanoUserFromKeycloak(t *testing.T) {
 	loginSecret := createTestLoginSecret()
-	k8sutil.ClientConfig = fakeRESTConfig
-	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
-	podExecFunc := k8sutilfake.PodExecResult
-
-	keycloakPod := &v1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      keycloakPodName,
-			Namespace: ComponentNamespace,
-		},
-		Status: v1.PodStatus{
-			Conditions: []v1.PodCondition{
-				{
-					Type:   v1.PodReady,
-					Status: v1.ConditionTrue,
-				},
-			},
-		},
-	}
+	keycloakPod := createTestKeycloakPod()
 
 	var tests = []struct {
 		name        string
 		c           client.Client
 		isErr       bool
 		errContains string
-		execFunc    func(url *url.URL) (string, string, error)
+		username    string
+		fail        func(r *http.Request) int
 	}{
 		{
 			"should fail when login fails",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(keycloakPod).Build(),
 			true,
 			"secrets \"keycloak-http\" not found",
-			fakeGetVerrazzanoUserFromKeycloak,
+			vzUserName,
+			nil,
 		},
 		{
 			"should fail when fails to get users",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
 			true,
-			"keycloak/keycloak-0: failed",
-			fakeGetVerrazzanoUserFromKeycloakFails,
+			"failed with status code 500",
+			vzUserName,
+			failRequests(http.MethodGet, "/users"),
 		},
 		{
-			"should fail when get users result is empty",
+			"should fail when verrazzano user is not found",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
 			true,
-			"",
-			fakeGetVerrazzanoUserFromKeycloakResultEmpty,
+			"verrazzano user does not exist",
+			"someone",
+			nil,
 		},
 		{
-			"should fail when get users result is invalid",
+			"should return the verrazzano user",
 			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			true,
+			false,
 			"",
-			fakeGetVerrazzanoUserFromKeycloakResultInvalid,
-		},
-		{
-			"should fail when verrazzano user is not found",
-			fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(loginSecret, keycloakPod).Build(),
-			true,
-			"verrazzano user does not exist",
-			fakeGetVerrazzanoUserFromKeycloakNoVerrazzanoUser,
+			vzUserName,
+			nil,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeKeycloak()
+			defer stopFakeKeycloak(server)
+			server.AddRealm(vzSysRealm)
+			userID := server.AddUser(vzSysRealm, tt.username, "pw")
+			server.Fail = tt.fail
 			ctx := spi.NewFakeContext(tt.c, testVZ, nil, false)
-			k8sutilfake.PodExecResult = tt.execFunc
-			defer func() { k8sutilfake.PodExecResult = podExecFunc }()
-			_, err := GetVerrazzanoUserFromKeycloak(ctx)
+			user, err := GetVerrazzanoUserFromKeycloak(ctx)
 			if tt.isErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errContains)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, userID, user.ID)
+				assert.Equal(t, vzUserName, user.Username)
 			}
 		})
 	}
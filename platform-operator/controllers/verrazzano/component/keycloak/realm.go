@@ -0,0 +1,502 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/kcadmin"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// The kinds of the objects of the realm configuration reported in the status
+	realmRoleKind             = "Role"
+	realmGroupKind            = "Group"
+	realmClientKind           = "Client"
+	realmIdentityProviderKind = "IdentityProvider"
+
+	// Keys of the secrets in the verrazzano-install namespace with the credentials of the identity providers
+	oidcClientSecretKey   = "clientSecret"
+	ldapBindCredentialKey = "bindCredential"
+
+	userStorageProviderType = "org.keycloak.storage.UserStorageProvider"
+	defaultLDAPVendor       = "other"
+	defaultLDAPUsernameAttr = "uid"
+	defaultLDAPEditMode     = "READ_ONLY"
+)
+
+var defaultLDAPUserObjectClasses = []string{"inetOrgPerson", "organizationalPerson"}
+
+// realmReconciler reconciles the realm configuration of the Verrazzano CR against the verrazzano-system realm, and
+// records what was done to each object
+type realmReconciler struct {
+	ctx     spi.ComponentContext
+	kc      *kcadmin.Client
+	objects []vzapi.KeycloakRealmObjectStatus
+}
+
+// ReconcileRealm creates the objects declared in the realm configuration of the Verrazzano CR that do not exist in the
+// verrazzano-system realm, and updates the objects that drifted from the declaration.  Objects that are not declared
+// are left alone.  It returns what was done to each declared object.
+func (c KeycloakComponent) ReconcileRealm(ctx spi.ComponentContext) ([]vzapi.KeycloakRealmObjectStatus, error) {
+	keycloak := ctx.EffectiveCR().Spec.Components.Keycloak
+	if keycloak == nil || keycloak.Realm == nil {
+		return nil, nil
+	}
+
+	kcPod := keycloakPod()
+	if err := ctx.Client().Get(context.TODO(), types.NamespacedName{Namespace: kcPod.Namespace, Name: kcPod.Name}, kcPod); err != nil {
+		return nil, err
+	}
+	if !isPodReady(kcPod) {
+		return nil, fmt.Errorf("Waiting for pod %s to be ready", kcPod.Name)
+	}
+	kc, closeAdminClient, err := newAdminClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeAdminClient()
+	if err := loginKeycloak(ctx, kc); err != nil {
+		return nil, err
+	}
+
+	r := &realmReconciler{ctx: ctx, kc: kc}
+	if err := r.reconcile(keycloak.Realm); err != nil {
+		return r.objects, err
+	}
+	ctx.Log().Oncef("Component Keycloak successfully reconciled the configuration of realm %s", vzSysRealm)
+	return r.objects, nil
+}
+
+func (r *realmReconciler) reconcile(realm *vzapi.KeycloakRealmConfig) error {
+	// The roles are reconciled first, so that they can be mapped to the groups
+	for _, role := range realm.Roles {
+		if err := r.reconcileRole(role); err != nil {
+			return err
+		}
+	}
+	for _, group := range realm.Groups {
+		if err := r.reconcileGroup(group); err != nil {
+			return err
+		}
+	}
+	for _, client := range realm.Clients {
+		if err := r.reconcileClient(client); err != nil {
+			return err
+		}
+	}
+	for _, provider := range realm.IdentityProviders {
+		var err error
+		if provider.OIDC != nil {
+			err = r.reconcileOIDCProvider(provider.Name, provider.OIDC)
+		} else if provider.LDAP != nil {
+			err = r.reconcileLDAPProvider(provider.Name, provider.LDAP)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// record records what was done to an object of the realm configuration
+func (r *realmReconciler) record(kind string, name string, state vzapi.KeycloakRealmObjectStateType) {
+	r.objects = append(r.objects, vzapi.KeycloakRealmObjectStatus{Kind: kind, Name: name, State: state})
+	if state != vzapi.KeycloakRealmObjectInSync {
+		r.ctx.Log().Oncef("Component Keycloak %s %s %s in realm %s", strings.ToLower(string(state)), strings.ToLower(kind), name, vzSysRealm)
+	}
+}
+
+// reconcileRole creates a realm role, or updates its description
+func (r *realmReconciler) reconcileRole(role vzapi.KeycloakRole) error {
+	roles, err := r.kc.GetRealmRoles(vzSysRealm)
+	if err != nil {
+		return err
+	}
+	representation := kcadmin.Role{Name: role.Name, Description: role.Description}
+	for _, current := range roles {
+		if current.Name != role.Name {
+			continue
+		}
+		if current.Description == role.Description {
+			r.record(realmRoleKind, role.Name, vzapi.KeycloakRealmObjectInSync)
+			return nil
+		}
+		if err := r.kc.UpdateRealmRole(vzSysRealm, role.Name, representation); err != nil {
+			return err
+		}
+		r.record(realmRoleKind, role.Name, vzapi.KeycloakRealmObjectUpdated)
+		return nil
+	}
+	if err := r.kc.CreateRealmRole(vzSysRealm, representation); err != nil {
+		return err
+	}
+	r.record(realmRoleKind, role.Name, vzapi.KeycloakRealmObjectCreated)
+	return nil
+}
+
+// reconcileGroup creates a group, and maps the declared realm roles that are not mapped to it yet.  The roles that are
+// mapped to the group but not declared are kept.
+func (r *realmReconciler) reconcileGroup(group vzapi.KeycloakGroup) error {
+	groups, err := r.kc.GetGroups(vzSysRealm)
+	if err != nil {
+		return err
+	}
+	state := vzapi.KeycloakRealmObjectInSync
+	groupID := getGroupID(groups, group.Name)
+	if groupID == "" {
+		parentID := ""
+		if group.Parent != "" {
+			if parentID = getGroupID(groups, group.Parent); parentID == "" {
+				return fmt.Errorf("Component Keycloak failed creating group %s, parent group %s does not exist", group.Name, group.Parent)
+			}
+		}
+		if groupID, err = createVerrazzanoGroup(r.ctx, r.kc, group.Name, parentID); err != nil {
+			return err
+		}
+		state = vzapi.KeycloakRealmObjectCreated
+	}
+
+	mapped, err := r.kc.GetGroupRealmRoles(vzSysRealm, groupID)
+	if err != nil {
+		return err
+	}
+	var missing []kcadmin.Role
+	for _, roleName := range group.RealmRoles {
+		if roleExists(mapped, roleName) {
+			continue
+		}
+		role, err := r.kc.GetRealmRole(vzSysRealm, roleName)
+		if err != nil {
+			return err
+		}
+		missing = append(missing, *role)
+	}
+	if len(missing) > 0 {
+		if err := r.kc.AddGroupRealmRoles(vzSysRealm, groupID, missing); err != nil {
+			return err
+		}
+		if state == vzapi.KeycloakRealmObjectInSync {
+			state = vzapi.KeycloakRealmObjectUpdated
+		}
+	}
+	r.record(realmGroupKind, group.Name, state)
+	return nil
+}
+
+// reconcileClient creates an OpenID Connect client, with a generated secret for a confidential client, or updates the
+// declared settings of the client
+func (r *realmReconciler) reconcileClient(client vzapi.KeycloakClient) error {
+	clients, err := r.kc.GetClients(vzSysRealm, client.ClientID)
+	if err != nil {
+		return err
+	}
+	current := getClient(clients, client.ClientID)
+	if current == nil {
+		desired := kcadmin.ClientRepresentation{ClientID: client.ClientID}
+		setRealmClient(&desired, client)
+		id, err := r.kc.CreateClient(vzSysRealm, desired)
+		if err != nil {
+			return err
+		}
+		if !client.PublicClient {
+			if err := generateClientSecret(r.ctx, r.kc, client.ClientID, id); err != nil {
+				return err
+			}
+		}
+		r.record(realmClientKind, client.ClientID, vzapi.KeycloakRealmObjectCreated)
+		return nil
+	}
+
+	// The client read from Keycloak is updated, so that the settings that are not declared are kept
+	desired := *current
+	setRealmClient(&desired, client)
+	desired.RedirectURIs = nonNil(desired.RedirectURIs)
+	desired.WebOrigins = nonNil(desired.WebOrigins)
+	current.RedirectURIs = nonNil(current.RedirectURIs)
+	current.WebOrigins = nonNil(current.WebOrigins)
+	if reflect.DeepEqual(*current, desired) {
+		r.record(realmClientKind, client.ClientID, vzapi.KeycloakRealmObjectInSync)
+		return nil
+	}
+	if err := r.kc.UpdateClient(vzSysRealm, current.ID, desired); err != nil {
+		return err
+	}
+	r.record(realmClientKind, client.ClientID, vzapi.KeycloakRealmObjectUpdated)
+	return nil
+}
+
+// setRealmClient sets the settings of a Keycloak client that are declared in the realm configuration
+func setRealmClient(kcClient *kcadmin.ClientRepresentation, client vzapi.KeycloakClient) {
+	kcClient.Enabled = true
+	kcClient.Protocol = "openid-connect"
+	kcClient.PublicClient = client.PublicClient
+	kcClient.StandardFlowEnabled = true
+	kcClient.DirectAccessGrantsEnabled = client.DirectAccessGrantsEnabled
+	kcClient.RedirectURIs = nonNil(client.RedirectURIs)
+	kcClient.WebOrigins = nonNil(client.WebOrigins)
+}
+
+// reconcileOIDCProvider creates an OpenID Connect identity provider, or updates it if its declared settings drifted.
+// The client secret cannot be compared, since Keycloak masks it, so it is set whenever the provider is updated.
+func (r *realmReconciler) reconcileOIDCProvider(alias string, oidc *vzapi.KeycloakOIDCProvider) error {
+	desired := kcadmin.IdentityProvider{
+		Alias:       alias,
+		DisplayName: oidc.DisplayName,
+		ProviderID:  "oidc",
+		Enabled:     true,
+		Config: map[string]string{
+			"authorizationUrl": oidc.AuthorizationURL,
+			"tokenUrl":         oidc.TokenURL,
+			"clientId":         oidc.ClientID,
+			"clientAuthMethod": "client_secret_post",
+			"syncMode":         "IMPORT",
+		},
+	}
+	for key, value := range map[string]string{"userInfoUrl": oidc.UserInfoURL, "issuer": oidc.Issuer, "defaultScope": oidc.DefaultScope} {
+		if len(value) > 0 {
+			desired.Config[key] = value
+		}
+	}
+
+	providers, err := r.kc.GetIdentityProviders(vzSysRealm)
+	if err != nil {
+		return err
+	}
+	var current *kcadmin.IdentityProvider
+	for i := range providers {
+		if providers[i].Alias == alias {
+			current = &providers[i]
+		}
+	}
+	if current != nil && current.DisplayName == desired.DisplayName && current.Enabled && containsConfig(current.Config, desired.Config) {
+		r.record(realmIdentityProviderKind, alias, vzapi.KeycloakRealmObjectInSync)
+		return nil
+	}
+
+	clientSecret, err := getInstallSecretValue(r.ctx, oidc.ClientSecret, oidcClientSecretKey)
+	if err != nil {
+		return err
+	}
+	desired.Config[oidcClientSecretKey] = clientSecret
+	if current != nil {
+		if err := r.kc.UpdateIdentityProvider(vzSysRealm, alias, desired); err != nil {
+			return err
+		}
+		r.record(realmIdentityProviderKind, alias, vzapi.KeycloakRealmObjectUpdated)
+		return nil
+	}
+	if err := r.kc.CreateIdentityProvider(vzSysRealm, desired); err != nil {
+		return err
+	}
+	r.record(realmIdentityProviderKind, alias, vzapi.KeycloakRealmObjectCreated)
+	return nil
+}
+
+// reconcileLDAPProvider creates an LDAP user federation provider, or updates it if its declared settings drifted.  The
+// bind credential cannot be compared, since Keycloak masks it, so it is set whenever the provider is updated.
+func (r *realmReconciler) reconcileLDAPProvider(name string, ldap *vzapi.KeycloakLDAPProvider) error {
+	vendor := defaultIfEmpty(ldap.Vendor, defaultLDAPVendor)
+	usernameAttr := defaultIfEmpty(ldap.UsernameLDAPAttribute, defaultLDAPUsernameAttr)
+	uuidAttr := "entryUUID"
+	if vendor == "ad" {
+		uuidAttr = "objectGUID"
+	}
+	objectClasses := ldap.UserObjectClasses
+	if len(objectClasses) == 0 {
+		objectClasses = defaultLDAPUserObjectClasses
+	}
+	authType := "none"
+	if len(ldap.BindDN) > 0 {
+		authType = "simple"
+	}
+	desired := kcadmin.Component{
+		Name:         name,
+		ProviderID:   "ldap",
+		ProviderType: userStorageProviderType,
+		ParentID:     vzSysRealm,
+		Config: map[string][]string{
+			"enabled":               {"true"},
+			"vendor":                {vendor},
+			"connectionUrl":         {ldap.ConnectionURL},
+			"usersDn":               {ldap.UsersDN},
+			"authType":              {authType},
+			"usernameLDAPAttribute": {usernameAttr},
+			"rdnLDAPAttribute":      {usernameAttr},
+			"uuidLDAPAttribute":     {uuidAttr},
+			"userObjectClasses":     {strings.Join(objectClasses, ", ")},
+			"editMode":              {defaultIfEmpty(ldap.EditMode, defaultLDAPEditMode)},
+		},
+	}
+	if len(ldap.BindDN) > 0 {
+		desired.Config["bindDn"] = []string{ldap.BindDN}
+	}
+
+	components, err := r.kc.GetComponents(vzSysRealm, userStorageProviderType)
+	if err != nil {
+		return err
+	}
+	var current *kcadmin.Component
+	for i := range components {
+		if components[i].Name == name {
+			current = &components[i]
+		}
+	}
+	if current != nil && current.ProviderID == desired.ProviderID && containsComponentConfig(current.Config, desired.Config) {
+		r.record(realmIdentityProviderKind, name, vzapi.KeycloakRealmObjectInSync)
+		return nil
+	}
+
+	if len(ldap.BindCredential) > 0 {
+		bindCredential, err := getInstallSecretValue(r.ctx, ldap.BindCredential, ldapBindCredentialKey)
+		if err != nil {
+			return err
+		}
+		desired.Config[ldapBindCredentialKey] = []string{bindCredential}
+	}
+	if current != nil {
+		desired.ID = current.ID
+		if err := r.kc.UpdateComponent(vzSysRealm, current.ID, desired); err != nil {
+			return err
+		}
+		r.record(realmIdentityProviderKind, name, vzapi.KeycloakRealmObjectUpdated)
+		return nil
+	}
+	if _, err := r.kc.CreateComponent(vzSysRealm, desired); err != nil {
+		return err
+	}
+	r.record(realmIdentityProviderKind, name, vzapi.KeycloakRealmObjectCreated)
+	return nil
+}
+
+// getInstallSecretValue returns the value of a key of a secret in the verrazzano-install namespace
+func getInstallSecretValue(ctx spi.ComponentContext, name string, key string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := ctx.Client().Get(context.TODO(), types.NamespacedName{Namespace: constants.VerrazzanoInstallNamespace, Name: name}, secret); err != nil {
+		return "", ctx.Log().ErrorfNewErr("Component Keycloak failed retrieving secret %s/%s: %v", constants.VerrazzanoInstallNamespace, name, err)
+	}
+	value := string(secret.Data[key])
+	if value == "" {
+		return "", ctx.Log().ErrorfNewErr("Component Keycloak failed, the %s key of secret %s/%s is empty", key, constants.VerrazzanoInstallNamespace, name)
+	}
+	return value, nil
+}
+
+// containsConfig returns true if the current config of an identity provider has all the entries of the desired config
+func containsConfig(current map[string]string, desired map[string]string) bool {
+	for key, value := range desired {
+		if currentValue, ok := current[key]; !ok || currentValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// containsComponentConfig returns true if the current config of a component has all the entries of the desired config
+func containsComponentConfig(current map[string][]string, desired map[string][]string) bool {
+	for key, value := range desired {
+		if currentValue, ok := current[key]; !ok || !reflect.DeepEqual(currentValue, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func defaultIfEmpty(value string, defaultValue string) string {
+	if len(value) == 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// validateRealmConfig validates the realm configuration of the Keycloak component
+func validateRealmConfig(realm *installv1beta1.KeycloakRealmConfig) error {
+	if realm == nil {
+		return nil
+	}
+	names := map[string]bool{}
+	checkName := func(kind string, name string) error {
+		if len(name) == 0 {
+			return fmt.Errorf("The name of a Keycloak realm %s must be specified", strings.ToLower(kind))
+		}
+		if strings.Contains(name, " ") {
+			return fmt.Errorf("Invalid name %q of Keycloak realm %s", name, strings.ToLower(kind))
+		}
+		if names[kind+"/"+name] {
+			return fmt.Errorf("Duplicate Keycloak realm %s %s", strings.ToLower(kind), name)
+		}
+		names[kind+"/"+name] = true
+		return nil
+	}
+	for _, role := range realm.Roles {
+		if err := checkName(realmRoleKind, role.Name); err != nil {
+			return err
+		}
+	}
+	for _, group := range realm.Groups {
+		if err := checkName(realmGroupKind, group.Name); err != nil {
+			return err
+		}
+	}
+	for _, client := range realm.Clients {
+		if err := checkName(realmClientKind, client.ClientID); err != nil {
+			return err
+		}
+	}
+	for _, provider := range realm.IdentityProviders {
+		if err := checkName(realmIdentityProviderKind, provider.Name); err != nil {
+			return err
+		}
+		if (provider.OIDC == nil) == (provider.LDAP == nil) {
+			return fmt.Errorf("Exactly one of oidc and ldap must be specified for Keycloak identity provider %s", provider.Name)
+		}
+		if oidc := provider.OIDC; oidc != nil {
+			if len(oidc.AuthorizationURL) == 0 || len(oidc.TokenURL) == 0 || len(oidc.ClientID) == 0 || len(oidc.ClientSecret) == 0 {
+				return fmt.Errorf("The authorization URL, token URL, client ID and client secret must be specified for Keycloak identity provider %s", provider.Name)
+			}
+		}
+		if ldap := provider.LDAP; ldap != nil {
+			if err := validateLDAPProvider(provider.Name, ldap); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateLDAPProvider(name string, ldap *installv1beta1.KeycloakLDAPProvider) error {
+	if len(ldap.ConnectionURL) == 0 || len(ldap.UsersDN) == 0 {
+		return fmt.Errorf("The connection URL and users DN must be specified for Keycloak LDAP provider %s", name)
+	}
+	if (len(ldap.BindDN) == 0) != (len(ldap.BindCredential) == 0) {
+		return fmt.Errorf("The bind DN and the bind credential must be specified together for Keycloak LDAP provider %s", name)
+	}
+	switch ldap.Vendor {
+	case "", "ad", "rhds", "tivoli", "edirectory", "other":
+	default:
+		return fmt.Errorf("Invalid vendor %s of Keycloak LDAP provider %s", ldap.Vendor, name)
+	}
+	switch ldap.EditMode {
+	case "", "READ_ONLY", "WRITABLE", "UNSYNCED":
+	default:
+		return fmt.Errorf("Invalid edit mode %s of Keycloak LDAP provider %s", ldap.EditMode, name)
+	}
+	return nil
+}
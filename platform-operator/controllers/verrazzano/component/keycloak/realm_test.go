@@ -0,0 +1,171 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package keycloak
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/kcadmin"
+	kcfake "github.com/verrazzano/verrazzano/platform-operator/internal/kcadmin/fake"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newRealmTestServer starts a fake Keycloak server with a Verrazzano system realm that has a role with an old
+// description, the API access role, the users group and an OIDC identity provider
+func newRealmTestServer() *kcfake.Server {
+	server := newFakeKeycloak()
+	realm := server.AddRealm(vzSysRealm)
+	realm.Roles = []kcadmin.Role{{ID: "1", Name: "existing-role", Description: "old"}, {ID: "2", Name: vzAPIAccessRole}}
+	realm.Groups = []kcadmin.Group{{ID: "users-id", Name: vzUsersGroup, Path: "/" + vzUsersGroup}}
+	realm.IdentityProviders = []kcadmin.IdentityProvider{{Alias: "corp", ProviderID: "oidc", Enabled: true, Config: map[string]string{
+		"authorizationUrl": "https://corp/auth", "tokenUrl": "https://corp/token", "clientId": "vz", "clientSecret": "**********",
+		"clientAuthMethod": "client_secret_post", "syncMode": "IMPORT"}}}
+	return server
+}
+
+// newRealmTestContext returns a context with a ready Keycloak pod, the login secret and the secrets of the identity
+// providers, and a Verrazzano CR with the given realm configuration
+func newRealmTestContext(realm *vzapi.KeycloakRealmConfig) spi.ComponentContext {
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(
+		createTestLoginSecret(),
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: keycloakPodName, Namespace: ComponentNamespace},
+			Status:     v1.PodStatus{Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ldap-bind", Namespace: constants.VerrazzanoInstallNamespace},
+			Data:       map[string][]byte{ldapBindCredentialKey: []byte("bindpw")},
+		},
+	).Build()
+	vz := &vzapi.Verrazzano{Spec: vzapi.VerrazzanoSpec{Components: vzapi.ComponentSpec{
+		Keycloak: &vzapi.KeycloakComponent{Realm: realm},
+	}}}
+	return spi.NewFakeContext(c, vz, nil, false)
+}
+
+// TestReconcileRealm tests the ReconcileRealm fn
+// GIVEN a realm configuration with a drifted role, a new role, group, client and LDAP provider, and an OIDC provider
+// that is in sync
+// WHEN ReconcileRealm is called, again without changes, and again after the client drifted
+// THEN the role is updated, the new objects are created, the OIDC provider is left alone, then all the objects are in
+// sync, then the client is updated, and what was done to each object is returned
+func TestReconcileRealm(t *testing.T) {
+	server := newRealmTestServer()
+	defer stopFakeKeycloak(server)
+
+	ctx := newRealmTestContext(&vzapi.KeycloakRealmConfig{
+		Roles:   []vzapi.KeycloakRole{{Name: "existing-role", Description: "new"}, {Name: "new-role"}},
+		Groups:  []vzapi.KeycloakGroup{{Name: "devs", Parent: vzUsersGroup, RealmRoles: []string{"new-role", vzAPIAccessRole}}},
+		Clients: []vzapi.KeycloakClient{{ClientID: "app", RedirectURIs: []string{"https://app/*"}}},
+		IdentityProviders: []vzapi.KeycloakIdentityProvider{
+			{Name: "corp", OIDC: &vzapi.KeycloakOIDCProvider{AuthorizationURL: "https://corp/auth", TokenURL: "https://corp/token", ClientID: "vz", ClientSecret: "corp-oidc"}},
+			{Name: "ldap", LDAP: &vzapi.KeycloakLDAPProvider{ConnectionURL: "ldaps://ldap:636", UsersDN: "ou=users,dc=example", BindDN: "cn=admin", BindCredential: "ldap-bind"}},
+		},
+	})
+	comp := NewComponent().(KeycloakComponent)
+	objects, err := comp.ReconcileRealm(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []vzapi.KeycloakRealmObjectStatus{
+		{Kind: realmRoleKind, Name: "existing-role", State: vzapi.KeycloakRealmObjectUpdated},
+		{Kind: realmRoleKind, Name: "new-role", State: vzapi.KeycloakRealmObjectCreated},
+		{Kind: realmGroupKind, Name: "devs", State: vzapi.KeycloakRealmObjectCreated},
+		{Kind: realmClientKind, Name: "app", State: vzapi.KeycloakRealmObjectCreated},
+		{Kind: realmIdentityProviderKind, Name: "corp", State: vzapi.KeycloakRealmObjectInSync},
+		{Kind: realmIdentityProviderKind, Name: "ldap", State: vzapi.KeycloakRealmObjectCreated},
+	}, objects)
+
+	realm := server.Realms[vzSysRealm]
+	assert.Equal(t, "new", realm.Roles[0].Description)
+	devs := realm.FindGroup("devs")
+	assert.Equal(t, "/verrazzano-users/devs", devs.Path)
+	assert.Len(t, realm.GroupRoles[devs.ID], 2)
+	app := realm.FindClient("app")
+	assert.Equal(t, []string{"https://app/*"}, app.RedirectURIs)
+	assert.NotEmpty(t, realm.ClientSecrets[app.ID])
+	assert.Equal(t, []string{"bindpw"}, realm.Components[0].Config[ldapBindCredentialKey])
+	assert.Equal(t, "**********", realm.IdentityProviders[0].Config[oidcClientSecretKey])
+
+	objects, err = comp.ReconcileRealm(ctx)
+	assert.NoError(t, err)
+	for _, object := range objects {
+		assert.Equal(t, vzapi.KeycloakRealmObjectInSync, object.State, object.Name)
+	}
+
+	realm.FindClient("app").RedirectURIs = []string{"https://other/*"}
+	objects, err = comp.ReconcileRealm(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, vzapi.KeycloakRealmObjectUpdated, objects[3].State)
+	assert.Equal(t, []string{"https://app/*"}, realm.FindClient("app").RedirectURIs)
+}
+
+// TestReconcileRealmErrors tests the ReconcileRealm fn
+// GIVEN a Verrazzano CR without a realm configuration, and one with a group whose parent does not exist
+// WHEN ReconcileRealm is called
+// THEN nothing is done without a realm configuration, and an error is returned with the objects that were reconciled
+// before the error otherwise
+func TestReconcileRealmErrors(t *testing.T) {
+	server := newRealmTestServer()
+	defer stopFakeKeycloak(server)
+
+	comp := NewComponent().(KeycloakComponent)
+	objects, err := comp.ReconcileRealm(newRealmTestContext(nil))
+	assert.NoError(t, err)
+	assert.Nil(t, objects)
+	assert.Empty(t, server.Requests)
+
+	objects, err = comp.ReconcileRealm(newRealmTestContext(&vzapi.KeycloakRealmConfig{
+		Roles:  []vzapi.KeycloakRole{{Name: vzAPIAccessRole}},
+		Groups: []vzapi.KeycloakGroup{{Name: "devs", Parent: "missing"}},
+	}))
+	assert.Error(t, err)
+	assert.Equal(t, []vzapi.KeycloakRealmObjectStatus{{Kind: realmRoleKind, Name: vzAPIAccessRole, State: vzapi.KeycloakRealmObjectInSync}}, objects)
+}
+
+// TestValidateRealmConfig tests the validateRealmConfig fn
+// GIVEN realm configurations
+// WHEN validateRealmConfig is called
+// THEN an error is returned for missing, invalid or duplicate names, and for invalid identity providers
+func TestValidateRealmConfig(t *testing.T) {
+	oidc := &installv1beta1.KeycloakOIDCProvider{AuthorizationURL: "https://corp/auth", TokenURL: "https://corp/token", ClientID: "vz", ClientSecret: "corp-oidc"}
+	ldap := &installv1beta1.KeycloakLDAPProvider{ConnectionURL: "ldaps://ldap:636", UsersDN: "ou=users,dc=example"}
+	tests := []struct {
+		name    string
+		realm   *installv1beta1.KeycloakRealmConfig
+		wantErr bool
+	}{
+		{name: "notConfigured"},
+		{name: "valid", realm: &installv1beta1.KeycloakRealmConfig{
+			Roles:             []installv1beta1.KeycloakRole{{Name: "role"}},
+			Groups:            []installv1beta1.KeycloakGroup{{Name: "role"}},
+			Clients:           []installv1beta1.KeycloakClient{{ClientID: "app"}},
+			IdentityProviders: []installv1beta1.KeycloakIdentityProvider{{Name: "corp", OIDC: oidc}, {Name: "ldap", LDAP: ldap}},
+		}},
+		{name: "missingName", realm: &installv1beta1.KeycloakRealmConfig{Roles: []installv1beta1.KeycloakRole{{}}}, wantErr: true},
+		{name: "quotedName", realm: &installv1beta1.KeycloakRealmConfig{Roles: []installv1beta1.KeycloakRole{{Name: "o'brien\"s-$role`\\"}}}},
+		{name: "invalidName", realm: &installv1beta1.KeycloakRealmConfig{Groups: []installv1beta1.KeycloakGroup{{Name: "my group"}}}, wantErr: true},
+		{name: "duplicateName", realm: &installv1beta1.KeycloakRealmConfig{Clients: []installv1beta1.KeycloakClient{{ClientID: "app"}, {ClientID: "app"}}}, wantErr: true},
+		{name: "noProvider", realm: &installv1beta1.KeycloakRealmConfig{IdentityProviders: []installv1beta1.KeycloakIdentityProvider{{Name: "corp"}}}, wantErr: true},
+		{name: "twoProviders", realm: &installv1beta1.KeycloakRealmConfig{IdentityProviders: []installv1beta1.KeycloakIdentityProvider{{Name: "corp", OIDC: oidc, LDAP: ldap}}}, wantErr: true},
+		{name: "missingClientSecret", realm: &installv1beta1.KeycloakRealmConfig{IdentityProviders: []installv1beta1.KeycloakIdentityProvider{
+			{Name: "corp", OIDC: &installv1beta1.KeycloakOIDCProvider{AuthorizationURL: "https://corp/auth", TokenURL: "https://corp/token", ClientID: "vz"}}}}, wantErr: true},
+		{name: "missingBindCredential", realm: &installv1beta1.KeycloakRealmConfig{IdentityProviders: []installv1beta1.KeycloakIdentityProvider{
+			{Name: "ldap", LDAP: &installv1beta1.KeycloakLDAPProvider{ConnectionURL: "ldaps://ldap:636", UsersDN: "ou=users,dc=example", BindDN: "cn=admin"}}}}, wantErr: true},
+		{name: "invalidEditMode", realm: &installv1beta1.KeycloakRealmConfig{IdentityProviders: []installv1beta1.KeycloakIdentityProvider{
+			{Name: "ldap", LDAP: &installv1beta1.KeycloakLDAPProvider{ConnectionURL: "ldaps://ldap:636", UsersDN: "ou=users,dc=example", EditMode: "SYNC"}}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRealmConfig(tt.realm)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package keycloak
+
+import (
+	"fmt"
+
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/credentials"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/kcadmin"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	keycloakAdminUser     = "keycloakadmin"
+	keycloakAdminRealm    = "master"
+	passwordKey           = "password"
+	monitoringOperatorDep = "verrazzano-monitoring-operator"
+)
+
+// GetRotatableCredentials returns the credentials of the keycloakadmin user and the verrazzano user, which can be
+// rotated
+func (c KeycloakComponent) GetRotatableCredentials() []string {
+	return []string{globalconst.KeycloakAdminCredential, globalconst.VerrazzanoUserCredential}
+}
+
+// RotateCredential generates a new password for the keycloakadmin user or the verrazzano user, sets it in Keycloak and
+// restarts the workloads that read it
+func (c KeycloakComponent) RotateCredential(ctx spi.ComponentContext, credential string, request string) error {
+	kc, closeAdminClient, err := newAdminClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeAdminClient()
+	switch credential {
+	case globalconst.KeycloakAdminCredential:
+		return rotateAdminPassword(ctx, kc)
+	case globalconst.VerrazzanoUserCredential:
+		return rotateVerrazzanoUserPassword(ctx, kc, request)
+	}
+	return fmt.Errorf("Component Keycloak has no credential %s", credential)
+}
+
+// rotateAdminPassword rotates the password of the keycloakadmin user in the keycloak-http secret.  Keycloak only reads
+// the secret when the admin user is created, so no workload is restarted.
+func rotateAdminPassword(ctx spi.ComponentContext, kc *kcadmin.Client) error {
+	nsn := types.NamespacedName{Namespace: ComponentNamespace, Name: "keycloak-http"}
+	return credentials.RotateSecret(ctx.Client(), ctx.EffectiveCR(), nsn, []string{passwordKey}, func(current map[string][]byte, rotated map[string][]byte) error {
+		// Login with the new password if a previous rotation attempt already set it
+		if err := loginKeycloakWithPassword(ctx, kc, string(current[passwordKey])); err != nil {
+			if err := loginKeycloakWithPassword(ctx, kc, string(rotated[passwordKey])); err != nil {
+				return err
+			}
+		}
+		return setUserPassword(ctx, kc, keycloakAdminRealm, keycloakAdminUser, string(rotated[passwordKey]))
+	})
+}
+
+// rotateVerrazzanoUserPassword rotates the password of the verrazzano user, and restarts the monitoring operator that
+// configures the VMI with the secret of the user
+func rotateVerrazzanoUserPassword(ctx spi.ComponentContext, kc *kcadmin.Client, request string) error {
+	nsn := types.NamespacedName{Namespace: constants.VerrazzanoSystemNamespace, Name: constants.VMISecret}
+	err := credentials.RotateSecret(ctx.Client(), ctx.EffectiveCR(), nsn, []string{passwordKey}, func(_ map[string][]byte, rotated map[string][]byte) error {
+		if err := loginKeycloak(ctx, kc); err != nil {
+			return err
+		}
+		return setUserPassword(ctx, kc, vzSysRealm, vzUserName, string(rotated[passwordKey]))
+	})
+	if err != nil {
+		return err
+	}
+	return credentials.RestartWorkloads(ctx.Client(), request,
+		[]types.NamespacedName{{Namespace: constants.VerrazzanoSystemNamespace, Name: monitoringOperatorDep}}, nil)
+}
+
+// setUserPassword sets the password of a Keycloak user
+func setUserPassword(ctx spi.ComponentContext, kc *kcadmin.Client, realm string, userName string, pw string) error {
+	users, err := kc.GetUsers(realm, userName)
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving user %s in realm %s: %v", userName, realm, err)
+		return err
+	}
+	for _, user := range users {
+		if user.Username != userName {
+			continue
+		}
+		if err := kc.ResetUserPassword(realm, user.ID, pw); err != nil {
+			ctx.Log().Errorf("Component Keycloak failed setting the password of user %s in realm %s: %v", userName, realm, err)
+			return err
+		}
+		ctx.Log().Oncef("Component Keycloak successfully set the password of user %s in realm %s", userName, realm)
+		return nil
+	}
+	return ctx.Log().ErrorfNewErr("Component Keycloak failed setting the password of user %s, the user does not exist in realm %s", userName, realm)
+}
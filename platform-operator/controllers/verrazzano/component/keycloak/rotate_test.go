@@ -0,0 +1,102 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package keycloak
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// getSecretData returns the data of a Secret
+func getSecretData(t *testing.T, c client.Client, namespace string, name string) map[string][]byte {
+	secret := v1.Secret{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, &secret))
+	return secret.Data
+}
+
+// TestRotateAdminPassword tests rotating the password of the keycloakadmin user
+// GIVEN the keycloak-http secret and a Keycloak server with the password of the secret
+// WHEN RotateCredential is called for the keycloak credential
+// THEN the new password is set in the master realm and saved in the secret
+func TestRotateAdminPassword(t *testing.T) {
+	server := newFakeKeycloak()
+	defer stopFakeKeycloak(server)
+
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret()).Build()
+	ctx := spi.NewFakeContext(c, &vzapi.Verrazzano{}, nil, false)
+	assert.NoError(t, NewComponent().(KeycloakComponent).RotateCredential(ctx, globalconst.KeycloakAdminCredential, "1"))
+
+	data := getSecretData(t, c, ComponentNamespace, "keycloak-http")
+	rotated := string(data[passwordKey])
+	assert.Len(t, rotated, 15)
+	assert.Len(t, data, 1)
+	assert.Equal(t, rotated, server.Realms[keycloakAdminRealm].Passwords[keycloakAdminUser])
+}
+
+// TestRotateAdminPasswordLoginFailed tests rotating the password of the keycloakadmin user when Keycloak rejects it
+// GIVEN a Keycloak server with an admin password that is not the password of the keycloak-http secret
+// WHEN RotateCredential is called for the keycloak credential
+// THEN an error is returned, and the password is not changed in Keycloak or in the secret
+func TestRotateAdminPasswordLoginFailed(t *testing.T) {
+	server := newFakeKeycloak()
+	defer stopFakeKeycloak(server)
+	server.Realms[keycloakAdminRealm].Passwords[keycloakAdminUser] = "other"
+
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(createTestLoginSecret()).Build()
+	ctx := spi.NewFakeContext(c, &vzapi.Verrazzano{}, nil, false)
+	assert.Error(t, NewComponent().(KeycloakComponent).RotateCredential(ctx, globalconst.KeycloakAdminCredential, "1"))
+
+	assert.Equal(t, "password", string(getSecretData(t, c, ComponentNamespace, "keycloak-http")[passwordKey]))
+	assert.Equal(t, "other", server.Realms[keycloakAdminRealm].Passwords[keycloakAdminUser])
+}
+
+// TestRotateVerrazzanoUserPassword tests rotating the password of the verrazzano user
+// GIVEN the keycloak-http secret, the verrazzano secret and the monitoring operator deployment
+// WHEN RotateCredential is called for the verrazzano credential
+// THEN the new password is set in the verrazzano-system realm and saved in the secret, and the monitoring operator is
+// restarted
+func TestRotateVerrazzanoUserPassword(t *testing.T) {
+	server := newFakeKeycloak()
+	defer stopFakeKeycloak(server)
+	server.AddRealm(vzSysRealm)
+	server.AddUser(vzSysRealm, vzUserName, "current")
+
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(
+		createTestLoginSecret(),
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: constants.VerrazzanoSystemNamespace, Name: constants.VMISecret},
+			Data:       map[string][]byte{"username": []byte(vzUserName), passwordKey: []byte("current")},
+		},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: constants.VerrazzanoSystemNamespace, Name: monitoringOperatorDep}},
+	).Build()
+	ctx := spi.NewFakeContext(c, &vzapi.Verrazzano{}, nil, false)
+	comp := NewComponent().(KeycloakComponent)
+	assert.Equal(t, []string{globalconst.KeycloakAdminCredential, globalconst.VerrazzanoUserCredential}, comp.GetRotatableCredentials())
+	assert.NoError(t, comp.RotateCredential(ctx, globalconst.VerrazzanoUserCredential, "1"))
+
+	data := getSecretData(t, c, constants.VerrazzanoSystemNamespace, constants.VMISecret)
+	rotated := string(data[passwordKey])
+	assert.NotEqual(t, "current", rotated)
+	assert.Equal(t, vzUserName, string(data["username"]))
+	assert.Equal(t, rotated, server.Realms[vzSysRealm].Passwords[vzUserName])
+
+	deployment := appsv1.Deployment{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: constants.VerrazzanoSystemNamespace, Name: monitoringOperatorDep}, &deployment))
+	assert.Equal(t, "1", deployment.Spec.Template.Annotations[globalconst.VerrazzanoRestartAnnotation])
+
+	assert.Error(t, comp.RotateCredential(ctx, globalconst.MySQLCredential, "1"))
+}
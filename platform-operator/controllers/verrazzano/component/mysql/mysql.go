@@ -20,6 +20,7 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/credentials"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/status"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
 	v1 "k8s.io/api/core/v1"
@@ -51,7 +52,7 @@ func isMySQLReady(context spi.ComponentContext) bool {
 		},
 	}
 	prefix := fmt.Sprintf("Component %s", context.GetComponent())
-	return status.DeploymentsAreReady(context.Log(), context.Client(), deployments, 1, prefix)
+	return status.DeploymentsAreReady(context.Log(), context.Client(), context.ActualCR(), deployments, 1, prefix)
 }
 
 // appendMySQLOverrides appends the MySQL helm overrides
@@ -130,6 +131,12 @@ func postInstall(ctx spi.ComponentContext) error {
 	}
 	// Delete create-mysql-db.sql after install
 	removeMySQLInitFile(ctx)
+
+	// Write the passwords generated by the chart to the credentials store
+	nsName := types.NamespacedName{Namespace: ComponentNamespace, Name: secretName}
+	if err := credentials.ExportSecret(ctx.Client(), ctx.EffectiveCR(), nsName); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed writing the MySQL secret to the credentials store: %v", err)
+	}
 	return nil
 }
 
@@ -294,14 +301,24 @@ func appendMySQLSecret(compContext spi.ComponentContext, kvs []bom.KeyValue) ([]
 	err := compContext.Client().Get(context.TODO(), nsName, secret)
 	if err != nil {
 		// A secret is not expected to be found the first time around (i.e. it's an install and not an update scenario).
-		// So do not return an error in this case.
+		// So do not return an error in this case, the passwords are generated by the chart unless the credentials
+		// store has them.
 		if errors.IsNotFound(err) && compContext.Init(ComponentName).GetOperation() == vzconst.InstallOperation {
-			return kvs, nil
+			stored, found, err := credentials.GetCredential(compContext.Client(), compContext.EffectiveCR(), nsName)
+			if err != nil {
+				return []bom.KeyValue{}, compContext.Log().ErrorfNewErr("Failed getting MySQL credentials from the credentials store: %v", err)
+			}
+			if !found {
+				return kvs, nil
+			}
+			secret.Data = stored
+		} else {
+			// Return an error for upgrade or update
+			return []bom.KeyValue{}, compContext.Log().ErrorfNewErr("Failed getting MySQL secret: %v", err)
 		}
-		// Return an error for upgrade or update
-		return []bom.KeyValue{}, compContext.Log().ErrorfNewErr("Failed getting MySQL secret: %v", err)
 	}
-	// Force mysql to use the initial password and root password during the upgrade or update, by specifying as helm overrides
+	// Force mysql to use the initial password and root password during the upgrade or update, or the passwords from the
+	// credentials store during the install, by specifying as helm overrides
 	kvs = append(kvs, bom.KeyValue{
 		Key:   helmRootPwd,
 		Value: string(secret.Data[mySQLRootKey]),
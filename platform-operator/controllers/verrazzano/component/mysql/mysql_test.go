@@ -15,6 +15,7 @@ import (
 	vzconst "github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/credentials"
 	"github.com/verrazzano/verrazzano/platform-operator/mocks"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
@@ -22,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -108,6 +110,29 @@ func TestAppendMySQLOverridesUpdate(t *testing.T) {
 	assert.NotEmpty(t, bom.FindKV(kvs, busyboxImageTagKey))
 }
 
+// TestAppendMySQLOverridesCredentialsStore tests the appendMySQLOverrides function
+// GIVEN a call to appendMySQLOverrides
+// WHEN the mysql secret doesn't exist during install and the credentials store has the MySQL passwords
+// THEN the passwords from the credentials store are returned as overrides
+func TestAppendMySQLOverridesCredentialsStore(t *testing.T) {
+	config.SetDefaultBomFilePath(testBomFilePath)
+	defer func() {
+		config.SetDefaultBomFilePath("")
+	}()
+	store := credentials.NewLocalStore()
+	_ = store.Put(ComponentNamespace+"/"+secretName, map[string]string{mySQLRootKey: "stored-root-key", mySQLKey: "stored-key"})
+	credentials.SetNewStoreFunc(func(_ client.Client, _ *vzapi.Verrazzano) (credentials.Store, error) { return store, nil })
+	defer credentials.ResetNewStoreFunc()
+
+	vz := &vzapi.Verrazzano{}
+	fakeClient := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	ctx := spi.NewFakeContext(fakeClient, vz, nil, false, profilesDir).Init(ComponentName).Operation(vzconst.InstallOperation)
+	kvs, err := appendMySQLOverrides(ctx, "", "", "", []bom.KeyValue{})
+	assert.NoError(t, err)
+	assert.Equal(t, "stored-root-key", bom.FindKV(kvs, helmRootPwd))
+	assert.Equal(t, "stored-key", bom.FindKV(kvs, helmPwd))
+}
+
 // TestAppendMySQLOverridesWithInstallArgs tests the appendMySQLOverrides function
 // GIVEN a call to appendMySQLOverrides
 // WHEN I pass in an empty VZ CR with MySQL install args
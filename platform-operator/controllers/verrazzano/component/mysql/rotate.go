@@ -0,0 +1,83 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/credentials"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+const (
+	mySQLPodLabel       = "app=mysql"
+	mySQLContainerName  = "mysql"
+	keycloakStatefulSet = "keycloak"
+
+	// mySQLStdinCmd reads the root password from the first line of stdin into MYSQL_PWD, then runs the SQL statements
+	// of the rest of stdin as the root user, so the passwords are not in the command line
+	mySQLStdinCmd = "IFS= read -r MYSQL_PWD && export MYSQL_PWD && exec mysql -uroot"
+)
+
+// sqlStringEscaper escapes the characters that end or escape a SQL string literal
+var sqlStringEscaper = strings.NewReplacer(`\`, `\\`, `'`, `''`)
+
+// GetRotatableCredentials returns the credential of the MySQL root and keycloak users, which can be rotated
+func (c mysqlComponent) GetRotatableCredentials() []string {
+	return []string{globalconst.MySQLCredential}
+}
+
+// RotateCredential generates new passwords for the MySQL root and keycloak users, sets them in MySQL and restarts
+// Keycloak, which reads the password of the keycloak user on startup
+func (c mysqlComponent) RotateCredential(ctx spi.ComponentContext, credential string, request string) error {
+	if credential != globalconst.MySQLCredential {
+		return fmt.Errorf("Component MySQL has no credential %s", credential)
+	}
+	cfg, cli, err := k8sutil.ClientConfig()
+	if err != nil {
+		return err
+	}
+	pod, err := k8sutil.GetRunningPodForLabel(ctx.Client(), mySQLPodLabel, ComponentNamespace, ctx.Log())
+	if err != nil {
+		return err
+	}
+	nsn := types.NamespacedName{Namespace: ComponentNamespace, Name: secretName}
+	err = credentials.RotateSecret(ctx.Client(), ctx.EffectiveCR(), nsn, []string{mySQLRootKey, mySQLKey}, func(current map[string][]byte, rotated map[string][]byte) error {
+		// Connect with the new root password if a previous rotation attempt already set it
+		err := alterUsers(ctx, cfg, cli, pod, string(current[mySQLRootKey]), rotated)
+		if err != nil {
+			err = alterUsers(ctx, cfg, cli, pod, string(rotated[mySQLRootKey]), rotated)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return credentials.RestartWorkloads(ctx.Client(), request, nil,
+		[]types.NamespacedName{{Namespace: ComponentNamespace, Name: keycloakStatefulSet}})
+}
+
+// alterUsers sets the passwords of the root and keycloak users in MySQL, connecting as the root user with the given
+// password.  The passwords are passed through the stdin of the mysql client, and the stdin is not logged.
+func alterUsers(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, pod *v1.Pod, rootPwd string, rotated map[string][]byte) error {
+	rootLiteral := sqlStringEscaper.Replace(string(rotated[mySQLRootKey]))
+	userLiteral := sqlStringEscaper.Replace(string(rotated[mySQLKey]))
+	sql := fmt.Sprintf("ALTER USER IF EXISTS 'root'@'%%' IDENTIFIED BY '%[1]s'; ALTER USER IF EXISTS 'root'@'localhost' IDENTIFIED BY '%[1]s'; "+
+		"ALTER USER IF EXISTS '%[2]s'@'%%' IDENTIFIED BY '%[3]s'; FLUSH PRIVILEGES;", rootLiteral, mySQLUsername, userLiteral)
+	cmd := []string{"bash", "-c", mySQLStdinCmd}
+	_, stderr, err := k8sutil.ExecPodWithStdin(cli, cfg, pod, mySQLContainerName, cmd, rootPwd+"\n"+sql+"\n")
+	if err != nil {
+		ctx.Log().Debugf("Component MySQL failed setting the passwords of the MySQL users: stderr = %s", stderr)
+		return fmt.Errorf("Failed setting the passwords of the MySQL users in pod %s/%s", pod.Namespace, pod.Name)
+	}
+	ctx.Log().Once("Component MySQL successfully set the passwords of the MySQL users")
+	return nil
+}
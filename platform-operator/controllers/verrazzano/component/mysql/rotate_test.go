@@ -0,0 +1,105 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	k8sutilfake "github.com/verrazzano/verrazzano/pkg/k8sutil/fake"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestRotateCredential tests rotating the passwords of the MySQL users
+// GIVEN the mysql secret, a running MySQL pod and the Keycloak StatefulSet
+// WHEN RotateCredential is called for the mysql credential, and the connection with the current root password fails
+// THEN the users are altered with the new passwords passed through stdin, the new passwords are saved in the secret,
+// and Keycloak is restarted
+func TestRotateCredential(t *testing.T) {
+	k8sutil.ClientConfig = func() (*rest.Config, kubernetes.Interface, error) {
+		cfg, cli := k8sutilfake.NewClientsetConfig()
+		return cfg, cli, nil
+	}
+	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
+	podExecFunc := k8sutilfake.PodExecResult
+	podExecStdinFunc := k8sutilfake.PodExecStdin
+	defer func() {
+		k8sutilfake.PodExecResult = podExecFunc
+		k8sutilfake.PodExecStdin = podExecStdinFunc
+	}()
+	var commands []string
+	var inputs []string
+	k8sutilfake.PodExecStdin = func(url *url.URL, stdin string) {
+		inputs = append(inputs, stdin)
+	}
+	k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) {
+		commands = append(commands, strings.Join(url.Query()["command"], " "))
+		if strings.HasPrefix(inputs[len(inputs)-1], "rootpw\n") {
+			return "", "Access denied for user 'root'@'localhost'", errors.New("command terminated with exit code 1")
+		}
+		return "", "", nil
+	}
+
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: secretName},
+			Data:       map[string][]byte{mySQLRootKey: []byte("rootpw"), mySQLKey: []byte("userpw")},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: "mysql-0", Labels: map[string]string{"app": "mysql"}},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: keycloakStatefulSet}},
+	).Build()
+	ctx := spi.NewFakeContext(c, &vzapi.Verrazzano{}, nil, false)
+	comp := NewComponent().(mysqlComponent)
+	assert.Equal(t, []string{globalconst.MySQLCredential}, comp.GetRotatableCredentials())
+	assert.NoError(t, comp.RotateCredential(ctx, globalconst.MySQLCredential, "1"))
+
+	secret := v1.Secret{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: secretName}, &secret))
+	rootPwd := string(secret.Data[mySQLRootKey])
+	userPwd := string(secret.Data[mySQLKey])
+	assert.Len(t, secret.Data, 2)
+	assert.NotEqual(t, "rootpw", rootPwd)
+	assert.NotEqual(t, "userpw", userPwd)
+	assert.Len(t, commands, 2)
+	for _, cmd := range commands {
+		assert.Equal(t, "bash -c "+mySQLStdinCmd, cmd)
+	}
+	assert.True(t, strings.HasPrefix(inputs[1], rootPwd+"\n"))
+	assert.Contains(t, inputs[1], "ALTER USER IF EXISTS 'root'@'%' IDENTIFIED BY '"+rootPwd+"'")
+	assert.Contains(t, inputs[1], "ALTER USER IF EXISTS 'keycloak'@'%' IDENTIFIED BY '"+userPwd+"'")
+
+	sts := appsv1.StatefulSet{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: keycloakStatefulSet}, &sts))
+	assert.Equal(t, "1", sts.Spec.Template.Annotations[globalconst.VerrazzanoRestartAnnotation])
+
+	assert.Error(t, comp.RotateCredential(ctx, globalconst.KeycloakAdminCredential, "1"))
+}
+
+// TestSQLStringEscaper tests escaping the SQL string literals of the passwords
+// GIVEN passwords with quotes and backslashes
+// WHEN they are escaped
+// THEN the quotes are doubled and the backslashes are escaped
+func TestSQLStringEscaper(t *testing.T) {
+	assert.Equal(t, "pass''word", sqlStringEscaper.Replace("pass'word"))
+	assert.Equal(t, `pass\\''; DROP`, sqlStringEscaper.Replace(`pass\'; DROP`))
+	assert.Equal(t, "password", sqlStringEscaper.Replace("password"))
+}
@@ -56,7 +56,7 @@ func AppendOverrides(compContext spi.ComponentContext, _ string, _ string, _ str
 
 // isReady - component specific checks for being ready
 func isReady(ctx spi.ComponentContext) bool {
-	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), getDeploymentList(), 1, getPrefix(ctx))
+	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), getDeploymentList(), 1, getPrefix(ctx))
 }
 
 // isInstalled checks that the deployment exists
@@ -50,7 +50,7 @@ func isNginxReady(context spi.ComponentContext) bool {
 	if err != nil && context.GetComponent() == ComponentName {
 		context.Log().Errorf("Ingress external IP pending for component %s: %v", ComponentName, err)
 	}
-	return err == nil && status.DeploymentsAreReady(context.Log(), context.Client(), deployments, 1, prefix)
+	return err == nil && status.DeploymentsAreReady(context.Log(), context.Client(), context.ActualCR(), deployments, 1, prefix)
 }
 
 func AppendOverrides(context spi.ComponentContext, _ string, _ string, _ string, kvs []bom.KeyValue) ([]bom.KeyValue, error) {
@@ -62,9 +62,13 @@ func AppendOverrides(context spi.ComponentContext, _ string, _ string, _ string,
 
 	newKvs := append(kvs, bom.KeyValue{Key: "controller.service.type", Value: string(ingressType)})
 
-	if cr.Spec.Components.DNS != nil && cr.Spec.Components.DNS.OCI != nil {
+	if vzconfig.IsExternalDNSEnabled(cr) {
+		dnsSuffix, err := vzconfig.GetDNSSuffix(context.Client(), cr)
+		if err != nil {
+			return []bom.KeyValue{}, err
+		}
 		newKvs = append(newKvs, bom.KeyValue{Key: "controller.service.annotations.external-dns\\.alpha\\.kubernetes\\.io/ttl", Value: "60", SetString: true})
-		hostName := fmt.Sprintf("verrazzano-ingress.%s.%s", cr.Spec.EnvironmentName, cr.Spec.Components.DNS.OCI.DNSZoneName)
+		hostName := fmt.Sprintf("verrazzano-ingress.%s.%s", cr.Spec.EnvironmentName, dnsSuffix)
 		newKvs = append(newKvs, bom.KeyValue{Key: "controller.service.annotations.external-dns\\.alpha\\.kubernetes\\.io/hostname", Value: hostName})
 	}
 
@@ -92,6 +92,29 @@ func TestAppendNGINXOverridesWithExternalDNS(t *testing.T) {
 	assert.Len(t, kvs, 6)
 }
 
+// TestAppendNGINXOverridesWithRFC2136DNS tests the AppendOverrides fn
+// GIVEN a call to AppendOverrides
+//  WHEN RFC2136 DNS is configured
+//  THEN the external-dns host name annotation of the controller service uses the RFC2136 zone
+func TestAppendNGINXOverridesWithRFC2136DNS(t *testing.T) {
+	vz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			EnvironmentName: "myenv",
+			Components: vzapi.ComponentSpec{
+				DNS: &vzapi.DNSComponent{
+					RFC2136: &vzapi.RFC2136{
+						Host:        "10.0.0.53",
+						DNSZoneName: "myzone",
+					},
+				},
+			},
+		},
+	}
+	kvs, err := AppendOverrides(spi.NewFakeContext(nil, vz, nil, false), ComponentName, ComponentNamespace, "", []bom.KeyValue{})
+	assert.NoError(t, err)
+	assert.Contains(t, kvs, bom.KeyValue{Key: "controller.service.annotations.external-dns\\.alpha\\.kubernetes\\.io/hostname", Value: "verrazzano-ingress.myenv.myzone"})
+}
+
 // TestAppendNGINXOverridesExtraKVs tests the AppendOverrides fn
 // GIVEN a call to AppendOverrides
 //  WHEN I pass in a KeyValue list
@@ -37,7 +37,7 @@ func isOAMReady(context spi.ComponentContext) bool {
 		},
 	}
 	prefix := fmt.Sprintf("Component %s", context.GetComponent())
-	return status.DeploymentsAreReady(context.Log(), context.Client(), deployments, 1, prefix)
+	return status.DeploymentsAreReady(context.Log(), context.Client(), context.ActualCR(), deployments, 1, prefix)
 }
 
 // ensureClusterRoles creates or updates additional OAM cluster roles during install and upgrade
@@ -78,7 +78,7 @@ func isOSNodeReady(ctx spi.ComponentContext, node vzapi.OpenSearchNode, prefix s
 
 	// If a node has the master role, it is a statefulset
 	if hasRole(node.Roles, vmov1.MasterRole) {
-		return status.StatefulSetsAreReady(ctx.Log(), ctx.Client(), []types.NamespacedName{{
+		return status.StatefulSetsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), []types.NamespacedName{{
 			Name:      nodeControllerName,
 			Namespace: ComponentNamespace,
 		}}, node.Replicas, prefix)
@@ -95,11 +95,11 @@ func isOSNodeReady(ctx spi.ComponentContext, node vzapi.OpenSearchNode, prefix s
 				Namespace: ComponentNamespace,
 			})
 		}
-		return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), dataDeployments, 1, prefix)
+		return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), dataDeployments, 1, prefix)
 	}
 
 	// Ingest nodes can be handled like normal deployments
-	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), []types.NamespacedName{{
+	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), []types.NamespacedName{{
 		Name:      nodeControllerName,
 		Namespace: ComponentNamespace,
 	}}, node.Replicas, prefix)
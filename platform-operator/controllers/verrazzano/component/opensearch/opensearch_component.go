@@ -89,11 +89,16 @@ func NewComponent() spi.Component {
 	return opensearchComponent{}
 }
 
+// EnsureCredentials ensures the VMI secret exists and is in sync with the credentials store
+func (o opensearchComponent) EnsureCredentials(ctx spi.ComponentContext) error {
+	return common.EnsureVMISecret(ctx.Client(), ctx.EffectiveCR())
+}
+
 // PreInstall OpenSearch component pre-install processing; create and label required namespaces, copy any
 // required secrets
 func (o opensearchComponent) PreInstall(ctx spi.ComponentContext) error {
 	// create or update  VMI secret
-	if err := common.EnsureVMISecret(ctx.Client()); err != nil {
+	if err := common.EnsureVMISecret(ctx.Client(), ctx.EffectiveCR()); err != nil {
 		return err
 	}
 	// create or update backup VMI secret
@@ -131,7 +136,7 @@ func (o opensearchComponent) PostUninstall(context spi.ComponentContext) error {
 // PreUpgrade OpenSearch component pre-upgrade processing
 func (o opensearchComponent) PreUpgrade(ctx spi.ComponentContext) error {
 	// create or update  VMI secret
-	return common.EnsureVMISecret(ctx.Client())
+	return common.EnsureVMISecret(ctx.Client(), ctx.EffectiveCR())
 }
 
 // Upgrade OpenSearch component upgrade processing
@@ -15,6 +15,9 @@ import (
 
 const (
 	system = "system"
+
+	// logRoutingClassDataStreamPrefix is the prefix of the data streams of the log routing classes
+	logRoutingClassDataStreamPrefix = "verrazzano-logs-"
 )
 
 // updateFunc is passed into CreateOrUpdateVMI to create the necessary VMI resources
@@ -53,6 +56,29 @@ func actualCRNodes(cr *vzapi.Verrazzano) map[string]vzapi.OpenSearchNode {
 	return nodeMap
 }
 
+// getLogRoutingClassPolicies returns the ISM policies of the log routing classes that send their log records to
+// OpenSearch with a retention.  The index pattern of a policy is the data stream name of its class, so the policies of
+// the classes do not overlap.
+func getLogRoutingClassPolicies(effectiveCR *vzapi.Verrazzano) []vmov1.IndexManagementPolicy {
+	fluentd := effectiveCR.Spec.Components.Fluentd
+	if fluentd == nil {
+		return nil
+	}
+	var policies []vmov1.IndexManagementPolicy
+	for _, class := range fluentd.LogRoutingClasses {
+		if class.Destination != vzapi.LogRoutingDestinationOpenSearch || class.Retention == nil {
+			continue
+		}
+		retention := *class.Retention
+		policies = append(policies, vmov1.IndexManagementPolicy{
+			PolicyName:   logRoutingClassDataStreamPrefix + class.Name,
+			IndexPattern: logRoutingClassDataStreamPrefix + class.Name,
+			MinIndexAge:  &retention,
+		})
+	}
+	return policies
+}
+
 //newOpenSearch creates a new OpenSearch resource for the VMI
 // The storage settings for OpenSearch nodes follow this order of precedence:
 // 1. ESInstallArgs values
@@ -88,6 +114,8 @@ func newOpenSearch(effectiveCR, actualCR *vzapi.Verrazzano, storage *common.Reso
 	for _, policy := range opensearchComponent.Policies {
 		opensearch.Policies = append(opensearch.Policies, *policy.DeepCopy())
 	}
+	// Add the ISM policies of the log routing classes retention
+	opensearch.Policies = append(opensearch.Policies, getLogRoutingClassPolicies(effectiveCR)...)
 
 	// Set the values in the OpenSearch object from the Verrazzano component InstallArgs
 	if err := populateOpenSearchFromInstallArgs(opensearch, opensearchComponent); err != nil {
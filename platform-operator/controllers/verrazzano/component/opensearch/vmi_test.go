@@ -167,6 +167,46 @@ func TestNewOpenSearchValuesAreCopied(t *testing.T) {
 	assert.Nil(t, openSearch.MasterNode.Storage.PvcNames)
 }
 
+// TestNewOpenSearchLogRoutingClassPolicies tests that the retention of the log routing classes is added to the policies
+// GIVEN a Verrazzano CR with an ISM policy and log routing classes, with and without a retention
+//  WHEN I create a new OpenSearch resource
+//  THEN the policies contain the ISM policy and a policy for the OpenSearch class with a retention
+func TestNewOpenSearchLogRoutingClassPolicies(t *testing.T) {
+	age := "1d"
+	retention := "30d"
+	r := &common.ResourceRequestValues{}
+	testvz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Elasticsearch: &vzapi.ElasticsearchComponent{
+					Policies: []vmov1.IndexManagementPolicy{
+						{
+							PolicyName:   "my-policy",
+							IndexPattern: "pattern",
+							MinIndexAge:  &age,
+						},
+					},
+				},
+				Fluentd: &vzapi.FluentdComponent{
+					LogRoutingClasses: []vzapi.LogRoutingClass{
+						{Name: "gold", Destination: vzapi.LogRoutingDestinationOpenSearch, Retention: &retention},
+						{Name: "silver", Destination: vzapi.LogRoutingDestinationOpenSearch},
+						{Name: "oci", Destination: vzapi.LogRoutingDestinationOCI, OCILogID: "ocid1.log.oc1.test"},
+					},
+				},
+			},
+		},
+	}
+
+	openSearch, err := newOpenSearch(testvz, nil, r, nil, false, false)
+	assert.NoError(t, err)
+	assert.Len(t, openSearch.Policies, 2)
+	assert.Equal(t, "my-policy", openSearch.Policies[0].PolicyName)
+	assert.Equal(t, "verrazzano-logs-gold", openSearch.Policies[1].PolicyName)
+	assert.Equal(t, "verrazzano-logs-gold", openSearch.Policies[1].IndexPattern)
+	assert.Equal(t, retention, *openSearch.Policies[1].MinIndexAge)
+}
+
 // TestCreateOrUpdateVMI tests a new VMI resources is created in K8s according to the CR
 // GIVEN a Verrazzano CR
 // WHEN I create a new VMI resource
@@ -28,7 +28,7 @@ func isOSDReady(ctx spi.ComponentContext) bool {
 			})
 	}
 
-	if !status.DeploymentsAreReady(ctx.Log(), ctx.Client(), deployments, 1, prefix) {
+	if !status.DeploymentsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), deployments, 1, prefix) {
 		return false
 	}
 
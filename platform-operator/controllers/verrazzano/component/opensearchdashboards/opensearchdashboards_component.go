@@ -97,7 +97,7 @@ func NewComponent() spi.Component {
 // required secrets
 func (d opensearchDashboardsComponent) PreInstall(ctx spi.ComponentContext) error {
 	// create or update  VMI secret
-	if err := common.EnsureVMISecret(ctx.Client()); err != nil {
+	if err := common.EnsureVMISecret(ctx.Client(), ctx.EffectiveCR()); err != nil {
 		return err
 	}
 	// create or update backup VMI secret
@@ -135,7 +135,7 @@ func (d opensearchDashboardsComponent) PostUninstall(context spi.ComponentContex
 // PreUpgrade OpenSearch-Dashboards component pre-upgrade processing
 func (d opensearchDashboardsComponent) PreUpgrade(ctx spi.ComponentContext) error {
 	// create or update  VMI secret
-	return common.EnsureVMISecret(ctx.Client())
+	return common.EnsureVMISecret(ctx.Client(), ctx.EffectiveCR())
 }
 
 // Upgrade OpenSearch-Dashboards component upgrade processing
@@ -0,0 +1,204 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	helmcli "github.com/verrazzano/verrazzano/pkg/helm"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	platformapi "github.com/verrazzano/verrazzano/platform-operator/apis/platform/v1alpha1"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// finalizerName is the finalizer that uninstalls the component when a VerrazzanoComponent is deleted
+const finalizerName = "verrazzanocomponent.finalizers.verrazzano.io"
+
+// uninstallReleaseFunc uninstalls the Helm release of a component, it can be overridden for unit testing
+var uninstallReleaseFunc = uninstallRelease
+
+// pluginComponent is a component declared by a VerrazzanoComponent resource
+type pluginComponent struct {
+	helm.HelmComponent
+
+	// enabled indicates whether the component should be installed
+	enabled bool
+
+	deployments  []types.NamespacedName
+	statefulSets []types.NamespacedName
+	daemonSets   []types.NamespacedName
+}
+
+// Verify that pluginComponent implements Component
+var _ spi.Component = pluginComponent{}
+
+// NewComponent materializes the component declared by a VerrazzanoComponent resource
+func NewComponent(vzc *platformapi.VerrazzanoComponent) spi.Component {
+	overrides := vzc.Spec.ValueOverrides
+	return pluginComponent{
+		HelmComponent: helm.HelmComponent{
+			ReleaseName:               vzc.Name,
+			JSONName:                  vzc.Name,
+			ChartDir:                  vzc.Spec.Chart.Path,
+			ChartNamespace:            vzc.Spec.TargetNamespace,
+			IgnoreNamespaceOverride:   true,
+			IgnoreImageOverrides:      true,
+			SupportsOperatorInstall:   true,
+			SupportsOperatorUninstall: true,
+			Dependencies:              vzc.Spec.Dependencies,
+			GetInstallOverridesFunc: func(object runtime.Object) interface{} {
+				if _, ok := object.(*installv1beta1.Verrazzano); ok {
+					return vzapi.ConvertValueOverridesToV1Beta1(overrides)
+				}
+				return overrides
+			},
+		},
+		enabled:      vzc.Spec.Enabled == nil || *vzc.Spec.Enabled,
+		deployments:  toNamespacedNames(vzc.Spec.Readiness.Deployments),
+		statefulSets: toNamespacedNames(vzc.Spec.Readiness.StatefulSets),
+		daemonSets:   toNamespacedNames(vzc.Spec.Readiness.DaemonSets),
+	}
+}
+
+// IsPluginComponent returns true if the component was declared by a VerrazzanoComponent resource
+func IsPluginComponent(comp spi.Component) bool {
+	_, ok := comp.(pluginComponent)
+	return ok
+}
+
+// ListComponents returns the components declared by the VerrazzanoComponent resources in the cluster.  No
+// components are returned if the VerrazzanoComponent API is not available.
+func ListComponents(client clipkg.Client) ([]spi.Component, error) {
+	vzcList := platformapi.VerrazzanoComponentList{}
+	if err := client.List(context.TODO(), &vzcList); err != nil {
+		if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var comps []spi.Component
+	for i := range vzcList.Items {
+		vzc := &vzcList.Items[i]
+		if !vzc.DeletionTimestamp.IsZero() {
+			continue
+		}
+		comps = append(comps, NewComponent(vzc))
+	}
+	return comps, nil
+}
+
+// ReconcileFinalizers adds the finalizer to the VerrazzanoComponent resources.  When a VerrazzanoComponent is deleted,
+// the Helm release of the component is uninstalled and the component status is removed from the Verrazzano CR in
+// memory.  It returns true when the status was changed, the caller must then update the Verrazzano status, and the
+// finalizer is removed by a later call once the status no longer contains the component.
+func ReconcileFinalizers(log vzlog.VerrazzanoLogger, client clipkg.Client, vz *vzapi.Verrazzano, dryRun bool) (bool, error) {
+	vzcList := platformapi.VerrazzanoComponentList{}
+	if err := client.List(context.TODO(), &vzcList); err != nil {
+		if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	statusChanged := false
+	for i := range vzcList.Items {
+		vzc := &vzcList.Items[i]
+		if vzc.DeletionTimestamp.IsZero() {
+			if !controllerutil.ContainsFinalizer(vzc, finalizerName) {
+				controllerutil.AddFinalizer(vzc, finalizerName)
+				if err := client.Update(context.TODO(), vzc); err != nil {
+					return statusChanged, err
+				}
+			}
+			continue
+		}
+		if !controllerutil.ContainsFinalizer(vzc, finalizerName) {
+			continue
+		}
+		changed, err := deleteComponent(log, client, vz, vzc, dryRun)
+		if err != nil {
+			return statusChanged, err
+		}
+		statusChanged = statusChanged || changed
+	}
+	return statusChanged, nil
+}
+
+// deleteComponent uninstalls the component of a deleted VerrazzanoComponent.  If the Verrazzano CR still has a status
+// for the component, the status is removed and true is returned, otherwise the finalizer is removed.
+func deleteComponent(log vzlog.VerrazzanoLogger, client clipkg.Client, vz *vzapi.Verrazzano, vzc *platformapi.VerrazzanoComponent, dryRun bool) (bool, error) {
+	log.Oncef("Uninstalling component %s, the VerrazzanoComponent was deleted", vzc.Name)
+	if err := uninstallReleaseFunc(log, vzc.Name, vzc.Spec.TargetNamespace, dryRun); err != nil {
+		return false, err
+	}
+	if _, ok := vz.Status.Components[vzc.Name]; ok {
+		delete(vz.Status.Components, vzc.Name)
+		return true, nil
+	}
+	controllerutil.RemoveFinalizer(vzc, finalizerName)
+	return false, client.Update(context.TODO(), vzc)
+}
+
+// uninstallRelease uninstalls the Helm release if it is installed
+func uninstallRelease(log vzlog.VerrazzanoLogger, releaseName string, namespace string, dryRun bool) error {
+	installed, err := helmcli.IsReleaseInstalled(releaseName, namespace)
+	if err != nil {
+		return err
+	}
+	if !installed {
+		return nil
+	}
+	_, stderr, err := helmcli.Uninstall(log, releaseName, namespace, dryRun)
+	if err != nil {
+		return log.ErrorfNewErr("Failed uninstalling the %s release, error: %v, stderr: %s", releaseName, err, stderr)
+	}
+	return nil
+}
+
+// IsEnabled returns true unless the component is explicitly disabled in the VerrazzanoComponent resource
+func (c pluginComponent) IsEnabled(_ runtime.Object) bool {
+	return c.enabled
+}
+
+// IsReady returns true if the Helm release is deployed and the readiness workloads are ready
+func (c pluginComponent) IsReady(ctx spi.ComponentContext) bool {
+	if !c.HelmComponent.IsReady(ctx) {
+		return false
+	}
+	prefix := fmt.Sprintf("Component %s", ctx.GetComponent())
+	return status.DeploymentsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), c.deployments, 1, prefix) &&
+		status.StatefulSetsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), c.statefulSets, 1, prefix) &&
+		status.DaemonSetsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), c.daemonSets, 1, prefix)
+}
+
+// PreInstall creates the target namespace of the component
+func (c pluginComponent) PreInstall(ctx spi.ComponentContext) error {
+	namespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: c.ChartNamespace}}
+	if _, err := controllerruntime.CreateOrUpdate(context.TODO(), ctx.Client(), &namespace, func() error {
+		return nil
+	}); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed to create or update the %s namespace: %v", c.ChartNamespace, err)
+	}
+	return c.HelmComponent.PreInstall(ctx)
+}
+
+func toNamespacedNames(workloads []platformapi.WorkloadName) []types.NamespacedName {
+	var names []types.NamespacedName
+	for _, workload := range workloads {
+		names = append(names, types.NamespacedName{Namespace: workload.Namespace, Name: workload.Name})
+	}
+	return names
+}
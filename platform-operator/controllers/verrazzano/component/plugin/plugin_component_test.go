@@ -0,0 +1,238 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	platformapi "github.com/verrazzano/verrazzano/platform-operator/apis/platform/v1alpha1"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	pluginName      = "my-plugin"
+	pluginNamespace = "my-plugin-ns"
+)
+
+func newVerrazzanoComponent() *platformapi.VerrazzanoComponent {
+	return &platformapi.VerrazzanoComponent{
+		ObjectMeta: metav1.ObjectMeta{Name: pluginName},
+		Spec: platformapi.VerrazzanoComponentSpec{
+			Chart:           platformapi.ChartSource{Path: "/plugins/my-plugin"},
+			TargetNamespace: pluginNamespace,
+			Dependencies:    []string{"cert-manager"},
+			Readiness: platformapi.ReadinessWorkloads{
+				Deployments: []platformapi.WorkloadName{{Namespace: pluginNamespace, Name: pluginName}},
+			},
+			ValueOverrides: []vzapi.Overrides{{Values: &apiextensionsv1.JSON{Raw: []byte(`{"replicas": 2}`)}}},
+		},
+	}
+}
+
+// TestNewComponent tests materializing a component from a VerrazzanoComponent
+// GIVEN a VerrazzanoComponent
+//
+//	WHEN NewComponent is called
+//	THEN the component has the name, namespace, dependencies and overrides declared in the VerrazzanoComponent
+func TestNewComponent(t *testing.T) {
+	comp := NewComponent(newVerrazzanoComponent())
+	assert.Equal(t, pluginName, comp.Name())
+	assert.Equal(t, pluginNamespace, comp.Namespace())
+	assert.Equal(t, []string{"cert-manager"}, comp.GetDependencies())
+	assert.True(t, comp.IsOperatorInstallSupported())
+	assert.True(t, comp.IsEnabled(&vzapi.Verrazzano{}))
+	assert.True(t, IsPluginComponent(comp))
+
+	overrides := comp.GetOverrides(&vzapi.Verrazzano{}).([]vzapi.Overrides)
+	assert.Len(t, overrides, 1)
+	assert.Equal(t, `{"replicas": 2}`, string(overrides[0].Values.Raw))
+	v1beta1Overrides := comp.GetOverrides(&installv1beta1.Verrazzano{}).([]installv1beta1.Overrides)
+	assert.Len(t, v1beta1Overrides, 1)
+	assert.Equal(t, `{"replicas": 2}`, string(v1beta1Overrides[0].Values.Raw))
+
+	vzc := newVerrazzanoComponent()
+	enabled := false
+	vzc.Spec.Enabled = &enabled
+	assert.False(t, NewComponent(vzc).IsEnabled(&vzapi.Verrazzano{}))
+}
+
+// TestListComponents tests listing the components declared by VerrazzanoComponent resources
+// GIVEN a VerrazzanoComponent
+//
+//	WHEN ListComponents is called
+//	THEN a component is returned for the VerrazzanoComponent, and no components are returned
+//	     if the VerrazzanoComponent API is not available
+func TestListComponents(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = platformapi.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newVerrazzanoComponent()).Build()
+	comps, err := ListComponents(client)
+	assert.NoError(t, err)
+	assert.Len(t, comps, 1)
+	assert.Equal(t, pluginName, comps[0].Name())
+
+	client = fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	comps, err = ListComponents(client)
+	assert.NoError(t, err)
+	assert.Empty(t, comps)
+}
+
+// TestIsReady tests the readiness of a plugin component
+// GIVEN a plugin component with a readiness deployment
+//
+//	WHEN IsReady is called
+//	THEN false is returned until the deployment is ready
+func TestIsReady(t *testing.T) {
+	comp := NewComponent(newVerrazzanoComponent())
+
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	ctx := spi.NewFakeContext(client, &vzapi.Verrazzano{}, nil, true).Init(pluginName)
+	assert.False(t, comp.IsReady(ctx))
+
+	client = fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: pluginNamespace, Name: pluginName},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": pluginName}},
+			},
+			Status: appsv1.DeploymentStatus{
+				AvailableReplicas: 1,
+				Replicas:          1,
+				UpdatedReplicas:   1,
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: pluginNamespace,
+				Name:      pluginName + "-95d8c5d96-m6mbr",
+				Labels:    map[string]string{"app": pluginName, "pod-template-hash": "95d8c5d96"},
+			},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   pluginNamespace,
+				Name:        pluginName + "-95d8c5d96",
+				Annotations: map[string]string{"deployment.kubernetes.io/revision": "1"},
+			},
+		},
+	).Build()
+	ctx = spi.NewFakeContext(client, &vzapi.Verrazzano{}, nil, true).Init(pluginName)
+	assert.True(t, comp.IsReady(ctx))
+}
+
+// TestPreInstall tests that the target namespace is created before the component is installed
+// GIVEN a plugin component
+//
+//	WHEN PreInstall is called
+//	THEN the target namespace is created
+func TestPreInstall(t *testing.T) {
+	comp := NewComponent(newVerrazzanoComponent())
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	assert.NoError(t, comp.PreInstall(spi.NewFakeContext(client, &vzapi.Verrazzano{}, nil, false)))
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: pluginNamespace}, &corev1.Namespace{}))
+}
+
+// TestReconcileFinalizers tests the finalizer of the VerrazzanoComponent resources
+// GIVEN a VerrazzanoComponent without a finalizer
+//
+//	WHEN ReconcileFinalizers is called
+//	THEN the finalizer is added and the component is not uninstalled
+func TestReconcileFinalizers(t *testing.T) {
+	defer func() { uninstallReleaseFunc = uninstallRelease }()
+	uninstalled := false
+	uninstallReleaseFunc = func(_ vzlog.VerrazzanoLogger, _ string, _ string, _ bool) error {
+		uninstalled = true
+		return nil
+	}
+
+	scheme := runtime.NewScheme()
+	_ = platformapi.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newVerrazzanoComponent()).Build()
+	statusChanged, err := ReconcileFinalizers(vzlog.DefaultLogger(), client, &vzapi.Verrazzano{}, false)
+	assert.NoError(t, err)
+	assert.False(t, statusChanged)
+
+	vzc := platformapi.VerrazzanoComponent{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: pluginName}, &vzc))
+	assert.True(t, controllerutil.ContainsFinalizer(&vzc, finalizerName))
+	assert.False(t, uninstalled)
+}
+
+// TestReconcileFinalizersDelete tests the deletion of a VerrazzanoComponent
+// GIVEN a deleted VerrazzanoComponent with the finalizer and a Verrazzano CR with the component status
+//
+//	WHEN ReconcileFinalizers is called twice, with the status updated in between
+//	THEN the Helm release is uninstalled and the component status is removed from the Verrazzano CR by the first call
+//	     without updating the status, and the finalizer is removed by the second call
+func TestReconcileFinalizersDelete(t *testing.T) {
+	defer func() { uninstallReleaseFunc = uninstallRelease }()
+	var uninstalledRelease, uninstalledNamespace string
+	uninstallReleaseFunc = func(_ vzlog.VerrazzanoLogger, releaseName string, namespace string, _ bool) error {
+		uninstalledRelease = releaseName
+		uninstalledNamespace = namespace
+		return nil
+	}
+
+	vzc := newVerrazzanoComponent()
+	vzc.Finalizers = []string{finalizerName}
+	now := metav1.Now()
+	vzc.DeletionTimestamp = &now
+	vz := &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+		Status: vzapi.VerrazzanoStatus{
+			Components: vzapi.ComponentStatusMap{
+				pluginName:     &vzapi.ComponentStatusDetails{Name: pluginName, State: vzapi.CompStateReady},
+				"cert-manager": &vzapi.ComponentStatusDetails{Name: "cert-manager", State: vzapi.CompStateReady},
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = platformapi.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vzc, vz).Build()
+	statusChanged, err := ReconcileFinalizers(vzlog.DefaultLogger(), client, vz, false)
+	assert.NoError(t, err)
+	assert.True(t, statusChanged)
+	assert.Equal(t, pluginName, uninstalledRelease)
+	assert.Equal(t, pluginNamespace, uninstalledNamespace)
+	assert.NotContains(t, vz.Status.Components, pluginName)
+	assert.Contains(t, vz.Status.Components, "cert-manager")
+
+	// The status is updated by the caller, the finalizer is kept until then
+	actualVZ := vzapi.Verrazzano{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, &actualVZ))
+	assert.Contains(t, actualVZ.Status.Components, pluginName)
+	actualVZC := platformapi.VerrazzanoComponent{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: pluginName}, &actualVZC))
+	assert.True(t, controllerutil.ContainsFinalizer(&actualVZC, finalizerName))
+
+	assert.NoError(t, client.Status().Update(context.TODO(), vz))
+	statusChanged, err = ReconcileFinalizers(vzlog.DefaultLogger(), client, vz, false)
+	assert.NoError(t, err)
+	assert.False(t, statusChanged)
+
+	err = client.Get(context.TODO(), types.NamespacedName{Name: pluginName}, &actualVZC)
+	if err == nil {
+		assert.False(t, controllerutil.ContainsFinalizer(&actualVZC, finalizerName))
+	}
+	comps, err := ListComponents(client)
+	assert.NoError(t, err)
+	assert.Empty(t, comps)
+}
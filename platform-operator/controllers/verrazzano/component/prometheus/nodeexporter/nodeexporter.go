@@ -37,7 +37,7 @@ func isPrometheusNodeExporterReady(ctx spi.ComponentContext) bool {
 		},
 	}
 	prefix := fmt.Sprintf("Component %s", ctx.GetComponent())
-	return status.DaemonSetsAreReady(ctx.Log(), ctx.Client(), sets, 1, prefix)
+	return status.DaemonSetsAreReady(ctx.Log(), ctx.Client(), ctx.ActualCR(), sets, 1, prefix)
 }
 
 // PreInstall implementation for the Prometheus Node-Exporter Component
@@ -68,7 +68,7 @@ func isPrometheusOperatorReady(ctx spi.ComponentContext) bool {
 		ctx.Log().Errorf("Failed to create selector for %s: %v", ComponentName, err)
 		return false
 	}
-	return status.DeploymentsReadyBySelectors(ctx.Log(), ctx.Client(), 1, prefix, &client.ListOptions{
+	return status.DeploymentsReadyBySelectors(ctx.Log(), ctx.Client(), ctx.ActualCR(), 1, prefix, &client.ListOptions{
 		Namespace:     ComponentNamespace,
 		LabelSelector: selector,
 	})
@@ -502,8 +502,8 @@ func appendAdditionalVolumeOverrides(ctx spi.ComponentContext, volumeMountKey, v
 	return kvs, nil
 }
 
-// applySystemMonitors applies templatized PodMonitor and ServiceMonitor custom resources for Verrazzano system
-// components to the cluster
+// applySystemMonitors applies templatized PodMonitor, ServiceMonitor and PrometheusRule custom resources for Verrazzano
+// system components to the cluster
 func applySystemMonitors(ctx spi.ComponentContext) error {
 	// create template key/value map
 	args := make(map[string]interface{})
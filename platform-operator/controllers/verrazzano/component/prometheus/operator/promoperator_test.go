@@ -461,8 +461,8 @@ func TestValidatePrometheusOperator(t *testing.T) {
 func TestApplySystemMonitors(t *testing.T) {
 	// GIVEN the Prometheus Operator is being installed or upgraded
 	// WHEN we call the applySystemMonitors function
-	// THEN ServiceMonitor and PodMonitor resources are applied so that
-	// Verrazzano system components will have their metrics collected
+	// THEN ServiceMonitor, PodMonitor and PrometheusRule resources are applied so that
+	// Verrazzano system components will have their metrics collected and alerted on
 	oldConfig := config.Get()
 	defer config.Set(oldConfig)
 	config.Set(config.OperatorConfig{
@@ -488,6 +488,13 @@ func TestApplySystemMonitors(t *testing.T) {
 	assert.NoError(t, err)
 	// expect that 9 ServiceMonitors are created
 	assert.Len(t, monitors.Items, 9)
+
+	// expect that the PrometheusRule for the platform operator is created
+	rules := &unstructured.UnstructuredList{}
+	rules.SetGroupVersionKind(schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"})
+	err = client.List(context.TODO(), rules)
+	assert.NoError(t, err)
+	assert.Len(t, rules.Items, 1)
 }
 
 // TestValidatePrometheusOperator tests the validation of the Prometheus Operator installation and the Verrazzano CR
@@ -260,7 +260,7 @@ func isRancherReady(ctx spi.ComponentContext) bool {
 	}
 
 	prefix := fmt.Sprintf("Component %s", ctx.GetComponent())
-	return status.DeploymentsAreReady(log, c, deployments, 1, prefix)
+	return status.DeploymentsAreReady(log, c, ctx.ActualCR(), deployments, 1, prefix)
 }
 
 // checkRancherUpgradeFailure - temporary work around for Rancher issue 36914. During an upgrade, the Rancher pods
@@ -12,6 +12,7 @@ import (
 	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	"github.com/verrazzano/verrazzano/platform-operator/internal/credentials"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -126,7 +127,7 @@ func appendCAOverrides(log vzlog.VerrazzanoLogger, kvs []bom.KeyValue, ctx spi.C
 	}
 
 	// Configure CA Issuer KVs
-	if (cm.Certificate.Acme != vzapi.Acme{}) {
+	if common.IsLetsEncryptIssuer(cm.Certificate.Acme) {
 		kvs = append(kvs,
 			bom.KeyValue{
 				Key:   letsEncryptIngressClassKey,
@@ -243,6 +244,7 @@ func (r rancherComponent) IsReady(ctx spi.ComponentContext) bool {
 // PostInstall
 /* Additional setup for Rancher after the component is installed
 - Create the Rancher admin secret if it does not already exist
+- Write the Rancher admin password to the credentials store, if one is configured
 - Retrieve the Rancher admin password
 - Retrieve the Rancher hostname
 - Set the Rancher server URL using the admin password and the hostname
@@ -257,6 +259,12 @@ func (r rancherComponent) PostInstall(ctx spi.ComponentContext) error {
 	}
 
 	vz := ctx.EffectiveCR()
+	// The admin password is set by the Rancher reset-password command
+	adminSecret := types.NamespacedName{Namespace: common.CattleSystem, Name: common.RancherAdminSecret}
+	if err := credentials.ExportSecret(c, vz, adminSecret); err != nil {
+		return log.ErrorfThrottledNewErr("Failed writing the Rancher admin secret to the credentials store: %s", err.Error())
+	}
+
 	rancherHostName, err := getRancherHostname(c, vz)
 	if err != nil {
 		return log.ErrorfThrottledNewErr("Failed getting Rancher hostname: %s", err.Error())
@@ -20,7 +20,10 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/keycloak"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/kcadmin"
+	kcfake "github.com/verrazzano/verrazzano/platform-operator/internal/kcadmin/fake"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/networking/v1"
@@ -246,6 +249,8 @@ func TestIsReady(t *testing.T) {
 //  WHEN PostInstall is called
 //  THEN PostInstall should return nil
 func TestPostInstall(t *testing.T) {
+	server := newFakeKeycloak()
+	defer stopFakeKeycloak(server)
 	component := NewComponent()
 	ctxWithoutIngress, ctxWithIngress := prepareContexts()
 	assert.IsType(t, fmt.Errorf(""), component.PostInstall(ctxWithoutIngress))
@@ -257,6 +262,8 @@ func TestPostInstall(t *testing.T) {
 //  WHEN PostUpgrade is called
 //  THEN PostUpgrade should return nil
 func TestPostUpgrade(t *testing.T) {
+	server := newFakeKeycloak()
+	defer stopFakeKeycloak(server)
 	component := NewComponent()
 	ctxWithoutIngress, ctxWithIngress := prepareContexts()
 	assert.Nil(t, component.PostUpgrade(ctxWithoutIngress))
@@ -369,6 +376,26 @@ func TestValidateUpdateV1beta1(t *testing.T) {
 	}
 }
 
+// newFakeKeycloak starts a fake Keycloak server with the rancher client and the verrazzano user, and sets it as the
+// Keycloak admin REST API of the Keycloak component
+func newFakeKeycloak() *kcfake.Server {
+	server := kcfake.NewServer("keycloakadmin", "blahblah")
+	realm := server.AddRealm("verrazzano-system")
+	realm.Clients = []kcadmin.ClientRepresentation{{ID: "something", ClientID: AuthConfigKeycloakClientIDRancher}}
+	realm.ClientSecrets["something"] = "abcdef"
+	server.AddUser("verrazzano-system", "verrazzano", "verrazzano")
+	keycloak.SetAdminURLFunction(func(_ spi.ComponentContext) (string, func(), error) {
+		return server.BaseURL(), func() {}, nil
+	})
+	return server
+}
+
+// stopFakeKeycloak stops a fake Keycloak server, and restores the Keycloak admin REST API of the Keycloak component
+func stopFakeKeycloak(server *kcfake.Server) {
+	keycloak.SetDefaultAdminURLFunction()
+	server.Close()
+}
+
 func prepareContexts() (spi.ComponentContext, spi.ComponentContext) {
 	// mock the k8s resources used in post install
 	caSecret := createCASecret()
@@ -436,18 +463,6 @@ func prepareContexts() (spi.ComponentContext, spi.ComponentContext) {
 	k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) {
 		var commands []string
 		if commands = url.Query()["command"]; len(commands) == 3 {
-			if strings.Contains(commands[2], "id,clientId") {
-				return "[{\"id\":\"something\", \"clientId\":\"" + AuthConfigKeycloakClientIDRancher + "\"}]", "", nil
-			}
-
-			if strings.Contains(commands[2], "client-secret") {
-				return "{\"type\":\"secret\",\"value\":\"abcdef\"}", "", nil
-			}
-
-			if strings.Contains(commands[2], "get users") {
-				return "[{\"id\":\"something\", \"username\":\"verrazzano\"}]", "", nil
-			}
-
 			if strings.Contains(commands[2], fmt.Sprintf("cat %s", SettingUILogoDarkLogoFilePath)) {
 				return "dark", "", nil
 			}
@@ -64,7 +64,7 @@ func patchRancherIngress(c client.Client, vz *vzapi.Verrazzano) error {
 	}
 	ingressMerge := client.MergeFrom(ingress.DeepCopy())
 	ingress.Annotations["kubernetes.io/tls-acme"] = "true"
-	if (cm.Certificate.Acme != vzapi.Acme{}) {
+	if common.IsLetsEncryptIssuer(cm.Certificate.Acme) {
 		addAcmeIngressAnnotations(vz.Spec.EnvironmentName, dnsSuffix, ingress)
 	} else {
 		addCAIngressAnnotations(vz.Spec.EnvironmentName, dnsSuffix, ingress)
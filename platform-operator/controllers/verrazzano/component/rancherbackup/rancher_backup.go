@@ -27,7 +27,7 @@ const (
 
 // isRancherBackupOperatorReady checks if the Rancher Backup deployment is ready
 func isRancherBackupOperatorReady(context spi.ComponentContext) bool {
-	return status.DeploymentsAreReady(context.Log(), context.Client(), deployments, 1, componentPrefix)
+	return status.DeploymentsAreReady(context.Log(), context.Client(), context.ActualCR(), deployments, 1, componentPrefix)
 }
 
 // GetOverrides gets the install overrides
@@ -4,6 +4,9 @@
 package registry
 
 import (
+	"sync"
+
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/appoper"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/authproxy"
@@ -43,6 +46,11 @@ var getComponentsFn = getComponents
 
 var componentsRegistry []spi.Component
 
+// pluginComponents are the components declared by VerrazzanoComponent resources, they are processed after
+// the built-in components
+var pluginComponents []spi.Component
+var pluginComponentsMutex sync.RWMutex
+
 // OverrideGetComponentsFn Allows overriding the set of registry components for testing purposes
 func OverrideGetComponentsFn(fnType GetCompoentsFnType) {
 	getComponentsFn = fnType
@@ -60,8 +68,43 @@ func GetComponents() []spi.Component {
 	return getComponentsFn()
 }
 
+// SetPluginComponents replaces the set of components declared by VerrazzanoComponent resources.  Plugin components
+// with the same name as a built-in component are ignored.
+func SetPluginComponents(log vzlog.VerrazzanoLogger, comps []spi.Component) {
+	builtIn := make(map[string]bool)
+	for _, comp := range getBuiltInComponents() {
+		builtIn[comp.Name()] = true
+	}
+	var plugins []spi.Component
+	for _, comp := range comps {
+		if builtIn[comp.Name()] {
+			log.Oncef("Ignoring VerrazzanoComponent %s, the name is used by a built-in component", comp.Name())
+			continue
+		}
+		plugins = append(plugins, comp)
+	}
+
+	pluginComponentsMutex.Lock()
+	defer pluginComponentsMutex.Unlock()
+	pluginComponents = plugins
+}
+
 // getComponents is the internal impl function for GetComponents, to allow overriding it for testing purposes
 func getComponents() []spi.Component {
+	builtIn := getBuiltInComponents()
+
+	pluginComponentsMutex.RLock()
+	defer pluginComponentsMutex.RUnlock()
+	if len(pluginComponents) == 0 {
+		return builtIn
+	}
+	comps := make([]spi.Component, 0, len(builtIn)+len(pluginComponents))
+	comps = append(comps, builtIn...)
+	return append(comps, pluginComponents...)
+}
+
+// getBuiltInComponents returns the components that are built into the operator
+func getBuiltInComponents() []spi.Component {
 	if len(componentsRegistry) == 0 {
 		componentsRegistry = []spi.Component{
 			oam.NewComponent(),
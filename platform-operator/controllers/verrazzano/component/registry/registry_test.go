@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/verrazzano/verrazzano/pkg/helm"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	vzconst "github.com/verrazzano/verrazzano/platform-operator/constants"
@@ -99,6 +100,32 @@ func TestGetComponents(t *testing.T) {
 	a.Equal(comps[28].Name(), rancherbackup.ComponentName)
 }
 
+// TestGetComponentsWithPlugins tests getting the components when plugin components are declared
+// GIVEN plugin components, one of which has the name of a built-in component
+//  WHEN I call GetComponents
+//  THEN the plugin components are returned after the built-in components, and the one with the
+//       name of a built-in component is ignored
+func TestGetComponentsWithPlugins(t *testing.T) {
+	a := assert.New(t)
+	SetPluginComponents(vzlog.DefaultLogger(), []spi.Component{
+		helm2.HelmComponent{ReleaseName: "my-plugin"},
+		helm2.HelmComponent{ReleaseName: istio.ComponentName},
+	})
+	defer SetPluginComponents(vzlog.DefaultLogger(), nil)
+
+	comps := GetComponents()
+	a.Len(comps, 30, "Wrong number of components")
+	a.Equal(rancherbackup.ComponentName, comps[28].Name())
+	a.Equal("my-plugin", comps[29].Name())
+
+	found, comp := FindComponent("my-plugin")
+	a.True(found)
+	a.Equal("my-plugin", comp.Name())
+
+	SetPluginComponents(vzlog.DefaultLogger(), nil)
+	a.Len(GetComponents(), 29, "Wrong number of components")
+}
+
 // TestFindComponent tests FindComponent
 // GIVEN a component
 //  WHEN I call FindComponent
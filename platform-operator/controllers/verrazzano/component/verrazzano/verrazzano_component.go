@@ -81,7 +81,7 @@ func (c verrazzanoComponent) PreInstall(ctx spi.ComponentContext) error {
 		}
 	}
 	// create or update  VMI secret
-	if err := common.EnsureVMISecret(ctx.Client()); err != nil {
+	if err := common.EnsureVMISecret(ctx.Client(), ctx.EffectiveCR()); err != nil {
 		return err
 	}
 	// create or update  backup secret
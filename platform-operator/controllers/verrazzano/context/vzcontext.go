@@ -4,6 +4,8 @@
 package context
 
 import (
+	"context"
+
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
@@ -11,6 +13,8 @@ import (
 
 // VerrazzanoContext the context needed to reconcile a Verrazzano CR
 type VerrazzanoContext struct {
+	// Ctx is the context of the reconcile, which carries the tracing span of the reconcile
+	Ctx context.Context
 	// Log is the logger for the execution context
 	Log vzlog.VerrazzanoLogger
 	// Client is a Kubernetes client
@@ -22,8 +26,9 @@ type VerrazzanoContext struct {
 }
 
 // NewVerrazzanoContext creates a VerrazzanoContext
-func NewVerrazzanoContext(log vzlog.VerrazzanoLogger, c clipkg.Client, actualCR *vzapi.Verrazzano, dryRun bool) (VerrazzanoContext, error) {
+func NewVerrazzanoContext(ctx context.Context, log vzlog.VerrazzanoLogger, c clipkg.Client, actualCR *vzapi.Verrazzano, dryRun bool) (VerrazzanoContext, error) {
 	return VerrazzanoContext{
+		Ctx:      ctx,
 		Log:      log,
 		Client:   c,
 		DryRun:   dryRun,
@@ -6,11 +6,14 @@ package verrazzano
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
 
+	cmapiv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/verrazzano/verrazzano/pkg/bom"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/keycloak"
@@ -21,14 +24,19 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	"github.com/verrazzano/verrazzano/pkg/semver"
 	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+	platformv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/platform/v1alpha1"
 	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/validators"
 	vzconst "github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/certmanager"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/mysql"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/plugin"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/vzinstance"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/status"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/tracing"
 	"github.com/verrazzano/verrazzano/platform-operator/metricsexporter"
 	"go.uber.org/zap"
 	batchv1 "k8s.io/api/batch/v1"
@@ -38,6 +46,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -57,6 +66,7 @@ type Reconciler struct {
 	WatchedComponents map[string]bool
 	WatchMutex        *sync.RWMutex
 	Bom               *bom.Bom
+	EventRecorder     record.EventRecorder
 }
 
 // Name of finalizer
@@ -65,6 +75,9 @@ const finalizerName = "install.verrazzano.io"
 // initializedSet is needed to keep track of which Verrazzano CRs have been initialized
 var initializedSet = make(map[string]bool)
 
+// issuerWatchedSet keeps track of the Verrazzano CRs whose cert-manager issuers are watched
+var issuerWatchedSet = make(map[string]bool)
+
 // systemNamespaceLabels the verrazzano-system namespace labels required
 var systemNamespaceLabels = map[string]string{
 	"istio-injection":         "enabled",
@@ -129,7 +142,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	log.Oncef("Reconciling Verrazzano resource %v, generation %v, version %s", req.NamespacedName, vz.Generation, vz.Status.Version)
+	ctx, span := tracing.StartSpan(ctx, "Reconcile", tracing.NameKey.String(vz.Name), tracing.NamespaceKey.String(vz.Namespace),
+		tracing.GenerationKey.Int64(vz.Generation))
 	res, err := r.doReconcile(ctx, log, vz)
+	tracing.EndSpan(span, err)
+	metricsexporter.AnalyzeComponentStateMetrics(log, vz)
 	if vzctrl.ShouldRequeue(res) {
 		return res, nil
 	}
@@ -170,7 +187,35 @@ func (r *Reconciler) doReconcile(ctx context.Context, log vzlog.VerrazzanoLogger
 		return reconcile.Result{Requeue: true}, nil
 	}
 
-	vzctx, err := vzcontext.NewVerrazzanoContext(log, r.Client, vz, r.DryRun)
+	// Refresh the components declared by VerrazzanoComponent resources
+	if requeue, err := r.loadPluginComponents(log, vz); err != nil || requeue {
+		return newRequeueWithDelay(), err
+	}
+
+	// Refresh the user profiles declared by profile ConfigMaps
+	if err := r.loadUserProfiles(log); err != nil {
+		return newRequeueWithDelay(), err
+	}
+
+	// Watch the cert-manager issuers once cert-manager is installed
+	if err := r.watchIssuers(vz.Namespace, vz.Name, vz.Status.Components, log); err != nil {
+		log.Errorf("Failed to set cert-manager issuer watches for Verrazzano CR %s: %v", vz.Name, err)
+		return newRequeueWithDelay(), err
+	}
+
+	// Allow egress to the credentials store before the credentials are read from it
+	if err := r.reconcileCredentialsStoreNetworkPolicy(log, vz); err != nil {
+		return newRequeueWithDelay(), err
+	}
+
+	// Publish the effective CR, a failure to do so doesn't block the reconcile
+	if !unitTesting {
+		if err := r.publishEffectiveCR(vz); err != nil {
+			log.ErrorfThrottled("Failed to publish the effective configuration of Verrazzano %s/%s: %v", vz.Namespace, vz.Name, err)
+		}
+	}
+
+	vzctx, err := vzcontext.NewVerrazzanoContext(ctx, log, r.Client, vz, r.DryRun)
 	if err != nil {
 		log.Errorf("Failed to create component context: %v", err)
 		return newRequeueWithDelay(), err
@@ -179,18 +224,26 @@ func (r *Reconciler) doReconcile(ctx context.Context, log vzlog.VerrazzanoLogger
 	// Process CR based on state
 	switch vz.Status.State {
 	case installv1alpha1.VzStateFailed:
-		return r.ProcFailedState(vzctx)
+		result, err = r.ProcFailedState(vzctx)
 	case installv1alpha1.VzStateReconciling:
-		return r.ProcInstallingState(vzctx)
+		result, err = r.ProcInstallingState(vzctx)
 	case installv1alpha1.VzStateReady:
-		return r.ProcReadyState(vzctx)
+		result, err = r.ProcReadyState(vzctx)
 	case installv1alpha1.VzStateUpgrading:
-		return r.ProcUpgradingState(vzctx)
+		result, err = r.ProcUpgradingState(vzctx)
 	case installv1alpha1.VzStatePaused:
-		return r.ProcPausedUpgradeState(vzctx)
+		result, err = r.ProcPausedUpgradeState(vzctx)
 	default:
 		panic("Invalid Verrazzano controller state")
 	}
+
+	// Record the history with the outcome of the reconcile, a failure to do so doesn't block the reconcile
+	if !unitTesting {
+		if err := r.recordHistory(vz); err != nil {
+			log.ErrorfThrottled("Failed to record the history of Verrazzano %s/%s: %v", vz.Namespace, vz.Name, err)
+		}
+	}
+	return result, err
 }
 
 // ProcReadyState processes the CR while in the ready state
@@ -235,7 +288,14 @@ func (r *Reconciler) ProcReadyState(vzctx vzcontext.VerrazzanoContext) (ctrl.Res
 			return result, nil
 		}
 
-		return ctrl.Result{}, nil
+		// Check the certificates of the components and applications for expiry
+		if err := r.checkCertificateExpiry(vzctx); err != nil {
+			return newRequeueWithDelay(), err
+		}
+
+		// Requeue to periodically check the Helm releases of the components for drift, the certificates for expiry, and
+		// the realm objects for drift
+		return realmRequeue(actualCR, certExpiryRequeue(actualCR, helmDriftRequeue(actualCR))), nil
 	}
 
 	// if an OCI DNS installation, make sure the secret required exists before proceeding
@@ -246,6 +306,14 @@ func (r *Reconciler) ProcReadyState(vzctx vzcontext.VerrazzanoContext) (ctrl.Res
 		}
 	}
 
+	// if an RFC2136 DNS installation with a TSIG key, make sure the TSIG secret exists before proceeding
+	if actualCR.Spec.Components.DNS != nil && actualCR.Spec.Components.DNS.RFC2136 != nil && len(actualCR.Spec.Components.DNS.RFC2136.TSIGSecret) > 0 {
+		err := r.doesRFC2136TSIGSecretExist(actualCR)
+		if err != nil {
+			return newRequeueWithDelay(), err
+		}
+	}
+
 	// Pre-create the Verrazzano System namespace if it doesn't already exist, before kicking off the install job,
 	// since it is needed for the subsequent step to syncLocalRegistration secret.
 	if err := r.createVerrazzanoSystemNamespace(ctx, actualCR, log); err != nil {
@@ -325,7 +393,7 @@ func (r *Reconciler) ProcUpgradingState(vzctx vzcontext.VerrazzanoContext) (ctrl
 
 	// Only upgrade if Version has changed.  When upgrade completes, it will update the status version, see upgrade.go
 	if len(actualCR.Spec.Version) > 0 && actualCR.Spec.Version != actualCR.Status.Version {
-		if result, err := r.reconcileUpgrade(log, actualCR); err != nil {
+		if result, err := r.reconcileUpgrade(vzctx.Ctx, log, actualCR); err != nil {
 			return newRequeueWithDelay(), err
 		} else if vzctrl.ShouldRequeue(result) {
 			return result, nil
@@ -424,6 +492,12 @@ func (r *Reconciler) doesOCIDNSConfigSecretExist(vz *installv1alpha1.Verrazzano)
 	return nil
 }
 
+// doesRFC2136TSIGSecretExist returns an error if the RFC2136 TSIG secret does not exist
+func (r *Reconciler) doesRFC2136TSIGSecretExist(vz *installv1alpha1.Verrazzano) error {
+	secret := &corev1.Secret{}
+	return r.Get(context.TODO(), types.NamespacedName{Name: vz.Spec.Components.DNS.RFC2136.TSIGSecret, Namespace: vzconst.VerrazzanoInstallNamespace}, secret)
+}
+
 // deleteServiceAccount deletes the service account used for install
 func (r *Reconciler) deleteServiceAccount(ctx context.Context, log vzlog.VerrazzanoLogger, vz *installv1alpha1.Verrazzano, namespace string) error {
 	sa := corev1.ServiceAccount{
@@ -693,6 +767,9 @@ func (r *Reconciler) updateComponentStatus(compContext spi.ComponentContext, mes
 
 	// Set the version of component when install and upgrade complete
 	if conditionType == installv1alpha1.CondInstallComplete || conditionType == installv1alpha1.CondUpgradeComplete {
+		// The component is ready, so there is nothing blocking it anymore
+		componentStatus.ReadinessReport = nil
+		status.ClearComponentReadinessReport(cr, componentName)
 		if bomFile, err := r.getBOM(); err == nil {
 			if component, er := bomFile.GetComponent(componentName); er == nil {
 				componentStatus.Version = component.Version
@@ -704,6 +781,23 @@ func (r *Reconciler) updateComponentStatus(compContext spi.ComponentContext, mes
 	return r.updateVerrazzanoStatus(log, cr)
 }
 
+// updateComponentReadinessReport records the workloads that are preventing a component from being ready in the
+// component status. The Verrazzano status is only updated if the report changed since the last check.
+func (r *Reconciler) updateComponentReadinessReport(compContext spi.ComponentContext) error {
+	componentName := compContext.GetComponent()
+	cr := compContext.ActualCR()
+	componentStatus := cr.Status.Components[componentName]
+	if componentStatus == nil {
+		return nil
+	}
+	report := status.GetComponentReadinessReport(cr, componentName)
+	if reflect.DeepEqual(report, componentStatus.ReadinessReport) {
+		return nil
+	}
+	componentStatus.ReadinessReport = report
+	return r.updateVerrazzanoStatus(compContext.Log(), cr)
+}
+
 func appendConditionIfNecessary(log vzlog.VerrazzanoLogger, resourceName string, conditions []installv1alpha1.Condition, newCondition installv1alpha1.Condition) []installv1alpha1.Condition {
 	var newConditionsList []installv1alpha1.Condition
 	for i, existingCondition := range conditions {
@@ -996,6 +1090,10 @@ func (r *Reconciler) procDelete(ctx context.Context, log vzlog.VerrazzanoLogger,
 	}
 
 	delete(initializedSet, vz.Name)
+	delete(issuerWatchedSet, vz.Name)
+	deleteCertExpiryChecked(vz.Namespace + "/" + vz.Name)
+	deleteCredentialsVersions(vz.Name)
+	status.ClearReadinessReports(vz)
 
 	// Delete the uninstall tracker so the memory can be freed up
 	DeleteUninstallTracker(vz)
@@ -1053,6 +1151,109 @@ func (r *Reconciler) watchPods(namespace string, name string, log vzlog.Verrazza
 		}))
 }
 
+// Watch the VerrazzanoComponent resources for this vz resource.  The loop to reconcile will be called
+// when a component plugin is created, updated or deleted.
+func (r *Reconciler) watchPluginComponents(namespace string, name string, log vzlog.VerrazzanoLogger) error {
+	log.Debugf("Watching for VerrazzanoComponents to activate reconcile for Verrazzano CR %s/%s", namespace, name)
+	return r.Controller.Watch(
+		&source.Kind{Type: &platformv1alpha1.VerrazzanoComponent{}},
+		createReconcileEventHandler(namespace, name),
+		predicate.GenerationChangedPredicate{})
+}
+
+// Watch the profile ConfigMaps for this vz resource.  The loop to reconcile will be called when a profile ConfigMap
+// is created, updated or deleted, so that the user profiles are loaded again and the effective CR is recomputed.  A
+// profile can extend other profiles, so the vz resource is reconciled for a change of any profile.
+func (r *Reconciler) watchProfileConfigMaps(namespace string, name string, log vzlog.VerrazzanoLogger) error {
+	log.Debugf("Watching for profile ConfigMaps to activate reconcile for Verrazzano CR %s/%s", namespace, name)
+	return r.Controller.Watch(
+		&source.Kind{Type: &corev1.ConfigMap{}},
+		createReconcileEventHandler(namespace, name),
+		predicate.NewPredicateFuncs(isProfileConfigMap))
+}
+
+// isProfileConfigMap returns true if the object is a profile ConfigMap
+func isProfileConfigMap(obj client.Object) bool {
+	_, ok := obj.GetLabels()[vzconst.ProfileLabel]
+	return ok && obj.GetNamespace() == vzconst.VerrazzanoInstallNamespace
+}
+
+// Watch the cert-manager Issuers and ClusterIssuers for this vz resource, once cert-manager is installed so that the
+// issuer CRDs exist.  The loop to reconcile will be called when the spec of an issuer changes, and the cert-manager
+// component is reconciled to keep the Verrazzano ClusterIssuer in sync with the existing issuer it is configured from.
+func (r *Reconciler) watchIssuers(namespace string, name string, components installv1alpha1.ComponentStatusMap, log vzlog.VerrazzanoLogger) error {
+	if unitTesting || issuerWatchedSet[name] {
+		return nil
+	}
+	if comp, ok := components[certmanager.ComponentName]; !ok || comp.State != installv1alpha1.CompStateReady {
+		return nil
+	}
+	log.Debugf("Watching for cert-manager issuers to activate reconcile for Verrazzano CR %s/%s", namespace, name)
+	for _, issuer := range []client.Object{&cmapiv1.Issuer{}, &cmapiv1.ClusterIssuer{}} {
+		err := r.Controller.Watch(
+			&source.Kind{Type: issuer},
+			createReconcileEventHandler(namespace, name),
+			predicate.Funcs{
+				CreateFunc:  func(e event.CreateEvent) bool { return false },
+				DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+				GenericFunc: func(e event.GenericEvent) bool { return false },
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					// The Verrazzano ClusterIssuer is updated by the cert-manager component
+					if e.ObjectNew.GetGeneration() == e.ObjectOld.GetGeneration() || e.ObjectNew.GetName() == certmanager.ClusterIssuerName {
+						return false
+					}
+					log.Debugf("Spec of the cert-manager issuer %s updated", e.ObjectNew.GetName())
+					r.AddWatch(certmanager.ComponentJSONName)
+					return true
+				},
+			})
+		if err != nil {
+			return err
+		}
+	}
+	issuerWatchedSet[name] = true
+	return nil
+}
+
+// loadPluginComponents uninstalls the components of deleted VerrazzanoComponent resources and updates the registry
+// with the components declared by the remaining VerrazzanoComponent resources.  It returns true when the Verrazzano
+// status was updated and the reconcile must be requeued.
+func (r *Reconciler) loadPluginComponents(log vzlog.VerrazzanoLogger, vz *installv1alpha1.Verrazzano) (bool, error) {
+	if unitTesting {
+		return false, nil
+	}
+	statusChanged, err := plugin.ReconcileFinalizers(log, r.Client, vz, r.DryRun)
+	if err != nil {
+		log.Errorf("Failed to reconcile the VerrazzanoComponent finalizers: %v", err)
+		return false, err
+	}
+	if statusChanged {
+		// Requeue so that the finalizers of the deleted components are removed once the status is updated
+		return true, r.updateVerrazzanoStatus(log, vz)
+	}
+	comps, err := plugin.ListComponents(r.Client)
+	if err != nil {
+		log.Errorf("Failed to list the VerrazzanoComponent resources: %v", err)
+		return false, err
+	}
+	registry.SetPluginComponents(log, comps)
+	return false, nil
+}
+
+// loadUserProfiles updates the user profiles declared by the profile ConfigMaps
+func (r *Reconciler) loadUserProfiles(log vzlog.VerrazzanoLogger) error {
+	if unitTesting {
+		return nil
+	}
+	profiles, err := transform.ListUserProfiles(r.Client)
+	if err != nil {
+		log.Errorf("Failed to list the profile ConfigMaps: %v", err)
+		return err
+	}
+	transform.SetUserProfiles(profiles)
+	return nil
+}
+
 func createReconcileEventHandler(namespace, name string) handler.EventHandler {
 	return handler.EnqueueRequestsFromMapFunc(
 		func(a client.Object) []reconcile.Request {
@@ -1106,6 +1307,18 @@ func (r *Reconciler) initForVzResource(vz *installv1alpha1.Verrazzano, log vzlog
 		return newRequeueWithDelay(), err
 	}
 
+	// Watch the VerrazzanoComponent resources to install component plugins
+	if err := r.watchPluginComponents(vz.Namespace, vz.Name, log); err != nil {
+		log.Errorf("Failed to set VerrazzanoComponent watch for Verrazzano CR %s: %v", vz.Name, err)
+		return newRequeueWithDelay(), err
+	}
+
+	// Watch the profile ConfigMaps to apply the changes of the user profiles
+	if err := r.watchProfileConfigMaps(vz.Namespace, vz.Name, log); err != nil {
+		log.Errorf("Failed to set profile ConfigMap watch for Verrazzano CR %s: %v", vz.Name, err)
+		return newRequeueWithDelay(), err
+	}
+
 	// Update the map indicating the resource is being watched
 	initializedSet[vz.Name] = true
 	return ctrl.Result{Requeue: true}, nil
@@ -1386,3 +1386,18 @@ func TestReconcileErrorCounter(t *testing.T) {
 	assert.NoError(t, err)
 	asserts.Equal(errorCounterBefore, errorCounterAfter-1)
 }
+
+// TestIsProfileConfigMap tests filtering the events of the profile ConfigMaps
+// GIVEN ConfigMaps with and without the profile label, in and out of the verrazzano-install namespace
+// WHEN isProfileConfigMap is called
+// THEN true is returned only for a ConfigMap with the profile label in the verrazzano-install namespace
+func TestIsProfileConfigMap(t *testing.T) {
+	asserts := assert.New(t)
+	newConfigMap := func(namespace string, labels map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: namespace, Labels: labels}}
+	}
+	profileLabels := map[string]string{constants.ProfileLabel: "edge"}
+	asserts.True(isProfileConfigMap(newConfigMap(constants.VerrazzanoInstallNamespace, profileLabels)))
+	asserts.False(isProfileConfigMap(newConfigMap(constants.VerrazzanoInstallNamespace, nil)))
+	asserts.False(isProfileConfigMap(newConfigMap("default", profileLabels)))
+}
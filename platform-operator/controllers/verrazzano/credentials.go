@@ -0,0 +1,79 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"strings"
+	"sync"
+
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/netpolicy"
+)
+
+// credentialsComponent is a component that generates credentials, which are synced with the credentials store again
+// when the credentials version annotation of the Verrazzano CR changes
+type credentialsComponent interface {
+	// EnsureCredentials creates or updates the secrets of the generated credentials of the component
+	EnsureCredentials(ctx spi.ComponentContext) error
+}
+
+// credentialsVersions tracks the credentials version annotation that the credentials of each component were last
+// ensured for, keyed by the Verrazzano CR name and the component name
+var credentialsVersions = make(map[string]string)
+var credentialsVersionsMutex sync.Mutex
+
+// ensureCredentials ensures the credentials of an installed component once for each credentials version annotation of
+// the Verrazzano CR, which creates missing secrets and syncs the secrets with the credentials store if one is
+// configured.  Existing credentials are not generated again here, a change of the annotation rotates the credentials
+// of the component that can be rotated, see rotateCredentials.
+func (r *Reconciler) ensureCredentials(ctx spi.ComponentContext, comp spi.Component) error {
+	credComp, ok := comp.(credentialsComponent)
+	if !ok || r.DryRun {
+		return nil
+	}
+	version := ctx.ActualCR().Annotations[globalconst.CredentialsVersionAnnotation]
+	key := ctx.ActualCR().Name + "/" + comp.Name()
+	credentialsVersionsMutex.Lock()
+	defer credentialsVersionsMutex.Unlock()
+	if ensured, ok := credentialsVersions[key]; ok && ensured == version {
+		return nil
+	}
+	if err := credComp.EnsureCredentials(ctx); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed to ensure the credentials of component %s: %v", comp.Name(), err)
+	}
+	ctx.Log().Oncef("Ensured the credentials of component %s for credentials version %q", comp.Name(), version)
+	credentialsVersions[key] = version
+	return nil
+}
+
+// deleteCredentialsVersions forgets the credentials versions of the components of a deleted Verrazzano CR
+func deleteCredentialsVersions(vzName string) {
+	credentialsVersionsMutex.Lock()
+	defer credentialsVersionsMutex.Unlock()
+	for key := range credentialsVersions {
+		if strings.HasPrefix(key, vzName+"/") {
+			delete(credentialsVersions, key)
+		}
+	}
+}
+
+// reconcileCredentialsStoreNetworkPolicy allows the operator egress to the Vault server of the credentials store
+// configured in the Verrazzano CR, and removes the egress when no store is configured
+func (r *Reconciler) reconcileCredentialsStoreNetworkPolicy(log vzlog.VerrazzanoLogger, vz *installv1alpha1.Verrazzano) error {
+	if unitTesting || r.DryRun {
+		return nil
+	}
+	address := ""
+	if vz.Spec.Security.CredentialsStore != nil && vz.Spec.Security.CredentialsStore.Vault != nil {
+		address = vz.Spec.Security.CredentialsStore.Vault.Address
+	}
+	if _, err := netpolicy.CreateOrUpdateCredentialsStoreNetworkPolicy(r.Client, address); err != nil {
+		log.Errorf("Failed to reconcile the network policy of the credentials store: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeCredentialsComponent is a component that counts the calls to ensure its credentials
+type fakeCredentialsComponent struct {
+	fakeComponent
+	ensured *int
+}
+
+// EnsureCredentials counts the call
+func (f fakeCredentialsComponent) EnsureCredentials(_ spi.ComponentContext) error {
+	*f.ensured++
+	return nil
+}
+
+// TestEnsureCredentials tests ensuring the credentials of a component
+// GIVEN a component that generates credentials and one that doesn't
+// WHEN ensureCredentials is called repeatedly, after the credentials version annotation changes, and after the
+// credentials versions of the Verrazzano CR are deleted
+// THEN the credentials are ensured once for each credentials version, and again after the versions are deleted
+func TestEnsureCredentials(t *testing.T) {
+	defer func() { credentialsVersions = make(map[string]string) }()
+	ensured := 0
+	comp := fakeCredentialsComponent{fakeComponent: fakeComponent{HelmComponent: helm.HelmComponent{ReleaseName: "creds"}}, ensured: &ensured}
+	vz := &vzapi.Verrazzano{ObjectMeta: metav1.ObjectMeta{Name: "verrazzano", Namespace: "default"}}
+	r := newVerrazzanoReconciler(fake.NewClientBuilder().WithScheme(scheme.Scheme).Build())
+	ctx := spi.NewFakeContext(r.Client, vz, nil, false)
+
+	assert.NoError(t, r.ensureCredentials(ctx, comp))
+	assert.NoError(t, r.ensureCredentials(ctx, comp))
+	assert.Equal(t, 1, ensured)
+
+	vz.Annotations = map[string]string{globalconst.CredentialsVersionAnnotation: "2"}
+	assert.NoError(t, r.ensureCredentials(ctx, comp))
+	assert.NoError(t, r.ensureCredentials(ctx, comp))
+	assert.Equal(t, 2, ensured)
+
+	// A component without credentials is skipped
+	assert.NoError(t, r.ensureCredentials(ctx, comp.fakeComponent))
+
+	// The credentials are ensured again once the versions of the deleted Verrazzano CR are forgotten
+	credentialsVersions["other/creds"] = "2"
+	deleteCredentialsVersions("verrazzano")
+	assert.Equal(t, map[string]string{"other/creds": "2"}, credentialsVersions)
+	assert.NoError(t, r.ensureCredentials(ctx, comp))
+	assert.Equal(t, 3, ensured)
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// effectiveConfigSuffix is the suffix of the name of the ConfigMap that publishes the effective CR
+	effectiveConfigSuffix = "-effective-config"
+
+	// EffectiveCRKey is the key of the effective CR, with the provenance of each field as a comment
+	EffectiveCRKey = "effective-cr.yaml"
+
+	// ProvenanceKey is the key of the provenance of each field of the effective CR, keyed by path
+	ProvenanceKey = "provenance.yaml"
+
+	// OverridesProvenanceKey is the key of the provenance of each Helm value set by the install overrides, keyed by
+	// component name and then by path
+	OverridesProvenanceKey = "overrides-provenance.yaml"
+
+	// effectiveConfigInputsAnnotation is the annotation of the ConfigMap with the hash of the inputs of the published
+	// effective CR
+	effectiveConfigInputsAnnotation = "verrazzano.io/effective-config-inputs"
+)
+
+// EffectiveConfigName returns the name of the ConfigMap that publishes the effective CR of a Verrazzano CR
+func EffectiveConfigName(vzName string) string {
+	return vzName + effectiveConfigSuffix
+}
+
+// publishEffectiveCR creates or updates the ConfigMap in the namespace of the Verrazzano CR that publishes its
+// effective CR, along with the source of each field of the effective CR and of each Helm value set by the install
+// overrides.  The inline values of the install overrides are masked in the published effective CR.  The ConfigMap is
+// owned by the Verrazzano CR, and is only published again when the effective spec, the components or the override
+// ConfigMaps and Secrets change.
+func (r *Reconciler) publishEffectiveCR(vz *installv1alpha1.Verrazzano) error {
+	actualCR := &v1beta1.Verrazzano{}
+	if err := vz.ConvertTo(actualCR); err != nil {
+		return err
+	}
+	inputs, err := r.getEffectiveConfigInputs(actualCR)
+	if err != nil {
+		return err
+	}
+	cm := corev1.ConfigMap{}
+	err = r.Get(context.TODO(), types.NamespacedName{Namespace: vz.Namespace, Name: EffectiveConfigName(vz.Name)}, &cm)
+	if err == nil && cm.Annotations[effectiveConfigInputsAnnotation] == inputs {
+		return nil
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	effectiveCR, provenance, err := transform.GetEffectiveV1beta1CRWithProvenance(actualCR)
+	if err != nil {
+		return err
+	}
+	effectiveCR.TypeMeta = metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "Verrazzano"}
+	maskedCR, err := transform.MaskOverrideValues(effectiveCR)
+	if err != nil {
+		return err
+	}
+	annotated, err := transform.AnnotateYAML(maskedCR, provenance)
+	if err != nil {
+		return err
+	}
+	provenanceYAML, err := yaml.Marshal(provenance)
+	if err != nil {
+		return err
+	}
+	overridesProvenance, err := r.getOverridesProvenance(effectiveCR, provenance)
+	if err != nil {
+		return err
+	}
+	overridesProvenanceYAML, err := yaml.Marshal(overridesProvenance)
+	if err != nil {
+		return err
+	}
+
+	cm = corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: EffectiveConfigName(vz.Name), Namespace: vz.Namespace}}
+	_, err = controllerutil.CreateOrUpdate(context.TODO(), r.Client, &cm, func() error {
+		if cm.Annotations == nil {
+			cm.Annotations = map[string]string{}
+		}
+		cm.Annotations[effectiveConfigInputsAnnotation] = inputs
+		cm.Data = map[string]string{
+			EffectiveCRKey:         annotated,
+			ProvenanceKey:          string(provenanceYAML),
+			OverridesProvenanceKey: string(overridesProvenanceYAML),
+		}
+		// Garbage collect the ConfigMap when the Verrazzano CR is deleted
+		return controllerutil.SetControllerReference(vz, &cm, r.Scheme)
+	})
+	return err
+}
+
+// getEffectiveConfigInputs returns a hash of the inputs of the effective CR and of its provenance, which are the
+// effective spec, the names of the components, and the resource versions of the ConfigMaps and Secrets referenced by
+// the install overrides
+func (r *Reconciler) getEffectiveConfigInputs(actualCR *v1beta1.Verrazzano) (string, error) {
+	effectiveCR, err := transform.GetEffectiveV1beta1CR(actualCR)
+	if err != nil {
+		return "", err
+	}
+	spec, err := json.Marshal(effectiveCR.Spec)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	hash.Write(spec)
+	for _, comp := range registry.GetComponents() {
+		hash.Write([]byte("\n" + comp.Name()))
+		overrides, ok := comp.GetOverrides(effectiveCR).([]v1beta1.Overrides)
+		if !ok {
+			continue
+		}
+		for _, override := range overrides {
+			var obj client.Object
+			var name string
+			switch {
+			case override.ConfigMapRef != nil:
+				obj, name = &corev1.ConfigMap{}, override.ConfigMapRef.Name
+			case override.SecretRef != nil:
+				obj, name = &corev1.Secret{}, override.SecretRef.Name
+			default:
+				continue
+			}
+			err := r.Get(context.TODO(), types.NamespacedName{Namespace: effectiveCR.Namespace, Name: name}, obj)
+			if err != nil && !errors.IsNotFound(err) {
+				return "", err
+			}
+			hash.Write([]byte(fmt.Sprintf("\n%T %s %s", obj, name, obj.GetResourceVersion())))
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// getOverridesProvenance returns the source of each Helm value set by the install overrides of the enabled
+// components, keyed by component name and then by path.  The first override in the list has the highest precedence.
+func (r *Reconciler) getOverridesProvenance(effectiveCR *v1beta1.Verrazzano, provenance transform.Provenance) (map[string]transform.Provenance, error) {
+	overridesProvenance := map[string]transform.Provenance{}
+	for _, comp := range registry.GetComponents() {
+		overrides, ok := comp.GetOverrides(effectiveCR).([]v1beta1.Overrides)
+		if !ok || len(overrides) == 0 {
+			continue
+		}
+		compProvenance := transform.Provenance{}
+		for i := len(overrides) - 1; i >= 0; i-- {
+			values, err := common.GetInstallOverridesYAMLUsingClient(r.Client, []v1beta1.Overrides{overrides[i]}, effectiveCR.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			source := getOverrideSource(overrides[i], effectiveCR.Namespace,
+				fmt.Sprintf("spec.components.%s.overrides[%d].values", comp.GetJSONName(), i), provenance)
+			for _, value := range values {
+				fields := map[string]interface{}{}
+				if err := yaml.Unmarshal([]byte(value), &fields); err != nil {
+					return nil, err
+				}
+				flattened, err := transform.FlattenFields(fields)
+				if err != nil {
+					return nil, err
+				}
+				for path := range flattened {
+					compProvenance[path] = source
+				}
+			}
+		}
+		if len(compProvenance) > 0 {
+			overridesProvenance[comp.Name()] = compProvenance
+		}
+	}
+	return overridesProvenance, nil
+}
+
+// getOverrideSource returns the source of the values of an install override.  The source of inline values is the
+// source of the override in the effective CR.
+func getOverrideSource(override v1beta1.Overrides, namespace string, valuesPath string, provenance transform.Provenance) string {
+	if override.ConfigMapRef != nil || override.SecretRef != nil {
+		return common.GetOverrideSource(override, namespace)
+	}
+	for path, source := range provenance {
+		if transform.HasPathPrefix(path, valuesPath) {
+			return source
+		}
+	}
+	return transform.CRProvenance
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+// TestPublishEffectiveCR tests publishing the effective CR
+// GIVEN a Verrazzano CR and a component with install overrides from a ConfigMap and inline values
+// WHEN publishEffectiveCR is called
+// THEN a ConfigMap owned by the CR is created with the annotated effective CR with masked inline override values, the
+// provenance of its fields, and the provenance of the Helm values set by the overrides, where the first override has
+// the highest precedence, and the ConfigMap is only updated when its inputs change
+func TestPublishEffectiveCR(t *testing.T) {
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+
+	comp := fakeComponent{HelmComponent: helm.HelmComponent{
+		ReleaseName: "my-comp",
+		JSONName:    "myComp",
+		GetInstallOverridesFunc: func(object runtime.Object) interface{} {
+			return []v1beta1.Overrides{
+				{ConfigMapRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "overrides"},
+					Key:                  "values.yaml",
+				}},
+				{Values: &apiextensionsv1.JSON{Raw: []byte(`{"master":{"replicas":1},"data":{"replicas":2}}`)}},
+			}
+		},
+	}}
+	registry.OverrideGetComponentsFn(func() []spi.Component { return []spi.Component{comp} })
+	defer registry.ResetGetComponentsFn()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	vz := &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-vz", Namespace: "default", UID: "uid"},
+		Spec: vzapi.VerrazzanoSpec{Profile: vzapi.Dev, EnvironmentName: "my-env", Components: vzapi.ComponentSpec{
+			PrometheusOperator: &vzapi.PrometheusOperatorComponent{InstallOverrides: vzapi.InstallOverrides{
+				ValueOverrides: []vzapi.Overrides{{Values: &apiextensionsv1.JSON{Raw: []byte(`{"admin":{"password":"my-secret"}}`)}}},
+			}},
+		}},
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "overrides", Namespace: "default"},
+		Data:       map[string]string{"values.yaml": "master:\n  replicas: 3\n"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vz, cm).Build()
+	r := newVerrazzanoReconciler(c)
+	r.Scheme = scheme
+
+	assert.NoError(t, r.publishEffectiveCR(vz))
+
+	published := corev1.ConfigMap{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: EffectiveConfigName("my-vz")}, &published))
+	assert.Len(t, published.OwnerReferences, 1)
+	assert.Equal(t, "my-vz", published.OwnerReferences[0].Name)
+	assert.Contains(t, published.Data[EffectiveCRKey], "environmentName: my-env # Verrazzano CR")
+	assert.Contains(t, published.Data[EffectiveCRKey], "kind: Verrazzano")
+	assert.NotContains(t, published.Data[EffectiveCRKey], "my-secret")
+	assert.Contains(t, published.Data[EffectiveCRKey], "password: '"+transform.MaskedValue+"' # Verrazzano CR")
+
+	provenance := transform.Provenance{}
+	assert.NoError(t, yaml.Unmarshal([]byte(published.Data[ProvenanceKey]), &provenance))
+	assert.Equal(t, transform.CRProvenance, provenance["spec.environmentName"])
+	assert.Equal(t, transform.ProfileProvenance("dev"), provenance["spec.defaultVolumeSource.emptyDir"])
+
+	overridesProvenance := map[string]transform.Provenance{}
+	assert.NoError(t, yaml.Unmarshal([]byte(published.Data[OverridesProvenanceKey]), &overridesProvenance))
+	assert.Equal(t, "ConfigMap default/overrides key values.yaml", overridesProvenance["my-comp"]["master.replicas"])
+	assert.Equal(t, transform.CRProvenance, overridesProvenance["my-comp"]["data.replicas"])
+
+	// Publishing again without a change of the inputs doesn't update the ConfigMap
+	resourceVersion := published.ResourceVersion
+	assert.NoError(t, r.publishEffectiveCR(vz))
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: EffectiveConfigName("my-vz")}, &published))
+	assert.Equal(t, resourceVersion, published.ResourceVersion)
+
+	// Publishing again after a change of an override ConfigMap updates the ConfigMap
+	cm.Data["values.yaml"] = "master:\n  replicas: 3\nclient:\n  replicas: 1\n"
+	assert.NoError(t, c.Update(context.TODO(), cm))
+	assert.NoError(t, r.publishEffectiveCR(vz))
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: EffectiveConfigName("my-vz")}, &published))
+	assert.NoError(t, yaml.Unmarshal([]byte(published.Data[OverridesProvenanceKey]), &overridesProvenance))
+	assert.Equal(t, "ConfigMap default/overrides key values.yaml", overridesProvenance["my-comp"]["client.replicas"])
+
+	// Publishing again after a change of the spec updates the ConfigMap
+	vz.Spec.EnvironmentName = "new-env"
+	assert.NoError(t, r.publishEffectiveCR(vz))
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: EffectiveConfigName("my-vz")}, &published))
+	assert.Contains(t, published.Data[EffectiveCRKey], "environmentName: new-env # Verrazzano CR")
+}
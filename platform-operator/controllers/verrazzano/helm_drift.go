@@ -0,0 +1,179 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/metricsexporter"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// defaultHelmDriftInterval is how often the Helm releases are checked for drift if the interval is not specified
+	defaultHelmDriftInterval = 5 * time.Minute
+
+	// maxHelmDriftPathsReported limits the number of drifted value paths included in the condition message
+	maxHelmDriftPathsReported = 10
+)
+
+// helmDriftChecked keeps track of the last time each component was checked for Helm drift
+var helmDriftChecked = make(map[string]time.Time)
+var helmDriftCheckedMutex sync.Mutex
+
+// getHelmDriftPolicy returns the Helm drift policy and check interval of the Verrazzano resource
+func getHelmDriftPolicy(cr *installv1alpha1.Verrazzano) (installv1alpha1.HelmDriftPolicy, time.Duration) {
+	policy := installv1alpha1.HelmDriftPolicyReport
+	interval := defaultHelmDriftInterval
+	if cr.Spec.HelmDrift != nil {
+		if len(cr.Spec.HelmDrift.Policy) > 0 {
+			policy = cr.Spec.HelmDrift.Policy
+		}
+		if cr.Spec.HelmDrift.Interval != nil && cr.Spec.HelmDrift.Interval.Duration > 0 {
+			interval = cr.Spec.HelmDrift.Interval.Duration
+		}
+	}
+	return policy, interval
+}
+
+// helmDriftRequeue returns the result that requeues the Verrazzano resource for the next Helm drift check
+func helmDriftRequeue(cr *installv1alpha1.Verrazzano) ctrl.Result {
+	policy, interval := getHelmDriftPolicy(cr)
+	if policy == installv1alpha1.HelmDriftPolicyDisabled {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: interval}
+}
+
+// checkHelmDrift checks if the Helm release of a ready component has drifted from the values generated by the operator,
+// at most once per check interval.  Drift is reported in the HelmDriftDetected condition of the component and in the
+// Helm drift metric.  Returns true if the component needs to be reconciled to correct the drift.
+func (r *Reconciler) checkHelmDrift(compContext spi.ComponentContext, comp spi.Component) (bool, error) {
+	cr := compContext.ActualCR()
+	policy, interval := getHelmDriftPolicy(cr)
+	if policy == installv1alpha1.HelmDriftPolicyDisabled {
+		return false, nil
+	}
+	detector, ok := comp.(helm.DriftDetector)
+	if !ok {
+		return false, nil
+	}
+	compName := comp.Name()
+	if !isHelmDriftCheckDue(compName, interval) {
+		return false, nil
+	}
+
+	log := compContext.Log()
+	drift, err := detector.DetectHelmDrift(compContext)
+	if err != nil {
+		// Failing to check for drift should not block the reconcile of the component
+		log.Errorf("Failed to check the Helm release of component %s for drift: %v", compName, err)
+		return false, nil
+	}
+
+	driftMetric, err := metricsexporter.GetComponentGaugeMetric(metricsexporter.HelmDriftDetected)
+	if err != nil {
+		log.Errorf("Failed to get the Helm drift metric: %v", err)
+	} else if len(drift) > 0 {
+		driftMetric.Set(compName, 1)
+	} else {
+		driftMetric.Set(compName, 0)
+	}
+
+	if len(drift) > 0 {
+		log.Infof("The Helm release of component %s has drifted, values: %v", compName, drift)
+	}
+	if setHelmDriftCondition(cr.Status.Components[compName], drift) {
+		if err := r.updateVerrazzanoStatus(log, cr); err != nil {
+			return false, err
+		}
+	}
+	return policy == installv1alpha1.HelmDriftPolicyReconcile && len(drift) > 0, nil
+}
+
+// repairHelmDrift upgrades the Helm release of a ready component with the values generated by the operator, which
+// restores the drifted values and objects.  The state of the component and of the Verrazzano resource is not changed,
+// a drift repair is not an install.
+func (r *Reconciler) repairHelmDrift(compContext spi.ComponentContext, comp spi.Component) error {
+	compContext.Log().Infof("Component %s is being upgraded to correct the drift of its Helm release", comp.Name())
+	if err := comp.Upgrade(compContext); err != nil {
+		compContext.Log().Errorf("Failed to correct the drift of the Helm release of component %s: %v", comp.Name(), err)
+		return err
+	}
+	return nil
+}
+
+// isHelmDriftCheckDue returns true if the component was not checked for Helm drift within the interval, and records
+// the time of the check
+func isHelmDriftCheckDue(compName string, interval time.Duration) bool {
+	helmDriftCheckedMutex.Lock()
+	defer helmDriftCheckedMutex.Unlock()
+	if lastChecked, ok := helmDriftChecked[compName]; ok && time.Since(lastChecked) < interval {
+		return false
+	}
+	helmDriftChecked[compName] = time.Now()
+	return true
+}
+
+// resetHelmDriftCheck forgets the last Helm drift check of the component
+func resetHelmDriftCheck(compName string) {
+	helmDriftCheckedMutex.Lock()
+	defer helmDriftCheckedMutex.Unlock()
+	delete(helmDriftChecked, compName)
+}
+
+// setHelmDriftCondition sets the HelmDriftDetected condition of the component status, without changing the position
+// of the other conditions.  The condition is added at the start of the list, the most recent lifecycle condition is
+// expected to be the last one.  Returns true if the condition changed.
+func setHelmDriftCondition(componentStatus *installv1alpha1.ComponentStatusDetails, drift []string) bool {
+	if componentStatus == nil {
+		return false
+	}
+	status := corev1.ConditionFalse
+	message := "The Helm release matches the values and manifest generated by Verrazzano"
+	if len(drift) > 0 {
+		status = corev1.ConditionTrue
+		paths := drift
+		if len(paths) > maxHelmDriftPathsReported {
+			paths = append(paths[:maxHelmDriftPathsReported:maxHelmDriftPathsReported], "...")
+		}
+		message = fmt.Sprintf("The Helm release has drifted from the values and manifest generated by Verrazzano: %s", strings.Join(paths, ", "))
+	}
+
+	for i, condition := range componentStatus.Conditions {
+		if condition.Type != installv1alpha1.CondHelmDriftDetected {
+			continue
+		}
+		if condition.Status == status && condition.Message == message {
+			return false
+		}
+		componentStatus.Conditions[i] = newHelmDriftCondition(status, message)
+		return true
+	}
+	// Only add the condition once drift has been detected
+	if status == corev1.ConditionFalse {
+		return false
+	}
+	componentStatus.Conditions = append([]installv1alpha1.Condition{newHelmDriftCondition(status, message)}, componentStatus.Conditions...)
+	return true
+}
+
+func newHelmDriftCondition(status corev1.ConditionStatus, message string) installv1alpha1.Condition {
+	t := time.Now().UTC()
+	return installv1alpha1.Condition{
+		Type:    installv1alpha1.CondHelmDriftDetected,
+		Status:  status,
+		Message: message,
+		LastTransitionTime: fmt.Sprintf("%d-%02d-%02dT%02d:%02d:%02dZ",
+			t.Year(), t.Month(), t.Day(),
+			t.Hour(), t.Minute(), t.Second()),
+	}
+}
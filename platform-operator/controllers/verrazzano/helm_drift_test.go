@@ -0,0 +1,193 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/metricsexporter"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const driftComponentName = "drift-component"
+
+// driftComponent is a fake component with a Helm release that has the given drift
+type driftComponent struct {
+	fakeComponent
+	drift []string
+}
+
+func (d driftComponent) DetectHelmDrift(_ spi.ComponentContext) ([]string, error) {
+	return d.drift, nil
+}
+
+func newDriftTestVerrazzano(policy vzapi.HelmDriftPolicy) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+		Spec: vzapi.VerrazzanoSpec{
+			HelmDrift: &vzapi.HelmDriftSpec{Policy: policy},
+		},
+		Status: vzapi.VerrazzanoStatus{
+			State: vzapi.VzStateReady,
+			Components: vzapi.ComponentStatusMap{
+				driftComponentName: {
+					Name:  driftComponentName,
+					State: vzapi.CompStateReady,
+					Conditions: []vzapi.Condition{
+						{Type: vzapi.CondInstallComplete, Status: corev1.ConditionTrue},
+					},
+				},
+			},
+		},
+	}
+}
+
+// TestGetHelmDriftPolicy tests the Helm drift policy defaults
+// GIVEN a Verrazzano resource
+// WHEN getHelmDriftPolicy is called
+// THEN the policy and interval of the resource are returned, or the defaults if they are not specified
+func TestGetHelmDriftPolicy(t *testing.T) {
+	policy, interval := getHelmDriftPolicy(&vzapi.Verrazzano{})
+	assert.Equal(t, vzapi.HelmDriftPolicyReport, policy)
+	assert.Equal(t, defaultHelmDriftInterval, interval)
+
+	vz := newDriftTestVerrazzano(vzapi.HelmDriftPolicyDisabled)
+	vz.Spec.HelmDrift.Interval = &metav1.Duration{Duration: time.Minute}
+	policy, interval = getHelmDriftPolicy(vz)
+	assert.Equal(t, vzapi.HelmDriftPolicyDisabled, policy)
+	assert.Equal(t, time.Minute, interval)
+	assert.Equal(t, time.Duration(0), helmDriftRequeue(vz).RequeueAfter)
+}
+
+// TestCheckHelmDriftReconcile tests checking a component for Helm drift with the Reconcile policy
+// GIVEN a ready component with a Helm release that has drifted
+// WHEN checkHelmDrift is called
+// THEN the HelmDriftDetected condition and metric are set, and the component needs to be reconciled
+func TestCheckHelmDriftReconcile(t *testing.T) {
+	defer resetHelmDriftCheck(driftComponentName)
+
+	vz := newDriftTestVerrazzano(vzapi.HelmDriftPolicyReconcile)
+	c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz).Build()
+	r := newVerrazzanoReconciler(c)
+	comp := driftComponent{
+		fakeComponent: fakeComponent{HelmComponent: helm.HelmComponent{ReleaseName: driftComponentName}},
+		drift:         []string{"image.tag"},
+	}
+	ctx := spi.NewFakeContext(c, vz, nil, false).Init(driftComponentName)
+
+	reconcileDrift, err := r.checkHelmDrift(ctx, comp)
+	assert.NoError(t, err)
+	assert.True(t, reconcileDrift)
+
+	driftMetric, err := metricsexporter.GetComponentGaugeMetric(metricsexporter.HelmDriftDetected)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(driftMetric.Get().WithLabelValues(driftComponentName)))
+
+	updated := vzapi.Verrazzano{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, &updated))
+	conditions := updated.Status.Components[driftComponentName].Conditions
+	assert.Len(t, conditions, 2)
+	assert.Equal(t, vzapi.CondHelmDriftDetected, conditions[0].Type)
+	assert.Equal(t, corev1.ConditionTrue, conditions[0].Status)
+	assert.Contains(t, conditions[0].Message, "image.tag")
+	assert.Equal(t, vzapi.CondInstallComplete, conditions[1].Type)
+
+	// The component is not checked again until the interval has passed
+	reconcileDrift, err = r.checkHelmDrift(ctx, comp)
+	assert.NoError(t, err)
+	assert.False(t, reconcileDrift)
+}
+
+// TestCheckHelmDriftReport tests checking a component for Helm drift with the Report policy
+// GIVEN a ready component with a Helm release that has drifted
+// WHEN checkHelmDrift is called
+// THEN the drift is reported, but the component does not need to be reconciled
+func TestCheckHelmDriftReport(t *testing.T) {
+	defer resetHelmDriftCheck(driftComponentName)
+
+	vz := newDriftTestVerrazzano(vzapi.HelmDriftPolicyReport)
+	c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz).Build()
+	r := newVerrazzanoReconciler(c)
+	comp := driftComponent{
+		fakeComponent: fakeComponent{HelmComponent: helm.HelmComponent{ReleaseName: driftComponentName}},
+		drift:         []string{"replicas"},
+	}
+
+	reconcileDrift, err := r.checkHelmDrift(spi.NewFakeContext(c, vz, nil, false).Init(driftComponentName), comp)
+	assert.NoError(t, err)
+	assert.False(t, reconcileDrift)
+	assert.Len(t, vz.Status.Components[driftComponentName].Conditions, 2)
+}
+
+// TestSetHelmDriftCondition tests setting the HelmDriftDetected condition
+// GIVEN a component status
+// WHEN setHelmDriftCondition is called with and without drift
+// THEN the condition is only added at the start of the list once drift is detected, and is updated in place afterwards
+func TestSetHelmDriftCondition(t *testing.T) {
+	componentStatus := &vzapi.ComponentStatusDetails{
+		Conditions: []vzapi.Condition{{Type: vzapi.CondInstallComplete, Status: corev1.ConditionTrue}},
+	}
+	assert.False(t, setHelmDriftCondition(componentStatus, nil))
+	assert.Len(t, componentStatus.Conditions, 1)
+
+	assert.True(t, setHelmDriftCondition(componentStatus, []string{"a"}))
+	assert.Len(t, componentStatus.Conditions, 2)
+	assert.Equal(t, vzapi.CondHelmDriftDetected, componentStatus.Conditions[0].Type)
+	assert.Equal(t, vzapi.CondInstallComplete, componentStatus.Conditions[1].Type)
+	assert.False(t, setHelmDriftCondition(componentStatus, []string{"a"}))
+
+	componentStatus.Conditions = append(componentStatus.Conditions, vzapi.Condition{Type: vzapi.CondPreInstall, Status: corev1.ConditionTrue})
+	assert.True(t, setHelmDriftCondition(componentStatus, nil))
+	assert.Len(t, componentStatus.Conditions, 3)
+	assert.Equal(t, vzapi.CondHelmDriftDetected, componentStatus.Conditions[0].Type)
+	assert.Equal(t, corev1.ConditionFalse, componentStatus.Conditions[0].Status)
+	assert.Equal(t, vzapi.CondPreInstall, componentStatus.Conditions[2].Type)
+}
+
+// upgradeCountComponent is a fake component that counts the upgrades
+type upgradeCountComponent struct {
+	driftComponent
+	upgrades *int
+}
+
+func (u upgradeCountComponent) Upgrade(_ spi.ComponentContext) error {
+	*u.upgrades++
+	return nil
+}
+
+// TestRepairHelmDrift tests correcting the drift of a Helm release
+// GIVEN a ready component of a ready Verrazzano resource with a Helm release that has drifted
+// WHEN repairHelmDrift is called
+// THEN the component is upgraded, and the states of the component and of the Verrazzano resource are not changed
+func TestRepairHelmDrift(t *testing.T) {
+	vz := newDriftTestVerrazzano(vzapi.HelmDriftPolicyReconcile)
+	c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz).Build()
+	r := newVerrazzanoReconciler(c)
+	upgrades := 0
+	comp := upgradeCountComponent{
+		driftComponent: driftComponent{
+			fakeComponent: fakeComponent{HelmComponent: helm.HelmComponent{ReleaseName: driftComponentName}},
+			drift:         []string{"image.tag"},
+		},
+		upgrades: &upgrades,
+	}
+	ctx := spi.NewFakeContext(c, vz, nil, false).Init(driftComponentName)
+
+	assert.NoError(t, r.repairHelmDrift(ctx, comp))
+	assert.Equal(t, 1, upgrades)
+	updated := vzapi.Verrazzano{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, &updated))
+	assert.Equal(t, vzapi.VzStateReady, updated.Status.State)
+	assert.Equal(t, vzapi.CompStateReady, updated.Status.Components[driftComponentName].State)
+}
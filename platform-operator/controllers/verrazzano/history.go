@@ -0,0 +1,162 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/verrazzano/verrazzano/pkg/history"
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// recordHistory creates or updates the ConfigMap in the namespace of the Verrazzano CR that stores the history of its
+// spec generations.  A new generation is recorded with the changes of the effective CR from the previous generation,
+// and the latest generation is updated with the component operations it triggered and the outcome of its reconcile.
+// The history is recorded after the reconcile, so the outcome reflects the result of the reconcile.  The ConfigMap is
+// owned by the Verrazzano CR.
+func (r *Reconciler) recordHistory(vz *installv1alpha1.Verrazzano) error {
+	cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: history.ConfigMapName(vz.Name), Namespace: vz.Namespace}}
+	_, err := controllerutil.CreateOrUpdate(context.TODO(), r.Client, &cm, func() error {
+		entries, err := history.GetEntries(&cm)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 || entries[len(entries)-1].Generation != vz.Generation {
+			entry, effectiveSpec, err := newHistoryEntry(vz, cm.Data[history.EffectiveSpecKey])
+			if err != nil {
+				return err
+			}
+			if len(entries) > 0 && entries[len(entries)-1].Outcome == history.OutcomeInProgress {
+				entries[len(entries)-1].Outcome = history.OutcomeSuperseded
+			}
+			entries = append(entries, entry)
+			if cm.Data == nil {
+				cm.Data = map[string]string{}
+			}
+			cm.Data[history.EffectiveSpecKey] = effectiveSpec
+		}
+		updateHistoryEntry(&entries[len(entries)-1], vz)
+		if err := history.SetEntries(&cm, entries); err != nil {
+			return err
+		}
+		// Garbage collect the ConfigMap when the Verrazzano CR is deleted
+		return controllerutil.SetControllerReference(vz, &cm, r.Scheme)
+	})
+	return err
+}
+
+// newHistoryEntry returns the history entry of the current generation of the Verrazzano CR, along with the flattened
+// effective spec of the generation.  The changes are computed from the flattened effective spec of the previous
+// generation, all the fields are changes of the first generation.  The inline values of the install overrides are
+// masked, so only the values that are added or removed are recorded as changes, not their values.
+func newHistoryEntry(vz *installv1alpha1.Verrazzano, previousSpec string) (history.Entry, string, error) {
+	entry := history.Entry{
+		Generation: vz.Generation,
+		Time:       metav1.NewTime(time.Now().UTC().Truncate(time.Second)),
+		ChangedBy:  getSpecManager(vz),
+		Outcome:    history.OutcomeInProgress,
+	}
+	actualCR := &v1beta1.Verrazzano{}
+	if err := vz.ConvertTo(actualCR); err != nil {
+		return entry, "", err
+	}
+	effectiveCR, err := transform.GetEffectiveV1beta1CR(actualCR)
+	if err != nil {
+		return entry, "", err
+	}
+	maskedSpec, err := transform.MaskOverrideValues(map[string]interface{}{"spec": effectiveCR.Spec})
+	if err != nil {
+		return entry, "", err
+	}
+	fields, err := transform.FlattenFields(maskedSpec)
+	if err != nil {
+		return entry, "", err
+	}
+	previousFields := map[string]interface{}{}
+	if len(previousSpec) > 0 {
+		if err := json.Unmarshal([]byte(previousSpec), &previousFields); err != nil {
+			return entry, "", err
+		}
+	}
+	entry.Changes = history.DiffFields(previousFields, fields)
+	effectiveSpec, err := json.Marshal(fields)
+	if err != nil {
+		return entry, "", err
+	}
+	return entry, string(effectiveSpec), nil
+}
+
+// getSpecManager returns the field manager of the latest update of the Verrazzano CR that is not an update of its
+// status
+func getSpecManager(vz *installv1alpha1.Verrazzano) string {
+	manager := ""
+	var latest *metav1.Time
+	for _, field := range vz.ManagedFields {
+		if field.Subresource != "" || field.Time == nil {
+			continue
+		}
+		if latest == nil || !field.Time.Before(latest) {
+			manager = field.Manager
+			latest = field.Time
+		}
+	}
+	return manager
+}
+
+// updateHistoryEntry adds the component conditions set since the generation was first reconciled as operations of
+// the entry, and updates the outcome of the entry from the state of the Verrazzano CR and of its components.  A failed
+// generation can still succeed when the reconcile is retried, the entry is not updated once it succeeded.
+func updateHistoryEntry(entry *history.Entry, vz *installv1alpha1.Verrazzano) {
+	if entry.Outcome == history.OutcomeSucceeded || entry.Outcome == history.OutcomeSuperseded {
+		return
+	}
+	recorded := map[history.Operation]bool{}
+	for _, op := range entry.Operations {
+		recorded[op] = true
+	}
+	succeeded := vz.Status.State == installv1alpha1.VzStateReady
+	for _, name := range getSortedComponentNames(vz.Status.Components) {
+		comp := vz.Status.Components[name]
+		for _, cond := range comp.Conditions {
+			condTime, err := time.Parse(time.RFC3339, cond.LastTransitionTime)
+			if err != nil || condTime.Before(entry.Time.Time) {
+				continue
+			}
+			op := history.Operation{Component: name, Condition: string(cond.Type), Time: cond.LastTransitionTime}
+			if !recorded[op] {
+				entry.Operations = append(entry.Operations, op)
+				recorded[op] = true
+			}
+		}
+		if comp.State != installv1alpha1.CompStateDisabled &&
+			(comp.LastReconciledGeneration < entry.Generation || comp.ReconcilingGeneration > 0) {
+			succeeded = false
+		}
+	}
+	if vz.Status.State == installv1alpha1.VzStateFailed {
+		entry.Outcome = history.OutcomeFailed
+	} else if succeeded {
+		entry.Outcome = history.OutcomeSucceeded
+	}
+}
+
+// getSortedComponentNames returns the names of the components in the status, sorted by name
+func getSortedComponentNames(components installv1alpha1.ComponentStatusMap) []string {
+	names := make([]string, 0, len(components))
+	for name, comp := range components {
+		if comp != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/history"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestRecordHistory tests recording the history of the spec generations of a Verrazzano CR
+// GIVEN a Verrazzano CR whose spec is updated while the components are reconciled
+// WHEN recordHistory is called for each reconcile
+// THEN a ConfigMap owned by the CR records each generation with the changes of the effective CR, the component
+// operations, and the outcome
+func TestRecordHistory(t *testing.T) {
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	managedTime := metav1.NewTime(time.Now())
+	vz := &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-vz",
+			Namespace:  "default",
+			UID:        "uid",
+			Generation: 1,
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kubectl-client-side-apply", Operation: metav1.ManagedFieldsOperationUpdate, Time: &managedTime},
+				{Manager: "verrazzano-platform-operator", Operation: metav1.ManagedFieldsOperationUpdate, Time: &managedTime, Subresource: "status"},
+			},
+		},
+		Spec: vzapi.VerrazzanoSpec{Profile: vzapi.Dev, EnvironmentName: "my-env"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vz).Build()
+	r := newVerrazzanoReconciler(c)
+	r.Scheme = scheme
+
+	// The first generation records all the fields as changes
+	assert.NoError(t, r.recordHistory(vz))
+	entries := getHistoryEntries(t, c)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, int64(1), entries[0].Generation)
+	assert.Equal(t, "kubectl-client-side-apply", entries[0].ChangedBy)
+	assert.Equal(t, history.OutcomeInProgress, entries[0].Outcome)
+	assert.Contains(t, entries[0].Changes, history.FieldChange{Path: "spec.environmentName", New: "my-env"})
+
+	// The component operations of the generation are recorded, and the generation succeeds when all the enabled
+	// components reconciled it
+	transitionTime := time.Now().UTC().Add(time.Second).Format(time.RFC3339)
+	vz.Status = vzapi.VerrazzanoStatus{
+		State: vzapi.VzStateReady,
+		Components: vzapi.ComponentStatusMap{
+			"my-comp": {
+				Name:                     "my-comp",
+				State:                    vzapi.CompStateReady,
+				LastReconciledGeneration: 1,
+				Conditions:               []vzapi.Condition{{Type: vzapi.CondInstallComplete, LastTransitionTime: transitionTime}},
+			},
+			"disabled-comp": {Name: "disabled-comp", State: vzapi.CompStateDisabled},
+		},
+	}
+	assert.NoError(t, r.recordHistory(vz))
+	assert.NoError(t, r.recordHistory(vz))
+	entries = getHistoryEntries(t, c)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, history.OutcomeSucceeded, entries[0].Outcome)
+	assert.Equal(t, []history.Operation{{Component: "my-comp", Condition: string(vzapi.CondInstallComplete), Time: transitionTime}}, entries[0].Operations)
+
+	// A new generation records only the changed fields
+	vz.Generation = 2
+	vz.Spec.EnvironmentName = "new-env"
+	vz.Status.State = vzapi.VzStateReconciling
+	assert.NoError(t, r.recordHistory(vz))
+	entries = getHistoryEntries(t, c)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, []history.FieldChange{{Path: "spec.environmentName", Old: "my-env", New: "new-env"}}, entries[1].Changes)
+	assert.Equal(t, history.OutcomeInProgress, entries[1].Outcome)
+
+	// A generation that is updated before it is reconciled is superseded
+	vz.Generation = 3
+	vz.Spec.EnvironmentName = "my-env"
+	assert.NoError(t, r.recordHistory(vz))
+	entries = getHistoryEntries(t, c)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, history.OutcomeSuperseded, entries[1].Outcome)
+
+	// The reconcile of the generation fails
+	vz.Status.State = vzapi.VzStateFailed
+	assert.NoError(t, r.recordHistory(vz))
+	entries = getHistoryEntries(t, c)
+	assert.Equal(t, history.OutcomeFailed, entries[2].Outcome)
+
+	// The number of entries is bounded
+	for gen := int64(4); gen < 4+history.MaxEntries; gen++ {
+		vz.Generation = gen
+		assert.NoError(t, r.recordHistory(vz))
+	}
+	entries = getHistoryEntries(t, c)
+	assert.Len(t, entries, history.MaxEntries)
+	assert.Equal(t, int64(4), entries[0].Generation)
+}
+
+// TestRecordHistoryMasksOverrideValues tests that the history doesn't record the inline values of the install overrides
+// GIVEN a Verrazzano CR with an inline override value that is added and then changed
+// WHEN recordHistory is called for each generation
+// THEN the changes and the effective spec of the history only have the masked value
+func TestRecordHistoryMasksOverrideValues(t *testing.T) {
+	config.TestProfilesDir = "../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	vz := &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-vz", Namespace: "default", UID: "uid", Generation: 1},
+		Spec:       vzapi.VerrazzanoSpec{Profile: vzapi.Dev},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vz).Build()
+	r := newVerrazzanoReconciler(c)
+	r.Scheme = scheme
+	assert.NoError(t, r.recordHistory(vz))
+
+	vz.Generation = 2
+	vz.Spec.Components.PrometheusOperator = &vzapi.PrometheusOperatorComponent{InstallOverrides: vzapi.InstallOverrides{
+		ValueOverrides: []vzapi.Overrides{{Values: &apiextensionsv1.JSON{Raw: []byte(`{"admin":{"password":"my-secret"}}`)}}},
+	}}
+	assert.NoError(t, r.recordHistory(vz))
+	entries := getHistoryEntries(t, c)
+	assert.Contains(t, entries[1].Changes, history.FieldChange{
+		Path: "spec.components.prometheusOperator.overrides[0].values.admin.password",
+		New:  transform.MaskedValue,
+	})
+
+	vz.Generation = 3
+	vz.Spec.Components.PrometheusOperator.ValueOverrides[0].Values.Raw = []byte(`{"admin":{"password":"new-secret"}}`)
+	assert.NoError(t, r.recordHistory(vz))
+	entries = getHistoryEntries(t, c)
+	assert.Empty(t, entries[2].Changes)
+
+	cm := corev1.ConfigMap{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: history.ConfigMapName("my-vz")}, &cm))
+	for key, value := range cm.Data {
+		assert.NotContains(t, value, "my-secret", key)
+		assert.NotContains(t, value, "new-secret", key)
+	}
+}
+
+// getHistoryEntries returns the entries of the history ConfigMap of the test Verrazzano CR
+func getHistoryEntries(t *testing.T, c client.Client) []history.Entry {
+	cm := corev1.ConfigMap{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: history.ConfigMapName("my-vz")}, &cm))
+	assert.Len(t, cm.OwnerReferences, 1)
+	entries, err := history.GetEntries(&cm)
+	assert.NoError(t, err)
+	return entries
+}
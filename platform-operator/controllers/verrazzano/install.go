@@ -8,20 +8,26 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/semver"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	vzconst "github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/plugin"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	vzcontext "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/context"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/tracing"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 // reconcileComponents reconciles each component using the following rules:
-// 1. Always requeue until all enabled components have completed installation
-// 2. Don't update the component state until all the work in that state is done, since
-//    that update will cause a state transition
-// 3. Loop through all components before returning, except for the case
-//    where update status fails, in which case we exit the function and requeue
-//    immediately.
-func (r *Reconciler) reconcileComponents(vzctx vzcontext.VerrazzanoContext, preUpgrade bool) (ctrl.Result, error) {
+//  1. Always requeue until all enabled components have completed installation
+//  2. Don't update the component state until all the work in that state is done, since
+//     that update will cause a state transition
+//  3. Loop through all components before returning, except for the case
+//     where update status fails, in which case we exit the function and requeue
+//     immediately.
+func (r *Reconciler) reconcileComponents(vzctx vzcontext.VerrazzanoContext, preUpgrade bool) (result ctrl.Result, err error) {
+	ctx, span := tracing.StartSpan(vzctx.Ctx, "ReconcileComponents", tracing.GenerationKey.Int64(vzctx.ActualCR.Generation),
+		preUpgradeKey.Bool(preUpgrade))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	spiCtx, err := spi.NewContext(vzctx.Log, r.Client, vzctx.ActualCR, nil, r.DryRun)
 	if err != nil {
 		spiCtx.Log().Errorf("Failed to create component context: %v", err)
@@ -83,6 +89,28 @@ func (r *Reconciler) reconcileComponents(vzctx vzcontext.VerrazzanoContext, preU
 			if !isInstalled(cr.Status) {
 				continue
 			}
+			// Ensure the credentials of the component, which are rotated when the credentials version changes
+			if err := r.ensureCredentials(compContext, comp); err != nil {
+				return ctrl.Result{Requeue: true}, err
+			}
+			// Rotate the credentials of the component that are requested by the rotate credentials annotations
+			if err := r.rotateCredentials(compContext, comp); err != nil {
+				return ctrl.Result{Requeue: true}, err
+			}
+			// Keep the realm objects declared in the Verrazzano CR in sync with the declaration
+			if err := r.reconcileRealm(compContext, comp); err != nil {
+				return ctrl.Result{Requeue: true}, err
+			}
+			// Check the Helm release for drift, and reconcile the component if required by the drift policy
+			reconcileDrift, err := r.checkHelmDrift(compContext, comp)
+			if err != nil {
+				return ctrl.Result{Requeue: true}, err
+			}
+			if reconcileDrift {
+				if err := r.repairHelmDrift(compContext, comp); err != nil {
+					return newRequeueWithDelay(), err
+				}
+			}
 			// If the component config is updated, or the component is watched, it should be reconciled
 			if !checkConfigUpdated(spiCtx, componentStatus, compName) && !r.IsWatchedComponent(comp.GetJSONName()) {
 				continue
@@ -112,10 +140,10 @@ func (r *Reconciler) reconcileComponents(vzctx vzcontext.VerrazzanoContext, preU
 					comp.Name(), comp.GetMinVerrazzanoVersion())
 				continue
 			}
-			if cr.Status.State == vzapi.VzStateReady {
+			if cr.Status.State == vzapi.VzStateReady && !plugin.IsPluginComponent(comp) {
 				// This is the case where the component was previously disabled but is now enabled in the effective CR, so
 				// we need to prevent the component from being installed when the VPO is upgraded and wait for the user
-				// to initiate the upgrade via the VZ CR
+				// to initiate the upgrade via the VZ CR.  Component plugins are installed as soon as they are declared.
 				compLog.Oncef("Component %s was previously disabled and upgrade is not in progress, skipping install", compName)
 				continue
 			}
@@ -131,13 +159,13 @@ func (r *Reconciler) reconcileComponents(vzctx vzcontext.VerrazzanoContext, preU
 				continue
 			}
 			compLog.Progressf("Component %s pre-install is running ", compName)
-			if err := comp.PreInstall(compContext); err != nil {
+			if err := traceComponentOperation(ctx, compContext, "PreInstall", comp.PreInstall); err != nil {
 				requeue = true
 				continue
 			}
 			// If component is not installed,install it
 			compLog.Oncef("Component %s install started ", compName)
-			if err := comp.Install(compContext); err != nil {
+			if err := traceComponentOperation(ctx, compContext, "Install", comp.Install); err != nil {
 				requeue = true
 				continue
 			}
@@ -152,7 +180,7 @@ func (r *Reconciler) reconcileComponents(vzctx vzcontext.VerrazzanoContext, preU
 			// If component is in deployed state, continue
 			if comp.IsReady(compContext) {
 				compLog.Progressf("Component %s post-install is running ", compName)
-				if err := comp.PostInstall(compContext); err != nil {
+				if err := traceComponentOperation(ctx, compContext, "PostInstall", comp.PostInstall); err != nil {
 					requeue = true
 					continue
 				}
@@ -163,8 +191,11 @@ func (r *Reconciler) reconcileComponents(vzctx vzcontext.VerrazzanoContext, preU
 				// Don't requeue because of this component, it is done install
 				continue
 			}
-			// Install of this component is not done, requeue to check status
+			// Install of this component is not done, report what it is waiting for and requeue to check status
 			compLog.Progressf("Component %s waiting to finish installing", compName)
+			if err := r.updateComponentReadinessReport(compContext); err != nil {
+				return ctrl.Result{Requeue: true}, err
+			}
 			requeue = true
 		}
 	}
@@ -199,6 +199,10 @@ func testUpdate(t *testing.T,
 			ingressList.Items = []networkingv1.Ingress{}
 			return nil
 		}).AnyTimes()
+	// The mock is added to accomodate the expected call to List the certificates in istio-system to check them for expiry
+	mock.EXPECT().
+		List(gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any()).
+		Return(nil).AnyTimes()
 	mock.EXPECT().Status().Return(mockStatus).AnyTimes()
 	mockStatus.EXPECT().
 		Update(gomock.Any(), gomock.Any()).
@@ -0,0 +1,95 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"time"
+
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// realmReconcileInterval is how often the Keycloak realm configuration is reconciled to correct the drift of the realm
+// objects, when the Verrazzano CR does not change
+const realmReconcileInterval = 10 * time.Minute
+
+// realmReconciler is a component that keeps the objects of an identity realm in sync with the realm configuration of
+// the Verrazzano CR
+type realmReconciler interface {
+	// ReconcileRealm creates or updates the objects of the realm configuration, and returns what was done to each object
+	ReconcileRealm(ctx spi.ComponentContext) ([]installv1alpha1.KeycloakRealmObjectStatus, error)
+}
+
+// reconcileRealm reconciles the realm configuration of an installed component when the Verrazzano CR changes, and at
+// most once per reconcile interval otherwise.  The objects that were created or updated are recorded in the Verrazzano
+// CR status.  Failing to reconcile the realm does not block the reconcile of the components, the error is recorded in
+// the status and the realm is reconciled again after the interval.
+func (r *Reconciler) reconcileRealm(ctx spi.ComponentContext, comp spi.Component) error {
+	reconciler, ok := comp.(realmReconciler)
+	if !ok || r.DryRun {
+		return nil
+	}
+	cr := ctx.ActualCR()
+	previous := cr.Status.KeycloakRealm
+	if keycloak := ctx.EffectiveCR().Spec.Components.Keycloak; keycloak == nil || keycloak.Realm == nil {
+		if previous == nil {
+			return nil
+		}
+		cr.Status.KeycloakRealm = nil
+		return r.updateVerrazzanoStatus(ctx.Log(), cr)
+	}
+	if previous != nil && previous.ObservedGeneration == cr.Generation && previous.LastReconcileTime != nil &&
+		time.Since(previous.LastReconcileTime.Time) < realmReconcileInterval {
+		return nil
+	}
+
+	objects, err := reconciler.ReconcileRealm(ctx)
+	now := metav1.Now()
+	realm := &installv1alpha1.KeycloakRealmStatus{
+		ObservedGeneration: cr.Generation,
+		LastReconcileTime:  &now,
+	}
+	for _, object := range objects {
+		if object.State == installv1alpha1.KeycloakRealmObjectInSync {
+			object.LastUpdateTime = getRealmObjectLastUpdateTime(previous, object)
+		} else {
+			object.LastUpdateTime = &now
+		}
+		realm.Objects = append(realm.Objects, object)
+	}
+	if err != nil {
+		ctx.Log().Errorf("Failed to reconcile the realm configuration of component %s: %v", comp.Name(), err)
+		realm.Message = err.Error()
+	}
+	cr.Status.KeycloakRealm = realm
+	return r.updateVerrazzanoStatus(ctx.Log(), cr)
+}
+
+// getRealmObjectLastUpdateTime returns the time an object of the realm configuration was last created or updated, as
+// recorded by the previous reconcile
+func getRealmObjectLastUpdateTime(previous *installv1alpha1.KeycloakRealmStatus, object installv1alpha1.KeycloakRealmObjectStatus) *metav1.Time {
+	if previous == nil {
+		return nil
+	}
+	for _, previousObject := range previous.Objects {
+		if previousObject.Kind == object.Kind && previousObject.Name == object.Name {
+			return previousObject.LastUpdateTime
+		}
+	}
+	return nil
+}
+
+// realmRequeue returns the result that requeues the Verrazzano CR to reconcile the realm configuration, unless the
+// given result already requeues it sooner
+func realmRequeue(cr *installv1alpha1.Verrazzano, result ctrl.Result) ctrl.Result {
+	if cr.Spec.Components.Keycloak == nil || cr.Spec.Components.Keycloak.Realm == nil {
+		return result
+	}
+	if result.RequeueAfter == 0 || result.RequeueAfter > realmReconcileInterval {
+		result.RequeueAfter = realmReconcileInterval
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeRealmComponent is a component with a realm configuration, which counts the realm reconciles
+type fakeRealmComponent struct {
+	fakeComponent
+	reconciles *int
+	objects    []vzapi.KeycloakRealmObjectStatus
+	err        error
+}
+
+// ReconcileRealm counts the reconcile and returns the objects of the component
+func (f fakeRealmComponent) ReconcileRealm(_ spi.ComponentContext) ([]vzapi.KeycloakRealmObjectStatus, error) {
+	*f.reconciles++
+	return f.objects, f.err
+}
+
+// TestReconcileRealm tests reconciling the realm configuration of a component
+// GIVEN a component with a realm configuration in the Verrazzano CR
+// WHEN reconcileRealm is called, again within the interval, after the CR changes, and after the realm configuration
+// is removed
+// THEN the realm is reconciled on the first call and after the CR changes, the objects are recorded in the status with
+// the time they were last created or updated, and the status is cleared when the configuration is removed
+func TestReconcileRealm(t *testing.T) {
+	reconciles := 0
+	comp := fakeRealmComponent{fakeComponent: fakeComponent{HelmComponent: helm.HelmComponent{ReleaseName: "realm"}}, reconciles: &reconciles,
+		objects: []vzapi.KeycloakRealmObjectStatus{{Kind: "Role", Name: "role", State: vzapi.KeycloakRealmObjectCreated}}}
+	vz := &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Name: "verrazzano", Namespace: "default", Generation: 1},
+		Spec: vzapi.VerrazzanoSpec{Components: vzapi.ComponentSpec{Keycloak: &vzapi.KeycloakComponent{
+			Realm: &vzapi.KeycloakRealmConfig{Roles: []vzapi.KeycloakRole{{Name: "role"}}},
+		}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz).Build()
+	r := newVerrazzanoReconciler(c)
+	ctx := spi.NewFakeContext(c, vz, nil, false)
+
+	assert.NoError(t, r.reconcileRealm(ctx, comp))
+	assert.NoError(t, r.reconcileRealm(ctx, comp))
+	assert.Equal(t, 1, reconciles)
+	realm := vz.Status.KeycloakRealm
+	assert.Equal(t, int64(1), realm.ObservedGeneration)
+	assert.Len(t, realm.Objects, 1)
+	created := realm.Objects[0].LastUpdateTime
+	assert.NotNil(t, created)
+
+	vz.Generation = 2
+	comp.objects[0].State = vzapi.KeycloakRealmObjectInSync
+	assert.NoError(t, r.reconcileRealm(ctx, comp))
+	assert.Equal(t, 2, reconciles)
+	assert.Equal(t, vzapi.KeycloakRealmObjectInSync, vz.Status.KeycloakRealm.Objects[0].State)
+	assert.Equal(t, created, vz.Status.KeycloakRealm.Objects[0].LastUpdateTime)
+
+	assert.Equal(t, ctrl.Result{RequeueAfter: realmReconcileInterval}, realmRequeue(vz, ctrl.Result{}))
+	assert.Equal(t, ctrl.Result{RequeueAfter: time.Minute}, realmRequeue(vz, ctrl.Result{RequeueAfter: time.Minute}))
+
+	vz.Spec.Components.Keycloak.Realm = nil
+	assert.NoError(t, r.reconcileRealm(ctx, comp))
+	assert.Equal(t, 2, reconciles)
+	assert.Nil(t, vz.Status.KeycloakRealm)
+	assert.Equal(t, ctrl.Result{}, realmRequeue(vz, ctrl.Result{}))
+}
+
+// TestReconcileRealmFailed tests a failed reconcile of the realm configuration
+// GIVEN a component whose realm reconcile fails
+// WHEN reconcileRealm is called
+// THEN no error is returned, so that the other components are reconciled, and the failure is recorded in the status
+func TestReconcileRealmFailed(t *testing.T) {
+	reconciles := 0
+	comp := fakeRealmComponent{fakeComponent: fakeComponent{HelmComponent: helm.HelmComponent{ReleaseName: "realm"}}, reconciles: &reconciles,
+		err: errors.New("unavailable")}
+	vz := &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Name: "verrazzano", Namespace: "default"},
+		Spec: vzapi.VerrazzanoSpec{Components: vzapi.ComponentSpec{Keycloak: &vzapi.KeycloakComponent{
+			Realm: &vzapi.KeycloakRealmConfig{Roles: []vzapi.KeycloakRole{{Name: "role"}}},
+		}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz).Build()
+	r := newVerrazzanoReconciler(c)
+	ctx := spi.NewFakeContext(c, vz, nil, false)
+
+	assert.NoError(t, r.reconcileRealm(ctx, comp))
+	assert.Equal(t, 1, reconciles)
+	assert.Equal(t, "unavailable", vz.Status.KeycloakRealm.Message)
+
+	// A component without a realm configuration is skipped
+	assert.NoError(t, r.reconcileRealm(ctx, comp.fakeComponent))
+	assert.Equal(t, 1, reconciles)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// credentialsRotator is a component with generated credentials that can be rotated on request, which updates the
+// service that uses a credential and restarts the workloads that read it
+type credentialsRotator interface {
+	// GetRotatableCredentials returns the names of the credentials of the component that can be rotated
+	GetRotatableCredentials() []string
+	// RotateCredential rotates a credential, the request identifies the rotation and must be idempotent for a request
+	RotateCredential(ctx spi.ComponentContext, credential string, request string) error
+}
+
+// rotateCredentials rotates the credentials of an installed component that are requested by the rotate credentials
+// annotations of the Verrazzano CR, or by the credentials version annotation for all the credentials of the component.
+// A credential is rotated once for each request, and a failed rotation is retried.  The state and the time of the rotations are recorded in the Verrazzano CR status.
+func (r *Reconciler) rotateCredentials(ctx spi.ComponentContext, comp spi.Component) error {
+	rotator, ok := comp.(credentialsRotator)
+	if !ok || r.DryRun {
+		return nil
+	}
+	cr := ctx.ActualCR()
+	for _, credential := range rotator.GetRotatableCredentials() {
+		request := rotationRequest(cr, credential)
+		if len(request) == 0 {
+			continue
+		}
+		rotation := cr.Status.CredentialRotations[credential]
+		if rotation != nil && rotation.Request == request && rotation.State == installv1alpha1.CredentialRotationStateRotated {
+			continue
+		}
+		if rotation == nil {
+			rotation = &installv1alpha1.CredentialRotationStatus{}
+		}
+		if err := r.updateCredentialRotationStatus(ctx, credential, rotation, request, installv1alpha1.CredentialRotationStateRotating, ""); err != nil {
+			return err
+		}
+		ctx.Log().Oncef("Rotating credential %s of component %s for request %q", credential, comp.Name(), request)
+		if err := rotator.RotateCredential(ctx, credential, request); err != nil {
+			if err := r.updateCredentialRotationStatus(ctx, credential, rotation, request, installv1alpha1.CredentialRotationStateFailed, err.Error()); err != nil {
+				return err
+			}
+			return ctx.Log().ErrorfNewErr("Failed to rotate credential %s of component %s: %v", credential, comp.Name(), err)
+		}
+		now := metav1.Now()
+		rotation.LastRotationTime = &now
+		if err := r.updateCredentialRotationStatus(ctx, credential, rotation, request, installv1alpha1.CredentialRotationStateRotated, ""); err != nil {
+			return err
+		}
+		ctx.Log().Infof("Rotated credential %s of component %s for request %q", credential, comp.Name(), request)
+	}
+	return nil
+}
+
+// rotationRequest returns the request to rotate a credential, which combines the rotate credentials annotation of the
+// credential and the credentials version annotation of the Verrazzano CR, so that a change of either annotation
+// rotates the credential.  The request is empty if neither annotation is set.
+func rotationRequest(cr *installv1alpha1.Verrazzano, credential string) string {
+	request := cr.Annotations[globalconst.RotateCredentialsAnnotationPrefix+credential]
+	if version := cr.Annotations[globalconst.CredentialsVersionAnnotation]; len(version) > 0 {
+		if len(request) > 0 {
+			request += ","
+		}
+		request += "version-" + version
+	}
+	return request
+}
+
+// updateCredentialRotationStatus updates the status of the rotation of a credential in the Verrazzano CR
+func (r *Reconciler) updateCredentialRotationStatus(ctx spi.ComponentContext, credential string, rotation *installv1alpha1.CredentialRotationStatus,
+	request string, state installv1alpha1.CredentialRotationStateType, message string) error {
+	cr := ctx.ActualCR()
+	if cr.Status.CredentialRotations == nil {
+		cr.Status.CredentialRotations = map[string]*installv1alpha1.CredentialRotationStatus{}
+	}
+	rotation.Request = request
+	rotation.State = state
+	rotation.Message = message
+	cr.Status.CredentialRotations[credential] = rotation
+	return r.updateVerrazzanoStatus(ctx.Log(), cr)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeRotatorComponent is a component with a credential that can be rotated, which records the rotation requests
+type fakeRotatorComponent struct {
+	fakeComponent
+	requests *[]string
+	err      error
+}
+
+// GetRotatableCredentials returns the credential of the component
+func (f fakeRotatorComponent) GetRotatableCredentials() []string {
+	return []string{"fake"}
+}
+
+// RotateCredential records the rotation request
+func (f fakeRotatorComponent) RotateCredential(_ spi.ComponentContext, credential string, request string) error {
+	*f.requests = append(*f.requests, credential+"="+request)
+	return f.err
+}
+
+// TestRotateCredentials tests rotating the credentials of a component
+// GIVEN a component with a credential that can be rotated
+// WHEN rotateCredentials is called without a rotate credentials annotation, with an annotation, again with the same
+// annotation, with a new annotation value, and with a credentials version annotation
+// THEN the credential is rotated once for each annotation value, and the rotation status is recorded in the CR
+func TestRotateCredentials(t *testing.T) {
+	var requests []string
+	comp := fakeRotatorComponent{fakeComponent: fakeComponent{HelmComponent: helm.HelmComponent{ReleaseName: "creds"}}, requests: &requests}
+	vz := &vzapi.Verrazzano{ObjectMeta: metav1.ObjectMeta{Name: "verrazzano", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz).Build()
+	r := newVerrazzanoReconciler(c)
+	ctx := spi.NewFakeContext(c, vz, nil, false)
+
+	assert.NoError(t, r.rotateCredentials(ctx, comp))
+	assert.Empty(t, requests)
+
+	vz.Annotations = map[string]string{globalconst.RotateCredentialsAnnotationPrefix + "fake": "1"}
+	assert.NoError(t, r.rotateCredentials(ctx, comp))
+	assert.NoError(t, r.rotateCredentials(ctx, comp))
+	assert.Equal(t, []string{"fake=1"}, requests)
+
+	updated := &vzapi.Verrazzano{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "verrazzano", Namespace: "default"}, updated))
+	rotation := updated.Status.CredentialRotations["fake"]
+	assert.NotNil(t, rotation)
+	assert.Equal(t, "1", rotation.Request)
+	assert.Equal(t, vzapi.CredentialRotationStateRotated, rotation.State)
+	assert.NotNil(t, rotation.LastRotationTime)
+
+	vz.Annotations[globalconst.RotateCredentialsAnnotationPrefix+"fake"] = "2"
+	assert.NoError(t, r.rotateCredentials(ctx, comp))
+	assert.Equal(t, []string{"fake=1", "fake=2"}, requests)
+
+	// The credentials version annotation rotates the credential again
+	vz.Annotations[globalconst.CredentialsVersionAnnotation] = "3"
+	assert.NoError(t, r.rotateCredentials(ctx, comp))
+	assert.NoError(t, r.rotateCredentials(ctx, comp))
+	assert.Equal(t, []string{"fake=1", "fake=2", "fake=2,version-3"}, requests)
+
+	// A component without credentials that can be rotated is skipped
+	assert.NoError(t, r.rotateCredentials(ctx, comp.fakeComponent))
+}
+
+// TestRotateCredentialsFailed tests a failed rotation of a credential
+// GIVEN a component with a credential whose rotation fails
+// WHEN rotateCredentials is called twice with the same rotate credentials annotation
+// THEN an error is returned, the failure is recorded in the CR status, and the rotation is retried
+func TestRotateCredentialsFailed(t *testing.T) {
+	var requests []string
+	comp := fakeRotatorComponent{fakeComponent: fakeComponent{HelmComponent: helm.HelmComponent{ReleaseName: "creds"}},
+		requests: &requests, err: errors.New("unavailable")}
+	vz := &vzapi.Verrazzano{ObjectMeta: metav1.ObjectMeta{Name: "verrazzano", Namespace: "default",
+		Annotations: map[string]string{globalconst.RotateCredentialsAnnotationPrefix + "fake": "1"}}}
+	c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz).Build()
+	r := newVerrazzanoReconciler(c)
+	ctx := spi.NewFakeContext(c, vz, nil, false)
+
+	assert.Error(t, r.rotateCredentials(ctx, comp))
+	rotation := vz.Status.CredentialRotations["fake"]
+	assert.Equal(t, vzapi.CredentialRotationStateFailed, rotation.State)
+	assert.Equal(t, "unavailable", rotation.Message)
+	assert.Nil(t, rotation.LastRotationTime)
+
+	assert.Error(t, r.rotateCredentials(ctx, comp))
+	assert.Equal(t, []string{"fake=1", "fake=1"}, requests)
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// preUpgradeKey is the span attribute that is true when the components are reconciled before they are upgraded
+const preUpgradeKey = attribute.Key("verrazzano.preupgrade")
+
+// traceComponentOperation calls an SPI operation of a component in a span, which is a child of the span in the context
+func traceComponentOperation(ctx context.Context, compContext spi.ComponentContext, operation string, f func(spi.ComponentContext) error) error {
+	_, span := tracing.StartSpan(ctx, operation,
+		tracing.ComponentAttributes(compContext.GetComponent(), operation, compContext.ActualCR().Generation)...)
+	err := f(compContext)
+	tracing.EndSpan(span, err)
+	return err
+}
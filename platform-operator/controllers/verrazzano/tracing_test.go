@@ -0,0 +1,84 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzano
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/tracing"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/tracing/tracingtest"
+	"go.opentelemetry.io/otel/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestTraceComponentOperation tests tracing an SPI operation of a component
+// GIVEN tracing to an in-memory exporter
+// WHEN a component operation is traced within a parent span
+// THEN a child span is recorded with the component name, operation and CR generation
+func TestTraceComponentOperation(t *testing.T) {
+	exporter := tracingtest.InitInMemory()
+	defer tracing.Reset()
+
+	cr := &vzapi.Verrazzano{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+	compContext := spi.NewFakeContext(nil, cr, nil, false).Init("my-comp")
+	ctx, parent := tracing.StartSpan(context.TODO(), "ReconcileComponents")
+	called := false
+	err := traceComponentOperation(ctx, compContext, "Install", func(spi.ComponentContext) error {
+		called = true
+		return nil
+	})
+	tracing.EndSpan(parent, nil)
+	assert.NoError(t, err)
+	assert.True(t, called)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "Install", spans[0].Name)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	assert.Contains(t, spans[0].Attributes, tracing.ComponentKey.String("my-comp"))
+	assert.Contains(t, spans[0].Attributes, tracing.OperationKey.String("Install"))
+	assert.Contains(t, spans[0].Attributes, tracing.GenerationKey.Int64(3))
+}
+
+// TestUpgradeSingleComponentSpans tests the spans of a component upgrade
+// GIVEN tracing to an in-memory exporter and a component whose upgrade fails
+// WHEN upgradeSingleComponent is called
+// THEN an Upgrade span with the error is recorded as a child of an UpgradeComponent span, and no span is recorded
+// once the upgrade of the component has ended
+func TestUpgradeSingleComponentSpans(t *testing.T) {
+	exporter := tracingtest.InitInMemory()
+	defer tracing.Reset()
+
+	comp := fakeComponent{HelmComponent: helm.HelmComponent{ReleaseName: "my-comp"}}
+	comp.upgradeFunc = func(ctx spi.ComponentContext) error {
+		return errors.New("upgrade failed")
+	}
+	cli := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	spiCtx := spi.NewFakeContext(cli, &vzapi.Verrazzano{}, nil, false)
+	r := Reconciler{Client: cli}
+
+	result, err := r.upgradeSingleComponent(context.TODO(), spiCtx, &componentUpgradeContext{state: compStateUpgrade}, comp)
+	assert.NoError(t, err)
+	assert.True(t, result.Requeue)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "Upgrade", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "UpgradeComponent", spans[1].Name)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+
+	exporter.Reset()
+	_, err = r.upgradeSingleComponent(context.TODO(), spiCtx, &componentUpgradeContext{state: compStateEnd}, comp)
+	assert.NoError(t, err)
+	assert.Empty(t, exporter.GetSpans())
+}
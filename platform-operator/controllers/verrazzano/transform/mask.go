@@ -0,0 +1,78 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package transform
+
+import (
+	"encoding/json"
+)
+
+const (
+	// MaskedValue replaces the leaf values of the inline install overrides
+	MaskedValue = "******"
+
+	overridesField = "overrides"
+	valuesField    = "values"
+)
+
+// MaskOverrideValues returns a copy of an object that can be marshaled to JSON, where each leaf value of the inline
+// values of the install overrides is replaced with MaskedValue.  The inline values can hold credentials, the masked
+// copy keeps the paths of the values so the copy can be published.
+func MaskOverrideValues(obj interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	masked := map[string]interface{}{}
+	if err := json.Unmarshal(data, &masked); err != nil {
+		return nil, err
+	}
+	maskOverrides(masked)
+	return masked, nil
+}
+
+// maskOverrides masks the inline values of the install overrides found in the value
+func maskOverrides(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if overrides, ok := child.([]interface{}); ok && key == overridesField {
+				for _, override := range overrides {
+					if o, ok := override.(map[string]interface{}); ok && o[valuesField] != nil {
+						o[valuesField] = maskLeaves(o[valuesField])
+					}
+				}
+				continue
+			}
+			maskOverrides(child)
+		}
+	case []interface{}:
+		for _, item := range v {
+			maskOverrides(item)
+		}
+	}
+}
+
+// maskLeaves returns the value with each leaf value replaced with MaskedValue.  Empty maps and lists are kept, and the
+// name of a list item is kept since it identifies the item in the paths of its fields.
+func maskLeaves(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = maskLeaves(child)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			m, ok := item.(map[string]interface{})
+			name, isString := m["name"].(string)
+			v[i] = maskLeaves(item)
+			if ok && isString {
+				m["name"] = name
+			}
+		}
+		return v
+	default:
+		return MaskedValue
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// TestMaskOverrideValues tests masking the inline values of the install overrides
+// GIVEN a spec with inline override values of a component and of a nested component, and a ConfigMap override
+// WHEN MaskOverrideValues is called
+// THEN each leaf of the inline values is masked except the names of the list items, and the other fields and the
+// ConfigMap override are kept
+func TestMaskOverrideValues(t *testing.T) {
+	spec := v1beta1.VerrazzanoSpec{
+		EnvironmentName: "my-env",
+		Components: v1beta1.ComponentSpec{
+			Keycloak: &v1beta1.KeycloakComponent{
+				InstallOverrides: v1beta1.InstallOverrides{ValueOverrides: []v1beta1.Overrides{
+					{Values: &apiextensionsv1.JSON{Raw: []byte(`{"auth":{"adminPassword":"secret","realms":["a"],"empty":{}},"users":[{"name":"admin","password":"secret"}]}`)}},
+					{ConfigMapRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm"}, Key: "values.yaml"}},
+				}},
+				MySQL: v1beta1.MySQLComponent{InstallOverrides: v1beta1.InstallOverrides{ValueOverrides: []v1beta1.Overrides{
+					{Values: &apiextensionsv1.JSON{Raw: []byte(`{"rootPassword":"secret","replicas":3}`)}},
+				}}},
+			},
+		},
+	}
+	masked, err := MaskOverrideValues(map[string]interface{}{"spec": spec})
+	assert.NoError(t, err)
+	fields, err := FlattenFields(masked)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "my-env", fields["spec.environmentName"])
+	assert.Equal(t, MaskedValue, fields["spec.components.keycloak.overrides[0].values.auth.adminPassword"])
+	assert.Equal(t, MaskedValue, fields["spec.components.keycloak.overrides[0].values.auth.realms[0]"])
+	assert.Equal(t, map[string]interface{}{}, fields["spec.components.keycloak.overrides[0].values.auth.empty"])
+	assert.Equal(t, "admin", fields["spec.components.keycloak.overrides[0].values.users[name=admin].name"])
+	assert.Equal(t, MaskedValue, fields["spec.components.keycloak.overrides[0].values.users[name=admin].password"])
+	assert.Equal(t, "cm", fields["spec.components.keycloak.overrides[1].configMapRef.name"])
+	assert.Equal(t, MaskedValue, fields["spec.components.keycloak.mysql.overrides[0].values.rootPassword"])
+	assert.Equal(t, MaskedValue, fields["spec.components.keycloak.mysql.overrides[0].values.replicas"])
+	for path, value := range fields {
+		assert.NotEqual(t, "secret", value, path)
+	}
+}
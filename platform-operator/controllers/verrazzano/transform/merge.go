@@ -4,13 +4,10 @@
 package transform
 
 import (
-	"strings"
-
 	"github.com/verrazzano/verrazzano/pkg/constants"
 	vzprofiles "github.com/verrazzano/verrazzano/pkg/profiles"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
-	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 )
 
 const (
@@ -19,23 +16,19 @@ const (
 )
 
 // GetEffectiveCR Creates an "effective" v1alpha1.Verrazzano CR based on the user defined resource merged with the profile definitions
-// - Effective CR == base profile + declared profiles + ActualCR (in order)
+// - Effective CR == base profile + declared profiles, each after the profiles it extends + ActualCR (in order)
 // - last definition wins
 func GetEffectiveCR(actualCR *v1alpha1.Verrazzano) (*v1alpha1.Verrazzano, error) {
 	if actualCR == nil {
 		return nil, nil
 	}
-	// Identify the set of profiles, base + declared
-	profiles := []string{baseProfile, string(v1alpha1.Prod)}
-	if len(actualCR.Spec.Profile) > 0 {
-		profiles = append([]string{baseProfile}, strings.Split(string(actualCR.Spec.Profile), ",")...)
-	}
-	var profileFiles []string
-	for _, profile := range profiles {
-		profileFiles = append(profileFiles, config.GetProfile(v1alpha1.SchemeGroupVersion, profile))
+	// Resolve the extends chains of the profiles, base + declared, which may be user profiles
+	profileDocuments, err := vzprofiles.ResolveProfiles(getProfileSource(v1alpha1.SchemeGroupVersion), getProfileNames(string(actualCR.Spec.Profile))...)
+	if err != nil {
+		return nil, err
 	}
-	// Merge the profile files into an effective profile YAML string
-	effectiveCR, err := vzprofiles.MergeProfiles(actualCR, profileFiles...)
+	// Merge the profiles into an effective profile YAML string
+	effectiveCR, err := vzprofiles.MergeProfileDocuments(actualCR, profileDocuments...)
 	if err != nil {
 		return nil, err
 	}
@@ -52,27 +45,29 @@ func GetEffectiveCR(actualCR *v1alpha1.Verrazzano) (*v1alpha1.Verrazzano, error)
 }
 
 // GetEffectiveV1beta1CR Creates an "effective" v1beta1.Verrazzano CR based on the user defined resource merged with the profile definitions
-// - Effective CR == base profile + declared profiles + ActualCR (in order)
+// - Effective CR == base profile + declared profiles, each after the profiles it extends + ActualCR (in order)
 // - last definition wins
 func GetEffectiveV1beta1CR(actualCR *v1beta1.Verrazzano) (*v1beta1.Verrazzano, error) {
 	if actualCR == nil {
 		return nil, nil
 	}
-	// Identify the set of profiles, base + declared
-	profiles := []string{baseProfile, string(v1beta1.Prod)}
-	if len(actualCR.Spec.Profile) > 0 {
-		profiles = append([]string{baseProfile}, strings.Split(string(actualCR.Spec.Profile), ",")...)
-	}
-	var profileFiles []string
-	for _, profile := range profiles {
-		profileFiles = append(profileFiles, config.GetProfile(v1beta1.SchemeGroupVersion, profile))
+	// Resolve the extends chains of the profiles, base + declared, which may be user profiles
+	profileDocuments, err := vzprofiles.ResolveProfiles(getProfileSource(v1beta1.SchemeGroupVersion), getProfileNames(string(actualCR.Spec.Profile))...)
+	if err != nil {
+		return nil, err
 	}
-	// Merge the profile files into an effective profile YAML string
-	effectiveCR, err := vzprofiles.MergeProfilesForV1beta1(actualCR, profileFiles...)
+	// Merge the profiles into an effective profile YAML string
+	effectiveCR, err := vzprofiles.MergeProfileDocumentsForV1beta1(actualCR, profileDocuments...)
 	if err != nil {
 		return nil, err
 	}
 	effectiveCR.Status = v1beta1.VerrazzanoStatus{} // Don't replicate the CR status in the effective config
+	setV1beta1Defaults(effectiveCR)
+	return effectiveCR, nil
+}
+
+// setV1beta1Defaults sets the defaults of the effective v1beta1 CR that are not set by the profiles
+func setV1beta1Defaults(effectiveCR *v1beta1.Verrazzano) {
 	// if Certificate in CertManager is empty, set it to default CA
 	var emptyCertConfig = v1beta1.Certificate{}
 	if effectiveCR.Spec.Components.CertManager.Certificate == emptyCertConfig {
@@ -81,5 +76,13 @@ func GetEffectiveV1beta1CR(actualCR *v1beta1.Verrazzano) (*v1beta1.Verrazzano, e
 			ClusterResourceNamespace: constants.CertManagerNamespace,
 		}
 	}
-	return effectiveCR, nil
+}
+
+// getProfileNames returns the names of the profiles of a CR, base + declared, where the declared profiles are a comma
+// separated list that defaults to prod
+func getProfileNames(profile string) []string {
+	if len(profile) == 0 {
+		return []string{baseProfile, string(v1beta1.Prod)}
+	}
+	return append([]string{baseProfile}, vzprofiles.SplitProfileNames(profile)...)
 }
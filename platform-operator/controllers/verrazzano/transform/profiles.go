@@ -0,0 +1,124 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package transform
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+
+	vzprofiles "github.com/verrazzano/verrazzano/pkg/profiles"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UserProfiles are the documents of the user profiles, keyed by API version and then by profile name
+type UserProfiles map[string]map[string]string
+
+// userProfiles are the user profiles defined by the profile ConfigMaps
+var userProfiles = UserProfiles{}
+var userProfilesMutex sync.RWMutex
+
+// userProfilesClient lists the profile ConfigMaps when a user profile isn't cached
+var userProfilesClient clipkg.Client
+
+// ListUserProfiles lists the user profiles defined by the profile ConfigMaps in the verrazzano-install namespace.  The
+// profile name is the value of the profile label, and the ConfigMap has a <version>.yaml key with the profile document
+// for each API version, for example v1alpha1.yaml.
+func ListUserProfiles(cli clipkg.Client) (UserProfiles, error) {
+	cmList := corev1.ConfigMapList{}
+	if err := cli.List(context.TODO(), &cmList, clipkg.InNamespace(constants.VerrazzanoInstallNamespace),
+		clipkg.HasLabels{constants.ProfileLabel}); err != nil {
+		return nil, err
+	}
+	profiles := UserProfiles{}
+	for _, cm := range cmList.Items {
+		name := cm.Labels[constants.ProfileLabel]
+		for _, gv := range []schema.GroupVersion{v1alpha1.SchemeGroupVersion, v1beta1.SchemeGroupVersion} {
+			document, ok := cm.Data[gv.Version+".yaml"]
+			if !ok {
+				continue
+			}
+			if profiles[gv.Version] == nil {
+				profiles[gv.Version] = map[string]string{}
+			}
+			profiles[gv.Version][name] = document
+		}
+	}
+	return profiles, nil
+}
+
+// SetUserProfiles sets the user profiles defined by the profile ConfigMaps
+func SetUserProfiles(profiles UserProfiles) {
+	userProfilesMutex.Lock()
+	defer userProfilesMutex.Unlock()
+	userProfiles = profiles
+}
+
+// SetUserProfilesClient sets the client used to list the profile ConfigMaps when a user profile isn't cached.  The
+// cache is refreshed by the reconciles, so a profile ConfigMap created after the last reconcile, or a webhook that
+// runs without reconciles, needs to list them again.
+func SetUserProfilesClient(cli clipkg.Client) {
+	userProfilesMutex.Lock()
+	defer userProfilesMutex.Unlock()
+	userProfilesClient = cli
+}
+
+// ValidateV1alpha1Profile validates that a v1alpha1 profile exists, and that the profiles it extends exist
+func ValidateV1alpha1Profile(name string) error {
+	_, err := vzprofiles.ResolveProfiles(getProfileSource(v1alpha1.SchemeGroupVersion), name)
+	return err
+}
+
+// ValidateV1beta1Profile validates that a v1beta1 profile exists, and that the profiles it extends exist
+func ValidateV1beta1Profile(name string) error {
+	_, err := vzprofiles.ResolveProfiles(getProfileSource(v1beta1.SchemeGroupVersion), name)
+	return err
+}
+
+// getProfileSource returns the source of the profiles of the API version.  The built-in profiles can't be replaced,
+// then the profile ConfigMaps take precedence over the files in the user profiles dir.
+func getProfileSource(gv schema.GroupVersion) vzprofiles.ProfileSource {
+	builtIn := vzprofiles.FileProfileSource(filepath.Dir(config.GetProfile(gv, baseProfile)))
+	userFiles := vzprofiles.FileProfileSource(config.GetUserProfilesDir(gv))
+	return func(name string) (string, bool, error) {
+		if document, found, err := builtIn(name); found || err != nil {
+			return document, found, err
+		}
+		if document, found, err := lookupUserProfile(gv, name); found || err != nil {
+			return document, found, err
+		}
+		return userFiles(name)
+	}
+}
+
+// lookupUserProfile returns the document of a user profile defined by a profile ConfigMap.  The profile ConfigMaps are
+// listed again when the profile isn't cached and there is a client to list them.
+func lookupUserProfile(gv schema.GroupVersion, name string) (string, bool, error) {
+	document, found, cli := getUserProfile(gv, name)
+	if found || cli == nil {
+		return document, found, nil
+	}
+	profiles, err := ListUserProfiles(cli)
+	if err != nil {
+		return "", false, err
+	}
+	SetUserProfiles(profiles)
+	document, found = profiles[gv.Version][name]
+	return document, found, nil
+}
+
+// getUserProfile returns the cached document of a user profile defined by a profile ConfigMap, and the client used to
+// list the profile ConfigMaps
+func getUserProfile(gv schema.GroupVersion, name string) (string, bool, clipkg.Client) {
+	userProfilesMutex.RLock()
+	defer userProfilesMutex.RUnlock()
+	document, found := userProfiles[gv.Version][name]
+	return document, found, userProfilesClient
+}
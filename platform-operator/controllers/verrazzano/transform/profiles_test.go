@@ -0,0 +1,145 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package transform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	edgeProfile = `extends: dev
+spec:
+  environmentName: edge
+  components:
+    console:
+      enabled: false
+`
+	perfProfile = `extends: edge
+spec:
+  environmentName: perf
+`
+	edgeV1beta1Profile = `extends: dev
+spec:
+  environmentName: edge-v1beta1
+`
+)
+
+// setProfilesDirs sets the built-in profiles dir and a user profiles dir, and returns a function to restore them
+func setProfilesDirs(t *testing.T) func() {
+	oldConfig := config.Get()
+	userDir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(userDir, "v1alpha1"), 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(userDir, "v1alpha1", "perf-test.yaml"), []byte(perfProfile), 0600))
+	config.Set(config.OperatorConfig{UserProfilesDir: userDir})
+	config.TestProfilesDir = "../../../manifests/profiles"
+	return func() {
+		config.Set(oldConfig)
+		config.TestProfilesDir = ""
+		SetUserProfiles(UserProfiles{})
+		SetUserProfilesClient(nil)
+	}
+}
+
+// TestGetEffectiveCRUserProfiles tests getting the effective CR of a CR with user profiles
+// GIVEN a user profile in a profile ConfigMap that extends the dev profile, and a user profile file that extends it
+// WHEN the effective CR is created
+// THEN the profiles are merged after the profiles they extend, and the CR overrides the profiles
+func TestGetEffectiveCRUserProfiles(t *testing.T) {
+	defer setProfilesDirs(t)()
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "edge",
+			Namespace: constants.VerrazzanoInstallNamespace,
+			Labels:    map[string]string{constants.ProfileLabel: "edge"},
+		},
+		Data: map[string]string{
+			"v1alpha1.yaml": edgeProfile,
+			"v1beta1.yaml":  edgeV1beta1Profile,
+		},
+	}
+	profiles, err := ListUserProfiles(fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cm).Build())
+	assert.NoError(t, err)
+	SetUserProfiles(profiles)
+
+	effectiveCR, err := GetEffectiveCR(&v1alpha1.Verrazzano{Spec: v1alpha1.VerrazzanoSpec{Profile: "edge"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "edge", effectiveCR.Spec.EnvironmentName)
+	assert.False(t, *effectiveCR.Spec.Components.Console.Enabled)
+	// The dev profile uses emptyDir volumes
+	assert.NotNil(t, effectiveCR.Spec.DefaultVolumeSource.EmptyDir)
+
+	effectiveCR, err = GetEffectiveCR(&v1alpha1.Verrazzano{Spec: v1alpha1.VerrazzanoSpec{
+		Profile:         "perf-test",
+		EnvironmentName: "my-env",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, "my-env", effectiveCR.Spec.EnvironmentName)
+	assert.False(t, *effectiveCR.Spec.Components.Console.Enabled)
+
+	effectiveV1beta1CR, err := GetEffectiveV1beta1CR(&v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Profile: "edge"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "edge-v1beta1", effectiveV1beta1CR.Spec.EnvironmentName)
+
+	assert.NoError(t, ValidateV1alpha1Profile("perf-test"))
+	assert.Error(t, ValidateV1beta1Profile("perf-test"))
+}
+
+// TestValidateProfileListsUserProfiles tests validating a user profile that isn't cached
+// GIVEN a profile ConfigMap that was created after the user profiles were cached
+// WHEN the profile is validated
+// THEN the profile ConfigMaps are listed and the profile is valid
+func TestValidateProfileListsUserProfiles(t *testing.T) {
+	defer setProfilesDirs(t)()
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "edge",
+			Namespace: constants.VerrazzanoInstallNamespace,
+			Labels:    map[string]string{constants.ProfileLabel: "edge"},
+		},
+		Data: map[string]string{"v1alpha1.yaml": edgeProfile},
+	}
+	assert.Error(t, ValidateV1alpha1Profile("edge"))
+
+	SetUserProfilesClient(fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cm).Build())
+	assert.NoError(t, ValidateV1alpha1Profile("edge"))
+	assert.NoError(t, ValidateV1alpha1Profile("perf-test"))
+	assert.Error(t, ValidateV1beta1Profile("edge"))
+}
+
+// TestGetEffectiveCRMissingProfile tests getting the effective CR of a CR with a profile that does not exist
+// GIVEN a CR with a profile that does not exist
+// WHEN the effective CR is created
+// THEN an error is returned
+func TestGetEffectiveCRMissingProfile(t *testing.T) {
+	defer setProfilesDirs(t)()
+
+	_, err := GetEffectiveCR(&v1alpha1.Verrazzano{Spec: v1alpha1.VerrazzanoSpec{Profile: "missing"}})
+	assert.EqualError(t, err, "Profile missing does not exist")
+	assert.EqualError(t, ValidateV1alpha1Profile("perf-test"), "Profile edge, extended by profile perf-test, does not exist")
+}
+
+// TestBuiltInProfilesCannotBeReplaced tests that user profiles can't replace the built-in profiles
+// GIVEN a profile ConfigMap that defines a user profile named dev
+// WHEN the effective CR of a CR with the dev profile is created
+// THEN the built-in dev profile is used
+func TestBuiltInProfilesCannotBeReplaced(t *testing.T) {
+	defer setProfilesDirs(t)()
+	SetUserProfiles(UserProfiles{"v1alpha1": {"dev": "spec:\n  environmentName: replaced\n"}})
+
+	effectiveCR, err := GetEffectiveCR(&v1alpha1.Verrazzano{Spec: v1alpha1.VerrazzanoSpec{Profile: v1alpha1.Dev}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, "replaced", effectiveCR.Spec.EnvironmentName)
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package transform
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	vzprofiles "github.com/verrazzano/verrazzano/pkg/profiles"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// CRProvenance is the provenance of the fields that are set in the Verrazzano CR
+	CRProvenance = "Verrazzano CR"
+
+	// DefaultProvenance is the provenance of the fields that are defaulted by the platform operator
+	DefaultProvenance = "default"
+)
+
+// Provenance maps the path of each leaf field of an effective CR to the source of its value.  A path is the dot
+// separated field names, where a list item is identified by [name=<name>] if it has a name, or by its index otherwise,
+// for example spec.components.opensearch.nodes[name=es-master].replicas.
+type Provenance map[string]string
+
+// ProfileProvenance returns the provenance of the fields that are set by a profile
+func ProfileProvenance(profile string) string {
+	return "profile " + profile
+}
+
+// GetEffectiveV1beta1CRWithProvenance returns the effective v1beta1 CR, like GetEffectiveV1beta1CR, along with the
+// provenance of each leaf field of its spec.  The provenance of a field is the last of the base profile, the profiles
+// in merge order, and the Verrazzano CR that changed the value of the field, and fields that are set in the Verrazzano
+// CR always have the Verrazzano CR provenance.
+func GetEffectiveV1beta1CRWithProvenance(actualCR *v1beta1.Verrazzano) (*v1beta1.Verrazzano, Provenance, error) {
+	if actualCR == nil {
+		return nil, nil, nil
+	}
+	profiles, err := vzprofiles.ResolveNamedProfiles(getProfileSource(v1beta1.SchemeGroupVersion), getProfileNames(string(actualCR.Spec.Profile))...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Merge the profiles one at a time, to find the fields that are changed by each profile
+	provenance := Provenance{}
+	previous := map[string]interface{}{}
+	var profileDocuments []string
+	for _, profile := range profiles {
+		profileDocuments = append(profileDocuments, profile.Document)
+		merged, err := vzprofiles.MergeProfileDocumentsForV1beta1(&v1beta1.Verrazzano{}, profileDocuments...)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields, err := flattenSpec(merged.Spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		provenance.update(previous, fields, ProfileProvenance(profile.Name))
+		previous = fields
+	}
+
+	// Merge the Verrazzano CR on top of the profiles
+	effectiveCR, err := vzprofiles.MergeProfileDocumentsForV1beta1(actualCR, profileDocuments...)
+	if err != nil {
+		return nil, nil, err
+	}
+	effectiveCR.Status = v1beta1.VerrazzanoStatus{} // Don't replicate the CR status in the effective config
+	fields, err := flattenSpec(effectiveCR.Spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	provenance.update(previous, fields, CRProvenance)
+	crFields, err := flattenSpec(actualCR.Spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	for path, value := range crFields {
+		if reflect.DeepEqual(fields[path], value) {
+			provenance[path] = CRProvenance
+		}
+	}
+
+	// Finally set the defaults
+	setV1beta1Defaults(effectiveCR)
+	previous = fields
+	fields, err = flattenSpec(effectiveCR.Spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	provenance.update(previous, fields, DefaultProvenance)
+	return effectiveCR, provenance, nil
+}
+
+// AnnotateYAML returns the YAML of an object, with the provenance of each leaf field as a line comment
+func AnnotateYAML(obj interface{}, provenance Provenance) (string, error) {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	doc := yamlv3.Node{}
+	if err := yamlv3.Unmarshal(data, &doc); err != nil {
+		return "", err
+	}
+	for _, node := range doc.Content {
+		annotateNode(node, "", provenance)
+	}
+	buf := bytes.Buffer{}
+	encoder := yamlv3.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// FlattenFields returns the leaf fields of an object that can be marshaled to JSON, keyed by path.  Empty maps and
+// lists are leaf fields.
+func FlattenFields(obj interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	flatten(fields, "", value)
+	return fields, nil
+}
+
+// update sets the provenance of the fields that are added or changed from the previous fields, and removes the
+// provenance of the fields that no longer exist
+func (p Provenance) update(previous map[string]interface{}, fields map[string]interface{}, source string) {
+	for path, value := range fields {
+		if prev, ok := previous[path]; !ok || !reflect.DeepEqual(prev, value) {
+			p[path] = source
+		}
+	}
+	for path := range p {
+		if _, ok := fields[path]; !ok {
+			delete(p, path)
+		}
+	}
+}
+
+// flattenSpec returns the leaf fields of a CR spec keyed by path
+func flattenSpec(spec v1beta1.VerrazzanoSpec) (map[string]interface{}, error) {
+	fields, err := FlattenFields(spec)
+	if err != nil {
+		return nil, err
+	}
+	specFields := map[string]interface{}{}
+	for path, value := range fields {
+		specFields[childPath("spec", path)] = value
+	}
+	return specFields, nil
+}
+
+// flatten adds the leaf fields of the value to the fields, keyed by path
+func flatten(fields map[string]interface{}, path string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			fields[path] = v
+		}
+		for key, child := range v {
+			flatten(fields, childPath(path, key), child)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			fields[path] = v
+		}
+		for i, item := range v {
+			name := ""
+			if m, ok := item.(map[string]interface{}); ok {
+				name, _ = m["name"].(string)
+			}
+			flatten(fields, itemPath(path, i, name), item)
+		}
+	default:
+		fields[path] = v
+	}
+}
+
+// annotateNode sets the provenance of the leaf nodes as line comments
+func annotateNode(node *yamlv3.Node, path string, provenance Provenance) {
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			annotateNode(node.Content[i+1], childPath(path, node.Content[i].Value), provenance)
+		}
+	case yamlv3.SequenceNode:
+		for i, item := range node.Content {
+			annotateNode(item, itemPath(path, i, getNodeName(item)), provenance)
+		}
+	}
+	if isLeafNode(node) {
+		if source, ok := provenance[path]; ok {
+			node.LineComment = "# " + source
+		}
+	}
+}
+
+// isLeafNode returns true if the node is a scalar, or an empty map or list
+func isLeafNode(node *yamlv3.Node) bool {
+	return node.Kind == yamlv3.ScalarNode || len(node.Content) == 0
+}
+
+// getNodeName returns the value of the name field of a map node, or an empty string
+func getNodeName(node *yamlv3.Node) string {
+	if node.Kind != yamlv3.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "name" && node.Content[i+1].Kind == yamlv3.ScalarNode {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// childPath returns the path of a field of a map
+func childPath(path string, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	if len(key) == 0 {
+		return path
+	}
+	return path + "." + key
+}
+
+// itemPath returns the path of an item of a list
+func itemPath(path string, index int, name string) string {
+	if len(name) > 0 {
+		return fmt.Sprintf("%s[name=%s]", path, name)
+	}
+	return fmt.Sprintf("%s[%d]", path, index)
+}
+
+// HasPathPrefix returns true if the path is the prefix path, or a path of a field within the prefix path
+func HasPathPrefix(path string, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+".") || strings.HasPrefix(path, prefix+"[")
+}
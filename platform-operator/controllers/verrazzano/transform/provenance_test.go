@@ -0,0 +1,64 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+)
+
+// TestGetEffectiveV1beta1CRWithProvenance tests getting the provenance of the fields of the effective CR
+// GIVEN a CR with a user profile that extends the dev profile, and a field that is set in the CR
+// WHEN the effective CR is created with its provenance
+// THEN each field is attributed to the last profile or CR that set it, or to the defaults
+func TestGetEffectiveV1beta1CRWithProvenance(t *testing.T) {
+	defer setProfilesDirs(t)()
+	SetUserProfiles(UserProfiles{"v1beta1": {"edge": edgeV1beta1Profile}})
+
+	cr := &v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Profile: "edge", Version: "1.4.0"}}
+	effectiveCR, provenance, err := GetEffectiveV1beta1CRWithProvenance(cr)
+	assert.NoError(t, err)
+	expected, err := GetEffectiveV1beta1CR(cr)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Spec, effectiveCR.Spec)
+
+	assert.Equal(t, ProfileProvenance("edge"), provenance["spec.environmentName"])
+	assert.Equal(t, CRProvenance, provenance["spec.profile"])
+	assert.Equal(t, CRProvenance, provenance["spec.version"])
+	assert.Equal(t, ProfileProvenance("dev"), provenance["spec.defaultVolumeSource.emptyDir"])
+	assert.Equal(t, ProfileProvenance("base"), provenance["spec.components.certManager.certificate.ca.secretName"])
+	fields, err := FlattenFields(effectiveCR)
+	assert.NoError(t, err)
+	for path := range provenance {
+		_, ok := fields[path]
+		assert.True(t, ok, "provenance of %s is not a field of the effective CR", path)
+	}
+
+	annotated, err := AnnotateYAML(effectiveCR, provenance)
+	assert.NoError(t, err)
+	assert.Contains(t, annotated, "environmentName: edge-v1beta1 # profile edge")
+	assert.Contains(t, annotated, "version: 1.4.0 # Verrazzano CR")
+}
+
+// TestFlattenFields tests flattening an object to its leaf fields
+// GIVEN an object with nested maps, named and unnamed list items, and empty maps
+// WHEN the object is flattened
+// THEN the leaf fields are keyed by path
+func TestFlattenFields(t *testing.T) {
+	fields, err := FlattenFields(map[string]interface{}{
+		"nodes":  []interface{}{map[string]interface{}{"name": "master", "replicas": 3}, "other"},
+		"labels": map[string]interface{}{},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"nodes[name=master].name":     "master",
+		"nodes[name=master].replicas": float64(3),
+		"nodes[1]":                    "other",
+		"labels":                      map[string]interface{}{},
+	}, fields)
+	assert.True(t, HasPathPrefix("nodes[name=master].replicas", "nodes"))
+	assert.False(t, HasPathPrefix("nodesFoo", "nodes"))
+}
@@ -69,7 +69,7 @@ type upgradeTracker struct {
 var upgradeTrackerMap = make(map[string]*upgradeTracker)
 
 // reconcileUpgrade will upgrade a Verrazzano installation
-func (r *Reconciler) reconcileUpgrade(log vzlog.VerrazzanoLogger, cr *installv1alpha1.Verrazzano) (ctrl.Result, error) {
+func (r *Reconciler) reconcileUpgrade(ctx context.Context, log vzlog.VerrazzanoLogger, cr *installv1alpha1.Verrazzano) (ctrl.Result, error) {
 	log.Oncef("Upgrading Verrazzano to version %s", cr.Spec.Version)
 
 	// Upgrade version was validated in webhook, see ValidateVersion
@@ -92,7 +92,7 @@ func (r *Reconciler) reconcileUpgrade(log vzlog.VerrazzanoLogger, cr *installv1a
 		case vzStateUpgradeComponents:
 			// Upgrade the components
 			log.Once("Upgrading all Verrazzano components")
-			res, err := r.upgradeComponents(log, cr, tracker)
+			res, err := r.upgradeComponents(ctx, log, cr, tracker)
 			if err != nil || res.Requeue {
 				return res, err
 			}
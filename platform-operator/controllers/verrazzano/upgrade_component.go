@@ -4,6 +4,7 @@
 package verrazzano
 
 import (
+	"context"
 	"time"
 
 	"github.com/verrazzano/verrazzano/pkg/controller"
@@ -12,6 +13,8 @@ import (
 	vzconst "github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/tracing"
+	"github.com/verrazzano/verrazzano/platform-operator/metricsexporter"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -47,7 +50,7 @@ type componentUpgradeContext struct {
 }
 
 // upgradeComponents will upgrade the components as required
-func (r *Reconciler) upgradeComponents(log vzlog.VerrazzanoLogger, cr *installv1alpha1.Verrazzano, tracker *upgradeTracker) (ctrl.Result, error) {
+func (r *Reconciler) upgradeComponents(ctx context.Context, log vzlog.VerrazzanoLogger, cr *installv1alpha1.Verrazzano, tracker *upgradeTracker) (ctrl.Result, error) {
 	spiCtx, err := spi.NewContext(log, r.Client, cr, nil, r.DryRun)
 	if err != nil {
 		return newRequeueWithDelay(), err
@@ -57,7 +60,7 @@ func (r *Reconciler) upgradeComponents(log vzlog.VerrazzanoLogger, cr *installv1
 	// Don't move to the next component until the current one has been succcessfully upgraded
 	for _, comp := range registry.GetComponents() {
 		upgradeContext := tracker.getComponentUpgradeContext(comp.Name())
-		result, err := r.upgradeSingleComponent(spiCtx, upgradeContext, comp)
+		result, err := r.upgradeSingleComponent(ctx, spiCtx, upgradeContext, comp)
 		if err != nil || result.Requeue {
 			return result, err
 		}
@@ -68,11 +71,17 @@ func (r *Reconciler) upgradeComponents(log vzlog.VerrazzanoLogger, cr *installv1
 }
 
 // upgradeSingleComponent upgrades a single component
-func (r *Reconciler) upgradeSingleComponent(spiCtx spi.ComponentContext, upgradeContext *componentUpgradeContext, comp spi.Component) (ctrl.Result, error) {
+func (r *Reconciler) upgradeSingleComponent(ctx context.Context, spiCtx spi.ComponentContext, upgradeContext *componentUpgradeContext, comp spi.Component) (result ctrl.Result, err error) {
+	if upgradeContext.state == compStateEnd {
+		return ctrl.Result{}, nil
+	}
 	compName := comp.Name()
 	compContext := spiCtx.Init(compName).Operation(vzconst.UpgradeOperation)
 	compLog := compContext.Log()
 
+	ctx, span := tracing.StartSpan(ctx, "UpgradeComponent", tracing.ComponentAttributes(compName, string(upgradeContext.state), spiCtx.ActualCR().Generation)...)
+	defer func() { tracing.EndSpan(span, err) }()
+
 	for upgradeContext.state != compStateEnd {
 		switch upgradeContext.state {
 		case compStateInit:
@@ -95,16 +104,18 @@ func (r *Reconciler) upgradeSingleComponent(spiCtx spi.ComponentContext, upgrade
 
 		case compStatePreUpgrade:
 			compLog.Oncef("Component %s pre-upgrade running", compName)
-			if err := comp.PreUpgrade(compContext); err != nil {
+			if err := traceComponentOperation(ctx, compContext, "PreUpgrade", comp.PreUpgrade); err != nil {
 				compLog.Errorf("Failed pre-upgrading component %s: %v", compName, err)
+				incrementUpgradeFailures(compLog, compName)
 				return ctrl.Result{}, err
 			}
 			upgradeContext.state = compStateUpgrade
 
 		case compStateUpgrade:
 			compLog.Progressf("Component %s upgrade running", compName)
-			if err := comp.Upgrade(compContext); err != nil {
+			if err := traceComponentOperation(ctx, compContext, "Upgrade", comp.Upgrade); err != nil {
 				compLog.Errorf("Failed upgrading component %s, will retry: %v", compName, err)
+				incrementUpgradeFailures(compLog, compName)
 				// check to see whether this is due to a pending upgrade
 				r.resolvePendingUpgrades(compName, compLog)
 				// requeue for 30 to 60 seconds later
@@ -115,6 +126,9 @@ func (r *Reconciler) upgradeSingleComponent(spiCtx spi.ComponentContext, upgrade
 		case compStateWaitReady:
 			if !comp.IsReady(compContext) {
 				compLog.Progressf("Component %s has been upgraded. Waiting for the component to be ready", compName)
+				if err := r.updateComponentReadinessReport(compContext); err != nil {
+					return ctrl.Result{Requeue: true}, err
+				}
 				return newRequeueWithDelay(), nil
 			}
 			compLog.Progressf("Component %s is ready after being upgraded", compName)
@@ -122,7 +136,8 @@ func (r *Reconciler) upgradeSingleComponent(spiCtx spi.ComponentContext, upgrade
 
 		case compStatePostUpgrade:
 			compLog.Oncef("Component %s post-upgrade running", compName)
-			if err := comp.PostUpgrade(compContext); err != nil {
+			if err := traceComponentOperation(ctx, compContext, "PostUpgrade", comp.PostUpgrade); err != nil {
+				incrementUpgradeFailures(compLog, compName)
 				return ctrl.Result{}, err
 			}
 			upgradeContext.state = compStateUpgradeDone
@@ -139,6 +154,16 @@ func (r *Reconciler) upgradeSingleComponent(spiCtx spi.ComponentContext, upgrade
 	return ctrl.Result{}, nil
 }
 
+// incrementUpgradeFailures increments the upgrade failures metric of a component
+func incrementUpgradeFailures(log vzlog.VerrazzanoLogger, compName string) {
+	failures, err := metricsexporter.GetComponentCounterMetric(metricsexporter.ComponentUpgradeFailures)
+	if err != nil {
+		log.Errorf("Failed to get the component upgrade failures metric: %v", err)
+		return
+	}
+	failures.Inc(compName)
+}
+
 // getComponentUpgradeContext gets the upgrade context for the component
 func (vuc *upgradeTracker) getComponentUpgradeContext(compName string) *componentUpgradeContext {
 	context, ok := vuc.compMap[compName]
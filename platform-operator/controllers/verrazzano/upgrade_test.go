@@ -155,7 +155,7 @@ func TestUpgradeNoVersion(t *testing.T) {
 	// Validate the results
 	asserts.NoError(err)
 	asserts.Equal(false, result.Requeue)
-	asserts.Equal(time.Duration(0), result.RequeueAfter)
+	asserts.Equal(defaultHelmDriftInterval, result.RequeueAfter)
 	verrazzano := vzapi.Verrazzano{}
 	err = c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, &verrazzano)
 	asserts.NoError(err)
@@ -255,7 +255,7 @@ func TestUpgradeSameVersion(t *testing.T) {
 	// Validate the results
 	asserts.NoError(err)
 	asserts.Equal(false, result.Requeue)
-	asserts.Equal(time.Duration(0), result.RequeueAfter)
+	asserts.Equal(defaultHelmDriftInterval, result.RequeueAfter)
 	verrazzano := vzapi.Verrazzano{}
 	err = c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, &verrazzano)
 	asserts.NoError(err)
@@ -1001,7 +1001,7 @@ func TestUpgradeComponent(t *testing.T) {
 	numComponentStates := 10
 	var result ctrl.Result
 	for i := 0; i < numComponentStates; i++ {
-		result, err = reconciler.reconcileUpgrade(vzlog.DefaultLogger(), &vz)
+		result, err = reconciler.reconcileUpgrade(context.TODO(), vzlog.DefaultLogger(), &vz)
 		if err != nil || !result.Requeue {
 			break
 		}
@@ -1710,7 +1710,7 @@ func TestInstanceRestoreWithEmptyStatus(t *testing.T) {
 	// Validate the results
 	asserts.NoError(err)
 	asserts.Equal(false, result.Requeue)
-	asserts.Equal(time.Duration(0), result.RequeueAfter)
+	asserts.Equal(defaultHelmDriftInterval, result.RequeueAfter)
 
 	// validating instance urls are updated
 	// Status is empty in this case
@@ -1883,7 +1883,7 @@ func TestInstanceRestoreWithPopulatedStatus(t *testing.T) {
 	// Validate the results
 	asserts.NoError(err)
 	asserts.Equal(false, result.Requeue)
-	asserts.Equal(time.Duration(0), result.RequeueAfter)
+	asserts.Equal(defaultHelmDriftInterval, result.RequeueAfter)
 
 	// validating instance urls are updated
 	fakeInstanceInfo := vzapi.InstanceInfo{}
@@ -1954,7 +1954,7 @@ func reconcileUpgradeLoop(reconciler Reconciler, cr *vzapi.Verrazzano) (ctrl.Res
 	var err error
 	var result ctrl.Result
 	for i := 0; i < numComponentStates; i++ {
-		result, err = reconciler.reconcileUpgrade(vzlog.DefaultLogger(), cr)
+		result, err = reconciler.reconcileUpgrade(context.TODO(), vzlog.DefaultLogger(), cr)
 		if err != nil || !result.Requeue {
 			break
 		}
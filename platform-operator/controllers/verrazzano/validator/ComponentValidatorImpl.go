@@ -31,7 +31,7 @@ func (c ComponentValidatorImpl) ValidateInstall(vz *v1alpha1.Verrazzano) []error
 		}
 	}
 
-	return errs
+	return append(errs, validateV1alpha1OverridesSchema(vz)...)
 }
 
 func (c ComponentValidatorImpl) ValidateInstallV1Beta1(vz *v1beta1.Verrazzano) []error {
@@ -49,7 +49,7 @@ func (c ComponentValidatorImpl) ValidateInstallV1Beta1(vz *v1beta1.Verrazzano) [
 		}
 	}
 
-	return errs
+	return append(errs, validateOverridesSchema(vz)...)
 }
 
 func (c ComponentValidatorImpl) ValidateUpdate(old *v1alpha1.Verrazzano, new *v1alpha1.Verrazzano) []error {
@@ -71,7 +71,7 @@ func (c ComponentValidatorImpl) ValidateUpdate(old *v1alpha1.Verrazzano, new *v1
 			errs = append(errs, err)
 		}
 	}
-	return errs
+	return append(errs, validateV1alpha1OverridesSchema(new)...)
 }
 
 func (c ComponentValidatorImpl) ValidateUpdateV1Beta1(old *v1beta1.Verrazzano, new *v1beta1.Verrazzano) []error {
@@ -93,5 +93,5 @@ func (c ComponentValidatorImpl) ValidateUpdateV1Beta1(old *v1beta1.Verrazzano, n
 			errs = append(errs, err)
 		}
 	}
-	return errs
+	return append(errs, validateOverridesSchema(new)...)
 }
@@ -0,0 +1,120 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package validator
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/verrazzano/verrazzano/pkg/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/validators"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
+	"go.uber.org/zap"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// chartComponent is a component that installs a Helm chart, where the install overrides are values of the chart
+type chartComponent interface {
+	GetChartDir() string
+}
+
+// getClientFunc returns the client used to read the ConfigMaps and Secrets referenced by install overrides
+var getClientFunc = defaultGetClientFunc
+
+// logWarningFunc logs the override values that may not be valid
+var logWarningFunc = defaultLogWarningFunc
+
+// defaultLogWarningFunc logs a warning of the webhook
+func defaultLogWarningFunc(template string, args ...interface{}) {
+	zap.S().With("source", "webhook").Warnf(template, args...)
+}
+
+// defaultGetClientFunc returns a client of the cluster
+func defaultGetClientFunc() (client.Client, error) {
+	return validators.GetClient(clientgoscheme.Scheme)
+}
+
+// validateV1alpha1OverridesSchema validates the install overrides of a v1alpha1 Verrazzano CR against the values
+// schemas of the component charts
+func validateV1alpha1OverridesSchema(vz *v1alpha1.Verrazzano) []error {
+	v1beta1CR := &v1beta1.Verrazzano{}
+	if err := vz.ConvertTo(v1beta1CR); err != nil {
+		return []error{err}
+	}
+	return validateOverridesSchema(v1beta1CR)
+}
+
+// validateOverridesSchema validates the install overrides of the enabled components against the values schemas of
+// the component charts, reporting invalid values with the component name and the override source.  Only the
+// values.schema.json files shipped with the charts are enforced, the unknown keys and type mismatches found with
+// the schemas generated from the default values of the other charts are logged as warnings, since the default values
+// don't always declare every value the templates use.  Templated values are rendered with placeholder facts,
+// reporting unknown template variables.  Overrides that reference a ConfigMap or Secret that can't be read are not
+// validated, they are reported when the component is installed.
+func validateOverridesSchema(vz *v1beta1.Verrazzano) []error {
+	effectiveCR, err := transform.GetEffectiveV1beta1CR(vz)
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	var cli client.Client
+	for _, comp := range registry.GetComponents() {
+		chartComp, ok := comp.(chartComponent)
+		if !ok || !isChartDir(chartComp.GetChartDir()) || !comp.IsEnabled(effectiveCR) {
+			continue
+		}
+		overrides, _ := comp.GetOverrides(effectiveCR).([]v1beta1.Overrides)
+		for i, override := range overrides {
+			if cli == nil && (override.ConfigMapRef != nil || override.SecretRef != nil) {
+				if cli, err = getClientFunc(); err != nil {
+					return append(errs, err)
+				}
+			}
+			// Read the values without rendering the template first, so that only template errors are reported
+			source := common.GetOverrideSource(override, effectiveCR.Namespace)
+			untemplated := override
+			untemplated.Template = false
+			if _, err := common.GetInstallOverridesYAMLUsingClient(cli, []v1beta1.Overrides{untemplated}, effectiveCR.Namespace); err != nil {
+				continue
+			}
+			values, err := common.GetInstallOverridesYAMLUsingClient(cli, []v1beta1.Overrides{override}, effectiveCR.Namespace)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("Invalid install override %d of component %s: %v", i, comp.Name(), err))
+				continue
+			}
+			for _, value := range values {
+				if err := validateOverrideValues(chartComp.GetChartDir(), value, helm.ValidateValues); err != nil {
+					errs = append(errs, fmt.Errorf("Invalid install override %d of component %s from %s:\n%v", i, comp.Name(), source, err))
+				} else if err := validateOverrideValues(chartComp.GetChartDir(), value, helm.CheckValues); err != nil {
+					logWarningFunc("Install override %d of component %s from %s may not be valid, the values are not declared by the chart:\n%v", i, comp.Name(), source, err)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// validateOverrideValues validates the YAML values of an override against the values schemas of a chart
+func validateOverrideValues(chartDir string, value string, validateFunc func(string, map[string]interface{}) error) error {
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(value), &values); err != nil {
+		return err
+	}
+	return validateFunc(chartDir, values)
+}
+
+// isChartDir returns true if the chart directory exists
+func isChartDir(chartDir string) bool {
+	if len(chartDir) == 0 {
+		return false
+	}
+	_, err := os.Stat(chartDir)
+	return err == nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package validator
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+const schemaChartDir = "../../../../pkg/helm/testdata/schemachart"
+
+// newOverridesComponent returns a Helm component of the test chart with the given install overrides
+func newOverridesComponent(overrides ...v1beta1.Overrides) spi.Component {
+	return helm.HelmComponent{
+		ReleaseName: "schemachart",
+		ChartDir:    schemaChartDir,
+		GetInstallOverridesFunc: func(object runtime.Object) interface{} {
+			if _, ok := object.(*v1beta1.Verrazzano); ok {
+				return overrides
+			}
+			return []vzapi.Overrides{}
+		},
+	}
+}
+
+// TestValidateOverridesSchema tests validating install overrides against the values schema of a chart
+// GIVEN a component with valid inline and templated overrides, an override in a ConfigMap that is invalid for the
+// values.schema.json of a dependency, inline overrides with keys and types not declared by the default values, and a
+// templated override with an unknown variable
+// WHEN the CR is validated
+// THEN the overrides that are invalid for the values.schema.json and the template are reported with the component
+// name and the override source, and the overrides not declared by the default values are logged as warnings
+func TestValidateOverridesSchema(t *testing.T) {
+	config.TestProfilesDir = "../../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "overrides", Namespace: "default"},
+		Data:       map[string]string{"values.yaml": "sub:\n  port: http\n"},
+	}
+	getClientFunc = func() (client.Client, error) {
+		return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cm).Build(), nil
+	}
+	defer func() { getClientFunc = defaultGetClientFunc }()
+	var warnings []string
+	logWarningFunc = func(template string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(template, args...))
+	}
+	defer func() { logWarningFunc = defaultLogWarningFunc }()
+	registry.OverrideGetComponentsFn(func() []spi.Component {
+		return []spi.Component{newOverridesComponent(
+			v1beta1.Overrides{Values: &apiextensionsv1.JSON{Raw: []byte(`{"replicas": 3}`)}},
+			v1beta1.Overrides{ConfigMapRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "overrides"},
+				Key:                  "values.yaml",
+			}},
+			v1beta1.Overrides{Values: &apiextensionsv1.JSON{Raw: []byte(`{"enabled": "maybe"}`)}},
+			v1beta1.Overrides{Values: &apiextensionsv1.JSON{Raw: []byte(`{"image": {"repository": "{{ .DNSDomain }}"}}`)}, Template: true},
+			v1beta1.Overrides{Values: &apiextensionsv1.JSON{Raw: []byte(`{"image": {"repository": "{{ .Domain }}"}}`)}, Template: true},
+			v1beta1.Overrides{Values: &apiextensionsv1.JSON{Raw: []byte(`{"image": {"tags": "latest"}}`)}},
+		)}
+	})
+	defer registry.ResetGetComponentsFn()
+
+	vz := &v1beta1.Verrazzano{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+	errs := validateOverridesSchema(vz)
+	assert.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), "Invalid install override 1 of component schemachart from ConfigMap default/overrides key values.yaml")
+	assert.Contains(t, errs[0].Error(), "port: Invalid type")
+	assert.Contains(t, errs[1].Error(), "Invalid install override 4 of component schemachart")
+	assert.Contains(t, errs[1].Error(), "Domain")
+	assert.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0], "Install override 2 of component schemachart from inline values")
+	assert.Contains(t, warnings[0], "enabled: Invalid type")
+	assert.Contains(t, warnings[1], "Install override 5 of component schemachart from inline values")
+	assert.Contains(t, warnings[1], "image: Additional property tags is not allowed")
+
+	errs = ComponentValidatorImpl{}.ValidateInstallV1Beta1(vz)
+	assert.Len(t, errs, 2)
+}
+
+// TestValidateOverridesSchemaMissingConfigMap tests validating an override that references a missing ConfigMap
+// GIVEN a component with an override that references a ConfigMap that does not exist
+// WHEN the CR is validated
+// THEN no error is reported
+func TestValidateOverridesSchemaMissingConfigMap(t *testing.T) {
+	config.TestProfilesDir = "../../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	getClientFunc = func() (client.Client, error) {
+		return fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(), nil
+	}
+	defer func() { getClientFunc = defaultGetClientFunc }()
+	registry.OverrideGetComponentsFn(func() []spi.Component {
+		return []spi.Component{newOverridesComponent(v1beta1.Overrides{ConfigMapRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+			Key:                  "values.yaml",
+		}})}
+	})
+	defer registry.ResetGetComponentsFn()
+
+	assert.Empty(t, validateOverridesSchema(&v1beta1.Verrazzano{}))
+}
+
+// TestValidateProfileOverridesSchema tests that the overrides of the built-in profiles are valid
+// GIVEN the Verrazzano charts and the built-in profiles
+// WHEN a CR with each profile is validated
+// THEN no error is reported
+func TestValidateProfileOverridesSchema(t *testing.T) {
+	config.TestProfilesDir = "../../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	oldConfig := config.Get()
+	config.Set(config.OperatorConfig{VerrazzanoRootDir: "../../../.."})
+	defer config.Set(oldConfig)
+
+	for _, profile := range []v1beta1.ProfileType{v1beta1.Dev, v1beta1.Prod, v1beta1.ManagedCluster} {
+		assert.Empty(t, validateOverridesSchema(&v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Profile: profile}}), profile)
+	}
+}
+
+// TestValidateUndeclaredOverrides tests that overrides of values that are not declared by the default values of the
+// charts are accepted
+// GIVEN a prod CR with an ingress-nginx externalTrafficPolicy override, which is not declared by the default values of
+// the ingress-nginx chart
+// WHEN the CR is validated on install and on update
+// THEN no error is reported
+func TestValidateUndeclaredOverrides(t *testing.T) {
+	config.TestProfilesDir = "../../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+	oldConfig := config.Get()
+	config.Set(config.OperatorConfig{VerrazzanoRootDir: "../../../.."})
+	defer config.Set(oldConfig)
+
+	data, err := os.ReadFile("../component/spi/testdata/prodIngressIstioOverridesMerged.yaml")
+	assert.NoError(t, err)
+	vz := &vzapi.Verrazzano{}
+	assert.NoError(t, yaml.Unmarshal(data, vz))
+	assert.Empty(t, validateV1alpha1OverridesSchema(vz))
+
+	v1beta1CR := &v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{
+		Profile: v1beta1.Prod,
+		Components: v1beta1.ComponentSpec{
+			IngressNGINX: &v1beta1.IngressNginxComponent{
+				InstallOverrides: v1beta1.InstallOverrides{
+					ValueOverrides: []v1beta1.Overrides{{Values: &apiextensionsv1.JSON{Raw: []byte(`{"controller": {"service": {"externalTrafficPolicy": "Local"}}}`)}}},
+				},
+			},
+		},
+	}}
+	assert.Empty(t, validateOverridesSchema(v1beta1CR))
+	assert.Empty(t, ComponentValidatorImpl{}.ValidateUpdateV1Beta1(v1beta1CR, v1beta1CR))
+}
@@ -66,6 +66,17 @@ type OperatorConfig struct {
 
 	// DryRun Run installs in a dry-run mode
 	DryRun bool
+
+	// TracingEndpoint is the host:port of the OTLP/HTTP endpoint that the reconcile traces are exported to, tracing is
+	// disabled if empty
+	TracingEndpoint string
+
+	// TracingInsecure disables TLS when exporting the reconcile traces
+	TracingInsecure bool
+
+	// UserProfilesDir is the directory of the user profile files, in a sub-directory for each API version, like the
+	// profiles dir
+	UserProfilesDir string
 }
 
 // The singleton instance of the operator config
@@ -178,6 +189,14 @@ func GetProfile(groupVersion schema.GroupVersion, profile string) string {
 	return filepath.Join(GetProfilesDir()+"/"+groupVersion.Version, profile+".yaml")
 }
 
+// GetUserProfilesDir returns the user profiles dir of the API version, or an empty string if there is no user profiles dir
+func GetUserProfilesDir(groupVersion schema.GroupVersion) string {
+	if len(instance.UserProfilesDir) == 0 {
+		return ""
+	}
+	return filepath.Join(instance.UserProfilesDir, groupVersion.Version)
+}
+
 // SetDefaultBomFilePath Sets the global default location for the BOM file
 func SetDefaultBomFilePath(p string) {
 	bomFilePathOverride = p
@@ -0,0 +1,211 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// vaultTokenKey is the key of the Vault token in the token Secret
+	vaultTokenKey = "token"
+
+	// vaultCAKey is the key of the CA bundle of the Vault server in the CA Secret
+	vaultCAKey = "ca.crt"
+)
+
+// GenerateFunc generates the data of a new credential
+type GenerateFunc func() (map[string][]byte, error)
+
+// NewStoreFuncType is the type of the function that creates the credentials store configured in a Verrazzano CR
+type NewStoreFuncType func(cli client.Client, vz *v1alpha1.Verrazzano) (Store, error)
+
+// newStoreFunc creates the credentials store configured in a Verrazzano CR, overridden in unit tests
+var newStoreFunc NewStoreFuncType = newStore
+
+// SetNewStoreFunc sets the function that creates the credentials store, for unit testing
+func SetNewStoreFunc(f NewStoreFuncType) {
+	newStoreFunc = f
+}
+
+// ResetNewStoreFunc restores the function that creates the credentials store
+func ResetNewStoreFunc() {
+	newStoreFunc = newStore
+}
+
+// GetStore returns the credentials store configured in a Verrazzano CR, or nil if the credentials are only stored in
+// Kubernetes Secrets
+func GetStore(cli client.Client, vz *v1alpha1.Verrazzano) (Store, error) {
+	return newStoreFunc(cli, vz)
+}
+
+// CredentialPath returns the path of the credential of a Secret in the credentials store
+func CredentialPath(secret types.NamespacedName) string {
+	return secret.Namespace + "/" + secret.Name
+}
+
+// EnsureSecret creates or updates a Secret that holds a generated credential.  Without a credentials store, the data of
+// an existing Secret is kept, and new data is generated when the Secret doesn't exist or is missing a generated key.
+// With a credentials store, the store is the source of truth: the data of the credential in the store is copied to
+// the Secret, and a credential that is not in the store yet is written to it.  An existing credential is never
+// generated again since the service and the workloads that use it would not be updated, see RotateSecret.
+func EnsureSecret(cli client.Client, vz *v1alpha1.Verrazzano, nsn types.NamespacedName, generate GenerateFunc) error {
+	store, err := GetStore(cli, vz)
+	if err != nil {
+		return err
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: nsn.Name, Namespace: nsn.Namespace}}
+	_, err = controllerutil.CreateOrUpdate(context.TODO(), cli, secret, func() error {
+		generated, err := generate()
+		if err != nil {
+			return err
+		}
+		var data map[string][]byte
+		var stored map[string]string
+		found := false
+		if store != nil {
+			if stored, found, err = store.Get(CredentialPath(nsn)); err != nil {
+				return err
+			}
+			if found && isComplete(toBytes(stored), generated) {
+				data = toBytes(stored)
+			}
+		}
+		if data == nil && isComplete(secret.Data, generated) {
+			data = secret.Data
+		}
+		if data == nil {
+			data = generated
+		}
+		if store != nil && (!found || !reflect.DeepEqual(stored, toStrings(data))) {
+			if err := store.Put(CredentialPath(nsn), toStrings(data)); err != nil {
+				return err
+			}
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		for k, v := range data {
+			secret.Data[k] = v
+		}
+		return nil
+	})
+	return err
+}
+
+// ExportSecret writes the data of a Secret that holds a credential that is not generated by Verrazzano, for example a
+// password set by a component on install, to the credentials store.  Nothing is written if there is no store or the
+// store already has the data.
+func ExportSecret(cli client.Client, vz *v1alpha1.Verrazzano, nsn types.NamespacedName) error {
+	store, err := GetStore(cli, vz)
+	if err != nil || store == nil {
+		return err
+	}
+	secret := corev1.Secret{}
+	if err := cli.Get(context.TODO(), nsn, &secret); err != nil {
+		return err
+	}
+	stored, found, err := store.Get(CredentialPath(nsn))
+	if err != nil {
+		return err
+	}
+	if found && reflect.DeepEqual(stored, toStrings(secret.Data)) {
+		return nil
+	}
+	return store.Put(CredentialPath(nsn), toStrings(secret.Data))
+}
+
+// GetCredential returns the data of the credential of a Secret from the credentials store, found is false if there is
+// no store or the store doesn't have the credential
+func GetCredential(cli client.Client, vz *v1alpha1.Verrazzano, nsn types.NamespacedName) (map[string][]byte, bool, error) {
+	store, err := GetStore(cli, vz)
+	if err != nil || store == nil {
+		return nil, false, err
+	}
+	data, found, err := store.Get(CredentialPath(nsn))
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return toBytes(data), true, nil
+}
+
+// newStore creates the credentials store configured in a Verrazzano CR, reading the Vault token and CA bundle from
+// Secrets in the verrazzano-install namespace
+func newStore(cli client.Client, vz *v1alpha1.Verrazzano) (Store, error) {
+	if vz == nil || vz.Spec.Security.CredentialsStore == nil || vz.Spec.Security.CredentialsStore.Vault == nil {
+		return nil, nil
+	}
+	vault := vz.Spec.Security.CredentialsStore.Vault
+	token, err := getSecretData(cli, vault.TokenSecret, vaultTokenKey)
+	if err != nil {
+		return nil, err
+	}
+	var caBundle []byte
+	if len(vault.CASecret) > 0 {
+		if caBundle, err = getSecretData(cli, vault.CASecret, vaultCAKey); err != nil {
+			return nil, err
+		}
+	}
+	return NewVaultKVStore(VaultConfig{
+		Address:    vault.Address,
+		MountPath:  vault.MountPath,
+		KVVersion:  vault.KVVersion,
+		PathPrefix: vault.PathPrefix,
+		Namespace:  vault.Namespace,
+		Token:      string(token),
+		CABundle:   caBundle,
+	})
+}
+
+// getSecretData returns the data of a key of a Secret in the verrazzano-install namespace
+func getSecretData(cli client.Client, name string, key string) ([]byte, error) {
+	secret := corev1.Secret{}
+	nsn := types.NamespacedName{Namespace: constants.VerrazzanoInstallNamespace, Name: name}
+	if err := cli.Get(context.TODO(), nsn, &secret); err != nil {
+		return nil, fmt.Errorf("Failed to get the Secret %s/%s of the credentials store: %v", nsn.Namespace, nsn.Name, err)
+	}
+	data, ok := secret.Data[key]
+	if !ok || len(data) == 0 {
+		return nil, fmt.Errorf("The Secret %s/%s of the credentials store has no %s key", nsn.Namespace, nsn.Name, key)
+	}
+	return data, nil
+}
+
+// isComplete returns true if the data has a non-empty value for each key of the generated data
+func isComplete(data map[string][]byte, generated map[string][]byte) bool {
+	for k := range generated {
+		if len(data[k]) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// toStrings converts the data of a Secret to the data of a credential in the store
+func toStrings(data map[string][]byte) map[string]string {
+	out := make(map[string]string, len(data))
+	for k, v := range data {
+		out[k] = string(v)
+	}
+	return out
+}
+
+// toBytes converts the data of a credential in the store to the data of a Secret
+func toBytes(data map[string]string) map[string][]byte {
+	out := make(map[string][]byte, len(data))
+	for k, v := range data {
+		out[k] = []byte(v)
+	}
+	return out
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testSecret = types.NamespacedName{Namespace: "keycloak", Name: "keycloak-http"}
+
+// newGenerator returns a generator of credentials with a new password for each call
+func newGenerator() GenerateFunc {
+	count := 0
+	return func() (map[string][]byte, error) {
+		count++
+		return map[string][]byte{"username": []byte("keycloakadmin"), "password": []byte(fmt.Sprintf("password%d", count))}, nil
+	}
+}
+
+// getTestSecret returns the data of the test Secret
+func getTestSecret(t *testing.T, cli client.Client) map[string][]byte {
+	secret := corev1.Secret{}
+	assert.NoError(t, cli.Get(context.TODO(), testSecret, &secret))
+	return secret.Data
+}
+
+// TestEnsureSecretNoStore tests ensuring a generated credential without a credentials store
+// GIVEN no credentials store
+// WHEN EnsureSecret is called for a Secret that doesn't exist, again, and after the credentials version changes
+// THEN the credential is generated and then kept, since the consumers of the credential would not be updated
+func TestEnsureSecretNoStore(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	vz := &v1alpha1.Verrazzano{}
+	generate := newGenerator()
+
+	assert.NoError(t, EnsureSecret(cli, vz, testSecret, generate))
+	data := getTestSecret(t, cli)
+	assert.Equal(t, "password1", string(data["password"]))
+
+	assert.NoError(t, EnsureSecret(cli, vz, testSecret, generate))
+	data = getTestSecret(t, cli)
+	assert.Equal(t, "password1", string(data["password"]))
+
+	vz.Annotations = map[string]string{globalconst.CredentialsVersionAnnotation: "2"}
+	assert.NoError(t, EnsureSecret(cli, vz, testSecret, generate))
+	data = getTestSecret(t, cli)
+	assert.Equal(t, "password1", string(data["password"]))
+	assert.Equal(t, "keycloakadmin", string(data["username"]))
+}
+
+// TestEnsureSecretStore tests ensuring a generated credential with a credentials store
+// GIVEN a credentials store
+// WHEN EnsureSecret is called for an existing Secret, after the credential changes in the store, and after the
+// credentials version changes
+// THEN the existing credential is written to the store, the Secret is updated from the store, and the credential is
+// not generated again
+func TestEnsureSecretStore(t *testing.T) {
+	store := NewLocalStore()
+	SetNewStoreFunc(func(_ client.Client, _ *v1alpha1.Verrazzano) (Store, error) { return store, nil })
+	defer ResetNewStoreFunc()
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testSecret.Namespace, Name: testSecret.Name},
+		Data:       map[string][]byte{"username": []byte("keycloakadmin"), "password": []byte("existing")},
+	}).Build()
+	vz := &v1alpha1.Verrazzano{}
+	generate := newGenerator()
+
+	assert.NoError(t, EnsureSecret(cli, vz, testSecret, generate))
+	stored, found, _ := store.Get("keycloak/keycloak-http")
+	assert.True(t, found)
+	assert.Equal(t, "existing", stored["password"])
+
+	assert.NoError(t, store.Put("keycloak/keycloak-http", map[string]string{"username": "keycloakadmin", "password": "external"}))
+	assert.NoError(t, EnsureSecret(cli, vz, testSecret, generate))
+	data := getTestSecret(t, cli)
+	assert.Equal(t, "external", string(data["password"]))
+
+	vz.Annotations = map[string]string{globalconst.CredentialsVersionAnnotation: "1"}
+	assert.NoError(t, EnsureSecret(cli, vz, testSecret, generate))
+	data = getTestSecret(t, cli)
+	assert.Equal(t, "external", string(data["password"]))
+	stored, _, _ = store.Get("keycloak/keycloak-http")
+	assert.Equal(t, "external", stored["password"])
+}
+
+// TestExportSecret tests writing a credential that is not generated to the credentials store
+// GIVEN a Secret and a credentials store
+// WHEN ExportSecret is called
+// THEN the data of the Secret is written to the store, and can be read with GetCredential
+func TestExportSecret(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cattle-system", Name: "rancher-admin-secret"},
+		Data:       map[string][]byte{"password": []byte("rancher")},
+	}).Build()
+	nsn := types.NamespacedName{Namespace: "cattle-system", Name: "rancher-admin-secret"}
+
+	// Nothing is done without a store
+	assert.NoError(t, ExportSecret(cli, &v1alpha1.Verrazzano{}, nsn))
+	_, found, err := GetCredential(cli, &v1alpha1.Verrazzano{}, nsn)
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	store := NewLocalStore()
+	SetNewStoreFunc(func(_ client.Client, _ *v1alpha1.Verrazzano) (Store, error) { return store, nil })
+	defer ResetNewStoreFunc()
+	assert.NoError(t, ExportSecret(cli, &v1alpha1.Verrazzano{}, nsn))
+	data, found, err := GetCredential(cli, &v1alpha1.Verrazzano{}, nsn)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "rancher", string(data["password"]))
+}
+
+// TestNewStore tests creating the credentials store configured in a Verrazzano CR
+// GIVEN a Verrazzano CR without a credentials store, with a Vault store and its token Secret, and with a Vault store
+// whose token Secret doesn't exist
+// WHEN newStore is called
+// THEN no store, a Vault store, and an error are returned
+func TestNewStore(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.VerrazzanoInstallNamespace, Name: "vault-token"},
+		Data:       map[string][]byte{vaultTokenKey: []byte("root")},
+	}).Build()
+
+	store, err := newStore(cli, &v1alpha1.Verrazzano{})
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+
+	vz := &v1alpha1.Verrazzano{Spec: v1alpha1.VerrazzanoSpec{Security: v1alpha1.SecuritySpec{
+		CredentialsStore: &v1alpha1.CredentialsStore{Vault: &v1alpha1.VaultCredentialsStore{
+			Address:     "https://vault.example.com:8200",
+			TokenSecret: "vault-token",
+		}},
+	}}}
+	store, err = newStore(cli, vz)
+	assert.NoError(t, err)
+	vaultStore, ok := store.(*VaultKVStore)
+	assert.True(t, ok)
+	assert.Equal(t, "root", vaultStore.config.Token)
+	assert.Equal(t, "https://vault.example.com:8200/v1/secret/data/verrazzano/keycloak/keycloak-http", vaultStore.url("keycloak/keycloak-http"))
+
+	vz.Spec.Security.CredentialsStore.Vault.TokenSecret = "missing"
+	_, err = newStore(cli, vz)
+	assert.ErrorContains(t, err, "Failed to get the Secret verrazzano-install/missing of the credentials store")
+}
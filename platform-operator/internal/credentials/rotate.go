@@ -0,0 +1,139 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzpassword "github.com/verrazzano/verrazzano/pkg/security/password"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// pendingKeySuffix is the suffix of the keys of a Secret that hold the new passwords of a rotation in progress
+	pendingKeySuffix = ".pending"
+
+	// rotatedPasswordLength is the length of the generated passwords of a rotation
+	rotatedPasswordLength = 15
+)
+
+// UpdateFunc sets the new passwords of a rotation in the service that uses the credential, given the current and the
+// new data of the Secret of the credential.  The function must succeed when the service already has the new passwords.
+type UpdateFunc func(current map[string][]byte, rotated map[string][]byte) error
+
+// RotateSecret rotates the passwords in the given keys of a Secret.  The new passwords are first saved in pending keys
+// of the Secret, so that a rotation that fails after the service was updated is resumed with the same passwords.  The
+// update function then sets the new passwords in the service.  Once the service is updated, the new passwords replace
+// the current ones in the credentials store if one is configured and in the Secret, and the pending keys are removed.
+func RotateSecret(cli client.Client, vz *v1alpha1.Verrazzano, nsn types.NamespacedName, keys []string, update UpdateFunc) error {
+	secret := &corev1.Secret{}
+	if err := cli.Get(context.TODO(), nsn, secret); err != nil {
+		return fmt.Errorf("Failed to get the Secret %s/%s of the credential: %v", nsn.Namespace, nsn.Name, err)
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+
+	// Generate the new passwords, unless a previous rotation attempt already did
+	pending := false
+	for _, key := range keys {
+		if len(secret.Data[key+pendingKeySuffix]) > 0 {
+			continue
+		}
+		pw, err := vzpassword.GeneratePassword(rotatedPasswordLength)
+		if err != nil {
+			return err
+		}
+		secret.Data[key+pendingKeySuffix] = []byte(pw)
+		pending = true
+	}
+	if pending {
+		if err := cli.Update(context.TODO(), secret); err != nil {
+			return fmt.Errorf("Failed to save the new passwords in the Secret %s/%s: %v", nsn.Namespace, nsn.Name, err)
+		}
+	}
+
+	current := make(map[string][]byte, len(secret.Data))
+	rotated := make(map[string][]byte, len(secret.Data))
+	for k, v := range secret.Data {
+		current[k] = v
+		rotated[k] = v
+	}
+	for _, key := range keys {
+		rotated[key] = secret.Data[key+pendingKeySuffix]
+		delete(current, key+pendingKeySuffix)
+		delete(rotated, key+pendingKeySuffix)
+	}
+	if err := update(current, rotated); err != nil {
+		return err
+	}
+
+	// Write the store first, the store is the source of truth of the Secret once the service is updated
+	store, err := GetStore(cli, vz)
+	if err != nil {
+		return err
+	}
+	if store != nil {
+		if err := store.Put(CredentialPath(nsn), toStrings(rotated)); err != nil {
+			return err
+		}
+	}
+	secret.Data = rotated
+	if err := cli.Update(context.TODO(), secret); err != nil {
+		return fmt.Errorf("Failed to save the rotated passwords in the Secret %s/%s: %v", nsn.Namespace, nsn.Name, err)
+	}
+	return nil
+}
+
+// RestartWorkloads restarts the Deployments and StatefulSets that read a rotated credential on startup, by setting
+// the restart annotation of the pod template to the rotation request.  Workloads that don't exist are ignored, and a
+// workload already restarted for the request is not restarted again.
+func RestartWorkloads(cli client.Client, request string, deployments []types.NamespacedName, statefulSets []types.NamespacedName) error {
+	for _, nsn := range deployments {
+		if err := restartWorkload(cli, nsn, request, &appsv1.Deployment{}); err != nil {
+			return err
+		}
+	}
+	for _, nsn := range statefulSets {
+		if err := restartWorkload(cli, nsn, request, &appsv1.StatefulSet{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restartWorkload sets the restart annotation of the pod template of a Deployment or StatefulSet
+func restartWorkload(cli client.Client, nsn types.NamespacedName, request string, obj client.Object) error {
+	if err := cli.Get(context.TODO(), nsn, obj); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	var template *corev1.PodTemplateSpec
+	switch workload := obj.(type) {
+	case *appsv1.Deployment:
+		template = &workload.Spec.Template
+	case *appsv1.StatefulSet:
+		template = &workload.Spec.Template
+	}
+	if template.Annotations[globalconst.VerrazzanoRestartAnnotation] == request {
+		return nil
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[globalconst.VerrazzanoRestartAnnotation] = request
+	if err := cli.Update(context.TODO(), obj); err != nil {
+		return fmt.Errorf("Failed to restart %s/%s: %v", nsn.Namespace, nsn.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestRotateSecret tests rotating the password of a Secret
+// GIVEN a Secret with a password and a credentials store
+// WHEN RotateSecret is called and the update of the service fails, then RotateSecret is called again
+// THEN the new password is kept in a pending key after the failure, the retry sets the same new password in the
+// service, and the new password replaces the current one in the Secret and the store
+func TestRotateSecret(t *testing.T) {
+	store := NewLocalStore()
+	SetNewStoreFunc(func(_ client.Client, _ *v1alpha1.Verrazzano) (Store, error) { return store, nil })
+	defer ResetNewStoreFunc()
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testSecret.Namespace, Name: testSecret.Name},
+		Data:       map[string][]byte{"username": []byte("keycloakadmin"), "password": []byte("current")},
+	}).Build()
+	vz := &v1alpha1.Verrazzano{}
+
+	var attempted string
+	err := RotateSecret(cli, vz, testSecret, []string{"password"}, func(current map[string][]byte, rotated map[string][]byte) error {
+		assert.Equal(t, "current", string(current["password"]))
+		assert.Equal(t, "keycloakadmin", string(rotated["username"]))
+		attempted = string(rotated["password"])
+		return errors.New("unavailable")
+	})
+	assert.Error(t, err)
+	assert.Len(t, attempted, rotatedPasswordLength)
+	data := getTestSecret(t, cli)
+	assert.Equal(t, "current", string(data["password"]))
+	assert.Equal(t, attempted, string(data["password"+pendingKeySuffix]))
+
+	err = RotateSecret(cli, vz, testSecret, []string{"password"}, func(current map[string][]byte, rotated map[string][]byte) error {
+		assert.Equal(t, "current", string(current["password"]))
+		assert.Equal(t, attempted, string(rotated["password"]))
+		return nil
+	})
+	assert.NoError(t, err)
+	data = getTestSecret(t, cli)
+	assert.Equal(t, map[string][]byte{"username": []byte("keycloakadmin"), "password": []byte(attempted)}, data)
+	stored, found, err := store.Get(CredentialPath(testSecret))
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, attempted, stored["password"])
+}
+
+// TestRestartWorkloads tests restarting the workloads that read a rotated credential
+// GIVEN a Deployment and a StatefulSet, and a Deployment that doesn't exist
+// WHEN RestartWorkloads is called
+// THEN the restart annotation of the pod templates of the existing workloads is set to the rotation request
+func TestRestartWorkloads(t *testing.T) {
+	dep := types.NamespacedName{Namespace: "verrazzano-system", Name: "verrazzano-monitoring-operator"}
+	sts := types.NamespacedName{Namespace: "keycloak", Name: "keycloak"}
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: dep.Namespace, Name: dep.Name}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: sts.Namespace, Name: sts.Name}},
+	).Build()
+
+	err := RestartWorkloads(cli, "2022-10-01T10:00:00Z",
+		[]types.NamespacedName{dep, {Namespace: "verrazzano-system", Name: "missing"}}, []types.NamespacedName{sts})
+	assert.NoError(t, err)
+
+	deployment := appsv1.Deployment{}
+	assert.NoError(t, cli.Get(context.TODO(), dep, &deployment))
+	assert.Equal(t, "2022-10-01T10:00:00Z", deployment.Spec.Template.Annotations[globalconst.VerrazzanoRestartAnnotation])
+	statefulSet := appsv1.StatefulSet{}
+	assert.NoError(t, cli.Get(context.TODO(), sts, &statefulSet))
+	assert.Equal(t, "2022-10-01T10:00:00Z", statefulSet.Spec.Template.Annotations[globalconst.VerrazzanoRestartAnnotation])
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package credentials
+
+import (
+	"sync"
+)
+
+// Store is an external store of credentials, keyed by path
+type Store interface {
+	// Get returns the data of the credential at the path, found is false if the credential doesn't exist
+	Get(path string) (data map[string]string, found bool, err error)
+	// Put creates or replaces the data of the credential at the path
+	Put(path string, data map[string]string) error
+}
+
+// LocalStore is an in-memory credentials store, used in place of an external store in tests
+type LocalStore struct {
+	mutex       sync.Mutex
+	credentials map[string]map[string]string
+}
+
+var _ Store = &LocalStore{}
+
+// NewLocalStore returns an empty in-memory credentials store
+func NewLocalStore() *LocalStore {
+	return &LocalStore{credentials: map[string]map[string]string{}}
+}
+
+// Get returns a copy of the data of the credential at the path
+func (s *LocalStore) Get(path string) (map[string]string, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	data, found := s.credentials[path]
+	return copyData(data), found, nil
+}
+
+// Put stores a copy of the data of the credential at the path
+func (s *LocalStore) Put(path string, data map[string]string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.credentials[path] = copyData(data)
+	return nil
+}
+
+// copyData returns a copy of the data of a credential
+func copyData(data map[string]string) map[string]string {
+	if data == nil {
+		return nil
+	}
+	dataCopy := make(map[string]string, len(data))
+	for k, v := range data {
+		dataCopy[k] = v
+	}
+	return dataCopy
+}
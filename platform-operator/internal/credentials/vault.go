@@ -0,0 +1,176 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package credentials
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultVaultMountPath is the default path of the KV secrets engine
+	defaultVaultMountPath = "secret"
+
+	// defaultVaultPathPrefix is the default prefix of the paths of the credentials
+	defaultVaultPathPrefix = "verrazzano"
+
+	// vaultTimeout is the timeout of the requests to the Vault server
+	vaultTimeout = 30 * time.Second
+)
+
+// VaultConfig is the configuration of a HashiCorp Vault KV secrets engine
+type VaultConfig struct {
+	// Address is the URL of the Vault server
+	Address string
+	// MountPath is the path the KV secrets engine is mounted at, the default is secret
+	MountPath string
+	// KVVersion is the version of the KV secrets engine, 1 or 2, the default is 2
+	KVVersion int
+	// PathPrefix is the prefix of the paths of the credentials, the default is verrazzano
+	PathPrefix string
+	// Namespace is the Vault Enterprise namespace, if any
+	Namespace string
+	// Token is the token used to authenticate to Vault
+	Token string
+	// CABundle is the PEM encoded CA bundle of the Vault server, the system CAs are used if it is empty
+	CABundle []byte
+}
+
+// VaultKVStore is a credentials store that uses the KV secrets engine of HashiCorp Vault
+type VaultKVStore struct {
+	config VaultConfig
+	client *http.Client
+}
+
+var _ Store = &VaultKVStore{}
+
+// NewVaultKVStore returns a credentials store that uses the KV secrets engine of HashiCorp Vault
+func NewVaultKVStore(config VaultConfig) (*VaultKVStore, error) {
+	if len(config.Address) == 0 {
+		return nil, fmt.Errorf("The address of the Vault server is required")
+	}
+	if len(config.MountPath) == 0 {
+		config.MountPath = defaultVaultMountPath
+	}
+	if len(config.PathPrefix) == 0 {
+		config.PathPrefix = defaultVaultPathPrefix
+	}
+	if config.KVVersion == 0 {
+		config.KVVersion = 2
+	}
+	if config.KVVersion != 1 && config.KVVersion != 2 {
+		return nil, fmt.Errorf("The version %d of the Vault KV secrets engine is not supported, the version must be 1 or 2", config.KVVersion)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(config.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(config.CABundle) {
+			return nil, fmt.Errorf("Failed to parse the CA bundle of the Vault server")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &VaultKVStore{config: config, client: &http.Client{Transport: transport, Timeout: vaultTimeout}}, nil
+}
+
+// Get reads the data of the credential at the path
+func (s *VaultKVStore) Get(path string) (map[string]string, bool, error) {
+	body, status, err := s.do(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if status == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if status != http.StatusOK {
+		return nil, false, fmt.Errorf("Failed to read the credential %s from Vault, status code %d: %s", path, status, string(body))
+	}
+	// The data of KV version 2 is nested in a data field, next to the metadata
+	response := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, false, fmt.Errorf("Failed to parse the credential %s from Vault: %v", path, err)
+	}
+	rawData := response.Data
+	if s.config.KVVersion == 2 {
+		nested := struct {
+			Data json.RawMessage `json:"data"`
+		}{}
+		if err := json.Unmarshal(rawData, &nested); err != nil {
+			return nil, false, fmt.Errorf("Failed to parse the credential %s from Vault: %v", path, err)
+		}
+		rawData = nested.Data
+	}
+	// A deleted version of KV version 2 has null data
+	if len(rawData) == 0 || string(rawData) == "null" {
+		return nil, false, nil
+	}
+	data := map[string]string{}
+	if err := json.Unmarshal(rawData, &data); err != nil {
+		return nil, false, fmt.Errorf("Failed to parse the credential %s from Vault: %v", path, err)
+	}
+	return data, true, nil
+}
+
+// Put writes the data of the credential at the path.  KV version 2 keeps the previous versions of the credential.
+func (s *VaultKVStore) Put(path string, data map[string]string) error {
+	var payload interface{} = data
+	if s.config.KVVersion == 2 {
+		payload = map[string]interface{}{"data": data}
+	}
+	requestBody, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	body, status, err := s.do(http.MethodPost, path, requestBody)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent {
+		return fmt.Errorf("Failed to write the credential %s to Vault, status code %d: %s", path, status, string(body))
+	}
+	return nil
+}
+
+// do sends a request for the credential at the path to the Vault server, returning the response body and status
+func (s *VaultKVStore) do(method string, path string, requestBody []byte) ([]byte, int, error) {
+	req, err := http.NewRequest(method, s.url(path), bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("X-Vault-Token", s.config.Token)
+	if len(s.config.Namespace) > 0 {
+		req.Header.Set("X-Vault-Namespace", s.config.Namespace)
+	}
+	if requestBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Failed to connect to Vault at %s: %v", s.config.Address, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return body, resp.StatusCode, nil
+}
+
+// url returns the URL of the API of the credential at the path
+func (s *VaultKVStore) url(path string) string {
+	segments := []string{strings.TrimSuffix(s.config.Address, "/"), "v1", strings.Trim(s.config.MountPath, "/")}
+	if s.config.KVVersion == 2 {
+		segments = append(segments, "data")
+	}
+	segments = append(segments, strings.Trim(s.config.PathPrefix, "/"), strings.Trim(path, "/"))
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package credentials
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeVault is an httptest server that implements the read and write APIs of a KV secrets engine
+type fakeVault struct {
+	mutex     sync.Mutex
+	kvVersion int
+	secrets   map[string]map[string]string
+	versions  map[string]int
+	namespace string
+}
+
+// newFakeVault returns a fake Vault server with a KV secrets engine of the given version mounted at secret
+func newFakeVault(t *testing.T, kvVersion int) (*fakeVault, *httptest.Server) {
+	vault := &fakeVault{kvVersion: kvVersion, secrets: map[string]map[string]string{}, versions: map[string]int{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vault.mutex.Lock()
+		defer vault.mutex.Unlock()
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		vault.namespace = r.Header.Get("X-Vault-Namespace")
+		prefix := "/v1/secret/"
+		if kvVersion == 2 {
+			prefix = "/v1/secret/data/"
+		}
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		path := strings.TrimPrefix(r.URL.Path, prefix)
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := vault.secrets[path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"errors":[]}`))
+				return
+			}
+			var response interface{} = map[string]interface{}{"data": data}
+			if kvVersion == 2 {
+				response = map[string]interface{}{"data": map[string]interface{}{
+					"data":     data,
+					"metadata": map[string]interface{}{"version": vault.versions[path]},
+				}}
+			}
+			_ = json.NewEncoder(w).Encode(response)
+		case http.MethodPost:
+			var data map[string]string
+			if kvVersion == 2 {
+				request := struct {
+					Data map[string]string `json:"data"`
+				}{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+				data = request.Data
+			} else {
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&data))
+			}
+			vault.secrets[path] = data
+			vault.versions[path]++
+			if kvVersion == 2 {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"version": vault.versions[path]}})
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	return vault, server
+}
+
+// TestVaultKVStore tests reading and writing credentials with the KV secrets engine of Vault
+// GIVEN a Vault server with a KV secrets engine of version 1 or 2
+// WHEN credentials are written and read
+// THEN the credentials are stored at the path with the prefix, and a missing credential is not found
+func TestVaultKVStore(t *testing.T) {
+	for _, kvVersion := range []int{1, 2} {
+		vault, server := newFakeVault(t, kvVersion)
+		store, err := NewVaultKVStore(VaultConfig{Address: server.URL, KVVersion: kvVersion, Token: "root", Namespace: "ns1"})
+		assert.NoError(t, err)
+
+		_, found, err := store.Get("keycloak/keycloak-http")
+		assert.NoError(t, err)
+		assert.False(t, found)
+
+		data := map[string]string{"username": "keycloakadmin", "password": "secret"}
+		assert.NoError(t, store.Put("keycloak/keycloak-http", data))
+		assert.Equal(t, data, vault.secrets["verrazzano/keycloak/keycloak-http"])
+		assert.Equal(t, "ns1", vault.namespace)
+
+		stored, found, err := store.Get("keycloak/keycloak-http")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, data, stored)
+		server.Close()
+	}
+}
+
+// TestVaultKVStoreErrors tests the errors of the Vault credentials store
+// GIVEN an invalid configuration, or a Vault server that denies the requests
+// WHEN the store is created or used
+// THEN an error is returned
+func TestVaultKVStoreErrors(t *testing.T) {
+	_, err := NewVaultKVStore(VaultConfig{})
+	assert.Error(t, err)
+	_, err = NewVaultKVStore(VaultConfig{Address: "https://vault:8200", KVVersion: 3})
+	assert.Error(t, err)
+	_, err = NewVaultKVStore(VaultConfig{Address: "https://vault:8200", CABundle: []byte("not a certificate")})
+	assert.Error(t, err)
+
+	_, server := newFakeVault(t, 2)
+	defer server.Close()
+	store, err := NewVaultKVStore(VaultConfig{Address: server.URL, Token: "wrong"})
+	assert.NoError(t, err)
+	_, _, err = store.Get("keycloak/keycloak-http")
+	assert.ErrorContains(t, err, "status code 403")
+	err = store.Put("keycloak/keycloak-http", map[string]string{"password": "secret"})
+	assert.ErrorContains(t, err, "status code 403")
+}
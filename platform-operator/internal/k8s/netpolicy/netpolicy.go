@@ -6,10 +6,15 @@ package netpolicy
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
@@ -32,17 +37,35 @@ const (
 	appInstanceLabel         = "app.kubernetes.io/instance"
 	appNameLabel             = "app.kubernetes.io/name"
 	apiServerEndpointName    = "kubernetes"
+	namespaceNameLabel       = "kubernetes.io/metadata.name"
+	serviceDomainSuffix      = ".svc.cluster.local"
+
+	credentialsStoreNetworkPolicyName = "verrazzano-platform-operator-credentials-store"
 )
 
+// EgressOptions are the optional egress destinations of the platform operator
+type EgressOptions struct {
+	// TracingEndpoint is the host:port of the OTLP/HTTP endpoint that reconcile traces are exported to
+	TracingEndpoint string
+}
+
 // CreateOrUpdateNetworkPolicies creates or updates network policies for the platform operator to
 // limit network ingress and egress.
-func CreateOrUpdateNetworkPolicies(clientset kubernetes.Interface, client client.Client) (controllerutil.OperationResult, error) {
+func CreateOrUpdateNetworkPolicies(clientset kubernetes.Interface, client client.Client, options EgressOptions) (controllerutil.OperationResult, error) {
 	ip, port, err := getAPIServerIPAndPort(clientset)
 	if err != nil {
 		return controllerutil.OperationResultNone, err
 	}
 
 	netPolicy := newNetworkPolicy(ip, port)
+	if len(options.TracingEndpoint) > 0 {
+		// egress to the OTLP/HTTP endpoint of the trace collector
+		rule, err := newEndpointEgressRule(options.TracingEndpoint)
+		if err != nil {
+			return controllerutil.OperationResultNone, fmt.Errorf("invalid tracing endpoint %s: %v", options.TracingEndpoint, err)
+		}
+		netPolicy.Spec.Egress = append(netPolicy.Spec.Egress, rule)
+	}
 	objKey := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: netPolicy.ObjectMeta.Name, Namespace: netPolicy.ObjectMeta.Namespace}}
 
 	opResult, err := controllerutil.CreateOrUpdate(context.TODO(), client, objKey, func() error {
@@ -53,6 +76,66 @@ func CreateOrUpdateNetworkPolicies(clientset kubernetes.Interface, client client
 	return opResult, err
 }
 
+// CreateOrUpdateCredentialsStoreNetworkPolicy creates or updates a network policy that allows the platform operator
+// egress to the Vault server of the credentials store at the given URL, or deletes the network policy if the address
+// is empty.  The policy is separate from the policy of the operator since the address is configured in the Verrazzano
+// CR, which is not known when the operator starts.
+func CreateOrUpdateCredentialsStoreNetworkPolicy(client client.Client, address string) (controllerutil.OperationResult, error) {
+	netPolicy := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: credentialsStoreNetworkPolicyName, Namespace: constants.VerrazzanoInstallNamespace}}
+	if len(address) == 0 {
+		if err := client.Delete(context.TODO(), netPolicy); err != nil && !errors.IsNotFound(err) {
+			return controllerutil.OperationResultNone, err
+		}
+		return controllerutil.OperationResultNone, nil
+	}
+	endpoint, err := urlEndpoint(address)
+	if err != nil {
+		return controllerutil.OperationResultNone, fmt.Errorf("invalid credentials store address %s: %v", address, err)
+	}
+	rule, err := newEndpointEgressRule(endpoint)
+	if err != nil {
+		return controllerutil.OperationResultNone, fmt.Errorf("invalid credentials store address %s: %v", address, err)
+	}
+	return controllerutil.CreateOrUpdate(context.TODO(), client, netPolicy, func() error {
+		netPolicy.Spec = netv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					podAppLabel: networkPolicyPodName,
+				},
+			},
+			PolicyTypes: []netv1.PolicyType{
+				netv1.PolicyTypeEgress,
+			},
+			Egress: []netv1.NetworkPolicyEgressRule{rule},
+		}
+		return nil
+	})
+}
+
+// urlEndpoint returns the endpoint in host:port form of a URL, using the default port of the scheme if the URL has no
+// port
+func urlEndpoint(address string) (string, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", err
+	}
+	if len(u.Hostname()) == 0 {
+		return "", fmt.Errorf("the URL has no host")
+	}
+	port := u.Port()
+	if len(port) == 0 {
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		case "http":
+			port = "80"
+		default:
+			return "", fmt.Errorf("the URL has no port and an unknown scheme %s", u.Scheme)
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
+
 // getAPIServerIPAndPort returns the IP address and port of the Kubernetes API server.
 func getAPIServerIPAndPort(clientset kubernetes.Interface) (string, int32, error) {
 	endpoints, err := clientset.CoreV1().Endpoints(corev1.NamespaceDefault).Get(context.TODO(), apiServerEndpointName, metav1.GetOptions{})
@@ -67,6 +150,59 @@ func getAPIServerIPAndPort(clientset kubernetes.Interface) (string, int32, error
 	return "", 0, fmt.Errorf("unable to find a host and port for the kubernetes API server")
 }
 
+// newEndpointEgressRule returns an egress rule to the TCP port of an endpoint in host:port form.  If the host is the
+// DNS name of a cluster service, the egress is limited to the namespace of the service, and if the host is an IP
+// address, the egress is limited to that address.  Network policies can't select DNS names, so the egress to another
+// host name is only limited by port, which allows any destination on that port.
+func newEndpointEgressRule(endpoint string) (netv1.NetworkPolicyEgressRule, error) {
+	host, portString, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return netv1.NetworkPolicyEgressRule{}, err
+	}
+	portNumber, err := strconv.Atoi(portString)
+	if err != nil {
+		return netv1.NetworkPolicyEgressRule{}, err
+	}
+	tcpProtocol := corev1.ProtocolTCP
+	port := intstr.FromInt(portNumber)
+	rule := netv1.NetworkPolicyEgressRule{
+		Ports: []netv1.NetworkPolicyPort{
+			{
+				Protocol: &tcpProtocol,
+				Port:     &port,
+			},
+		},
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		bits := 32
+		if ip.To4() == nil {
+			bits = 128
+		}
+		rule.To = []netv1.NetworkPolicyPeer{
+			{
+				IPBlock: &netv1.IPBlock{
+					CIDR: fmt.Sprintf("%s/%d", ip.String(), bits),
+				},
+			},
+		}
+	} else if strings.HasSuffix(host, serviceDomainSuffix) {
+		// the service host is <service>.<namespace>.svc.cluster.local
+		labels := strings.Split(strings.TrimSuffix(host, serviceDomainSuffix), ".")
+		if len(labels) == 2 {
+			rule.To = []netv1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							namespaceNameLabel: labels[1],
+						},
+					},
+				},
+			}
+		}
+	}
+	return rule, nil
+}
+
 // newNetworkPolicy returns a populated NetworkPolicy with ingress and egress rules for this operator.
 func newNetworkPolicy(apiServerIP string, apiServerPort int32) *netv1.NetworkPolicy {
 	tcpProtocol := corev1.ProtocolTCP
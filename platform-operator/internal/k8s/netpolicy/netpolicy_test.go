@@ -36,7 +36,7 @@ func TestCreateNetworkPolicies(t *testing.T) {
 	mockClientset := k8sfake.NewSimpleClientset(makeKubeAPIServerEndpoint())
 
 	// create the network policy
-	opResult, err := CreateOrUpdateNetworkPolicies(mockClientset, mockClient)
+	opResult, err := CreateOrUpdateNetworkPolicies(mockClientset, mockClient, EgressOptions{})
 	asserts.NoError(err)
 	asserts.Equal(controllerutil.OperationResultCreated, opResult)
 
@@ -65,7 +65,7 @@ func TestUpdateNetworkPolicies(t *testing.T) {
 	mockClient.Create(context.TODO(), existingNetPolicy)
 
 	// this call should update the network policy
-	opResult, err := CreateOrUpdateNetworkPolicies(mockClientset, mockClient)
+	opResult, err := CreateOrUpdateNetworkPolicies(mockClientset, mockClient, EgressOptions{})
 	asserts.NoError(err)
 	asserts.Equal(controllerutil.OperationResultUpdated, opResult)
 
@@ -78,6 +78,95 @@ func TestUpdateNetworkPolicies(t *testing.T) {
 	asserts.Equal(expectedNetPolicy.Spec, netPolicy.Spec)
 }
 
+// TestCreateNetworkPoliciesTracing tests creating network policies for the operator when tracing is enabled.
+// GIVEN a call to CreateOrUpdateNetworkPolicies with a tracing endpoint
+// WHEN the endpoint is a cluster service, an external host, or an IPv4 or IPv6 address
+// THEN the network policy allows egress to the port of the endpoint, limited to the namespace of a cluster service
+// and to the IP address of an endpoint with an address, and an invalid endpoint returns an error
+func TestCreateNetworkPoliciesTracing(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoint  string
+		namespace string
+		cidr      string
+	}{
+		{name: "service", endpoint: "jaeger-operator-jaeger-collector.verrazzano-monitoring.svc.cluster.local:4318", namespace: "verrazzano-monitoring"},
+		{name: "external", endpoint: "collector.example.com:4318"},
+		{name: "ipv4", endpoint: "10.0.0.5:4318", cidr: "10.0.0.5/32"},
+		{name: "ipv6", endpoint: "[fd00::5]:4318", cidr: "fd00::5/128"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asserts := assert.New(t)
+			mockClient := ctrlfake.NewFakeClientWithScheme(k8scheme.Scheme)
+			mockClientset := k8sfake.NewSimpleClientset(makeKubeAPIServerEndpoint())
+
+			_, err := CreateOrUpdateNetworkPolicies(mockClientset, mockClient, EgressOptions{TracingEndpoint: tt.endpoint})
+			asserts.NoError(err)
+
+			netPolicy := &netv1.NetworkPolicy{}
+			err = mockClient.Get(context.TODO(), client.ObjectKey{Namespace: constants.VerrazzanoInstallNamespace, Name: networkPolicyPodName}, netPolicy)
+			asserts.NoError(err)
+			egress := netPolicy.Spec.Egress
+			asserts.Len(egress, len(newNetworkPolicy(apiServerIP, apiServerPort).Spec.Egress)+1)
+			tracingRule := egress[len(egress)-1]
+			asserts.Equal(4318, tracingRule.Ports[0].Port.IntValue())
+			if len(tt.cidr) > 0 {
+				asserts.Len(tracingRule.To, 1)
+				asserts.Equal(tt.cidr, tracingRule.To[0].IPBlock.CIDR)
+				return
+			}
+			if len(tt.namespace) == 0 {
+				asserts.Empty(tracingRule.To)
+				return
+			}
+			asserts.Len(tracingRule.To, 1)
+			asserts.Equal(tt.namespace, tracingRule.To[0].NamespaceSelector.MatchLabels[namespaceNameLabel])
+		})
+	}
+
+	mockClient := ctrlfake.NewFakeClientWithScheme(k8scheme.Scheme)
+	mockClientset := k8sfake.NewSimpleClientset(makeKubeAPIServerEndpoint())
+	_, err := CreateOrUpdateNetworkPolicies(mockClientset, mockClient, EgressOptions{TracingEndpoint: "collector"})
+	assert.Error(t, err)
+}
+
+// TestCreateOrUpdateCredentialsStoreNetworkPolicy tests the network policy of the credentials store
+// GIVEN a call to CreateOrUpdateCredentialsStoreNetworkPolicy
+// WHEN the address of the Vault server is set, has no port, is invalid, and is removed
+// THEN the network policy allows egress to the port of the server, an invalid address returns an error, and the
+// network policy is deleted when the address is removed
+func TestCreateOrUpdateCredentialsStoreNetworkPolicy(t *testing.T) {
+	asserts := assert.New(t)
+	mockClient := ctrlfake.NewFakeClientWithScheme(k8scheme.Scheme)
+	key := client.ObjectKey{Namespace: constants.VerrazzanoInstallNamespace, Name: credentialsStoreNetworkPolicyName}
+
+	opResult, err := CreateOrUpdateCredentialsStoreNetworkPolicy(mockClient, "https://vault.vault.svc.cluster.local:8200")
+	asserts.NoError(err)
+	asserts.Equal(controllerutil.OperationResultCreated, opResult)
+	netPolicy := &netv1.NetworkPolicy{}
+	asserts.NoError(mockClient.Get(context.TODO(), key, netPolicy))
+	asserts.Equal(networkPolicyPodName, netPolicy.Spec.PodSelector.MatchLabels[podAppLabel])
+	asserts.Len(netPolicy.Spec.Egress, 1)
+	asserts.Equal(8200, netPolicy.Spec.Egress[0].Ports[0].Port.IntValue())
+	asserts.Equal("vault", netPolicy.Spec.Egress[0].To[0].NamespaceSelector.MatchLabels[namespaceNameLabel])
+
+	_, err = CreateOrUpdateCredentialsStoreNetworkPolicy(mockClient, "https://vault.example.com")
+	asserts.NoError(err)
+	asserts.NoError(mockClient.Get(context.TODO(), key, netPolicy))
+	asserts.Equal(443, netPolicy.Spec.Egress[0].Ports[0].Port.IntValue())
+	asserts.Empty(netPolicy.Spec.Egress[0].To)
+
+	_, err = CreateOrUpdateCredentialsStoreNetworkPolicy(mockClient, "vault.example.com")
+	asserts.Error(err)
+
+	_, err = CreateOrUpdateCredentialsStoreNetworkPolicy(mockClient, "")
+	asserts.NoError(err)
+	asserts.Error(mockClient.Get(context.TODO(), key, netPolicy))
+	_, err = CreateOrUpdateCredentialsStoreNetworkPolicy(mockClient, "")
+	asserts.NoError(err)
+}
+
 // TestNetworkPoliciesFailures tests failure cases attempting to create or update
 // the operator network policies.
 func TestNetworkPoliciesFailures(t *testing.T) {
@@ -92,7 +181,7 @@ func TestNetworkPoliciesFailures(t *testing.T) {
 	mockClientset := k8sfake.NewSimpleClientset()
 
 	// this call should fail
-	_, err := CreateOrUpdateNetworkPolicies(mockClientset, mockClient)
+	_, err := CreateOrUpdateNetworkPolicies(mockClientset, mockClient, EgressOptions{})
 	asserts.Error(err)
 
 	// GIVEN a call to CreateOrUpdateNetworkPolicies
@@ -109,7 +198,7 @@ func TestNetworkPoliciesFailures(t *testing.T) {
 	mockClientset = k8sfake.NewSimpleClientset(emptyEndpoints)
 
 	// this call should fail
-	_, err = CreateOrUpdateNetworkPolicies(mockClientset, mockClient)
+	_, err = CreateOrUpdateNetworkPolicies(mockClientset, mockClient, EgressOptions{})
 	asserts.Error(err)
 }
 
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -18,7 +19,8 @@ import (
 )
 
 // DaemonSetsAreReady Check that the named daemonsets have the minimum number of specified nodes ready and available
-func DaemonSetsAreReady(log vzlog.VerrazzanoLogger, client client.Client, namespacedNames []types.NamespacedName, expectedNodes int32, prefix string) bool {
+// The workloads that are not ready are recorded in the readiness report of the component of the Verrazzano resource
+func DaemonSetsAreReady(log vzlog.VerrazzanoLogger, client client.Client, vz *vzapi.Verrazzano, namespacedNames []types.NamespacedName, expectedNodes int32, prefix string) bool {
 	resticPodLabel := map[string]string{
 		"name": constants.ResticDaemonSetName,
 	}
@@ -30,33 +32,45 @@ func DaemonSetsAreReady(log vzlog.VerrazzanoLogger, client client.Client, namesp
 		if err := client.Get(context.TODO(), namespacedName, &daemonset); err != nil {
 			if errors.IsNotFound(err) {
 				log.Progressf("%s is waiting for daemonsets %v to exist", prefix, namespacedName)
+				workload := newWorkloadReadiness(daemonSetKind, namespacedName, expectedNodes, 0)
+				workload.Message = "Waiting for the daemonset to exist"
+				recordNotReady(log, client, vz, prefix, workload, nil)
 				return false
 			}
 			log.Errorf("Failed getting daemonset %v: %v", namespacedName, err)
 			return false
 		}
+
+		// Velero install deploys a daemonset and deployment with common labels. The labels need to be adjusted so the pod fetch logic works
+		// as expected
+		podSelector := daemonset.Spec.Selector
+		if namespacedName.Namespace == constants.VeleroNameSpace {
+			podSelector = resticPodSelector
+		}
+
+		workload := newWorkloadReadiness(daemonSetKind, namespacedName, expectedNodes, daemonset.Status.NumberAvailable)
 		if daemonset.Status.UpdatedNumberScheduled < expectedNodes {
 			log.Progressf("%s is waiting for daemonset %s nodes to be %v. Current updated nodes is %v", prefix, namespacedName,
 				expectedNodes, daemonset.Status.NumberAvailable)
+			workload.Message = fmt.Sprintf("Waiting for updated nodes to be %v, current updated nodes is %v", expectedNodes, daemonset.Status.UpdatedNumberScheduled)
+			recordNotReady(log, client, vz, prefix, workload, podSelector)
 			return false
 		}
 
 		if daemonset.Status.NumberAvailable < expectedNodes {
 			log.Progressf("%s is waiting for daemonset %s nodes to be %v. Current available nodes is %v", prefix, namespacedName,
 				expectedNodes, daemonset.Status.NumberAvailable)
+			workload.Message = fmt.Sprintf("Waiting for available nodes to be %v, current available nodes is %v", expectedNodes, daemonset.Status.NumberAvailable)
+			recordNotReady(log, client, vz, prefix, workload, podSelector)
 			return false
 		}
 
-		// Velero install deploys a daemonset and deployment with common labels. The labels need to be adjusted so the pod fetch logic works
-		// as expected
-		podSelector := daemonset.Spec.Selector
-		if namespacedName.Namespace == constants.VeleroNameSpace {
-			podSelector = resticPodSelector
-		}
-
 		if !podsReadyDaemonSet(log, client, namespacedName, podSelector, expectedNodes, prefix) {
+			workload.Message = "Waiting for the pods of the latest controller revision to be ready"
+			recordNotReady(log, client, vz, prefix, workload, podSelector)
 			return false
 		}
+		recordReady(vz, prefix, workload)
 		log.Oncef("%s has enough nodes for daemonsets %v", prefix, namespacedName)
 	}
 	return true
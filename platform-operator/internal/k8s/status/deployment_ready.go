@@ -7,6 +7,7 @@ import (
 	"fmt"
 	pkgConstants "github.com/verrazzano/verrazzano/pkg/constants"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -23,7 +24,7 @@ var veleroPodSelector = &metav1.LabelSelector{
 	},
 }
 
-func DeploymentsReadyBySelectors(log vzlog.VerrazzanoLogger, client clipkg.Client, expectedReplicas int32, prefix string, opts ...clipkg.ListOption) bool {
+func DeploymentsReadyBySelectors(log vzlog.VerrazzanoLogger, client clipkg.Client, vz *vzapi.Verrazzano, expectedReplicas int32, prefix string, opts ...clipkg.ListOption) bool {
 	deploymentList := &appsv1.DeploymentList{}
 	if err := client.List(context.TODO(), deploymentList, opts...); err != nil {
 		log.Errorf("%s failed listing deployments for selectors %v: %v", prefix, opts, err)
@@ -39,7 +40,7 @@ func DeploymentsReadyBySelectors(log vzlog.VerrazzanoLogger, client clipkg.Clien
 			Namespace: deployment.Namespace,
 			Name:      deployment.Name,
 		}
-		if !deploymentFullyReady(log, client, deployment, namespacedName, expectedReplicas, prefix) {
+		if !deploymentFullyReady(log, client, vz, deployment, namespacedName, expectedReplicas, prefix) {
 			return false
 		}
 	}
@@ -47,18 +48,22 @@ func DeploymentsReadyBySelectors(log vzlog.VerrazzanoLogger, client clipkg.Clien
 }
 
 // DeploymentsAreReady check that the named deployments have the minimum number of specified replicas ready and available
-func DeploymentsAreReady(log vzlog.VerrazzanoLogger, client clipkg.Client, namespacedNames []types.NamespacedName, expectedReplicas int32, prefix string) bool {
+// The workloads that are not ready are recorded in the readiness report of the component of the Verrazzano resource
+func DeploymentsAreReady(log vzlog.VerrazzanoLogger, client clipkg.Client, vz *vzapi.Verrazzano, namespacedNames []types.NamespacedName, expectedReplicas int32, prefix string) bool {
 	for _, namespacedName := range namespacedNames {
 		deployment := appsv1.Deployment{}
 		if err := client.Get(context.TODO(), namespacedName, &deployment); err != nil {
 			if errors.IsNotFound(err) {
 				log.Progressf("%s is waiting for deployment %v to exist", prefix, namespacedName)
+				workload := newWorkloadReadiness(deploymentKind, namespacedName, expectedReplicas, 0)
+				workload.Message = "Waiting for the deployment to exist"
+				recordNotReady(log, client, vz, prefix, workload, nil)
 				return false
 			}
 			log.Errorf("%s failed getting deployment %v: %v", prefix, namespacedName, err)
 			return false
 		}
-		if !deploymentFullyReady(log, client, &deployment, namespacedName, expectedReplicas, prefix) {
+		if !deploymentFullyReady(log, client, vz, &deployment, namespacedName, expectedReplicas, prefix) {
 			return false
 		}
 	}
@@ -81,28 +86,36 @@ func DoDeploymentsExist(log vzlog.VerrazzanoLogger, client clipkg.Client, namesp
 	return true
 }
 
-func deploymentFullyReady(log vzlog.VerrazzanoLogger, client clipkg.Client, deployment *appsv1.Deployment, namespacedName types.NamespacedName, expectedReplicas int32, prefix string) bool {
+func deploymentFullyReady(log vzlog.VerrazzanoLogger, client clipkg.Client, vz *vzapi.Verrazzano, deployment *appsv1.Deployment, namespacedName types.NamespacedName, expectedReplicas int32, prefix string) bool {
+	// Velero install deploys a daemonset and deployment with common labels. The labels need to be adjusted so the pod fetch logic works
+	// as expected
+	podSelector := deployment.Spec.Selector
+	if namespacedName.Namespace == constants.VeleroNameSpace && namespacedName.Name == pkgConstants.Velero {
+		podSelector = veleroPodSelector
+	}
+
+	workload := newWorkloadReadiness(deploymentKind, namespacedName, expectedReplicas, deployment.Status.AvailableReplicas)
 	if deployment.Status.UpdatedReplicas < expectedReplicas {
 		log.Progressf("%s is waiting for deployment %s replicas to be %v. Current updated replicas is %v", prefix, namespacedName,
 			expectedReplicas, deployment.Status.UpdatedReplicas)
+		workload.Message = fmt.Sprintf("Waiting for updated replicas to be %v, current updated replicas is %v", expectedReplicas, deployment.Status.UpdatedReplicas)
+		recordNotReady(log, client, vz, prefix, workload, podSelector)
 		return false
 	}
 	if deployment.Status.AvailableReplicas < expectedReplicas {
 		log.Progressf("%s is waiting for deployment %s replicas to be %v. Current available replicas is %v", prefix, namespacedName,
 			expectedReplicas, deployment.Status.AvailableReplicas)
+		workload.Message = fmt.Sprintf("Waiting for available replicas to be %v, current available replicas is %v", expectedReplicas, deployment.Status.AvailableReplicas)
+		recordNotReady(log, client, vz, prefix, workload, podSelector)
 		return false
 	}
 
-	// Velero install deploys a daemonset and deployment with common labels. The labels need to be adjusted so the pod fetch logic works
-	// as expected
-	podSelector := deployment.Spec.Selector
-	if namespacedName.Namespace == constants.VeleroNameSpace && namespacedName.Name == pkgConstants.Velero {
-		podSelector = veleroPodSelector
-	}
-
 	if !podsReadyDeployment(log, client, namespacedName, podSelector, expectedReplicas, prefix) {
+		workload.Message = "Waiting for the pods of the latest replicaset revision to be ready"
+		recordNotReady(log, client, vz, prefix, workload, podSelector)
 		return false
 	}
+	recordReady(vz, prefix, workload)
 	log.Oncef("%s has enough replicas for deployment %v", prefix, namespacedName)
 	return true
 }
@@ -102,7 +102,7 @@ func TestDeploymentsReadyBySelectors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ready := DeploymentsReadyBySelectors(log, tt.c, 1, "foo", tt.opts...)
+			ready := DeploymentsReadyBySelectors(log, tt.c, nil, 1, "foo", tt.opts...)
 			assert.Equal(t, tt.ready, ready)
 		})
 	}
@@ -124,7 +124,7 @@ func TestDeploymentsReady(t *testing.T) {
 		testReadyPod,
 		testReadyReplicaSet,
 	)
-	assert.True(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, namespacedName, 1, ""))
+	assert.True(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, nil, namespacedName, 1, ""))
 }
 
 // TestDeploymentsContainerNotReady tests a deployment ready status check
@@ -183,7 +183,7 @@ func TestDeploymentsContainerNotReady(t *testing.T) {
 			},
 		},
 	)
-	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, namespacedName, 1, ""))
+	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, nil, namespacedName, 1, ""))
 }
 
 // TestDeploymentsInitContainerNotReady tests a deployment ready status check
@@ -242,7 +242,7 @@ func TestDeploymentsInitContainerNotReady(t *testing.T) {
 			},
 		},
 	)
-	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, namespacedName, 1, ""))
+	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, nil, namespacedName, 1, ""))
 }
 
 // TestMultipleReplicasReady tests a deployment ready status check
@@ -316,7 +316,7 @@ func TestMultipleReplicasReady(t *testing.T) {
 			},
 		},
 	)
-	assert.True(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, namespacedName, 2, ""))
+	assert.True(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, nil, namespacedName, 2, ""))
 }
 
 // TestMultipleReplicasReadyAboveThreshold tests a deployment ready status check
@@ -390,7 +390,7 @@ func TestMultipleReplicasReadyAboveThreshold(t *testing.T) {
 			},
 		},
 	)
-	assert.True(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, namespacedName, 1, ""))
+	assert.True(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, nil, namespacedName, 1, ""))
 }
 
 // TestDeploymentsNoneAvailable tests a deployment ready status check
@@ -415,7 +415,7 @@ func TestDeploymentsNoneAvailable(t *testing.T) {
 			UpdatedReplicas:   1,
 		},
 	})
-	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, namespacedName, 1, ""))
+	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, nil, namespacedName, 1, ""))
 }
 
 // TestDeploymentsNoneUpdated tests a deployment ready status check
@@ -440,7 +440,7 @@ func TestDeploymentsNoneUpdated(t *testing.T) {
 			UpdatedReplicas:   0,
 		},
 	})
-	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, namespacedName, 1, ""))
+	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, nil, namespacedName, 1, ""))
 }
 
 // TestMultipleReplicasReadyBelowThreshold tests a deployment ready status check
@@ -499,7 +499,7 @@ func TestMultipleReplicasReadyBelowThreshold(t *testing.T) {
 			},
 		},
 	)
-	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, namespacedName, 3, ""))
+	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, nil, namespacedName, 3, ""))
 }
 
 // TestDeploymentsReadyDeploymentNotFound tests a deployment ready status check
@@ -514,7 +514,7 @@ func TestDeploymentsReadyDeploymentNotFound(t *testing.T) {
 		},
 	}
 	client := fake.NewFakeClientWithScheme(k8scheme.Scheme)
-	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, namespacedName, 1, ""))
+	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, nil, namespacedName, 1, ""))
 }
 
 // TestDeploymentsReadyReplicaSetNotFound tests a deployment ready status check
@@ -566,7 +566,7 @@ func TestDeploymentsReadyReplicaSetNotFound(t *testing.T) {
 			},
 		},
 	)
-	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, namespacedName, 1, ""))
+	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, nil, namespacedName, 1, ""))
 }
 
 // TestDeploymentsReadyPodNotFound tests a deployment ready status check
@@ -601,5 +601,5 @@ func TestDeploymentsReadyPodNotFound(t *testing.T) {
 			},
 		})
 
-	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, namespacedName, 1, ""))
+	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, nil, namespacedName, 1, ""))
 }
@@ -0,0 +1,213 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package status
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	deploymentKind  = "Deployment"
+	statefulSetKind = "StatefulSet"
+	daemonSetKind   = "DaemonSet"
+
+	// maxUnreadyPodsReported limits the number of unready pods reported for a single workload,
+	// so a large workload does not bloat the Verrazzano status
+	maxUnreadyPodsReported = 5
+)
+
+// readinessReportKey identifies the readiness report of a component of a Verrazzano resource, the component is
+// identified by the prefix passed to the readiness checks
+type readinessReportKey struct {
+	verrazzano types.NamespacedName
+	prefix     string
+}
+
+// readinessReports holds the workloads that were not ready the last time they were checked.  The reports are
+// keyed by the Verrazzano resource and component, and then by workload.
+var readinessReports = map[readinessReportKey]map[string]vzapi.WorkloadReadiness{}
+var readinessReportsMutex sync.Mutex
+
+// ComponentPrefix returns the prefix that components pass to the readiness checks
+func ComponentPrefix(componentName string) string {
+	return fmt.Sprintf("Component %s", componentName)
+}
+
+// newReadinessReportKey returns the key of the readiness report of the Verrazzano resource and prefix
+func newReadinessReportKey(vz *vzapi.Verrazzano, prefix string) readinessReportKey {
+	key := readinessReportKey{prefix: prefix}
+	if vz != nil {
+		key.verrazzano = types.NamespacedName{Namespace: vz.Namespace, Name: vz.Name}
+	}
+	return key
+}
+
+// GetComponentReadinessReport returns the workloads of a component of a Verrazzano resource that were not ready the
+// last time the readiness of the component was checked, or nil if there are none
+func GetComponentReadinessReport(vz *vzapi.Verrazzano, componentName string) *vzapi.ReadinessReport {
+	readinessReportsMutex.Lock()
+	defer readinessReportsMutex.Unlock()
+
+	workloads := readinessReports[newReadinessReportKey(vz, ComponentPrefix(componentName))]
+	if len(workloads) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(workloads))
+	for key := range workloads {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	report := &vzapi.ReadinessReport{}
+	for _, key := range keys {
+		report.Workloads = append(report.Workloads, workloads[key])
+	}
+	return report
+}
+
+// ClearComponentReadinessReport forgets the recorded readiness of the workloads of a component of a Verrazzano
+// resource
+func ClearComponentReadinessReport(vz *vzapi.Verrazzano, componentName string) {
+	readinessReportsMutex.Lock()
+	defer readinessReportsMutex.Unlock()
+	delete(readinessReports, newReadinessReportKey(vz, ComponentPrefix(componentName)))
+}
+
+// ClearReadinessReports forgets the recorded readiness of the workloads of all the components of a deleted
+// Verrazzano resource
+func ClearReadinessReports(vz *vzapi.Verrazzano) {
+	readinessReportsMutex.Lock()
+	defer readinessReportsMutex.Unlock()
+	verrazzano := newReadinessReportKey(vz, "").verrazzano
+	for key := range readinessReports {
+		if key.verrazzano == verrazzano {
+			delete(readinessReports, key)
+		}
+	}
+}
+
+// newWorkloadReadiness creates the readiness details of a workload
+func newWorkloadReadiness(kind string, namespacedName types.NamespacedName, expectedReplicas int32, readyReplicas int32) vzapi.WorkloadReadiness {
+	return vzapi.WorkloadReadiness{
+		Kind:             kind,
+		Namespace:        namespacedName.Namespace,
+		Name:             namespacedName.Name,
+		ExpectedReplicas: expectedReplicas,
+		ReadyReplicas:    readyReplicas,
+	}
+}
+
+// recordNotReady records the details of a workload that is not ready, including the pods matching the
+// selector that are not ready
+func recordNotReady(log vzlog.VerrazzanoLogger, client clipkg.Client, vz *vzapi.Verrazzano, prefix string, workload vzapi.WorkloadReadiness, selector *metav1.LabelSelector) {
+	if selector != nil {
+		workload.UnreadyPods = getUnreadyPods(log, client, types.NamespacedName{Namespace: workload.Namespace, Name: workload.Name}, selector)
+	}
+
+	readinessReportsMutex.Lock()
+	defer readinessReportsMutex.Unlock()
+	key := newReadinessReportKey(vz, prefix)
+	workloads, ok := readinessReports[key]
+	if !ok {
+		workloads = map[string]vzapi.WorkloadReadiness{}
+		readinessReports[key] = workloads
+	}
+	workloads[workloadKey(workload)] = workload
+}
+
+// recordReady removes a workload that is ready from the recorded readiness details
+func recordReady(vz *vzapi.Verrazzano, prefix string, workload vzapi.WorkloadReadiness) {
+	readinessReportsMutex.Lock()
+	defer readinessReportsMutex.Unlock()
+	key := newReadinessReportKey(vz, prefix)
+	workloads, ok := readinessReports[key]
+	if !ok {
+		return
+	}
+	delete(workloads, workloadKey(workload))
+	if len(workloads) == 0 {
+		delete(readinessReports, key)
+	}
+}
+
+func workloadKey(workload vzapi.WorkloadReadiness) string {
+	return fmt.Sprintf("%s/%s/%s", workload.Kind, workload.Namespace, workload.Name)
+}
+
+// getUnreadyPods returns the readiness details of the pods matching a selector that are not ready
+func getUnreadyPods(log vzlog.VerrazzanoLogger, client clipkg.Client, namespacedName types.NamespacedName, selector *metav1.LabelSelector) []vzapi.PodReadiness {
+	pods := getPodsList(log, client, namespacedName, selector)
+	if pods == nil {
+		return nil
+	}
+	var unreadyPods []vzapi.PodReadiness
+	for i := range pods.Items {
+		if podReadiness := getPodReadiness(&pods.Items[i]); podReadiness != nil {
+			unreadyPods = append(unreadyPods, *podReadiness)
+			if len(unreadyPods) == maxUnreadyPodsReported {
+				break
+			}
+		}
+	}
+	return unreadyPods
+}
+
+// getPodReadiness returns the readiness details of a pod, or nil if the pod is ready
+func getPodReadiness(pod *corev1.Pod) *vzapi.PodReadiness {
+	var containers []vzapi.ContainerReadiness
+	for _, containerStatus := range pod.Status.InitContainerStatuses {
+		if !containerStatus.Ready {
+			containers = append(containers, getContainerReadiness(containerStatus, true))
+		}
+	}
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if !containerStatus.Ready {
+			containers = append(containers, getContainerReadiness(containerStatus, false))
+		}
+	}
+	if len(containers) == 0 && pod.Status.Phase != corev1.PodPending {
+		return nil
+	}
+
+	podReadiness := &vzapi.PodReadiness{
+		Name:       pod.Name,
+		Phase:      string(pod.Status.Phase),
+		Reason:     pod.Status.Reason,
+		Message:    pod.Status.Message,
+		Containers: containers,
+	}
+	// Explain why a pod could not be scheduled, for example insufficient resources
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+			podReadiness.Reason = condition.Reason
+			podReadiness.Message = condition.Message
+		}
+	}
+	return podReadiness
+}
+
+// getContainerReadiness returns the readiness details of a container that is not ready
+func getContainerReadiness(containerStatus corev1.ContainerStatus, initContainer bool) vzapi.ContainerReadiness {
+	containerReadiness := vzapi.ContainerReadiness{
+		Name:          containerStatus.Name,
+		InitContainer: initContainer,
+		RestartCount:  containerStatus.RestartCount,
+	}
+	if containerStatus.State.Waiting != nil {
+		containerReadiness.Reason = containerStatus.State.Waiting.Reason
+		containerReadiness.Message = containerStatus.State.Waiting.Message
+	} else if containerStatus.State.Terminated != nil {
+		containerReadiness.Reason = containerStatus.State.Terminated.Reason
+		containerReadiness.Message = containerStatus.State.Terminated.Message
+	}
+	return containerReadiness
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testComponentName = "test-component"
+
+// testVerrazzano is the Verrazzano resource whose components are checked
+var testVerrazzano = &vzapi.Verrazzano{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"}}
+
+// TestReadinessReportDeploymentNotReady tests the readiness report for a deployment
+// GIVEN a deployment with a pod that cannot pull its image
+// WHEN DeploymentsAreReady is called
+// THEN the component readiness report includes the deployment, the pod and the container waiting reason
+func TestReadinessReportDeploymentNotReady(t *testing.T) {
+	defer ClearComponentReadinessReport(testVerrazzano, testComponentName)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:        1,
+			UpdatedReplicas: 1,
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "bar",
+			Name:      "foo-95d8c5d96-m6mbr",
+			Labels:    map[string]string{"app": "foo", podTemplateHashLabel: "95d8c5d96"},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  "foo",
+					Ready: false,
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{
+							Reason:  "ImagePullBackOff",
+							Message: "Back-off pulling image",
+						},
+					},
+				},
+			},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(deployment, pod).Build()
+
+	namespacedNames := []types.NamespacedName{{Namespace: "bar", Name: "foo"}}
+	assert.False(t, DeploymentsAreReady(vzlog.DefaultLogger(), client, testVerrazzano, namespacedNames, 1, ComponentPrefix(testComponentName)))
+
+	report := GetComponentReadinessReport(testVerrazzano, testComponentName)
+	assert.NotNil(t, report)
+	assert.Len(t, report.Workloads, 1)
+	workload := report.Workloads[0]
+	assert.Equal(t, deploymentKind, workload.Kind)
+	assert.Equal(t, "bar", workload.Namespace)
+	assert.Equal(t, "foo", workload.Name)
+	assert.Equal(t, int32(1), workload.ExpectedReplicas)
+	assert.Equal(t, int32(0), workload.ReadyReplicas)
+	assert.NotEmpty(t, workload.Message)
+	assert.Len(t, workload.UnreadyPods, 1)
+	assert.Equal(t, "foo-95d8c5d96-m6mbr", workload.UnreadyPods[0].Name)
+	assert.Equal(t, string(corev1.PodPending), workload.UnreadyPods[0].Phase)
+	assert.Len(t, workload.UnreadyPods[0].Containers, 1)
+	assert.Equal(t, "foo", workload.UnreadyPods[0].Containers[0].Name)
+	assert.Equal(t, "ImagePullBackOff", workload.UnreadyPods[0].Containers[0].Reason)
+}
+
+// TestReadinessReportWorkloadBecomesReady tests that ready workloads are removed from the readiness report
+// GIVEN a statefulset that does not exist
+// WHEN StatefulSetsAreReady is called before and after the statefulset is ready
+// THEN the readiness report includes the statefulset first, and is empty once the statefulset is ready
+func TestReadinessReportWorkloadBecomesReady(t *testing.T) {
+	defer ClearComponentReadinessReport(testVerrazzano, testComponentName)
+
+	namespacedNames := []types.NamespacedName{{Namespace: "bar", Name: "foo"}}
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	assert.False(t, StatefulSetsAreReady(vzlog.DefaultLogger(), client, testVerrazzano, namespacedNames, 1, ComponentPrefix(testComponentName)))
+
+	report := GetComponentReadinessReport(testVerrazzano, testComponentName)
+	assert.NotNil(t, report)
+	assert.Len(t, report.Workloads, 1)
+	assert.Equal(t, statefulSetKind, report.Workloads[0].Kind)
+	assert.Equal(t, "Waiting for the statefulset to exist", report.Workloads[0].Message)
+
+	client = fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"},
+			Spec: appsv1.StatefulSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+			},
+			Status: appsv1.StatefulSetStatus{
+				ReadyReplicas:   1,
+				UpdatedReplicas: 1,
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "bar",
+				Name:      "foo-0",
+				Labels:    map[string]string{"app": "foo", controllerRevisionHashLabel: "foo-95d8c5d96"},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		&appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo-95d8c5d96"},
+			Revision:   1,
+		},
+	).Build()
+	assert.True(t, StatefulSetsAreReady(vzlog.DefaultLogger(), client, testVerrazzano, namespacedNames, 1, ComponentPrefix(testComponentName)))
+	assert.Nil(t, GetComponentReadinessReport(testVerrazzano, testComponentName))
+}
+
+// TestReadinessReportsPerVerrazzano tests that the readiness reports are kept for each Verrazzano resource
+// GIVEN two Verrazzano resources with the same component
+// WHEN the workloads of the component of one resource are not ready, and then the resource is deleted
+// THEN only the component of that resource has a readiness report, and the report is cleared with the resource
+func TestReadinessReportsPerVerrazzano(t *testing.T) {
+	other := &vzapi.Verrazzano{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "verrazzano"}}
+	namespacedNames := []types.NamespacedName{{Namespace: "bar", Name: "foo"}}
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	assert.False(t, DaemonSetsAreReady(vzlog.DefaultLogger(), client, testVerrazzano, namespacedNames, 1, ComponentPrefix(testComponentName)))
+
+	assert.NotNil(t, GetComponentReadinessReport(testVerrazzano, testComponentName))
+	assert.Nil(t, GetComponentReadinessReport(other, testComponentName))
+
+	ClearReadinessReports(testVerrazzano)
+	assert.Nil(t, GetComponentReadinessReport(testVerrazzano, testComponentName))
+}
+
+// TestGetPodReadinessUnschedulable tests the readiness details of a pod that cannot be scheduled
+// GIVEN a pending pod with a PodScheduled condition that is false
+// WHEN getPodReadiness is called
+// THEN the scheduling reason and message are reported
+func TestGetPodReadinessUnschedulable(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo-0"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Unschedulable",
+					Message: "0/3 nodes are available: 3 Insufficient memory.",
+				},
+			},
+		},
+	}
+	podReadiness := getPodReadiness(pod)
+	assert.NotNil(t, podReadiness)
+	assert.Equal(t, "Unschedulable", podReadiness.Reason)
+	assert.Equal(t, "0/3 nodes are available: 3 Insufficient memory.", podReadiness.Message)
+
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.Conditions = nil
+	assert.Nil(t, getPodReadiness(pod))
+}
@@ -5,8 +5,10 @@ package status
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -17,31 +19,43 @@ import (
 )
 
 // StatefulSetsAreReady Check that the named statefulsets have the minimum number of specified replicas ready and available
-func StatefulSetsAreReady(log vzlog.VerrazzanoLogger, client client.Client, namespacedNames []types.NamespacedName, expectedReplicas int32, prefix string) bool {
+// The workloads that are not ready are recorded in the readiness report of the component of the Verrazzano resource
+func StatefulSetsAreReady(log vzlog.VerrazzanoLogger, client client.Client, vz *vzapi.Verrazzano, namespacedNames []types.NamespacedName, expectedReplicas int32, prefix string) bool {
 	for _, namespacedName := range namespacedNames {
 		statefulset := appsv1.StatefulSet{}
 		if err := client.Get(context.TODO(), namespacedName, &statefulset); err != nil {
 			if errors.IsNotFound(err) {
 				log.Progressf("%s is waiting for statefulset %v to exist", prefix, namespacedName)
 				// StatefulSet not found
+				workload := newWorkloadReadiness(statefulSetKind, namespacedName, expectedReplicas, 0)
+				workload.Message = "Waiting for the statefulset to exist"
+				recordNotReady(log, client, vz, prefix, workload, nil)
 				return false
 			}
 			log.Errorf("Failed getting statefulset %v: %v", namespacedName, err)
 			return false
 		}
+		workload := newWorkloadReadiness(statefulSetKind, namespacedName, expectedReplicas, statefulset.Status.ReadyReplicas)
 		if statefulset.Status.UpdatedReplicas < expectedReplicas {
 			log.Progressf("%s is waiting for statefulset %s replicas to be %v. Current updated replicas is %v", prefix, namespacedName,
 				expectedReplicas, statefulset.Status.ReadyReplicas)
+			workload.Message = fmt.Sprintf("Waiting for updated replicas to be %v, current updated replicas is %v", expectedReplicas, statefulset.Status.UpdatedReplicas)
+			recordNotReady(log, client, vz, prefix, workload, statefulset.Spec.Selector)
 			return false
 		}
 		if statefulset.Status.ReadyReplicas < expectedReplicas {
 			log.Progressf("%s is waiting for statefulset %s replicas to be %v. Current ready replicas is %v", prefix, namespacedName,
 				expectedReplicas, statefulset.Status.ReadyReplicas)
+			workload.Message = fmt.Sprintf("Waiting for ready replicas to be %v, current ready replicas is %v", expectedReplicas, statefulset.Status.ReadyReplicas)
+			recordNotReady(log, client, vz, prefix, workload, statefulset.Spec.Selector)
 			return false
 		}
 		if !podsReadyStatefulSet(log, client, namespacedName, statefulset.Spec.Selector, expectedReplicas, prefix) {
+			workload.Message = "Waiting for the pods of the latest controller revision to be ready"
+			recordNotReady(log, client, vz, prefix, workload, statefulset.Spec.Selector)
 			return false
 		}
+		recordReady(vz, prefix, workload)
 		log.Oncef("%s has enough replicas for statefulsets %v", prefix, namespacedName)
 	}
 	return true
@@ -0,0 +1,382 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package kcadmin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// adminCLIClientID is the client that the admin users log in with
+	adminCLIClientID = "admin-cli"
+
+	defaultTimeout = 30 * time.Second
+
+	// tokenExpiryMargin is how long before the access token expires the client logs in again
+	tokenExpiryMargin = 10 * time.Second
+)
+
+// Client is a client of the Keycloak admin REST API
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+	// tokenExpiry is when the client logs in again, it is zero if the access token has no known lifespan
+	tokenExpiry time.Time
+	// loginRealm and loginForm are the realm and form of the last successful login, to log in again when the access
+	// token expires
+	loginRealm string
+	loginForm  url.Values
+}
+
+// APIError is an error response of the Keycloak admin REST API
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Keycloak request %s %s failed with status code %d: %s", e.Method, e.Path, e.StatusCode, e.Body)
+}
+
+// IsNotFound returns true if the error is a response of the Keycloak admin REST API that an object was not found
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// NewClient returns a client of the Keycloak admin REST API at the given URL, such as http://localhost:8080/auth.  The
+// default HTTP client is used if httpClient is nil.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	return &Client{baseURL: strings.TrimSuffix(baseURL, "/"), httpClient: httpClient}
+}
+
+// Login gets an access token for an admin user of a realm, which is used by the subsequent requests.  The client logs
+// in again with the same credentials when the access token expires, or when a request is unauthorized.
+func (c *Client) Login(realm string, username string, password string) error {
+	form := url.Values{}
+	form.Set("grant_type", "password")
+	form.Set("client_id", adminCLIClientID)
+	form.Set("username", username)
+	form.Set("password", password)
+	return c.login(realm, form)
+}
+
+// login gets an access token with the password grant form
+func (c *Client) login(realm string, form url.Values) error {
+	tokenPath := fmt.Sprintf("/realms/%s/protocol/openid-connect/token", url.PathEscape(realm))
+	resp, err := c.httpClient.Post(c.baseURL+tokenPath, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// The body of a failed login does not contain the password, but it is not returned to be safe
+		return &APIError{Method: http.MethodPost, Path: tokenPath, StatusCode: resp.StatusCode}
+	}
+	token := struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return err
+	}
+	if len(token.AccessToken) == 0 {
+		return fmt.Errorf("Keycloak login of user %s in realm %s returned an empty access token", form.Get("username"), realm)
+	}
+	c.token = token.AccessToken
+	c.tokenExpiry = time.Time{}
+	if token.ExpiresIn > 0 {
+		c.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenExpiryMargin)
+	}
+	c.loginRealm = realm
+	c.loginForm = form
+	return nil
+}
+
+// relogin logs in again with the realm and form of the last successful login
+func (c *Client) relogin() error {
+	return c.login(c.loginRealm, c.loginForm)
+}
+
+// do sends a request to the admin REST API, with the JSON encoding of in as the body if not nil, and decodes the JSON
+// response into out if not nil.  It returns the ID of the created object for a response with a Location header.  The
+// client logs in again before the request if the access token expired, and retries the request once if it is
+// unauthorized, since the access token can be revoked or expire early.
+func (c *Client) do(method string, apiPath string, query url.Values, in interface{}, out interface{}) (string, error) {
+	var data []byte
+	if in != nil {
+		var err error
+		if data, err = json.Marshal(in); err != nil {
+			return "", err
+		}
+	}
+	reqURL := c.baseURL + "/admin" + apiPath
+	if len(query) > 0 {
+		reqURL = reqURL + "?" + query.Encode()
+	}
+	if c.loginForm != nil && !c.tokenExpiry.IsZero() && time.Now().After(c.tokenExpiry) {
+		if err := c.relogin(); err != nil {
+			return "", err
+		}
+	}
+	resp, respBody, err := c.send(method, reqURL, data)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized && c.loginForm != nil {
+		if err := c.relogin(); err != nil {
+			return "", err
+		}
+		resp, respBody, err = c.send(method, reqURL, data)
+	}
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", &APIError{Method: method, Path: apiPath, StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return "", fmt.Errorf("Failed to decode the response of Keycloak request %s %s: %v", method, apiPath, err)
+		}
+	}
+	if location := resp.Header.Get("Location"); len(location) > 0 {
+		return path.Base(location), nil
+	}
+	return "", nil
+}
+
+// send sends a request with the access token, and data as the JSON body if not nil.  It returns the response, whose
+// body is closed, and the response body.
+func (c *Client) send(method string, reqURL string, data []byte) (*http.Response, []byte, error) {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, reqURL, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, respBody, nil
+}
+
+func realmPath(realm string, elems ...string) string {
+	p := "/realms/" + url.PathEscape(realm)
+	for _, elem := range elems {
+		p = p + "/" + url.PathEscape(elem)
+	}
+	return p
+}
+
+// GetRealm returns a realm
+func (c *Client) GetRealm(realm string) (*Realm, error) {
+	r := &Realm{}
+	if _, err := c.do(http.MethodGet, realmPath(realm), nil, nil, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// CreateRealm creates a realm
+func (c *Client) CreateRealm(realm Realm) error {
+	_, err := c.do(http.MethodPost, "/realms", nil, realm, nil)
+	return err
+}
+
+// UpdateRealm updates the fields of a realm that are set
+func (c *Client) UpdateRealm(realm string, update Realm) error {
+	_, err := c.do(http.MethodPut, realmPath(realm), nil, update, nil)
+	return err
+}
+
+// GetGroups returns the top level groups of a realm, with their subgroups
+func (c *Client) GetGroups(realm string) ([]Group, error) {
+	var groups []Group
+	_, err := c.do(http.MethodGet, realmPath(realm, "groups"), nil, nil, &groups)
+	return groups, err
+}
+
+// CreateGroup creates a top level group, or a subgroup of the parent group if parentID is not empty, and returns the
+// ID of the group
+func (c *Client) CreateGroup(realm string, parentID string, group Group) (string, error) {
+	groupsPath := realmPath(realm, "groups")
+	if len(parentID) > 0 {
+		groupsPath = realmPath(realm, "groups", parentID, "children")
+	}
+	// A subgroup is returned in the response, rather than its location
+	created := Group{}
+	id, err := c.do(http.MethodPost, groupsPath, nil, group, &created)
+	if err == nil && len(id) == 0 {
+		id = created.ID
+	}
+	return id, err
+}
+
+// GetGroupRealmRoles returns the realm roles that are mapped to a group
+func (c *Client) GetGroupRealmRoles(realm string, groupID string) ([]Role, error) {
+	var roles []Role
+	_, err := c.do(http.MethodGet, realmPath(realm, "groups", groupID, "role-mappings", "realm"), nil, nil, &roles)
+	return roles, err
+}
+
+// AddGroupRealmRoles maps realm roles to a group
+func (c *Client) AddGroupRealmRoles(realm string, groupID string, roles []Role) error {
+	_, err := c.do(http.MethodPost, realmPath(realm, "groups", groupID, "role-mappings", "realm"), nil, roles, nil)
+	return err
+}
+
+// GetRealmRoles returns the roles of a realm
+func (c *Client) GetRealmRoles(realm string) ([]Role, error) {
+	var roles []Role
+	_, err := c.do(http.MethodGet, realmPath(realm, "roles"), nil, nil, &roles)
+	return roles, err
+}
+
+// GetRealmRole returns a role of a realm
+func (c *Client) GetRealmRole(realm string, name string) (*Role, error) {
+	role := &Role{}
+	if _, err := c.do(http.MethodGet, realmPath(realm, "roles", name), nil, nil, role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// CreateRealmRole creates a role of a realm
+func (c *Client) CreateRealmRole(realm string, role Role) error {
+	_, err := c.do(http.MethodPost, realmPath(realm, "roles"), nil, role, nil)
+	return err
+}
+
+// UpdateRealmRole updates a role of a realm
+func (c *Client) UpdateRealmRole(realm string, name string, role Role) error {
+	_, err := c.do(http.MethodPut, realmPath(realm, "roles", name), nil, role, nil)
+	return err
+}
+
+// GetUsers returns the users of a realm, or the user with the given username if not empty
+func (c *Client) GetUsers(realm string, username string) ([]User, error) {
+	var query url.Values
+	if len(username) > 0 {
+		query = url.Values{"username": {username}, "exact": {"true"}}
+	}
+	var users []User
+	_, err := c.do(http.MethodGet, realmPath(realm, "users"), query, nil, &users)
+	return users, err
+}
+
+// CreateUser creates a user, and returns the ID of the user
+func (c *Client) CreateUser(realm string, user User) (string, error) {
+	return c.do(http.MethodPost, realmPath(realm, "users"), nil, user, nil)
+}
+
+// ResetUserPassword sets the password of a user
+func (c *Client) ResetUserPassword(realm string, userID string, password string) error {
+	_, err := c.do(http.MethodPut, realmPath(realm, "users", userID, "reset-password"), nil,
+		Credential{Type: "password", Value: password}, nil)
+	return err
+}
+
+// GetClients returns the clients of a realm, or the client with the given client ID if not empty
+func (c *Client) GetClients(realm string, clientID string) ([]ClientRepresentation, error) {
+	var query url.Values
+	if len(clientID) > 0 {
+		query = url.Values{"clientId": {clientID}}
+	}
+	var clients []ClientRepresentation
+	_, err := c.do(http.MethodGet, realmPath(realm, "clients"), query, nil, &clients)
+	return clients, err
+}
+
+// CreateClient creates a client, and returns the ID of the client
+func (c *Client) CreateClient(realm string, client ClientRepresentation) (string, error) {
+	return c.do(http.MethodPost, realmPath(realm, "clients"), nil, client, nil)
+}
+
+// UpdateClient updates a client
+func (c *Client) UpdateClient(realm string, id string, client ClientRepresentation) error {
+	_, err := c.do(http.MethodPut, realmPath(realm, "clients", id), nil, client, nil)
+	return err
+}
+
+// GetClientSecret returns the secret of a confidential client
+func (c *Client) GetClientSecret(realm string, id string) (*Credential, error) {
+	secret := &Credential{}
+	if _, err := c.do(http.MethodGet, realmPath(realm, "clients", id, "client-secret"), nil, nil, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// RegenerateClientSecret generates a new secret for a confidential client
+func (c *Client) RegenerateClientSecret(realm string, id string) (*Credential, error) {
+	secret := &Credential{}
+	if _, err := c.do(http.MethodPost, realmPath(realm, "clients", id, "client-secret"), nil, nil, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// GetIdentityProviders returns the identity providers of a realm
+func (c *Client) GetIdentityProviders(realm string) ([]IdentityProvider, error) {
+	var providers []IdentityProvider
+	_, err := c.do(http.MethodGet, realmPath(realm, "identity-provider", "instances"), nil, nil, &providers)
+	return providers, err
+}
+
+// CreateIdentityProvider creates an identity provider
+func (c *Client) CreateIdentityProvider(realm string, provider IdentityProvider) error {
+	_, err := c.do(http.MethodPost, realmPath(realm, "identity-provider", "instances"), nil, provider, nil)
+	return err
+}
+
+// UpdateIdentityProvider updates an identity provider
+func (c *Client) UpdateIdentityProvider(realm string, alias string, provider IdentityProvider) error {
+	_, err := c.do(http.MethodPut, realmPath(realm, "identity-provider", "instances", alias), nil, provider, nil)
+	return err
+}
+
+// GetComponents returns the components of a realm with the given provider type
+func (c *Client) GetComponents(realm string, providerType string) ([]Component, error) {
+	var components []Component
+	_, err := c.do(http.MethodGet, realmPath(realm, "components"), url.Values{"type": {providerType}}, nil, &components)
+	return components, err
+}
+
+// CreateComponent creates a component, and returns the ID of the component
+func (c *Client) CreateComponent(realm string, component Component) (string, error) {
+	return c.do(http.MethodPost, realmPath(realm, "components"), nil, component, nil)
+}
+
+// UpdateComponent updates a component
+func (c *Client) UpdateComponent(realm string, id string, component Component) error {
+	_, err := c.do(http.MethodPut, realmPath(realm, "components", id), nil, component, nil)
+	return err
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package kcadmin_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/kcadmin"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/kcadmin/fake"
+)
+
+const (
+	testRealm    = "test"
+	testUser     = "admin"
+	testPassword = "secret"
+)
+
+// newLoggedInClient starts a fake Keycloak server with a test realm, and returns a client that is logged in
+func newLoggedInClient(t *testing.T) (*kcadmin.Client, *fake.Server) {
+	server := fake.NewServer(testUser, testPassword)
+	server.AddRealm(testRealm)
+	kc := kcadmin.NewClient(server.BaseURL(), nil)
+	assert.NoError(t, kc.Login("master", testUser, testPassword))
+	return kc, server
+}
+
+// TestLogin tests the Login fn
+// GIVEN a Keycloak server
+// WHEN Login is called with a wrong and then the right password
+// THEN an error is returned that does not contain the password, and then the admin API can be called
+func TestLogin(t *testing.T) {
+	server := fake.NewServer(testUser, testPassword)
+	defer server.Close()
+	kc := kcadmin.NewClient(server.BaseURL(), nil)
+
+	err := kc.Login("master", testUser, "wrong")
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "wrong")
+	_, err = kc.GetRealm("master")
+	assert.Error(t, err)
+
+	assert.NoError(t, kc.Login("master", testUser, testPassword))
+	realm, err := kc.GetRealm("master")
+	assert.NoError(t, err)
+	assert.Equal(t, "master", realm.Realm)
+}
+
+// TestRefreshToken tests logging in again when the access token is revoked or expires
+// GIVEN a logged in client
+// WHEN the access token is revoked, and then the access tokens expire before the next request
+// THEN the client logs in again and the requests succeed
+func TestRefreshToken(t *testing.T) {
+	kc, server := newLoggedInClient(t)
+	defer server.Close()
+	const tokenRequest = "POST /realms/master/protocol/openid-connect/token"
+
+	server.RevokeTokens()
+	server.Requests = nil
+	_, err := kc.GetRealm(testRealm)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GET /admin/realms/test", tokenRequest, "GET /admin/realms/test"}, server.Requests)
+
+	// The lifespan is shorter than the expiry margin, so the token expires before each request
+	server.TokenLifespan = 1
+	assert.NoError(t, kc.Login("master", testUser, testPassword))
+	server.Requests = nil
+	_, err = kc.GetRealm(testRealm)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{tokenRequest, "GET /admin/realms/test"}, server.Requests)
+
+	// A request is not retried if logging in again fails
+	server.RevokeTokens()
+	server.Realms["master"].Passwords[testUser] = "changed"
+	_, err = kc.GetRealm(testRealm)
+	apiErr, ok := err.(*kcadmin.APIError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+}
+
+// TestAPIError tests the errors of the admin API
+// GIVEN a Keycloak server
+// WHEN an object that does not exist is requested, and a request fails
+// THEN an APIError with the status code is returned, and IsNotFound is true only for the missing object
+func TestAPIError(t *testing.T) {
+	kc, server := newLoggedInClient(t)
+	defer server.Close()
+
+	_, err := kc.GetRealmRole(testRealm, "missing")
+	assert.True(t, kcadmin.IsNotFound(err))
+
+	server.Fail = func(_ *http.Request) int { return http.StatusInternalServerError }
+	_, err = kc.GetRealm(testRealm)
+	assert.False(t, kcadmin.IsNotFound(err))
+	apiErr, ok := err.(*kcadmin.APIError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	assert.Equal(t, "/realms/test", apiErr.Path)
+}
+
+// TestRealm tests the realm fns
+// GIVEN a Keycloak server
+// WHEN a realm is created and a field is updated
+// THEN only the updated field changes
+func TestRealm(t *testing.T) {
+	kc, server := newLoggedInClient(t)
+	defer server.Close()
+
+	enabled := false
+	assert.NoError(t, kc.CreateRealm(kcadmin.Realm{Realm: "new", Enabled: &enabled}))
+	assert.NoError(t, kc.UpdateRealm("new", kcadmin.Realm{LoginTheme: "oracle"}))
+	realm, err := kc.GetRealm("new")
+	assert.NoError(t, err)
+	assert.Equal(t, "oracle", realm.LoginTheme)
+	assert.False(t, *realm.Enabled)
+}
+
+// TestGroupsAndRoles tests the group and role fns
+// GIVEN a Keycloak server
+// WHEN a group, a subgroup and a role are created, and the role is mapped to the subgroup
+// THEN the groups are nested, and the role is mapped to the subgroup
+func TestGroupsAndRoles(t *testing.T) {
+	kc, server := newLoggedInClient(t)
+	defer server.Close()
+
+	parentID, err := kc.CreateGroup(testRealm, "", kcadmin.Group{Name: "parent"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, parentID)
+	childID, err := kc.CreateGroup(testRealm, parentID, kcadmin.Group{Name: "child"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, childID)
+	groups, err := kc.GetGroups(testRealm)
+	assert.NoError(t, err)
+	assert.Len(t, groups, 1)
+	assert.Equal(t, childID, groups[0].SubGroups[0].ID)
+	assert.Equal(t, "/parent/child", groups[0].SubGroups[0].Path)
+
+	assert.NoError(t, kc.CreateRealmRole(testRealm, kcadmin.Role{Name: "role"}))
+	assert.NoError(t, kc.UpdateRealmRole(testRealm, "role", kcadmin.Role{Name: "role", Description: "desc"}))
+	role, err := kc.GetRealmRole(testRealm, "role")
+	assert.NoError(t, err)
+	assert.Equal(t, "desc", role.Description)
+	assert.NoError(t, kc.AddGroupRealmRoles(testRealm, childID, []kcadmin.Role{*role}))
+	roles, err := kc.GetGroupRealmRoles(testRealm, childID)
+	assert.NoError(t, err)
+	assert.Len(t, roles, 1)
+	assert.Equal(t, "role", roles[0].Name)
+}
+
+// TestUsers tests the user fns
+// GIVEN a Keycloak server
+// WHEN a user is created and its password is set
+// THEN the user can be found by its username and can log in with the password
+func TestUsers(t *testing.T) {
+	kc, server := newLoggedInClient(t)
+	defer server.Close()
+
+	id, err := kc.CreateUser(testRealm, kcadmin.User{Username: "user", Enabled: true})
+	assert.NoError(t, err)
+	assert.NoError(t, kc.ResetUserPassword(testRealm, id, "userpw"))
+	users, err := kc.GetUsers(testRealm, "user")
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, id, users[0].ID)
+	users, err = kc.GetUsers(testRealm, "other")
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+	assert.Equal(t, "userpw", server.Realms[testRealm].Passwords["user"])
+}
+
+// TestClients tests the client fns
+// GIVEN a Keycloak server
+// WHEN a client is created, updated and its secret is read and regenerated
+// THEN the client is found by its client ID, and a new secret is returned after regenerating it
+func TestClients(t *testing.T) {
+	kc, server := newLoggedInClient(t)
+	defer server.Close()
+
+	id, err := kc.CreateClient(testRealm, kcadmin.ClientRepresentation{ClientID: "app"})
+	assert.NoError(t, err)
+	clients, err := kc.GetClients(testRealm, "app")
+	assert.NoError(t, err)
+	assert.Len(t, clients, 1)
+	clients[0].RedirectURIs = []string{"https://app/*"}
+	assert.NoError(t, kc.UpdateClient(testRealm, id, clients[0]))
+	assert.Equal(t, []string{"https://app/*"}, server.Realms[testRealm].FindClient("app").RedirectURIs)
+
+	secret, err := kc.GetClientSecret(testRealm, id)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, secret.Value)
+	regenerated, err := kc.RegenerateClientSecret(testRealm, id)
+	assert.NoError(t, err)
+	assert.NotEqual(t, secret.Value, regenerated.Value)
+}
+
+// TestIdentityProvidersAndComponents tests the identity provider and component fns
+// GIVEN a Keycloak server
+// WHEN an identity provider and a component are created and updated
+// THEN the updates are returned
+func TestIdentityProvidersAndComponents(t *testing.T) {
+	kc, server := newLoggedInClient(t)
+	defer server.Close()
+
+	assert.NoError(t, kc.CreateIdentityProvider(testRealm, kcadmin.IdentityProvider{Alias: "corp", ProviderID: "oidc"}))
+	assert.NoError(t, kc.UpdateIdentityProvider(testRealm, "corp", kcadmin.IdentityProvider{Alias: "corp", ProviderID: "oidc", Enabled: true}))
+	providers, err := kc.GetIdentityProviders(testRealm)
+	assert.NoError(t, err)
+	assert.Len(t, providers, 1)
+	assert.True(t, providers[0].Enabled)
+
+	id, err := kc.CreateComponent(testRealm, kcadmin.Component{Name: "ldap", ProviderType: "org.keycloak.storage.UserStorageProvider"})
+	assert.NoError(t, err)
+	assert.NoError(t, kc.UpdateComponent(testRealm, id, kcadmin.Component{ID: id, Name: "ldap", ProviderType: "org.keycloak.storage.UserStorageProvider",
+		Config: map[string][]string{"editMode": {"READ_ONLY"}}}))
+	components, err := kc.GetComponents(testRealm, "org.keycloak.storage.UserStorageProvider")
+	assert.NoError(t, err)
+	assert.Len(t, components, 1)
+	assert.Equal(t, []string{"READ_ONLY"}, components[0].Config["editMode"])
+}
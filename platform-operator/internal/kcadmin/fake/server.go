@@ -0,0 +1,562 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/verrazzano/verrazzano/platform-operator/internal/kcadmin"
+)
+
+const (
+	// The realm that the admin users log in to
+	adminRealm = "master"
+
+	accessTokenTemplate = "fake-access-token-%d"
+)
+
+// Realm is the state of a realm of the fake Keycloak server
+type Realm struct {
+	kcadmin.Realm
+	Groups            []kcadmin.Group
+	GroupRoles        map[string][]kcadmin.Role
+	Roles             []kcadmin.Role
+	Users             []kcadmin.User
+	Passwords         map[string]string
+	Clients           []kcadmin.ClientRepresentation
+	ClientSecrets     map[string]string
+	IdentityProviders []kcadmin.IdentityProvider
+	Components        []kcadmin.Component
+}
+
+// Server is an in-memory Keycloak admin REST API for unit tests.  The master realm has an admin user with the given
+// password.  The realms can be seeded and inspected through the Realms map, while no request is in progress.
+type Server struct {
+	*httptest.Server
+	Realms map[string]*Realm
+	// Requests records the method and path of each request, such as "POST /admin/realms/verrazzano-system/groups"
+	Requests []string
+	// Fail returns the status code of an error response to a request, or zero to serve the request
+	Fail func(r *http.Request) int
+	// TokenLifespan is the lifespan in seconds of the access tokens, or zero to not return a lifespan
+	TokenLifespan int
+
+	mutex  sync.Mutex
+	nextID int
+	// tokenGeneration is incremented to revoke the access tokens that were issued
+	tokenGeneration int
+}
+
+// NewServer starts a fake Keycloak server, with an admin user of the master realm
+func NewServer(adminUser string, adminPassword string) *Server {
+	s := &Server{Realms: map[string]*Realm{}}
+	master := s.AddRealm(adminRealm)
+	master.Users = append(master.Users, kcadmin.User{ID: s.newID(), Username: adminUser, Enabled: true})
+	master.Passwords[adminUser] = adminPassword
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// RevokeTokens revokes the access tokens that were issued, so the requests with them are unauthorized
+func (s *Server) RevokeTokens() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.tokenGeneration++
+}
+
+// BaseURL returns the base URL of the Keycloak server, to create a client with
+func (s *Server) BaseURL() string {
+	return s.URL + "/auth"
+}
+
+// AddRealm adds an enabled realm to the server
+func (s *Server) AddRealm(name string) *Realm {
+	enabled := true
+	realm := &Realm{
+		Realm:         kcadmin.Realm{ID: name, Realm: name, Enabled: &enabled},
+		GroupRoles:    map[string][]kcadmin.Role{},
+		Passwords:     map[string]string{},
+		ClientSecrets: map[string]string{},
+	}
+	s.Realms[name] = realm
+	return realm
+}
+
+// AddUser adds a user with a password to a realm, and returns the ID of the user
+func (s *Server) AddUser(realm string, username string, password string) string {
+	r := s.Realms[realm]
+	id := s.newID()
+	r.Users = append(r.Users, kcadmin.User{ID: id, Username: username, Enabled: true})
+	r.Passwords[username] = password
+	return id
+}
+
+// FindGroup returns a group or subgroup of a realm with the given name, or nil if it does not exist
+func (r *Realm) FindGroup(name string) *kcadmin.Group {
+	return findGroup(r.Groups, func(g *kcadmin.Group) bool { return g.Name == name })
+}
+
+// FindClient returns the client of a realm with the given client ID, or nil if it does not exist
+func (r *Realm) FindClient(clientID string) *kcadmin.ClientRepresentation {
+	for i := range r.Clients {
+		if r.Clients[i].ClientID == clientID {
+			return &r.Clients[i]
+		}
+	}
+	return nil
+}
+
+// FindUser returns the user of a realm with the given username, or nil if it does not exist
+func (r *Realm) FindUser(username string) *kcadmin.User {
+	for i := range r.Users {
+		if r.Users[i].Username == username {
+			return &r.Users[i]
+		}
+	}
+	return nil
+}
+
+func findGroup(groups []kcadmin.Group, match func(g *kcadmin.Group) bool) *kcadmin.Group {
+	for i := range groups {
+		if match(&groups[i]) {
+			return &groups[i]
+		}
+		if g := findGroup(groups[i].SubGroups, match); g != nil {
+			return g
+		}
+	}
+	return nil
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("%08d-0000-0000-0000-000000000000", s.nextID)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	p := strings.TrimPrefix(r.URL.Path, "/auth")
+	s.Requests = append(s.Requests, r.Method+" "+p)
+	if s.Fail != nil {
+		if status := s.Fail(r); status != 0 {
+			http.Error(w, "injected failure", status)
+			return
+		}
+	}
+
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	if len(parts) == 5 && parts[0] == "realms" && strings.Join(parts[2:], "/") == "protocol/openid-connect/token" {
+		s.token(w, r, parts[1])
+		return
+	}
+	if len(parts) < 2 || parts[0] != "admin" || parts[1] != "realms" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+fmt.Sprintf(accessTokenTemplate, s.tokenGeneration) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if len(parts) == 2 {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var realm kcadmin.Realm
+		if !decode(w, r, &realm) {
+			return
+		}
+		if _, ok := s.Realms[realm.Realm]; ok {
+			http.Error(w, "realm exists", http.StatusConflict)
+			return
+		}
+		s.AddRealm(realm.Realm).Realm.Enabled = realm.Enabled
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	realm, ok := s.Realms[parts[2]]
+	if !ok {
+		http.Error(w, "realm not found", http.StatusNotFound)
+		return
+	}
+	s.serveRealm(w, r, realm, parts[3:])
+}
+
+// token serves the password grant of the admin-cli client
+func (s *Server) token(w http.ResponseWriter, r *http.Request, realmName string) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	realm, ok := s.Realms[realmName]
+	if !ok || r.PostForm.Get("grant_type") != "password" || r.PostForm.Get("client_id") != "admin-cli" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	password, ok := realm.Passwords[r.PostForm.Get("username")]
+	if !ok || password != r.PostForm.Get("password") {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusUnauthorized)
+		return
+	}
+	token := map[string]interface{}{"access_token": fmt.Sprintf(accessTokenTemplate, s.tokenGeneration), "token_type": "bearer"}
+	if s.TokenLifespan > 0 {
+		token["expires_in"] = s.TokenLifespan
+	}
+	encode(w, token)
+}
+
+func (s *Server) serveRealm(w http.ResponseWriter, r *http.Request, realm *Realm, parts []string) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			encode(w, realm.Realm)
+		case http.MethodPut:
+			var update kcadmin.Realm
+			if !decode(w, r, &update) {
+				return
+			}
+			if update.Enabled != nil {
+				realm.Realm.Enabled = update.Enabled
+			}
+			if len(update.PasswordPolicy) > 0 {
+				realm.PasswordPolicy = update.PasswordPolicy
+			}
+			if len(update.LoginTheme) > 0 {
+				realm.LoginTheme = update.LoginTheme
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+	switch parts[0] {
+	case "groups":
+		s.serveGroups(w, r, realm, parts[1:])
+	case "roles":
+		s.serveRoles(w, r, realm, parts[1:])
+	case "users":
+		s.serveUsers(w, r, realm, parts[1:])
+	case "clients":
+		s.serveClients(w, r, realm, parts[1:])
+	case "identity-provider":
+		s.serveIdentityProviders(w, r, realm, parts[1:])
+	case "components":
+		s.serveComponents(w, r, realm, parts[1:])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) serveGroups(w http.ResponseWriter, r *http.Request, realm *Realm, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		encode(w, nonNilGroups(realm.Groups))
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var group kcadmin.Group
+		if !decode(w, r, &group) {
+			return
+		}
+		if realm.FindGroup(group.Name) != nil {
+			http.Error(w, "group exists", http.StatusConflict)
+			return
+		}
+		group.ID = s.newID()
+		group.Path = "/" + group.Name
+		realm.Groups = append(realm.Groups, group)
+		created(w, r, group.ID)
+	case len(parts) == 2 && parts[1] == "children" && r.Method == http.MethodPost:
+		parent := findGroup(realm.Groups, func(g *kcadmin.Group) bool { return g.ID == parts[0] })
+		if parent == nil {
+			http.Error(w, "group not found", http.StatusNotFound)
+			return
+		}
+		var group kcadmin.Group
+		if !decode(w, r, &group) {
+			return
+		}
+		group.ID = s.newID()
+		group.Path = parent.Path + "/" + group.Name
+		parent.SubGroups = append(parent.SubGroups, group)
+		created(w, r, group.ID)
+	case len(parts) == 3 && parts[1] == "role-mappings" && parts[2] == "realm":
+		if findGroup(realm.Groups, func(g *kcadmin.Group) bool { return g.ID == parts[0] }) == nil {
+			http.Error(w, "group not found", http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			encode(w, nonNilRoles(realm.GroupRoles[parts[0]]))
+		case http.MethodPost:
+			var roles []kcadmin.Role
+			if !decode(w, r, &roles) {
+				return
+			}
+			for _, role := range roles {
+				if findRole(realm.Roles, role.Name) == nil {
+					http.Error(w, "role not found", http.StatusNotFound)
+					return
+				}
+				if findRole(realm.GroupRoles[parts[0]], role.Name) == nil {
+					realm.GroupRoles[parts[0]] = append(realm.GroupRoles[parts[0]], *findRole(realm.Roles, role.Name))
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) serveRoles(w http.ResponseWriter, r *http.Request, realm *Realm, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		encode(w, nonNilRoles(realm.Roles))
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var role kcadmin.Role
+		if !decode(w, r, &role) {
+			return
+		}
+		if findRole(realm.Roles, role.Name) != nil {
+			http.Error(w, "role exists", http.StatusConflict)
+			return
+		}
+		role.ID = s.newID()
+		role.ContainerID = realm.ID
+		realm.Roles = append(realm.Roles, role)
+		w.WriteHeader(http.StatusCreated)
+	case len(parts) == 1:
+		role := findRole(realm.Roles, parts[0])
+		if role == nil {
+			http.Error(w, "role not found", http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			encode(w, role)
+		case http.MethodPut:
+			var update kcadmin.Role
+			if !decode(w, r, &update) {
+				return
+			}
+			role.Description = update.Description
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) serveUsers(w http.ResponseWriter, r *http.Request, realm *Realm, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		users := []kcadmin.User{}
+		for _, user := range realm.Users {
+			if username := r.URL.Query().Get("username"); len(username) == 0 || user.Username == username {
+				users = append(users, user)
+			}
+		}
+		encode(w, users)
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var user kcadmin.User
+		if !decode(w, r, &user) {
+			return
+		}
+		if realm.FindUser(user.Username) != nil {
+			http.Error(w, "user exists", http.StatusConflict)
+			return
+		}
+		for _, path := range user.Groups {
+			if findGroup(realm.Groups, func(g *kcadmin.Group) bool { return g.Path == path }) == nil {
+				http.Error(w, "group not found", http.StatusNotFound)
+				return
+			}
+		}
+		user.ID = s.newID()
+		realm.Users = append(realm.Users, user)
+		created(w, r, user.ID)
+	case len(parts) == 2 && parts[1] == "reset-password" && r.Method == http.MethodPut:
+		var credential kcadmin.Credential
+		if !decode(w, r, &credential) {
+			return
+		}
+		for _, user := range realm.Users {
+			if user.ID == parts[0] {
+				realm.Passwords[user.Username] = credential.Value
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		http.Error(w, "user not found", http.StatusNotFound)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) serveClients(w http.ResponseWriter, r *http.Request, realm *Realm, parts []string) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			clients := []kcadmin.ClientRepresentation{}
+			for _, client := range realm.Clients {
+				if clientID := r.URL.Query().Get("clientId"); len(clientID) == 0 || client.ClientID == clientID {
+					clients = append(clients, client)
+				}
+			}
+			encode(w, clients)
+		case http.MethodPost:
+			var client kcadmin.ClientRepresentation
+			if !decode(w, r, &client) {
+				return
+			}
+			if realm.FindClient(client.ClientID) != nil {
+				http.Error(w, "client exists", http.StatusConflict)
+				return
+			}
+			client.ID = s.newID()
+			realm.Clients = append(realm.Clients, client)
+			created(w, r, client.ID)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+	var client *kcadmin.ClientRepresentation
+	for i := range realm.Clients {
+		if realm.Clients[i].ID == parts[0] {
+			client = &realm.Clients[i]
+		}
+	}
+	if client == nil {
+		http.Error(w, "client not found", http.StatusNotFound)
+		return
+	}
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		encode(w, client)
+	case len(parts) == 1 && r.Method == http.MethodPut:
+		var update kcadmin.ClientRepresentation
+		if !decode(w, r, &update) {
+			return
+		}
+		update.ID = client.ID
+		*client = update
+		w.WriteHeader(http.StatusNoContent)
+	case len(parts) == 2 && parts[1] == "client-secret" && (r.Method == http.MethodGet || r.Method == http.MethodPost):
+		if r.Method == http.MethodPost || len(realm.ClientSecrets[client.ID]) == 0 {
+			realm.ClientSecrets[client.ID] = "secret-" + s.newID()
+		}
+		encode(w, kcadmin.Credential{Type: "secret", Value: realm.ClientSecrets[client.ID]})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) serveIdentityProviders(w http.ResponseWriter, r *http.Request, realm *Realm, parts []string) {
+	switch {
+	case len(parts) == 1 && parts[0] == "instances" && r.Method == http.MethodGet:
+		providers := []kcadmin.IdentityProvider{}
+		encode(w, append(providers, realm.IdentityProviders...))
+	case len(parts) == 1 && parts[0] == "instances" && r.Method == http.MethodPost:
+		var provider kcadmin.IdentityProvider
+		if !decode(w, r, &provider) {
+			return
+		}
+		realm.IdentityProviders = append(realm.IdentityProviders, provider)
+		w.WriteHeader(http.StatusCreated)
+	case len(parts) == 2 && parts[0] == "instances" && r.Method == http.MethodPut:
+		for i := range realm.IdentityProviders {
+			if realm.IdentityProviders[i].Alias == parts[1] {
+				if decode(w, r, &realm.IdentityProviders[i]) {
+					w.WriteHeader(http.StatusNoContent)
+				}
+				return
+			}
+		}
+		http.Error(w, "identity provider not found", http.StatusNotFound)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) serveComponents(w http.ResponseWriter, r *http.Request, realm *Realm, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		components := []kcadmin.Component{}
+		for _, component := range realm.Components {
+			if providerType := r.URL.Query().Get("type"); len(providerType) == 0 || component.ProviderType == providerType {
+				components = append(components, component)
+			}
+		}
+		encode(w, components)
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var component kcadmin.Component
+		if !decode(w, r, &component) {
+			return
+		}
+		component.ID = s.newID()
+		realm.Components = append(realm.Components, component)
+		created(w, r, component.ID)
+	case len(parts) == 1 && r.Method == http.MethodPut:
+		for i := range realm.Components {
+			if realm.Components[i].ID == parts[0] {
+				if decode(w, r, &realm.Components[i]) {
+					realm.Components[i].ID = parts[0]
+					w.WriteHeader(http.StatusNoContent)
+				}
+				return
+			}
+		}
+		http.Error(w, "component not found", http.StatusNotFound)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func findRole(roles []kcadmin.Role, name string) *kcadmin.Role {
+	for i := range roles {
+		if roles[i].Name == name {
+			return &roles[i]
+		}
+	}
+	return nil
+}
+
+func nonNilGroups(groups []kcadmin.Group) []kcadmin.Group {
+	return append([]kcadmin.Group{}, groups...)
+}
+
+func nonNilRoles(roles []kcadmin.Role) []kcadmin.Role {
+	return append([]kcadmin.Role{}, roles...)
+}
+
+// created writes a created response with the location of the created object
+func created(w http.ResponseWriter, r *http.Request, id string) {
+	w.Header().Set("Location", "http://"+r.Host+r.URL.Path+"/"+id)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func encode(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package kcadmin
+
+// Realm is a Keycloak realm.  The fields that are not set are not changed when a realm is updated.
+type Realm struct {
+	ID             string `json:"id,omitempty"`
+	Realm          string `json:"realm,omitempty"`
+	Enabled        *bool  `json:"enabled,omitempty"`
+	PasswordPolicy string `json:"passwordPolicy,omitempty"`
+	LoginTheme     string `json:"loginTheme,omitempty"`
+}
+
+// Group is a group of a realm, with its subgroups
+type Group struct {
+	ID        string  `json:"id,omitempty"`
+	Name      string  `json:"name"`
+	Path      string  `json:"path,omitempty"`
+	SubGroups []Group `json:"subGroups,omitempty"`
+}
+
+// Role is a role of a realm
+type Role struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Composite   bool   `json:"composite"`
+	ClientRole  bool   `json:"clientRole"`
+	ContainerID string `json:"containerId,omitempty"`
+}
+
+// User is a user of a realm.  The groups of a user are only used when the user is created.
+type User struct {
+	ID               string   `json:"id,omitempty"`
+	CreatedTimestamp int64    `json:"createdTimestamp,omitempty"`
+	Username         string   `json:"username"`
+	Enabled          bool     `json:"enabled"`
+	FirstName        string   `json:"firstName,omitempty"`
+	LastName         string   `json:"lastName,omitempty"`
+	Email            string   `json:"email,omitempty"`
+	EmailVerified    bool     `json:"emailVerified"`
+	Groups           []string `json:"groups,omitempty"`
+}
+
+// Credential is a credential of a user, or the secret of a client
+type Credential struct {
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+	Temporary bool   `json:"temporary,omitempty"`
+}
+
+// ProtocolMapper maps a user attribute, role or group to a claim of the tokens of a client
+type ProtocolMapper struct {
+	ID              string            `json:"id,omitempty"`
+	Name            string            `json:"name"`
+	Protocol        string            `json:"protocol"`
+	ProtocolMapper  string            `json:"protocolMapper"`
+	ConsentRequired bool              `json:"consentRequired"`
+	Config          map[string]string `json:"config,omitempty"`
+}
+
+// ClientRepresentation is a client of a realm.  The fields that are not set are not changed when a client is updated, so a client
+// should be read before it is updated.
+type ClientRepresentation struct {
+	ID                                 string            `json:"id,omitempty"`
+	ClientID                           string            `json:"clientId"`
+	Name                               string            `json:"name,omitempty"`
+	RootURL                            string            `json:"rootUrl,omitempty"`
+	AdminURL                           string            `json:"adminUrl,omitempty"`
+	BaseURL                            string            `json:"baseUrl,omitempty"`
+	Enabled                            bool              `json:"enabled"`
+	SurrogateAuthRequired              bool              `json:"surrogateAuthRequired"`
+	AlwaysDisplayInConsole             bool              `json:"alwaysDisplayInConsole"`
+	ClientAuthenticatorType            string            `json:"clientAuthenticatorType,omitempty"`
+	RedirectURIs                       []string          `json:"redirectUris"`
+	WebOrigins                         []string          `json:"webOrigins"`
+	NotBefore                          int               `json:"notBefore"`
+	BearerOnly                         bool              `json:"bearerOnly"`
+	ConsentRequired                    bool              `json:"consentRequired"`
+	StandardFlowEnabled                bool              `json:"standardFlowEnabled"`
+	ImplicitFlowEnabled                bool              `json:"implicitFlowEnabled"`
+	DirectAccessGrantsEnabled          bool              `json:"directAccessGrantsEnabled"`
+	ServiceAccountsEnabled             bool              `json:"serviceAccountsEnabled"`
+	PublicClient                       bool              `json:"publicClient"`
+	FrontchannelLogout                 bool              `json:"frontchannelLogout"`
+	Protocol                           string            `json:"protocol,omitempty"`
+	Attributes                         map[string]string `json:"attributes,omitempty"`
+	AuthenticationFlowBindingOverrides map[string]string `json:"authenticationFlowBindingOverrides,omitempty"`
+	FullScopeAllowed                   bool              `json:"fullScopeAllowed"`
+	NodeReRegistrationTimeout          int               `json:"nodeReRegistrationTimeout"`
+	ProtocolMappers                    []ProtocolMapper  `json:"protocolMappers,omitempty"`
+	DefaultClientScopes                []string          `json:"defaultClientScopes,omitempty"`
+	OptionalClientScopes               []string          `json:"optionalClientScopes,omitempty"`
+}
+
+// IdentityProvider is an identity provider of a realm, such as an OpenID Connect provider
+type IdentityProvider struct {
+	Alias       string            `json:"alias"`
+	DisplayName string            `json:"displayName,omitempty"`
+	ProviderID  string            `json:"providerId"`
+	Enabled     bool              `json:"enabled"`
+	Config      map[string]string `json:"config,omitempty"`
+}
+
+// Component is a component of a realm, such as an LDAP user federation provider
+type Component struct {
+	ID           string              `json:"id,omitempty"`
+	Name         string              `json:"name"`
+	ProviderID   string              `json:"providerId"`
+	ProviderType string              `json:"providerType"`
+	ParentID     string              `json:"parentId"`
+	Config       map[string][]string `json:"config,omitempty"`
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// serviceName is the name of the service reported in the spans
+	serviceName = "verrazzano-platform-operator"
+
+	// tracerName is the name of the tracer that creates the spans of the platform operator
+	tracerName = "github.com/verrazzano/verrazzano/platform-operator"
+
+	// jaegerOTLPPort is the port of the OTLP/HTTP receiver of the Jaeger collector
+	jaegerOTLPPort = 4318
+)
+
+// Attribute keys of the spans created by the platform operator
+const (
+	ComponentKey  = attribute.Key("verrazzano.component")
+	OperationKey  = attribute.Key("verrazzano.operation")
+	GenerationKey = attribute.Key("verrazzano.generation")
+	NameKey       = attribute.Key("verrazzano.name")
+	NamespaceKey  = attribute.Key("verrazzano.namespace")
+)
+
+// JaegerEndpoint is the OTLP/HTTP endpoint of the Jaeger collector of the Jaeger instance installed by Verrazzano
+var JaegerEndpoint = fmt.Sprintf("%s-%s.%s.svc.cluster.local:%d", globalconst.JaegerInstanceName,
+	globalconst.JaegerCollectorComponentName, constants.VerrazzanoMonitoringNamespace, jaegerOTLPPort)
+
+// InitOTLP configures tracing to export the spans using OTLP/HTTP to the endpoint, in host:port form.  If the endpoint
+// is empty then tracing is disabled and the spans are not recorded.  The returned function flushes and stops the
+// exporter.
+func InitOTLP(endpoint string, insecure bool) (func(context.Context) error, error) {
+	if len(endpoint) == 0 {
+		return func(context.Context) error { return nil }, nil
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint)}
+	if insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create the OTLP trace exporter for endpoint %s: %v", endpoint, err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(newResource()),
+	)
+	setTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// InitSpanProcessor configures tracing to send the spans to a span processor.  This is used by unit tests to record
+// the spans that are created.
+func InitSpanProcessor(processor sdktrace.SpanProcessor) {
+	setTracerProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(newResource()),
+	))
+}
+
+// Reset disables tracing
+func Reset() {
+	otel.SetTracerProvider(trace.NewNoopTracerProvider())
+}
+
+// StartSpan starts a span, which is a child of the span in the context if there is one
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends the span, recording the error if there is one
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ComponentAttributes returns the attributes of a span for an operation on a component
+func ComponentAttributes(compName string, operation string, generation int64) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		ComponentKey.String(compName),
+		OperationKey.String(operation),
+		GenerationKey.Int64(generation),
+	}
+}
+
+// setTracerProvider sets the global tracer provider, and propagates the trace context
+func setTracerProvider(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// newResource returns the resource describing the platform operator
+func newResource() *resource.Resource {
+	return resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))
+}
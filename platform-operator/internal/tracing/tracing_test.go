@@ -0,0 +1,79 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// initInMemory configures tracing to record the spans in memory, synchronously when each span ends
+func initInMemory() *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	InitSpanProcessor(sdktrace.NewSimpleSpanProcessor(exporter))
+	return exporter
+}
+
+// TestSpans tests starting and ending spans
+// GIVEN tracing to an in-memory exporter
+// WHEN a parent and a child span are started and ended
+// THEN the spans are recorded with their attributes, parent and error status
+func TestSpans(t *testing.T) {
+	exporter := initInMemory()
+	defer Reset()
+
+	ctx, parent := StartSpan(context.TODO(), "Reconcile", NameKey.String("my-verrazzano"))
+	_, child := StartSpan(ctx, "Install", ComponentAttributes("istio", "Install", 2)...)
+	EndSpan(child, errors.New("install failed"))
+	EndSpan(parent, nil)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "Install", spans[0].Name)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Contains(t, spans[0].Attributes, ComponentKey.String("istio"))
+	assert.Contains(t, spans[0].Attributes, OperationKey.String("Install"))
+	assert.Contains(t, spans[0].Attributes, GenerationKey.Int64(2))
+	assert.Equal(t, "Reconcile", spans[1].Name)
+	assert.Equal(t, codes.Unset, spans[1].Status.Code)
+}
+
+// TestStartSpanNilContext tests starting a span without a context
+// GIVEN tracing to an in-memory exporter
+// WHEN a span is started with a nil context
+// THEN the span is recorded as a root span
+func TestStartSpanNilContext(t *testing.T) {
+	exporter := initInMemory()
+	defer Reset()
+
+	//nolint:staticcheck // testing the nil context
+	_, span := StartSpan(nil, "Reconcile")
+	EndSpan(span, nil)
+	assert.Len(t, exporter.GetSpans(), 1)
+	assert.False(t, exporter.GetSpans()[0].Parent.IsValid())
+}
+
+// TestInitOTLP tests configuring the OTLP exporter
+// GIVEN an empty and a non-empty endpoint
+// WHEN InitOTLP is called
+// THEN tracing is only configured for the non-empty endpoint, and the shutdown function succeeds
+func TestInitOTLP(t *testing.T) {
+	defer Reset()
+
+	shutdown, err := InitOTLP("", false)
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.TODO()))
+
+	shutdown, err = InitOTLP(JaegerEndpoint, true)
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.TODO()))
+	assert.Equal(t, "jaeger-operator-jaeger-collector.verrazzano-monitoring.svc.cluster.local:4318", JaegerEndpoint)
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+// Package tracingtest provides the tracing setup used by unit tests to check the spans that are created
+package tracingtest
+
+import (
+	"github.com/verrazzano/verrazzano/platform-operator/internal/tracing"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// InitInMemory configures tracing to record the spans in memory, synchronously when each span ends.  Call
+// tracing.Reset to disable tracing at the end of the test.
+func InitInMemory() *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	tracing.InitSpanProcessor(sdktrace.NewSimpleSpanProcessor(exporter))
+	return exporter
+}
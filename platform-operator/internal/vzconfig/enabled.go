@@ -178,15 +178,18 @@ func IsRancherEnabled(cr runtime.Object) bool {
 	return true
 }
 
-// IsExternalDNSEnabled Indicates if the external-dns service is expected to be deployed, true if OCI DNS is configured
+// IsExternalDNSEnabled Indicates if the external-dns service is expected to be deployed, true if OCI or RFC2136 DNS is
+// configured
 func IsExternalDNSEnabled(cr runtime.Object) bool {
 	if vzv1alpha1, ok := cr.(*vzapi.Verrazzano); ok {
-		if vzv1alpha1 != nil && vzv1alpha1.Spec.Components.DNS != nil && vzv1alpha1.Spec.Components.DNS.OCI != nil {
-			return true
+		if vzv1alpha1 != nil && vzv1alpha1.Spec.Components.DNS != nil {
+			dns := vzv1alpha1.Spec.Components.DNS
+			return dns.OCI != nil || dns.RFC2136 != nil
 		}
 	} else if vzv1beta1, ok := cr.(*installv1beta1.Verrazzano); ok {
-		if vzv1beta1 != nil && vzv1beta1.Spec.Components.DNS != nil && vzv1beta1.Spec.Components.DNS.OCI != nil {
-			return true
+		if vzv1beta1 != nil && vzv1beta1.Spec.Components.DNS != nil {
+			dns := vzv1beta1.Spec.Components.DNS
+			return dns.OCI != nil || dns.RFC2136 != nil
 		}
 	}
 	return false
@@ -232,7 +235,9 @@ func IsKubeStateMetricsEnabled(cr runtime.Object) bool {
 			return *vzv1alpha1.Spec.Components.KubeStateMetrics.Enabled
 		}
 	} else if vzv1beta1, ok := cr.(*installv1beta1.Verrazzano); ok {
-		return *vzv1beta1.Spec.Components.KubeStateMetrics.Enabled
+		if vzv1beta1 != nil && vzv1beta1.Spec.Components.KubeStateMetrics != nil && vzv1beta1.Spec.Components.KubeStateMetrics.Enabled != nil {
+			return *vzv1beta1.Spec.Components.KubeStateMetrics.Enabled
+		}
 	}
 	return false
 }
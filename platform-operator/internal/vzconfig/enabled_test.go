@@ -46,6 +46,27 @@ func TestIsExternalDNSEnabledOCIDNS(t *testing.T) {
 	assert.True(t, IsExternalDNSEnabled(vz))
 }
 
+// TestIsExternalDNSEnabledRFC2136DNS tests the IsExternalDNSEnabled function
+// GIVEN a call to IsExternalDNSEnabled
+//  WHEN the VZ config has RFC2136 DNS configured
+//  THEN true is returned
+func TestIsExternalDNSEnabledRFC2136DNS(t *testing.T) {
+	vz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			EnvironmentName: "myenv",
+			Components: vzapi.ComponentSpec{
+				DNS: &vzapi.DNSComponent{
+					RFC2136: &vzapi.RFC2136{
+						Host:        "10.0.0.53",
+						DNSZoneName: "mydomain.com",
+					},
+				},
+			},
+		},
+	}
+	assert.True(t, IsExternalDNSEnabled(vz))
+}
+
 // TestIsExternalDNSEnabledWildcardDNS tests the IsExternalDNSEnabled function
 // GIVEN a call to IsExternalDNSEnabled
 //  WHEN the VZ config has Wildcard DNS explicitly configured
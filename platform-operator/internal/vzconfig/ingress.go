@@ -61,9 +61,11 @@ func GetDNSSuffix(client client.Client, vz *vzapi.Verrazzano) (string, error) {
 		dnsSuffix = dnsConfig.OCI.DNSZoneName
 	} else if dnsConfig.External != nil {
 		dnsSuffix = dnsConfig.External.Suffix
+	} else if dnsConfig.RFC2136 != nil {
+		dnsSuffix = dnsConfig.RFC2136.DNSZoneName
 	}
 	if len(dnsSuffix) == 0 {
-		return "", fmt.Errorf("Invalid OCI DNS configuration, no zone name specified")
+		return "", fmt.Errorf("Invalid DNS configuration, no zone name specified")
 	}
 	return dnsSuffix, nil
 }
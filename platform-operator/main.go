@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	oam "github.com/crossplane/oam-kubernetes-runtime/apis/core"
 	cmapiv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
@@ -33,16 +34,19 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/helm"
 	vzlog "github.com/verrazzano/verrazzano/pkg/log"
 	clustersv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/clusters/v1alpha1"
+	platformv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/platform/v1alpha1"
 	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	clusterscontroller "github.com/verrazzano/verrazzano/platform-operator/controllers/clusters"
 	configmapcontroller "github.com/verrazzano/verrazzano/platform-operator/controllers/configmaps"
 	secretscontroller "github.com/verrazzano/verrazzano/platform-operator/controllers/secrets"
 	vzcontroller "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/validator"
 	internalconfig "github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/certificate"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/netpolicy"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/tracing"
 	"github.com/verrazzano/verrazzano/platform-operator/metricsexporter"
 	// +kubebuilder:scaffold:imports
 )
@@ -55,6 +59,7 @@ func init() {
 	_ = installv1alpha1.AddToScheme(scheme)
 	_ = installv1beta1.AddToScheme(scheme)
 	_ = clustersv1alpha1.AddToScheme(scheme)
+	_ = platformv1alpha1.AddToScheme(scheme)
 
 	_ = istioclinet.AddToScheme(scheme)
 	_ = istioclisec.AddToScheme(scheme)
@@ -81,6 +86,7 @@ func main() {
 	// config will hold the entire operator config
 	config := internalconfig.Get()
 	var bomOverride string
+	var tracingToJaeger bool
 
 	flag.StringVar(&config.MetricsAddr, "metrics-addr", config.MetricsAddr, "The address the metric endpoint binds to.")
 	flag.BoolVar(&config.LeaderElectionEnabled, "enable-leader-election", config.LeaderElectionEnabled,
@@ -97,6 +103,14 @@ func main() {
 	flag.StringVar(&config.VerrazzanoRootDir, "vz-root-dir", config.VerrazzanoRootDir,
 		"Specify the root directory of Verrazzano (used for development)")
 	flag.StringVar(&bomOverride, "bom-path", "", "BOM file location")
+	flag.StringVar(&config.UserProfilesDir, "user-profiles-dir", config.UserProfilesDir,
+		"The directory of user profile files, in a sub-directory for each API version")
+	flag.StringVar(&config.TracingEndpoint, "tracing-endpoint", config.TracingEndpoint,
+		"The host:port of the OTLP/HTTP endpoint that reconcile traces are exported to, tracing is disabled if not set")
+	flag.BoolVar(&config.TracingInsecure, "tracing-insecure", config.TracingInsecure,
+		"Disable TLS when exporting reconcile traces")
+	flag.BoolVar(&tracingToJaeger, "tracing-to-verrazzano-jaeger", false,
+		"Export reconcile traces to the Jaeger instance installed by Verrazzano, if tracing-endpoint is not set")
 	flag.BoolVar(&helm.Debug, "helm-debug", helm.Debug, "Add the --debug flag to helm commands")
 
 	// Add the zap logger flag set to the CLI.
@@ -107,6 +121,11 @@ func main() {
 	kzap.UseFlagOptions(&opts)
 	vzlog.InitLogs(opts)
 
+	if tracingToJaeger && len(config.TracingEndpoint) == 0 {
+		config.TracingEndpoint = tracing.JaegerEndpoint
+		config.TracingInsecure = true
+	}
+
 	// Save the config as immutable from this point on.
 	internalconfig.Set(config)
 	log := zap.S()
@@ -175,7 +194,9 @@ func main() {
 		}
 
 		log.Debug("Creating or updating network policies")
-		_, err = netpolicy.CreateOrUpdateNetworkPolicies(kubeClient, client)
+		_, err = netpolicy.CreateOrUpdateNetworkPolicies(kubeClient, client, netpolicy.EgressOptions{
+			TracingEndpoint: internalconfig.Get().TracingEndpoint,
+		})
 		if err != nil {
 			log.Errorf("Failed to create or update network policies: %v", err)
 			os.Exit(1)
@@ -197,9 +218,23 @@ func main() {
 
 	installv1alpha1.SetComponentValidator(validator.ComponentValidatorImpl{})
 	installv1beta1.SetComponentValidator(validator.ComponentValidatorImpl{})
+	installv1alpha1.SetUserProfileValidator(transform.ValidateV1alpha1Profile)
+	installv1beta1.SetUserProfileValidator(transform.ValidateV1beta1Profile)
+	transform.SetUserProfilesClient(mgr.GetClient())
 
 	metricsexporter.StartMetricsServer(log)
 
+	// Export the traces of the reconciles
+	shutdownTracing, err := tracing.InitOTLP(config.TracingEndpoint, config.TracingInsecure)
+	if err != nil {
+		log.Errorf("Failed to initialize tracing: %v", err)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
+	if len(config.TracingEndpoint) > 0 {
+		log.Infof("Exporting reconcile traces to %s", config.TracingEndpoint)
+	}
+
 	// Setup the reconciler
 	reconciler := vzcontroller.Reconciler{
 		Client:            mgr.GetClient(),
@@ -207,6 +242,7 @@ func main() {
 		DryRun:            config.DryRun,
 		WatchedComponents: map[string]bool{},
 		WatchMutex:        &sync.RWMutex{},
+		EventRecorder:     mgr.GetEventRecorderFor("verrazzano-platform-operator"),
 	}
 	if err = reconciler.SetupWithManager(mgr); err != nil {
 		log.Error(err, "Failed to setup controller", vzlog.FieldController, "Verrazzano")
@@ -269,6 +305,7 @@ func main() {
 	log.Info("Starting controller-runtime manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		log.Errorf("Failed starting controller-runtime manager: %v", err)
+		shutdownTracing(context.Background())
 		os.Exit(1)
 	}
 }
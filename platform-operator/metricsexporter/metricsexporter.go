@@ -21,10 +21,13 @@ type configuration struct {
 }
 
 type data struct {
-	simpleCounterMetricMap map[metricName]*SimpleCounterMetric
-	simpleGaugeMetricMap   map[metricName]*SimpleGaugeMetric
-	durationMetricMap      map[metricName]*DurationMetric
-	metricsComponentMap    map[metricName]*MetricsComponent
+	simpleCounterMetricMap    map[metricName]*SimpleCounterMetric
+	simpleGaugeMetricMap      map[metricName]*SimpleGaugeMetric
+	durationMetricMap         map[metricName]*DurationMetric
+	metricsComponentMap       map[metricName]*MetricsComponent
+	componentGaugeMetricMap   map[metricName]*ComponentGaugeMetric
+	componentCounterMetricMap map[metricName]*ComponentCounterMetric
+	componentVersions         map[string]string
 }
 type SimpleCounterMetric struct {
 	metric prometheus.Counter
@@ -69,6 +72,44 @@ func (g *SimpleGaugeMetric) Get() prometheus.Gauge {
 	return g.metric
 }
 
+type ComponentGaugeMetric struct {
+	metric *prometheus.GaugeVec
+}
+
+// This member function sets the ComponentGaugeMetric of a component to a user provided float64 number
+func (g *ComponentGaugeMetric) Set(componentName string, num float64) {
+	g.metric.WithLabelValues(componentName).Set(num)
+}
+
+// This member function sets the ComponentGaugeMetric of a component and a second label value to a user provided float64 number
+func (g *ComponentGaugeMetric) SetLabelled(componentName string, labelValue string, num float64) {
+	g.metric.WithLabelValues(componentName, labelValue).Set(num)
+}
+
+// This member function deletes the ComponentGaugeMetric of a component and a second label value
+func (g *ComponentGaugeMetric) DeleteLabelled(componentName string, labelValue string) bool {
+	return g.metric.DeleteLabelValues(componentName, labelValue)
+}
+
+// This member function returns the underlying metric in a ComponentGaugeMetric
+func (g *ComponentGaugeMetric) Get() *prometheus.GaugeVec {
+	return g.metric
+}
+
+type ComponentCounterMetric struct {
+	metric *prometheus.CounterVec
+}
+
+// This member function increases the ComponentCounterMetric of a component by one
+func (c *ComponentCounterMetric) Inc(componentName string) {
+	c.metric.WithLabelValues(componentName).Inc()
+}
+
+// This member function returns the underlying metric in a ComponentCounterMetric
+func (c *ComponentCounterMetric) Get() *prometheus.CounterVec {
+	return c.metric
+}
+
 type DurationMetric struct {
 	metric prometheus.Summary
 	timer  *prometheus.Timer
@@ -5,11 +5,16 @@ package metricsexporter
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	asserts "github.com/stretchr/testify/assert"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/certmanager"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/grafana"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/vmo"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 )
 
 // Constants that hold the times that are used to test various cases of component timestamps being passed
@@ -259,7 +264,7 @@ func TestAnalyzeVerrazzanoResourceMetrics(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			AnalyzeVerrazzanoResourceMetrics(testLog, tt.vzcr)
-			grafanaMetricComponentObject, err := GetMetricComponent(grafanaMetricName)
+			grafanaMetricComponentObject, err := GetMetricComponent(metricName(grafana.ComponentName))
 			assert.NoError(err)
 			grafanaInstallMetric := grafanaMetricComponentObject.getInstallDuration()
 			assert.Equal(tt.expectedValueForInstallMetric, testutil.ToFloat64(grafanaInstallMetric.Get()))
@@ -268,3 +273,87 @@ func TestAnalyzeVerrazzanoResourceMetrics(t *testing.T) {
 		})
 	}
 }
+
+// TestGetMetricsComponentName tests the names used in the install and upgrade duration metrics of components
+// GIVEN a component with a legacy metric name and a component without one
+// WHEN getMetricsComponentName is called
+// THEN the legacy name or the component name with dashes replaced is returned
+func TestGetMetricsComponentName(t *testing.T) {
+	assert := asserts.New(t)
+	assert.Equal("certManager", getMetricsComponentName(certmanager.ComponentName))
+	assert.Equal("verrazzano_monitoring_operator", getMetricsComponentName(vmo.ComponentName))
+}
+
+// TestAnalyzeComponentStateMetrics tests the AnalyzeComponentStateMetrics fn
+// GIVEN a VZ CR with the status of a ready component and a component that is not ready
+// WHEN AnalyzeComponentStateMetrics is called
+// THEN the state, enabled, version and readiness duration metrics are set for the components
+func TestAnalyzeComponentStateMetrics(t *testing.T) {
+	assert := asserts.New(t)
+	defer config.Set(config.Get())
+	config.Set(config.OperatorConfig{VerrazzanoRootDir: "../.."})
+
+	testLog := vzlog.DefaultLogger()
+	since := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	cr := &installv1alpha1.Verrazzano{
+		Status: installv1alpha1.VerrazzanoStatus{
+			Components: installv1alpha1.ComponentStatusMap{
+				grafana.ComponentName: &installv1alpha1.ComponentStatusDetails{
+					State:   installv1alpha1.CompStateReady,
+					Version: "1.4.0",
+					Conditions: []installv1alpha1.Condition{
+						{Type: installv1alpha1.CondInstallComplete, LastTransitionTime: since},
+					},
+				},
+				certmanager.ComponentName: &installv1alpha1.ComponentStatusDetails{
+					State: installv1alpha1.CompStateInstalling,
+					Conditions: []installv1alpha1.Condition{
+						{Type: installv1alpha1.CondInstallStarted, LastTransitionTime: since},
+					},
+				},
+			},
+		},
+	}
+	AnalyzeComponentStateMetrics(testLog, cr)
+
+	state, err := GetComponentGaugeMetric(ComponentState)
+	assert.NoError(err)
+	assert.Equal(float64(1), testutil.ToFloat64(state.Get().WithLabelValues(grafana.ComponentName, string(installv1alpha1.CompStateReady))))
+	assert.Equal(float64(0), testutil.ToFloat64(state.Get().WithLabelValues(grafana.ComponentName, string(installv1alpha1.CompStateInstalling))))
+	assert.Equal(float64(1), testutil.ToFloat64(state.Get().WithLabelValues(certmanager.ComponentName, string(installv1alpha1.CompStateInstalling))))
+
+	enabled, err := GetComponentGaugeMetric(ComponentEnabled)
+	assert.NoError(err)
+	assert.Equal(float64(1), testutil.ToFloat64(enabled.Get().WithLabelValues(grafana.ComponentName)))
+
+	ready, err := GetComponentGaugeMetric(ComponentReadyDuration)
+	assert.NoError(err)
+	assert.InDelta(float64(3600), testutil.ToFloat64(ready.Get().WithLabelValues(grafana.ComponentName)), 60)
+	assert.Equal(float64(0), testutil.ToFloat64(ready.Get().WithLabelValues(certmanager.ComponentName)))
+	notReady, err := GetComponentGaugeMetric(ComponentNotReadyDuration)
+	assert.NoError(err)
+	assert.Equal(float64(0), testutil.ToFloat64(notReady.Get().WithLabelValues(grafana.ComponentName)))
+	assert.InDelta(float64(3600), testutil.ToFloat64(notReady.Get().WithLabelValues(certmanager.ComponentName)), 60)
+
+	// The series of the previous version is removed when the version changes
+	version, err := GetComponentGaugeMetric(ComponentVersion)
+	assert.NoError(err)
+	assert.Equal(float64(1), testutil.ToFloat64(version.Get().WithLabelValues(grafana.ComponentName, "1.4.0")))
+	cr.Status.Components[grafana.ComponentName].Version = "1.5.0"
+	AnalyzeComponentStateMetrics(testLog, cr)
+	assert.False(version.DeleteLabelled(grafana.ComponentName, "1.4.0"))
+	assert.Equal(float64(1), testutil.ToFloat64(version.Get().WithLabelValues(grafana.ComponentName, "1.5.0")))
+}
+
+// TestComponentUpgradeFailures tests the component upgrade failures counter
+// GIVEN the component upgrade failures counter
+// WHEN Inc is called for a component
+// THEN the counter of the component is increased by one
+func TestComponentUpgradeFailures(t *testing.T) {
+	assert := asserts.New(t)
+	failures, err := GetComponentCounterMetric(ComponentUpgradeFailures)
+	assert.NoError(err)
+	before := testutil.ToFloat64(failures.Get().WithLabelValues(grafana.ComponentName))
+	failures.Inc(grafana.ComponentName)
+	assert.Equal(float64(1), testutil.ToFloat64(failures.Get().WithLabelValues(grafana.ComponentName))-before)
+}
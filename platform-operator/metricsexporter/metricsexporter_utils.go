@@ -6,6 +6,7 @@ package metricsexporter
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -18,29 +19,14 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/authproxy"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/certmanager"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/coherence"
-	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/console"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/externaldns"
-	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/fluentd"
-	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/grafana"
-	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/istio"
-	jaegeroperator "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/jaeger/operator"
-	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/keycloak"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/kiali"
-	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/mysql"
-	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/mysqloperator"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/nginx"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/oam"
-	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/opensearch"
-	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/opensearchdashboards"
-	promadapter "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/prometheus/adapter"
-	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/prometheus/kubestatemetrics"
-	promnodeexporter "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/prometheus/nodeexporter"
-	promoperator "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/prometheus/operator"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/prometheus/pushgateway"
-	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/rancher"
-	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/verrazzano"
-	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/vmo"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/weblogic"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
@@ -50,36 +36,17 @@ var MetricsExp MetricsExporter
 type metricName string
 
 const (
-	ReconcileCounter               metricName = "reconcile counter"
-	ReconcileError                 metricName = "reconcile error"
-	ReconcileDuration              metricName = "reconcile duration"
-	authproxyMetricName            metricName = authproxy.ComponentName
-	oamMetricName                  metricName = oam.ComponentName
-	appoperMetricName              metricName = appoper.ComponentName
-	istioMetricName                metricName = istio.ComponentName
-	weblogicMetricName             metricName = weblogic.ComponentName
-	nginxMetricName                metricName = nginx.ComponentName
-	certmanagerMetricName          metricName = certmanager.ComponentName
-	externaldnsMetricName          metricName = externaldns.ComponentName
-	rancherMetricName              metricName = rancher.ComponentName
-	verrazzanoMetricName           metricName = verrazzano.ComponentName
-	vmoMetricName                  metricName = vmo.ComponentName
-	opensearchMetricName           metricName = opensearch.ComponentName
-	opensearchdashboardsMetricName metricName = opensearchdashboards.ComponentName
-	grafanaMetricName              metricName = grafana.ComponentName
-	coherenceMetricName            metricName = coherence.ComponentName
-	mysqlMetricName                metricName = mysql.ComponentName
-	mysqlOperatorMetricName        metricName = mysqloperator.ComponentName
-	keycloakMetricname             metricName = keycloak.ComponentName
-	kialiMetricName                metricName = kiali.ComponentName
-	promoperatorMetricname         metricName = promoperator.ComponentName
-	promadapterMetricname          metricName = promadapter.ComponentName
-	kubestatemmetricsMetricName    metricName = kubestatemetrics.ComponentName
-	pushgatewayMetricName          metricName = pushgateway.ComponentName
-	promnodeexporterMetricname     metricName = promnodeexporter.ComponentName
-	jaegeroperatorMetricName       metricName = jaegeroperator.ComponentName
-	consoleMetricName              metricName = console.ComponentName
-	fluentdMetricName              metricName = fluentd.ComponentName
+	ReconcileCounter          metricName = "reconcile counter"
+	ReconcileError            metricName = "reconcile error"
+	ReconcileDuration         metricName = "reconcile duration"
+	HelmDriftDetected         metricName = "helm drift detected"
+	ComponentState            metricName = "component state"
+	ComponentEnabled          metricName = "component enabled"
+	ComponentVersion          metricName = "component version"
+	ComponentReadyDuration    metricName = "component ready duration"
+	ComponentNotReadyDuration metricName = "component not ready duration"
+	ComponentUpgradeFailures  metricName = "component upgrade failures"
+	CertificateExpiryDays     metricName = "certificate expiry days"
 )
 
 func init() {
@@ -92,16 +59,19 @@ func RequiredInitialization() {
 	MetricsExp = MetricsExporter{
 		internalConfig: initConfiguration(),
 		internalData: data{
-			simpleCounterMetricMap: initSimpleCounterMetricMap(),
-			simpleGaugeMetricMap:   initSimpleGaugeMetricMap(),
-			durationMetricMap:      initDurationMetricMap(),
-			metricsComponentMap:    initMetricComponentMap(),
+			simpleCounterMetricMap:    initSimpleCounterMetricMap(),
+			simpleGaugeMetricMap:      initSimpleGaugeMetricMap(),
+			durationMetricMap:         initDurationMetricMap(),
+			metricsComponentMap:       map[metricName]*MetricsComponent{},
+			componentGaugeMetricMap:   initComponentGaugeMetricMap(),
+			componentCounterMetricMap: initComponentCounterMetricMap(),
+			componentVersions:         map[string]string{},
 		},
 	}
 
 }
 
-//This function begins the process of registering metrics
+// This function begins the process of registering metrics
 func RegisterMetrics(log *zap.SugaredLogger) {
 	InitializeAllMetricsArray()
 	go registerMetricsHandlers(log)
@@ -126,7 +96,7 @@ func newMetricsComponent(name string) *MetricsComponent {
 	}
 }
 
-//This function initalizes the simpleCounterMetricMap for the metricsExporter object
+// This function initalizes the simpleCounterMetricMap for the metricsExporter object
 func initSimpleCounterMetricMap() map[metricName]*SimpleCounterMetric {
 	return map[metricName]*SimpleCounterMetric{
 		ReconcileCounter: {
@@ -144,37 +114,48 @@ func initSimpleCounterMetricMap() map[metricName]*SimpleCounterMetric {
 	}
 }
 
-// This function initalizes the metricComponentMap for the metricsExporter object
-func initMetricComponentMap() map[metricName]*MetricsComponent {
-	return map[metricName]*MetricsComponent{
-		authproxyMetricName:            newMetricsComponent("authproxy"),
-		oamMetricName:                  newMetricsComponent("oam"),
-		appoperMetricName:              newMetricsComponent("appoper"),
-		istioMetricName:                newMetricsComponent("istio"),
-		weblogicMetricName:             newMetricsComponent("weblogic"),
-		nginxMetricName:                newMetricsComponent("nginx"),
-		certmanagerMetricName:          newMetricsComponent("certManager"),
-		externaldnsMetricName:          newMetricsComponent("externalDNS"),
-		rancherMetricName:              newMetricsComponent("rancher"),
-		verrazzanoMetricName:           newMetricsComponent("verrazzano"),
-		vmoMetricName:                  newMetricsComponent("verrazzano_monitoring_operator"),
-		opensearchMetricName:           newMetricsComponent("opensearch"),
-		opensearchdashboardsMetricName: newMetricsComponent("opensearch_dashboards"),
-		grafanaMetricName:              newMetricsComponent("grafana"),
-		coherenceMetricName:            newMetricsComponent("coherence"),
-		mysqlMetricName:                newMetricsComponent("mysql"),
-		mysqlOperatorMetricName:        newMetricsComponent("mysql_operator"),
-		keycloakMetricname:             newMetricsComponent("keycloak"),
-		kialiMetricName:                newMetricsComponent("kiali"),
-		promoperatorMetricname:         newMetricsComponent("prometheus_operator"),
-		promadapterMetricname:          newMetricsComponent("prometheus_adapter"),
-		kubestatemmetricsMetricName:    newMetricsComponent("kube_state_metrics"),
-		pushgatewayMetricName:          newMetricsComponent("prometheus_push_gateway"),
-		promnodeexporterMetricname:     newMetricsComponent("prometheus_node_exporter"),
-		jaegeroperatorMetricName:       newMetricsComponent("jaeger_operator"),
-		consoleMetricName:              newMetricsComponent("verrazzano_console"),
-		fluentdMetricName:              newMetricsComponent("fluentd"),
+// legacyMetricsComponentNames are the names used in the install and upgrade duration metrics of the components whose
+// metric names were chosen before the metrics were generated from the component registry.  The names are kept so that
+// existing dashboards and alerts continue to work, the metrics of all other components use the component name.
+var legacyMetricsComponentNames = map[string]string{
+	authproxy.ComponentName:   "authproxy",
+	oam.ComponentName:         "oam",
+	appoper.ComponentName:     "appoper",
+	weblogic.ComponentName:    "weblogic",
+	nginx.ComponentName:       "nginx",
+	certmanager.ComponentName: "certManager",
+	externaldns.ComponentName: "externalDNS",
+	coherence.ComponentName:   "coherence",
+	kiali.ComponentName:       "kiali",
+	pushgateway.ComponentName: "prometheus_push_gateway",
+}
+
+// getMetricsComponentName returns the name used in the install and upgrade duration metrics of a component
+func getMetricsComponentName(componentName string) string {
+	if name, ok := legacyMetricsComponentNames[componentName]; ok {
+		return name
+	}
+	return strings.ReplaceAll(componentName, "-", "_")
+}
+
+// This function returns the MetricsComponent of a component in the registry, creating and registering its metrics
+// the first time the component is seen.  The components are not known until the operator configuration is loaded, so
+// the metrics cannot be created when the package is initialized.
+func getOrCreateMetricsComponent(log vzlog.VerrazzanoLogger, componentName metricName) (*MetricsComponent, bool) {
+	if metricsComponent, ok := MetricsExp.internalData.metricsComponentMap[componentName]; ok {
+		return metricsComponent, true
+	}
+	if found, _ := registry.FindComponent(string(componentName)); !found {
+		return nil, false
+	}
+	metricsComponent := newMetricsComponent(getMetricsComponentName(string(componentName)))
+	for _, metric := range []prometheus.Collector{metricsComponent.latestInstallDuration.metric, metricsComponent.latestUpgradeDuration.metric} {
+		if err := MetricsExp.internalConfig.registry.Register(metric); err != nil {
+			log.Errorf("Failed to register metrics for component %s: %v", componentName, err)
+		}
 	}
+	MetricsExp.internalData.metricsComponentMap[componentName] = metricsComponent
+	return metricsComponent, true
 }
 
 // This function initalizes the simpleGaugeMetricMap for the metricsExporter object
@@ -182,6 +163,66 @@ func initSimpleGaugeMetricMap() map[metricName]*SimpleGaugeMetric {
 	return map[metricName]*SimpleGaugeMetric{}
 }
 
+// This function initalizes the componentGaugeMetricMap for the metricsExporter object
+func initComponentGaugeMetricMap() map[metricName]*ComponentGaugeMetric {
+	return map[metricName]*ComponentGaugeMetric{
+		HelmDriftDetected: {
+			prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "vpo_helm_drift_detected",
+				Help: "Whether the values of the Helm release of a component have drifted from the values generated by the verrazzano-platform-operator, 1 if drift was detected",
+			}, []string{"component"}),
+		},
+		ComponentState: {
+			prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "vpo_component_state",
+				Help: "The state of a component, 1 for the current state of the component and 0 for all other states",
+			}, []string{"component", "state"}),
+		},
+		ComponentEnabled: {
+			prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "vpo_component_enabled",
+				Help: "Whether a component is enabled in the Verrazzano resource, 1 if the component is enabled",
+			}, []string{"component"}),
+		},
+		ComponentVersion: {
+			prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "vpo_component_version_info",
+				Help: "The Verrazzano version installed for a component, always 1",
+			}, []string{"component", "version"}),
+		},
+		ComponentReadyDuration: {
+			prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "vpo_component_ready_duration_seconds",
+				Help: "The number of seconds a component has been ready, 0 if the component is not ready",
+			}, []string{"component"}),
+		},
+		ComponentNotReadyDuration: {
+			prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "vpo_component_not_ready_duration_seconds",
+				Help: "The number of seconds an enabled component has not been ready, 0 if the component is ready or disabled",
+			}, []string{"component"}),
+		},
+		CertificateExpiryDays: {
+			prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "vpo_certificate_expiry_days",
+				Help: "The number of days until a certificate of a component or of an application ingress expires, negative if the certificate has expired",
+			}, []string{"component", "certificate"}),
+		},
+	}
+}
+
+// This function initalizes the componentCounterMetricMap for the metricsExporter object
+func initComponentCounterMetricMap() map[metricName]*ComponentCounterMetric {
+	return map[metricName]*ComponentCounterMetric{
+		ComponentUpgradeFailures: {
+			prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "vpo_component_upgrade_failures_total",
+				Help: "The number of times the upgrade of a component has failed",
+			}, []string{"component"}),
+		},
+	}
+}
+
 // This function initalizes the durationMetricMap for the metricsExporter object
 func initDurationMetricMap() map[metricName]*DurationMetric {
 	return map[metricName]*DurationMetric{
@@ -199,7 +240,7 @@ func initDurationMetricMap() map[metricName]*DurationMetric {
 // After this check, the function calculates the duration time and tries to set the metric of the component
 // If the component's name is not in the metric map, an error will be raised to prevent a seg fault
 func metricParserHelperFunction(log vzlog.VerrazzanoLogger, componentName metricName, startTime string, completionTime string, typeofOperation string) {
-	_, ok := MetricsExp.internalData.metricsComponentMap[componentName]
+	_, ok := getOrCreateMetricsComponent(log, componentName)
 	if !ok {
 		log.Errorf("Component %s does not have metrics in the metrics map", componentName)
 		return
@@ -310,6 +351,89 @@ func AnalyzeVerrazzanoResourceMetrics(log vzlog.VerrazzanoLogger, cr vzapi.Verra
 	}
 }
 
+// componentStates are the states reported by the component state metric
+var componentStates = []vzapi.CompStateType{
+	vzapi.CompStateDisabled,
+	vzapi.CompStatePreInstalling,
+	vzapi.CompStateInstalling,
+	vzapi.CompStateUninstalling,
+	vzapi.CompStateUninstalled,
+	vzapi.CompStateUpgrading,
+	vzapi.CompStateError,
+	vzapi.CompStateReady,
+	vzapi.CompStateFailed,
+}
+
+// This function parses the VZ CR and sets the state, enabled, version and readiness metrics of each component in the
+// component registry
+func AnalyzeComponentStateMetrics(log vzlog.VerrazzanoLogger, cr *vzapi.Verrazzano) {
+	effectiveCR, err := transform.GetEffectiveCR(cr)
+	if err != nil {
+		log.Errorf("Failed to get the effective CR for the component metrics: %v", err)
+		return
+	}
+	stateMetric := MetricsExp.internalData.componentGaugeMetricMap[ComponentState]
+	enabledMetric := MetricsExp.internalData.componentGaugeMetricMap[ComponentEnabled]
+	versionMetric := MetricsExp.internalData.componentGaugeMetricMap[ComponentVersion]
+	readyMetric := MetricsExp.internalData.componentGaugeMetricMap[ComponentReadyDuration]
+	notReadyMetric := MetricsExp.internalData.componentGaugeMetricMap[ComponentNotReadyDuration]
+
+	now := time.Now()
+	for _, comp := range registry.GetComponents() {
+		name := comp.Name()
+		enabled := comp.IsEnabled(effectiveCR)
+		enabledMetric.Set(name, boolToFloat64(enabled))
+
+		var status *vzapi.ComponentStatusDetails
+		if cr.Status.Components != nil {
+			status = cr.Status.Components[name]
+		}
+		if status == nil {
+			status = &vzapi.ComponentStatusDetails{}
+		}
+		for _, state := range componentStates {
+			stateMetric.SetLabelled(name, string(state), boolToFloat64(status.State == state))
+		}
+
+		// Only the installed version is reported, remove the series of the previous version
+		if previous, ok := MetricsExp.internalData.componentVersions[name]; ok && previous != status.Version {
+			versionMetric.DeleteLabelled(name, previous)
+			delete(MetricsExp.internalData.componentVersions, name)
+		}
+		if status.Version != "" {
+			versionMetric.SetLabelled(name, status.Version, 1)
+			MetricsExp.internalData.componentVersions[name] = status.Version
+		}
+
+		// The latest condition is the transition into the current state
+		var duration float64
+		if len(status.Conditions) > 0 {
+			since, err := time.Parse(time.RFC3339, status.Conditions[len(status.Conditions)-1].LastTransitionTime)
+			if err == nil && now.After(since) {
+				duration = now.Sub(since).Seconds()
+			}
+		}
+		if status.State == vzapi.CompStateReady {
+			readyMetric.Set(name, duration)
+			notReadyMetric.Set(name, 0)
+		} else {
+			readyMetric.Set(name, 0)
+			if !enabled {
+				duration = 0
+			}
+			notReadyMetric.Set(name, duration)
+		}
+	}
+}
+
+// This function returns 1 for true and 0 for false
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 // This function initalizes the allMetrics array
 func InitializeAllMetricsArray() {
 	//loop through all metrics declarations in metric maps
@@ -322,6 +446,12 @@ func InitializeAllMetricsArray() {
 	for _, value := range MetricsExp.internalData.metricsComponentMap {
 		MetricsExp.internalConfig.allMetrics = append(MetricsExp.internalConfig.allMetrics, value.latestInstallDuration.metric, value.latestUpgradeDuration.metric)
 	}
+	for _, value := range MetricsExp.internalData.componentGaugeMetricMap {
+		MetricsExp.internalConfig.allMetrics = append(MetricsExp.internalConfig.allMetrics, value.metric)
+	}
+	for _, value := range MetricsExp.internalData.componentCounterMetricMap {
+		MetricsExp.internalConfig.allMetrics = append(MetricsExp.internalConfig.allMetrics, value.metric)
+	}
 }
 
 // This function returns an empty struct of type configuration
@@ -360,11 +490,30 @@ func GetSimpleGaugeMetric(name metricName) (*SimpleGaugeMetric, error) {
 	return gaugeMetric, nil
 }
 
-// This function returns a metricComponent from the metricComponentMap given a metricName
+// This function returns a metricComponent from the metricComponentMap given a metricName, creating the metrics of a
+// registry component that has not been seen yet
 func GetMetricComponent(name metricName) (*MetricsComponent, error) {
-	metricComponent, ok := MetricsExp.internalData.metricsComponentMap[name]
+	metricComponent, ok := getOrCreateMetricsComponent(vzlog.DefaultLogger(), name)
 	if !ok {
 		return nil, fmt.Errorf("%v not found in metricsComponentMap due to metricName being defined, but not being a key in the map", name)
 	}
 	return metricComponent, nil
 }
+
+// This function returns a componentCounterMetric from the componentCounterMetricMap given a metricName
+func GetComponentCounterMetric(name metricName) (*ComponentCounterMetric, error) {
+	counterMetric, ok := MetricsExp.internalData.componentCounterMetricMap[name]
+	if !ok {
+		return nil, fmt.Errorf("%v not found in componentCounterMetricMap due to metricName being defined, but not being a key in the map", name)
+	}
+	return counterMetric, nil
+}
+
+// This function returns a componentGaugeMetric from the componentGaugeMetricMap given a metricName
+func GetComponentGaugeMetric(name metricName) (*ComponentGaugeMetric, error) {
+	gaugeMetric, ok := MetricsExp.internalData.componentGaugeMetricMap[name]
+	if !ok {
+		return nil, fmt.Errorf("%v not found in componentGaugeMetricMap due to metricName being defined, but not being a key in the map", name)
+	}
+	return gaugeMetric, nil
+}
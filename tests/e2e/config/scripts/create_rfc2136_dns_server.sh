@@ -0,0 +1,167 @@
+#!/bin/bash
+
+#
+# Copyright (c) 2022, Oracle and/or its affiliates.
+# Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+#
+
+# Creates a BIND DNS server in the cluster that is the stand-in for an RFC2136 DNS server, with a zone that accepts
+# dynamic updates signed with a generated TSIG key.  The TSIG secret is created in the verrazzano-install namespace
+# for the RFC2136 DNS configuration of the Verrazzano CR, see process_rfc2136_dns_install_yaml.sh.  The server is
+# verified with a TSIG-signed dynamic update of a test record, which is read back and deleted.
+
+if [ "$DNS_TYPE" != "rfc2136" ]; then
+  echo "Skipping creating the RFC2136 DNS server when not using DNS_TYPE rfc2136"
+  exit 0
+fi
+
+RFC2136_NAMESPACE=${RFC2136_NAMESPACE:-"rfc2136-dns"}
+RFC2136_DNS_ZONE_NAME=${RFC2136_DNS_ZONE_NAME:-"vz.example.com"}
+RFC2136_TSIG_KEY_NAME=${RFC2136_TSIG_KEY_NAME:-"verrazzano-key"}
+RFC2136_TSIG_SECRET_NAME=${RFC2136_TSIG_SECRET_NAME:-"rfc2136-tsig-secret"}
+BIND_IMAGE=${BIND_IMAGE:-"internetsystemsconsortium/bind9:9.18"}
+BIND_HOST="bind.${RFC2136_NAMESPACE}.svc.cluster.local"
+
+TSIG_SECRET=$(openssl rand -base64 32)
+if [ -z "$TSIG_SECRET" ]; then
+  echo "Failed to generate the TSIG secret"
+  exit 1
+fi
+
+kubectl create namespace "${RFC2136_NAMESPACE}" --dry-run=client -o yaml | kubectl apply -f -
+kubectl create namespace verrazzano-install --dry-run=client -o yaml | kubectl apply -f -
+
+# The TSIG key in the format of named.conf, which is also read by nsupdate and dig
+kubectl -n "${RFC2136_NAMESPACE}" create secret generic bind-tsig-key --dry-run=client -o yaml \
+  --from-literal=tsig.key="key \"${RFC2136_TSIG_KEY_NAME}\" { algorithm hmac-sha256; secret \"${TSIG_SECRET}\"; };" | kubectl apply -f -
+
+# The TSIG secret read by external-dns
+kubectl -n verrazzano-install create secret generic "${RFC2136_TSIG_SECRET_NAME}" --dry-run=client -o yaml \
+  --from-literal=tsigSecret="${TSIG_SECRET}" | kubectl apply -f -
+
+cat <<EOF | kubectl apply -f -
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: bind-config
+  namespace: ${RFC2136_NAMESPACE}
+data:
+  named.conf: |
+    include "/etc/bind/keys/tsig.key";
+    options {
+      directory "/var/lib/bind";
+      listen-on { any; };
+      listen-on-v6 { none; };
+      allow-query { any; };
+      recursion no;
+      dnssec-validation no;
+    };
+    zone "${RFC2136_DNS_ZONE_NAME}" {
+      type primary;
+      file "/var/lib/bind/db.zone";
+      allow-transfer { key "${RFC2136_TSIG_KEY_NAME}"; };
+      update-policy { grant "${RFC2136_TSIG_KEY_NAME}" zonesub ANY; };
+    };
+  db.zone: |
+    \$TTL 60
+    @ IN SOA ns.${RFC2136_DNS_ZONE_NAME}. admin.${RFC2136_DNS_ZONE_NAME}. ( 1 60 60 86400 60 )
+    @ IN NS ns.${RFC2136_DNS_ZONE_NAME}.
+    ns IN A 127.0.0.1
+  check.sh: |
+    set -e
+    echo "server ${BIND_HOST} 53
+    zone ${RFC2136_DNS_ZONE_NAME}
+    update delete rfc2136-check.${RFC2136_DNS_ZONE_NAME} A
+    update add rfc2136-check.${RFC2136_DNS_ZONE_NAME} 60 A 127.0.0.2
+    send" | nsupdate -k /etc/bind/keys/tsig.key
+    dig +short @${BIND_HOST} rfc2136-check.${RFC2136_DNS_ZONE_NAME} A | grep -q '^127.0.0.2$'
+    echo "server ${BIND_HOST} 53
+    zone ${RFC2136_DNS_ZONE_NAME}
+    update delete rfc2136-check.${RFC2136_DNS_ZONE_NAME} A
+    send" | nsupdate -k /etc/bind/keys/tsig.key
+    # An update that is not signed must be refused
+    if echo "server ${BIND_HOST} 53
+    zone ${RFC2136_DNS_ZONE_NAME}
+    update add rfc2136-unsigned.${RFC2136_DNS_ZONE_NAME} 60 A 127.0.0.3
+    send" | nsupdate; then
+      echo "The unsigned update was not refused"
+      exit 1
+    fi
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: bind
+  namespace: ${RFC2136_NAMESPACE}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: bind
+  template:
+    metadata:
+      labels:
+        app: bind
+    spec:
+      containers:
+        - name: bind
+          image: ${BIND_IMAGE}
+          # The zone file is copied to a writable directory for the journal of the dynamic updates
+          command: ["/bin/sh", "-c", "cp /etc/bind/config/db.zone /var/lib/bind/db.zone && exec named -g -c /etc/bind/config/named.conf"]
+          ports:
+            - name: dns-tcp
+              containerPort: 53
+              protocol: TCP
+            - name: dns-udp
+              containerPort: 53
+              protocol: UDP
+          readinessProbe:
+            tcpSocket:
+              port: 53
+          volumeMounts:
+            - name: config
+              mountPath: /etc/bind/config
+            - name: keys
+              mountPath: /etc/bind/keys
+            - name: zones
+              mountPath: /var/lib/bind
+      volumes:
+        - name: config
+          configMap:
+            name: bind-config
+        - name: keys
+          secret:
+            secretName: bind-tsig-key
+        - name: zones
+          emptyDir: {}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: bind
+  namespace: ${RFC2136_NAMESPACE}
+spec:
+  selector:
+    app: bind
+  ports:
+    - name: dns-tcp
+      port: 53
+      protocol: TCP
+    - name: dns-udp
+      port: 53
+      protocol: UDP
+EOF
+
+# Restart the server to read a new TSIG key when the server already exists
+kubectl -n "${RFC2136_NAMESPACE}" rollout restart deployment/bind
+if ! kubectl -n "${RFC2136_NAMESPACE}" rollout status deployment/bind --timeout=300s; then
+  echo "The RFC2136 DNS server is not ready"
+  exit 1
+fi
+
+echo "Verifying a TSIG-signed dynamic update of the RFC2136 DNS server ${BIND_HOST}"
+if ! kubectl -n "${RFC2136_NAMESPACE}" exec deployment/bind -- /bin/sh /etc/bind/config/check.sh; then
+  echo "The TSIG-signed dynamic update of the RFC2136 DNS server failed"
+  exit 1
+fi
+echo "The RFC2136 DNS server ${BIND_HOST} accepts TSIG-signed dynamic updates of zone ${RFC2136_DNS_ZONE_NAME}"
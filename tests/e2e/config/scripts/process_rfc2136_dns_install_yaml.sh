@@ -0,0 +1,22 @@
+#!/bin/bash
+
+# Copyright (c) 2022, Oracle and/or its affiliates.
+# Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+# Edits the install config to use the RFC2136 DNS server created by create_rfc2136_dns_server.sh
+
+INSTALL_CONFIG_TO_EDIT=$1
+RFC2136_NAMESPACE=${RFC2136_NAMESPACE:-"rfc2136-dns"}
+RFC2136_DNS_ZONE_NAME=${RFC2136_DNS_ZONE_NAME:-"vz.example.com"}
+RFC2136_TSIG_KEY_NAME=${RFC2136_TSIG_KEY_NAME:-"verrazzano-key"}
+RFC2136_TSIG_SECRET_NAME=${RFC2136_TSIG_SECRET_NAME:-"rfc2136-tsig-secret"}
+
+echo "Editing install config file for RFC2136 DNS ${INSTALL_CONFIG_TO_EDIT}"
+yq -i eval ".spec.environmentName = \"${VZ_ENVIRONMENT_NAME}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.profile = \"${INSTALL_PROFILE}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.components.dns.rfc2136.host = \"bind.${RFC2136_NAMESPACE}.svc.cluster.local\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.components.dns.rfc2136.dnsZoneName = \"${RFC2136_DNS_ZONE_NAME}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.components.dns.rfc2136.tsigKeyName = \"${RFC2136_TSIG_KEY_NAME}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.components.dns.rfc2136.tsigAlgorithm = \"hmac-sha256\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.components.dns.rfc2136.tsigSecret = \"${RFC2136_TSIG_SECRET_NAME}\"" ${INSTALL_CONFIG_TO_EDIT}
+cat ${INSTALL_CONFIG_TO_EDIT}
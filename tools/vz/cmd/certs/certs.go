@@ -0,0 +1,28 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package certs
+
+import (
+	"github.com/spf13/cobra"
+	cmdhelpers "github.com/verrazzano/verrazzano/tools/vz/cmd/helpers"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+)
+
+const (
+	CommandName = "certs"
+	helpShort   = "Verrazzano certificate operations"
+	helpLong    = `The command 'certs <subcommand>' performs the certificate operation specified by the subcommand`
+	helpExample = `vz certs <subcommand>`
+)
+
+func NewCmdCerts(vzHelper helpers.VZHelper) *cobra.Command {
+	cmd := cmdhelpers.NewCommand(vzHelper, CommandName, helpShort, helpLong)
+	addSubCommandsCerts(vzHelper, cmd)
+	cmd.Example = helpExample
+	return cmd
+}
+
+func addSubCommandsCerts(vzHelper helpers.VZHelper, parentCmd *cobra.Command) {
+	parentCmd.AddCommand(newSubcmdList(vzHelper))
+}
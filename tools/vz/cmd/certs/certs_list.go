@@ -0,0 +1,106 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package certs
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	cmdhelpers "github.com/verrazzano/verrazzano/tools/vz/cmd/helpers"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+)
+
+const (
+	listSubCommandName = "list"
+	listHelpShort      = "List the certificates of the Verrazzano installation"
+	listHelpLong       = `The command 'certs list' lists the certificates of the Verrazzano components and of the application ingresses, with the time they expire and the number of days until they expire.  The certificates that are expiring soon are marked with EXPIRING SOON.`
+	listHelpExample    = `
+vz certs list
+vz certs list --expiring-within 30`
+
+	expiringWithinFlag     = "expiring-within"
+	expiringWithinFlagHelp = "Only list the certificates that expire within the given number of days"
+
+	// expiringSoonDays is the number of days before a certificate expires that it is reported as expiring soon by the
+	// Verrazzano platform operator
+	expiringSoonDays = 14
+
+	timeFormat = "2006-01-02T15:04:05Z"
+)
+
+func newSubcmdList(vzHelper helpers.VZHelper) *cobra.Command {
+	cmd := cmdhelpers.NewCommand(vzHelper, listSubCommandName, listHelpShort, listHelpLong)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runCmdCertsList(cmd, vzHelper)
+	}
+	cmd.Example = listHelpExample
+	cmd.PersistentFlags().Int(expiringWithinFlag, 0, expiringWithinFlagHelp)
+
+	return cmd
+}
+
+// runCmdCertsList - run the "vz certs list" command
+func runCmdCertsList(cmd *cobra.Command, vzHelper helpers.VZHelper) error {
+	expiringWithin, err := cmd.PersistentFlags().GetInt(expiringWithinFlag)
+	if err != nil {
+		return err
+	}
+	client, err := vzHelper.GetClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Get the VZ resource
+	vz, err := helpers.FindVerrazzanoResource(client)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var certificates []v1beta1.CertificateStatus
+	for _, certificate := range vz.Status.Certificates {
+		if expiringWithin > 0 && (certificate.NotAfter == nil || daysUntil(certificate.NotAfter.Time, now) >= expiringWithin) {
+			continue
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		fmt.Fprintf(vzHelper.GetOutputStream(), "No certificates found for the Verrazzano resource %s/%s\n", vz.Namespace, vz.Name)
+		return nil
+	}
+	printCertificates(vzHelper.GetOutputStream(), certificates, now)
+	return nil
+}
+
+// printCertificates prints a table of the certificates
+func printCertificates(out io.Writer, certificates []v1beta1.CertificateStatus, now time.Time) {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "COMPONENT\tNAMESPACE\tNAME\tREADY\tEXPIRES\tDAYS\tRENEWAL\t")
+	for _, certificate := range certificates {
+		expires, days, renewal := "-", "-", "-"
+		if certificate.NotAfter != nil {
+			expires = certificate.NotAfter.UTC().Format(timeFormat)
+			remaining := daysUntil(certificate.NotAfter.Time, now)
+			days = fmt.Sprintf("%d", remaining)
+			if remaining < expiringSoonDays {
+				days += " EXPIRING SOON"
+			}
+		}
+		if certificate.RenewalTime != nil {
+			renewal = certificate.RenewalTime.UTC().Format(timeFormat)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\t%s\t\n", certificate.Component, certificate.Namespace, certificate.Name,
+			certificate.Ready, expires, days, renewal)
+	}
+	w.Flush()
+}
+
+// daysUntil returns the number of whole days until the given time, which is negative if the time has passed
+func daysUntil(t time.Time, now time.Time) int {
+	return int(t.Sub(now).Hours() / 24)
+}
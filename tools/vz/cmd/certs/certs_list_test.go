@@ -0,0 +1,82 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package certs
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	testhelpers "github.com/verrazzano/verrazzano/tools/vz/test/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newCertsRootCmdContext returns a root command context with a Verrazzano resource that has the given certificates
+func newCertsRootCmdContext(buf *bytes.Buffer, certificates []v1beta1.CertificateStatus) *testhelpers.FakeRootCmdContext {
+	vz := &v1beta1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+		Status:     v1beta1.VerrazzanoStatus{Certificates: certificates},
+	}
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: new(bytes.Buffer)})
+	rc.SetClient(fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(vz).Build())
+	return rc
+}
+
+// TestCertsListCmd tests the certs list command
+// GIVEN an environment with a VZ resource that has a certificate expiring soon and a valid certificate
+//  WHEN I run the command vz certs list
+//  THEN expect both certificates to be listed, and the certificate expiring soon to be marked
+func TestCertsListCmd(t *testing.T) {
+	buf := new(bytes.Buffer)
+	rc := newCertsRootCmdContext(buf, []v1beta1.CertificateStatus{
+		{Component: "rancher", Namespace: "cattle-system", Name: "tls-rancher-ingress", Ready: true,
+			NotAfter: &metav1.Time{Time: time.Now().Add(5*24*time.Hour + time.Hour)}},
+		{Component: "application", Namespace: "istio-system", Name: "hello-trait-cert", Ready: true,
+			NotAfter:    &metav1.Time{Time: time.Now().Add(60*24*time.Hour + time.Hour)},
+			RenewalTime: &metav1.Time{Time: time.Date(2022, 10, 1, 10, 0, 0, 0, time.UTC)}},
+	})
+	cmd := NewCmdCerts(rc)
+	cmd.SetArgs([]string{listSubCommandName})
+	assert.NoError(t, cmd.Execute())
+
+	out := buf.String()
+	assert.Contains(t, out, "COMPONENT")
+	assert.Regexp(t, "tls-rancher-ingress .* 5 EXPIRING SOON", out)
+	assert.Regexp(t, "hello-trait-cert .* 60 +2022-10-01T10:00:00Z", out)
+}
+
+// TestCertsListCmdExpiringWithin tests the certs list command with the expiring within flag
+// GIVEN an environment with a VZ resource that has a certificate expiring soon and a valid certificate
+//  WHEN I run the command vz certs list --expiring-within 30
+//  THEN expect only the certificate expiring soon to be listed
+func TestCertsListCmdExpiringWithin(t *testing.T) {
+	buf := new(bytes.Buffer)
+	rc := newCertsRootCmdContext(buf, []v1beta1.CertificateStatus{
+		{Component: "rancher", Namespace: "cattle-system", Name: "tls-rancher-ingress", NotAfter: &metav1.Time{Time: time.Now().Add(5 * 24 * time.Hour)}},
+		{Component: "application", Namespace: "istio-system", Name: "hello-trait-cert", NotAfter: &metav1.Time{Time: time.Now().Add(60 * 24 * time.Hour)}},
+	})
+	cmd := NewCmdCerts(rc)
+	cmd.SetArgs([]string{listSubCommandName, "--" + expiringWithinFlag, "30"})
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, buf.String(), "tls-rancher-ingress")
+	assert.NotContains(t, buf.String(), "hello-trait-cert")
+}
+
+// TestCertsListCmdNoCertificates tests the certs list command without certificates
+// GIVEN an environment with a VZ resource that has no certificates
+//  WHEN I run the command vz certs list
+//  THEN expect a message that no certificates were found
+func TestCertsListCmdNoCertificates(t *testing.T) {
+	buf := new(bytes.Buffer)
+	cmd := NewCmdCerts(newCertsRootCmdContext(buf, nil))
+	cmd.SetArgs([]string{listSubCommandName})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "No certificates found for the Verrazzano resource default/verrazzano\n", buf.String())
+}
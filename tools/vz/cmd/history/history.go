@@ -0,0 +1,117 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package history
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/verrazzano/verrazzano/pkg/history"
+	cmdhelpers "github.com/verrazzano/verrazzano/tools/vz/cmd/helpers"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	CommandName = "history"
+	helpShort   = "History of the changes to the Verrazzano installation"
+	helpLong    = `The command 'history' displays the generations of the Verrazzano resource, oldest first.  For each generation it displays who changed the resource, the fields of the effective configuration that changed, the component operations that were triggered, and the outcome.  The oldest generations are removed from the history when it is full.`
+	helpExample = `
+vz history
+vz history --generation 3
+vz history --context minikube`
+)
+
+const (
+	generationFlag     = "generation"
+	generationFlagHelp = "Display only the given generation of the Verrazzano resource"
+)
+
+func NewCmdHistory(vzHelper helpers.VZHelper) *cobra.Command {
+	cmd := cmdhelpers.NewCommand(vzHelper, CommandName, helpShort, helpLong)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runCmdHistory(cmd, vzHelper)
+	}
+	cmd.Example = helpExample
+	cmd.PersistentFlags().Int64(generationFlag, 0, generationFlagHelp)
+
+	return cmd
+}
+
+// runCmdHistory - run the "vz history" command
+func runCmdHistory(cmd *cobra.Command, vzHelper helpers.VZHelper) error {
+	generation, err := cmd.PersistentFlags().GetInt64(generationFlag)
+	if err != nil {
+		return err
+	}
+	client, err := vzHelper.GetClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Get the VZ resource
+	vz, err := helpers.FindVerrazzanoResource(client)
+	if err != nil {
+		return err
+	}
+
+	// Get the history of the VZ resource
+	cm := corev1.ConfigMap{}
+	err = client.Get(context.TODO(), types.NamespacedName{Namespace: vz.Namespace, Name: history.ConfigMapName(vz.Name)}, &cm)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("No history was recorded for the Verrazzano resource %s/%s", vz.Namespace, vz.Name)
+		}
+		return fmt.Errorf("Failed to get the history of the Verrazzano resource %s/%s: %s", vz.Namespace, vz.Name, err.Error())
+	}
+	entries, err := history.GetEntries(&cm)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, entry := range entries {
+		if generation != 0 && entry.Generation != generation {
+			continue
+		}
+		found = true
+		printEntry(vzHelper.GetOutputStream(), entry)
+	}
+	if generation != 0 && !found {
+		return fmt.Errorf("Generation %d of the Verrazzano resource %s/%s is not in the history", generation, vz.Namespace, vz.Name)
+	}
+	return nil
+}
+
+// printEntry - display a history entry
+func printEntry(out io.Writer, entry history.Entry) {
+	fmt.Fprintf(out, "Generation %d: %s\n", entry.Generation, entry.Outcome)
+	fmt.Fprintf(out, "  Time: %s\n", entry.Time.UTC().Format("2006-01-02T15:04:05Z"))
+	if entry.ChangedBy != "" {
+		fmt.Fprintf(out, "  Changed By: %s\n", entry.ChangedBy)
+	}
+	if len(entry.Changes) > 0 {
+		fmt.Fprintln(out, "  Changes:")
+		for _, change := range entry.Changes {
+			switch {
+			case change.Old == "":
+				fmt.Fprintf(out, "    %s: %s (added)\n", change.Path, change.New)
+			case change.New == "":
+				fmt.Fprintf(out, "    %s: %s (removed)\n", change.Path, change.Old)
+			default:
+				fmt.Fprintf(out, "    %s: %s -> %s\n", change.Path, change.Old, change.New)
+			}
+		}
+	}
+	if len(entry.Operations) > 0 {
+		fmt.Fprintln(out, "  Component Operations:")
+		for _, op := range entry.Operations {
+			fmt.Fprintf(out, "    %s %s: %s\n", op.Time, op.Component, op.Condition)
+		}
+	}
+}
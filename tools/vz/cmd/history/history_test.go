@@ -0,0 +1,125 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package history
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/history"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	testhelpers "github.com/verrazzano/verrazzano/tools/vz/test/helpers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const expectedHistory = `Generation 1: Succeeded
+  Time: 2022-10-01T10:00:00Z
+  Changed By: kubectl-client-side-apply
+  Changes:
+    spec.profile: dev (added)
+  Component Operations:
+    2022-10-01T10:00:05Z keycloak: InstallStarted
+    2022-10-01T10:02:00Z keycloak: InstallComplete
+Generation 2: InProgress
+  Time: 2022-10-02T10:00:00Z
+  Changed By: kubectl-edit
+  Changes:
+    spec.components.keycloak.enabled: true -> false
+    spec.environmentName: default (removed)
+`
+
+// newHistoryClient returns a fake client with a Verrazzano resource and its history
+func newHistoryClient(t *testing.T) client.Client {
+	vz := &v1beta1.Verrazzano{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"}}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: history.ConfigMapName("verrazzano")}}
+	assert.NoError(t, history.SetEntries(cm, []history.Entry{
+		{
+			Generation: 1,
+			Time:       metav1.NewTime(time.Date(2022, 10, 1, 10, 0, 0, 0, time.UTC)),
+			ChangedBy:  "kubectl-client-side-apply",
+			Changes:    []history.FieldChange{{Path: "spec.profile", New: "dev"}},
+			Operations: []history.Operation{
+				{Component: "keycloak", Condition: "InstallStarted", Time: "2022-10-01T10:00:05Z"},
+				{Component: "keycloak", Condition: "InstallComplete", Time: "2022-10-01T10:02:00Z"},
+			},
+			Outcome: history.OutcomeSucceeded,
+		},
+		{
+			Generation: 2,
+			Time:       metav1.NewTime(time.Date(2022, 10, 2, 10, 0, 0, 0, time.UTC)),
+			ChangedBy:  "kubectl-edit",
+			Changes: []history.FieldChange{
+				{Path: "spec.components.keycloak.enabled", Old: "true", New: "false"},
+				{Path: "spec.environmentName", Old: "default"},
+			},
+			Outcome: history.OutcomeInProgress,
+		},
+	}))
+	return fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(vz, cm).Build()
+}
+
+// TestHistoryCmd tests the history command
+// GIVEN an environment with a VZ resource that has a history of two generations
+//  WHEN I run the command vz history
+//  THEN expect both generations to be displayed, oldest first
+func TestHistoryCmd(t *testing.T) {
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(newHistoryClient(t))
+	historyCmd := NewCmdHistory(rc)
+	assert.NotNil(t, historyCmd)
+
+	err := historyCmd.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHistory, buf.String())
+}
+
+// TestHistoryCmdGeneration tests the history command for a single generation
+// GIVEN an environment with a VZ resource that has a history of two generations
+//  WHEN I run the command vz history with a generation that is in the history, and then one that is not
+//  THEN expect only the given generation to be displayed, and then an error
+func TestHistoryCmdGeneration(t *testing.T) {
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(newHistoryClient(t))
+	historyCmd := NewCmdHistory(rc)
+	historyCmd.SetArgs([]string{"--generation", "1"})
+
+	err := historyCmd.Execute()
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "Generation 1: Succeeded")
+	assert.NotContains(t, buf.String(), "Generation 2")
+
+	historyCmd.SetArgs([]string{"--generation", "5"})
+	err = historyCmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Generation 5 of the Verrazzano resource default/verrazzano is not in the history")
+}
+
+// TestHistoryCmdNoHistory tests the history command when no history was recorded
+// GIVEN an environment with a VZ resource that has no history ConfigMap
+//  WHEN I run the command vz history
+//  THEN expect an error
+func TestHistoryCmdNoHistory(t *testing.T) {
+	vz := &v1beta1.Verrazzano{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"}}
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(vz).Build())
+	historyCmd := NewCmdHistory(rc)
+
+	err := historyCmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "No history was recorded for the Verrazzano resource default/verrazzano")
+}
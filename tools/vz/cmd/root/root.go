@@ -7,9 +7,12 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/analyze"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/bugreport"
+	"github.com/verrazzano/verrazzano/tools/vz/cmd/certs"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/cluster"
 	cmdhelpers "github.com/verrazzano/verrazzano/tools/vz/cmd/helpers"
+	"github.com/verrazzano/verrazzano/tools/vz/cmd/history"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/install"
+	"github.com/verrazzano/verrazzano/tools/vz/cmd/rotatecredentials"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/status"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/uninstall"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/upgrade"
@@ -44,6 +47,9 @@ func NewRootCmd(vzHelper helpers.VZHelper) *cobra.Command {
 	cmd.AddCommand(analyze.NewCmdAnalyze(vzHelper))
 	cmd.AddCommand(bugreport.NewCmdBugReport(vzHelper))
 	cmd.AddCommand(cluster.NewCmdCluster(vzHelper))
+	cmd.AddCommand(history.NewCmdHistory(vzHelper))
+	cmd.AddCommand(rotatecredentials.NewCmdRotateCredentials(vzHelper))
+	cmd.AddCommand(certs.NewCmdCerts(vzHelper))
 
 	return cmd
 }
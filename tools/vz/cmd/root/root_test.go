@@ -12,9 +12,12 @@ import (
 
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/analyze"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/bugreport"
+	"github.com/verrazzano/verrazzano/tools/vz/cmd/certs"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/cluster"
+	"github.com/verrazzano/verrazzano/tools/vz/cmd/history"
 
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/install"
+	"github.com/verrazzano/verrazzano/tools/vz/cmd/rotatecredentials"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/uninstall"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/upgrade"
 
@@ -35,7 +38,7 @@ func TestNewRootCmd(t *testing.T) {
 	assert.NotNil(t, rootCmd)
 
 	// Verify the expected commands are defined
-	assert.Len(t, rootCmd.Commands(), 8)
+	assert.Len(t, rootCmd.Commands(), 11)
 	foundCount := 0
 	for _, cmd := range rootCmd.Commands() {
 		switch cmd.Name() {
@@ -55,9 +58,15 @@ func TestNewRootCmd(t *testing.T) {
 			foundCount++
 		case cluster.CommandName:
 			foundCount++
+		case history.CommandName:
+			foundCount++
+		case rotatecredentials.CommandName:
+			foundCount++
+		case certs.CommandName:
+			foundCount++
 		}
 	}
-	assert.Equal(t, 8, foundCount)
+	assert.Equal(t, 11, foundCount)
 
 	// Verify the expected global flags are defined
 	assert.NotNil(t, rootCmd.PersistentFlags().Lookup(constants.GlobalFlagKubeConfig))
@@ -0,0 +1,71 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package rotatecredentials
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	cmdhelpers "github.com/verrazzano/verrazzano/tools/vz/cmd/helpers"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+)
+
+const (
+	CommandName = "rotate-credentials"
+	helpShort   = "Rotate a generated credential of the Verrazzano installation"
+	helpLong    = `The command 'rotate-credentials' rotates a generated credential of the Verrazzano installation.  Verrazzano generates a new password, sets it in the service that uses the credential, updates the secret of the credential and restarts the workloads that read it.  The credential is one of:
+  keycloak     The password of the Keycloak keycloakadmin user
+  mysql        The passwords of the MySQL root and keycloak users
+  verrazzano   The password of the verrazzano user
+The state and the time of the rotation are displayed in the credentialRotations field of the Verrazzano resource status.`
+	helpExample = `
+vz rotate-credentials mysql
+vz rotate-credentials keycloak --context minikube`
+)
+
+// credentials are the names of the credentials that can be rotated
+var credentials = []string{globalconst.KeycloakAdminCredential, globalconst.MySQLCredential, globalconst.VerrazzanoUserCredential}
+
+func NewCmdRotateCredentials(vzHelper helpers.VZHelper) *cobra.Command {
+	cmd := cmdhelpers.NewCommand(vzHelper, CommandName, helpShort, helpLong)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runCmdRotateCredentials(cmd, args, vzHelper)
+	}
+	cmd.Example = helpExample
+	cmd.Use = CommandName + " <credential>"
+	cmd.ValidArgs = credentials
+	cmd.Args = cobra.ExactValidArgs(1)
+
+	return cmd
+}
+
+// runCmdRotateCredentials - run the "vz rotate-credentials" command
+func runCmdRotateCredentials(cmd *cobra.Command, args []string, vzHelper helpers.VZHelper) error {
+	credential := args[0]
+	client, err := vzHelper.GetClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Get the VZ resource
+	vz, err := helpers.FindVerrazzanoResource(client)
+	if err != nil {
+		return err
+	}
+
+	// Request the rotation with a new value of the rotate credentials annotation
+	request := time.Now().UTC().Format(time.RFC3339)
+	if vz.Annotations == nil {
+		vz.Annotations = map[string]string{}
+	}
+	vz.Annotations[globalconst.RotateCredentialsAnnotationPrefix+credential] = request
+	if err := helpers.UpdateVerrazzanoResource(client, vz); err != nil {
+		return fmt.Errorf("Failed to request the rotation of credential %s: %s", credential, err.Error())
+	}
+	fmt.Fprintf(vzHelper.GetOutputStream(), "Requested the rotation of credential %s of the Verrazzano resource %s/%s at %s\n",
+		credential, vz.Namespace, vz.Name, request)
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2022, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package rotatecredentials
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	globalconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	testhelpers "github.com/verrazzano/verrazzano/tools/vz/test/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestRotateCredentialsCmd tests the rotate-credentials command
+// GIVEN an environment with a VZ resource
+//  WHEN I run the command vz rotate-credentials mysql
+//  THEN expect the rotate credentials annotation of the mysql credential to be set on the VZ resource
+func TestRotateCredentialsCmd(t *testing.T) {
+	vz := &v1beta1.Verrazzano{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"}}
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(vz).Build()
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(c)
+	rotateCmd := NewCmdRotateCredentials(rc)
+	assert.NotNil(t, rotateCmd)
+	rotateCmd.SetArgs([]string{globalconst.MySQLCredential})
+
+	err := rotateCmd.Execute()
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "Requested the rotation of credential mysql of the Verrazzano resource default/verrazzano")
+
+	updated := &v1beta1.Verrazzano{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, updated))
+	assert.NotEmpty(t, updated.Annotations[globalconst.RotateCredentialsAnnotationPrefix+globalconst.MySQLCredential])
+}
+
+// TestRotateCredentialsCmdInvalidCredential tests the rotate-credentials command with a credential that can't be rotated
+// GIVEN an environment with a VZ resource
+//  WHEN I run the command vz rotate-credentials with an unknown credential, and without a credential
+//  THEN expect an error
+func TestRotateCredentialsCmdInvalidCredential(t *testing.T) {
+	vz := &v1beta1.Verrazzano{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"}}
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(vz).Build())
+	rotateCmd := NewCmdRotateCredentials(rc)
+
+	rotateCmd.SetArgs([]string{"rancher"})
+	err := rotateCmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid argument \"rancher\"")
+
+	rotateCmd.SetArgs([]string{})
+	assert.Error(t, rotateCmd.Execute())
+}
@@ -6,6 +6,7 @@ package status
 import (
 	"fmt"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -140,6 +141,10 @@ Verrazzano Status
 {{- if .comp_weblogicoperator_state}}
     WebLogic Operator: {{.comp_weblogicoperator_state}}
 {{- end}}
+{{- if .readiness_report}}
+  Not Ready:
+{{.readiness_report}}
+{{- end}}
 `
 
 func NewCmdStatus(vzHelper helpers.VZHelper) *cobra.Command {
@@ -179,6 +184,7 @@ func runCmdStatus(cmd *cobra.Command, vzHelper helpers.VZHelper) error {
 	}
 	addAccessEndpoints(vz.Status.VerrazzanoInstance, templateValues)
 	addComponents(vz.Status.Components, templateValues)
+	addReadinessReports(vz.Status.Components, templateValues)
 	result, err := templates.ApplyTemplate(statusOutputTemplate, templateValues)
 	if err != nil {
 		return fmt.Errorf("Failed to generate %s command output: %s", CommandName, err.Error())
@@ -231,3 +237,41 @@ func addComponents(components v1beta1.ComponentStatusMap, values map[string]stri
 		}
 	}
 }
+
+// addReadinessReports - add the details of what is preventing components from being ready
+func addReadinessReports(components v1beta1.ComponentStatusMap, values map[string]string) {
+	var names []string
+	for name, component := range components {
+		if component != nil && component.ReadinessReport != nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+
+	var lines []string
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("    %s:", name))
+		for _, workload := range components[name].ReadinessReport.Workloads {
+			lines = append(lines, fmt.Sprintf("      %s %s/%s: %d/%d ready. %s", workload.Kind, workload.Namespace, workload.Name,
+				workload.ReadyReplicas, workload.ExpectedReplicas, workload.Message))
+			for _, pod := range workload.UnreadyPods {
+				podLine := fmt.Sprintf("        Pod %s (%s)", pod.Name, pod.Phase)
+				if pod.Reason != "" {
+					podLine = fmt.Sprintf("%s: %s %s", podLine, pod.Reason, pod.Message)
+				}
+				lines = append(lines, strings.TrimRight(podLine, " "))
+				for _, container := range pod.Containers {
+					kind := "Container"
+					if container.InitContainer {
+						kind = "Init container"
+					}
+					lines = append(lines, strings.TrimRight(fmt.Sprintf("          %s %s: %s %s", kind, container.Name, container.Reason, container.Message), " "))
+				}
+			}
+		}
+	}
+	values["readiness_report"] = strings.Join(lines, "\n")
+}
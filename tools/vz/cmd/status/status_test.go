@@ -182,6 +182,73 @@ func TestStatusCmdDefaultProfile(t *testing.T) {
 	assert.Equal(t, expectedResult, result)
 }
 
+// TestStatusCmdNotReady tests the status command
+// GIVEN an environment with a single VZ resource that has a component that is not ready
+//  WHEN I run the command vz status
+//  THEN expect the status report to explain what the component is waiting for
+func TestStatusCmdNotReady(t *testing.T) {
+	components := makeVerrazzanoComponentStatusMap()
+	components["keycloak"] = &v1beta1.ComponentStatusDetails{
+		Name:  "keycloak",
+		State: v1beta1.CompStateInstalling,
+		ReadinessReport: &v1beta1.ReadinessReport{
+			Workloads: []v1beta1.WorkloadReadiness{
+				{
+					Kind:             "StatefulSet",
+					Namespace:        "keycloak",
+					Name:             "keycloak",
+					ExpectedReplicas: 1,
+					ReadyReplicas:    0,
+					Message:          "Waiting for ready replicas to be 1, current ready replicas is 0",
+					UnreadyPods: []v1beta1.PodReadiness{
+						{
+							Name:  "keycloak-0",
+							Phase: "Pending",
+							Containers: []v1beta1.ContainerReadiness{
+								{
+									Name:    "keycloak",
+									Reason:  "ImagePullBackOff",
+									Message: "Back-off pulling image",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	vz := v1beta1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "verrazzano",
+		},
+		Status: v1beta1.VerrazzanoStatus{
+			Version:    "1.2.3",
+			State:      v1beta1.VzStateReconciling,
+			Components: components,
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(&vz).Build()
+
+	// Send the command output to a byte buffer
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(c)
+	statusCmd := NewCmdStatus(rc)
+	assert.NotNil(t, statusCmd)
+
+	// Run the status command, check that the readiness report is displayed
+	err := statusCmd.Execute()
+	assert.NoError(t, err)
+	result := buf.String()
+	assert.Contains(t, result, "  Not Ready:\n    keycloak:\n")
+	assert.Contains(t, result, "      StatefulSet keycloak/keycloak: 0/1 ready. Waiting for ready replicas to be 1, current ready replicas is 0\n")
+	assert.Contains(t, result, "        Pod keycloak-0 (Pending)\n")
+	assert.Contains(t, result, "          Container keycloak: ImagePullBackOff Back-off pulling image\n")
+}
+
 // TestVZNotFound tests the status command
 // GIVEN an environment with a no VZ resources exist
 //  WHEN I run the command vz status